@@ -0,0 +1,178 @@
+// Package codec cung cấp các codec dùng để mã hóa giá trị cache thành byte và registry
+// để chọn codec theo tên.
+//
+// Các driver lưu trữ ngoài process (file, redis, mongodb) chọn codec theo tên trong cấu
+// hình và lưu tên codec cùng mỗi entry, nên đổi codec trong cấu hình không làm các entry
+// đã ghi trước đó trở nên không đọc được: entry cũ vẫn được giải mã bằng codec đã ghi nó.
+//
+// Package đăng ký sẵn ba codec: json, gob và msgpack. Ứng dụng có thể đăng ký codec
+// riêng (ví dụ protobuf, CBOR) bằng Register trước khi khởi tạo driver:
+//
+//	codec.Register(myCBORCodec{})
+//
+//	cfg.File.Codec = "cbor"
+package codec
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Tên các codec được đăng ký sẵn.
+const (
+	// JSON mã hóa giá trị bằng encoding/json
+	JSON = "json"
+
+	// Gob mã hóa giá trị bằng encoding/gob; kiểu struct của ứng dụng cần được đăng ký
+	// bằng gob.Register khi giải mã vào interface{}
+	Gob = "gob"
+
+	// MsgPack mã hóa giá trị bằng MessagePack
+	MsgPack = "msgpack"
+)
+
+// MaxNameLength là độ dài tối đa của tên codec tính theo byte.
+//
+// Tên codec được lưu cùng mỗi entry, trong một số driver với độ dài một byte.
+const MaxNameLength = 255
+
+// ErrUnknownCodec được trả về khi không có codec nào được đăng ký với tên cho trước.
+var ErrUnknownCodec = errors.New("unknown cache codec")
+
+// Codec mã hóa và giải mã giá trị cache.
+//
+// Mọi phương thức phải an toàn khi được gọi đồng thời.
+type Codec interface {
+	// Name trả về tên của codec, được lưu cùng mỗi entry để chọn codec khi giải mã.
+	Name() string
+
+	// Marshal mã hóa v thành byte.
+	//
+	// Params:
+	//   - v: Giá trị cần mã hóa
+	//
+	// Returns:
+	//   - []byte: Dữ liệu đã mã hóa
+	//   - error: Lỗi nếu không mã hóa được giá trị
+	Marshal(v interface{}) ([]byte, error)
+
+	// Unmarshal giải mã data vào v.
+	//
+	// Params:
+	//   - data: Dữ liệu đã mã hóa
+	//   - v: Con trỏ tới biến nhận giá trị
+	//
+	// Returns:
+	//   - error: Lỗi nếu không giải mã được dữ liệu
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// registry lưu các codec theo tên.
+var registry = struct {
+	sync.RWMutex
+	codecs map[string]Codec
+}{
+	codecs: map[string]Codec{
+		JSON:    jsonCodec{},
+		Gob:     gobCodec{},
+		MsgPack: msgpackCodec{},
+	},
+}
+
+// Register đăng ký một codec theo tên của nó.
+//
+// Codec đăng ký sau thay thế codec đã đăng ký cùng tên. Register panic nếu c là nil,
+// tên rỗng hoặc dài hơn MaxNameLength, giống các registry của thư viện chuẩn.
+//
+// Params:
+//   - c: Codec cần đăng ký
+func Register(c Codec) {
+	if c == nil {
+		panic("codec: Register codec is nil")
+	}
+	name := c.Name()
+	if name == "" || len(name) > MaxNameLength {
+		panic(fmt.Sprintf("codec: Register codec name '%s' must be 1 to %d bytes", name, MaxNameLength))
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	registry.codecs[name] = c
+}
+
+// Get trả về codec đã được đăng ký với tên cho trước.
+//
+// Params:
+//   - name: Tên codec
+//
+// Returns:
+//   - Codec: Codec đã đăng ký
+//   - error: Lỗi wrap ErrUnknownCodec nếu không có codec nào mang tên này
+func Get(name string) (Codec, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	c, ok := registry.codecs[name]
+	if !ok {
+		return nil, fmt.Errorf("%w '%s'", ErrUnknownCodec, name)
+	}
+	return c, nil
+}
+
+// Names trả về tên các codec đã được đăng ký, theo thứ tự bảng chữ cái.
+//
+// Returns:
+//   - []string: Tên các codec
+func Names() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	names := make([]string, 0, len(registry.codecs))
+	for name := range registry.codecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// jsonCodec cài đặt Codec bằng encoding/json.
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string { return JSON }
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+// gobCodec cài đặt Codec bằng encoding/gob.
+type gobCodec struct{}
+
+func (gobCodec) Name() string { return Gob }
+
+func (gobCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gobCodec) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// msgpackCodec cài đặt Codec bằng MessagePack.
+type msgpackCodec struct{}
+
+func (msgpackCodec) Name() string { return MsgPack }
+
+func (msgpackCodec) Marshal(v interface{}) ([]byte, error) { return msgpack.Marshal(v) }
+
+func (msgpackCodec) Unmarshal(data []byte, v interface{}) error { return msgpack.Unmarshal(data, v) }
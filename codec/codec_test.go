@@ -0,0 +1,79 @@
+package codec_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.fork.vn/cache/codec"
+)
+
+// upperCodec là codec tùy chỉnh dùng trong test.
+type upperCodec struct{}
+
+func (upperCodec) Name() string { return "upper" }
+
+func (upperCodec) Marshal(v interface{}) ([]byte, error) {
+	return []byte(strings.ToUpper(v.(string))), nil
+}
+
+func (upperCodec) Unmarshal(data []byte, v interface{}) error {
+	*v.(*string) = string(data)
+	return nil
+}
+
+func TestBuiltinCodecs(t *testing.T) {
+	for _, name := range []string{codec.JSON, codec.Gob, codec.MsgPack} {
+		t.Run(name, func(t *testing.T) {
+			c, err := codec.Get(name)
+			assert.NoError(t, err)
+			assert.Equal(t, name, c.Name())
+
+			data, err := c.Marshal(map[string]string{"name": "cache"})
+			assert.NoError(t, err)
+
+			var value map[string]string
+			assert.NoError(t, c.Unmarshal(data, &value))
+			assert.Equal(t, map[string]string{"name": "cache"}, value)
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	t.Run("registers custom codec", func(t *testing.T) {
+		codec.Register(upperCodec{})
+
+		c, err := codec.Get("upper")
+		assert.NoError(t, err)
+		data, err := c.Marshal("value")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("VALUE"), data)
+		assert.Contains(t, codec.Names(), "upper")
+	})
+
+	t.Run("panics on invalid codec", func(t *testing.T) {
+		assert.Panics(t, func() { codec.Register(nil) })
+		assert.Panics(t, func() { codec.Register(namedCodec("")) })
+		assert.Panics(t, func() { codec.Register(namedCodec(strings.Repeat("x", codec.MaxNameLength+1))) })
+	})
+}
+
+func TestGet(t *testing.T) {
+	_, err := codec.Get("unknown")
+	assert.ErrorIs(t, err, codec.ErrUnknownCodec)
+}
+
+func TestNames(t *testing.T) {
+	names := codec.Names()
+	assert.Subset(t, names, []string{codec.Gob, codec.JSON, codec.MsgPack})
+	assert.IsNonDecreasing(t, names)
+}
+
+// namedCodec là codec chỉ có tên, dùng để kiểm tra việc đăng ký.
+type namedCodec string
+
+func (c namedCodec) Name() string { return string(c) }
+
+func (namedCodec) Marshal(v interface{}) ([]byte, error) { return nil, nil }
+
+func (namedCodec) Unmarshal(data []byte, v interface{}) error { return nil }
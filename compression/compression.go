@@ -0,0 +1,303 @@
+// Package compression cung cấp các thuật toán nén dùng giữa codec và storage của các
+// driver lưu trữ ngoài process (redis, mongodb).
+//
+// Mỗi thuật toán có một tên dùng trong cấu hình và một ID một byte được lưu cùng mỗi entry
+// đã nén, nên entry đã nén và entry chưa nén (hoặc nén bằng thuật toán khác) có thể cùng
+// tồn tại. ID được ghi vào dữ liệu lưu trữ, vì vậy tập thuật toán là cố định và ID của một
+// thuật toán không bao giờ thay đổi.
+package compression
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4"
+)
+
+// Tên các thuật toán nén.
+const (
+	// None tắt nén
+	None = "none"
+
+	// Gzip nén bằng gzip, tỉ lệ nén tốt nhưng chậm nhất
+	Gzip = "gzip"
+
+	// Zstd nén bằng Zstandard, cân bằng giữa tỉ lệ nén và tốc độ
+	Zstd = "zstd"
+
+	// Snappy nén bằng Snappy (block format), rất nhanh với tỉ lệ nén vừa phải
+	Snappy = "snappy"
+
+	// LZ4 nén bằng LZ4 (block format), rất nhanh với tỉ lệ nén vừa phải
+	LZ4 = "lz4"
+)
+
+// ID của các thuật toán nén được lưu cùng entry; 0 nghĩa là dữ liệu không được nén.
+const (
+	IDNone   byte = 0
+	IDGzip   byte = 1
+	IDZstd   byte = 2
+	IDSnappy byte = 3
+	IDLZ4    byte = 4
+)
+
+// MaxDecompressedSize là kích thước tối đa (byte) của dữ liệu sau khi giải nén, bằng giới
+// hạn kích thước một giá trị của Redis.
+const MaxDecompressedSize = 512 << 20
+
+// ErrUnknownCompressor được trả về khi không có thuật toán nén nào với tên hoặc ID cho trước.
+var ErrUnknownCompressor = errors.New("unknown cache compressor")
+
+// errTooLarge được trả về khi dữ liệu giải nén vượt quá MaxDecompressedSize.
+var errTooLarge = fmt.Errorf("decompressed value exceeds %d bytes", MaxDecompressedSize)
+
+// Compressor nén và giải nén dữ liệu đã được codec mã hóa.
+//
+// Mọi phương thức phải an toàn khi được gọi đồng thời.
+type Compressor interface {
+	// Name trả về tên của thuật toán dùng trong cấu hình.
+	Name() string
+
+	// ID trả về ID một byte của thuật toán được lưu cùng entry đã nén.
+	ID() byte
+
+	// Compress nén src.
+	//
+	// Params:
+	//   - src: Dữ liệu cần nén
+	//
+	// Returns:
+	//   - []byte: Dữ liệu đã nén
+	//   - error: Lỗi nếu không nén được dữ liệu
+	Compress(src []byte) ([]byte, error)
+
+	// Decompress giải nén src.
+	//
+	// Params:
+	//   - src: Dữ liệu đã nén
+	//
+	// Returns:
+	//   - []byte: Dữ liệu gốc
+	//   - error: Lỗi nếu dữ liệu hỏng hoặc vượt quá MaxDecompressedSize
+	Decompress(src []byte) ([]byte, error)
+}
+
+// compressors lưu các thuật toán nén theo ID.
+var compressors = []Compressor{
+	gzipCompressor{},
+	&zstdCompressor{},
+	snappyCompressor{},
+	lz4Compressor{},
+}
+
+// Get trả về thuật toán nén theo tên.
+//
+// Params:
+//   - name: Tên thuật toán
+//
+// Returns:
+//   - Compressor: Thuật toán nén, nil nếu name rỗng hoặc là None
+//   - error: Lỗi wrap ErrUnknownCompressor nếu không có thuật toán nào mang tên này
+func Get(name string) (Compressor, error) {
+	if name == "" || name == None {
+		return nil, nil
+	}
+	for _, c := range compressors {
+		if c.Name() == name {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("%w '%s'", ErrUnknownCompressor, name)
+}
+
+// ByID trả về thuật toán nén theo ID được lưu cùng entry.
+//
+// Params:
+//   - id: ID của thuật toán
+//
+// Returns:
+//   - Compressor: Thuật toán nén
+//   - error: Lỗi wrap ErrUnknownCompressor nếu ID không hợp lệ
+func ByID(id byte) (Compressor, error) {
+	for _, c := range compressors {
+		if c.ID() == id {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("%w %d", ErrUnknownCompressor, id)
+}
+
+// Names trả về tên các thuật toán nén, theo thứ tự bảng chữ cái.
+//
+// Returns:
+//   - []string: Tên các thuật toán
+func Names() []string {
+	names := make([]string, 0, len(compressors))
+	for _, c := range compressors {
+		names = append(names, c.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// gzipCompressor cài đặt Compressor bằng gzip.
+type gzipCompressor struct{}
+
+// gzipWriters tái sử dụng gzip writer giữa các lần nén.
+var gzipWriters = sync.Pool{
+	New: func() interface{} { return gzip.NewWriter(nil) },
+}
+
+func (gzipCompressor) Name() string { return Gzip }
+
+func (gzipCompressor) ID() byte { return IDGzip }
+
+func (gzipCompressor) Compress(src []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzipWriters.Get().(*gzip.Writer)
+	defer gzipWriters.Put(w)
+
+	w.Reset(&buf)
+	if _, err := w.Write(src); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gzipCompressor) Decompress(src []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(src))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(io.LimitReader(r, MaxDecompressedSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxDecompressedSize {
+		return nil, errTooLarge
+	}
+	return data, nil
+}
+
+// zstdCompressor cài đặt Compressor bằng Zstandard.
+//
+// Encoder và decoder được tạo khi dùng lần đầu; EncodeAll và DecodeAll an toàn khi được
+// gọi đồng thời.
+type zstdCompressor struct {
+	once    sync.Once
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+	err     error
+}
+
+func (*zstdCompressor) Name() string { return Zstd }
+
+func (*zstdCompressor) ID() byte { return IDZstd }
+
+// init tạo encoder và decoder dùng chung.
+func (c *zstdCompressor) init() error {
+	c.once.Do(func() {
+		c.encoder, c.err = zstd.NewWriter(nil)
+		if c.err == nil {
+			c.decoder, c.err = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(MaxDecompressedSize))
+		}
+	})
+	return c.err
+}
+
+func (c *zstdCompressor) Compress(src []byte) ([]byte, error) {
+	if err := c.init(); err != nil {
+		return nil, err
+	}
+	return c.encoder.EncodeAll(src, nil), nil
+}
+
+func (c *zstdCompressor) Decompress(src []byte) ([]byte, error) {
+	if err := c.init(); err != nil {
+		return nil, err
+	}
+	return c.decoder.DecodeAll(src, nil)
+}
+
+// snappyCompressor cài đặt Compressor bằng Snappy block format.
+type snappyCompressor struct{}
+
+func (snappyCompressor) Name() string { return Snappy }
+
+func (snappyCompressor) ID() byte { return IDSnappy }
+
+func (snappyCompressor) Compress(src []byte) ([]byte, error) {
+	return snappy.Encode(nil, src), nil
+}
+
+func (snappyCompressor) Decompress(src []byte) ([]byte, error) {
+	size, err := snappy.DecodedLen(src)
+	if err != nil {
+		return nil, err
+	}
+	if size > MaxDecompressedSize {
+		return nil, errTooLarge
+	}
+	return snappy.Decode(nil, src)
+}
+
+// lz4Compressor cài đặt Compressor bằng LZ4 block format.
+//
+// Block LZ4 không lưu kích thước gốc, nên dữ liệu nén bắt đầu bằng kích thước gốc dạng
+// uvarint. Dữ liệu không nén được được lưu nguyên vẹn sau kích thước 0.
+type lz4Compressor struct{}
+
+func (lz4Compressor) Name() string { return LZ4 }
+
+func (lz4Compressor) ID() byte { return IDLZ4 }
+
+func (lz4Compressor) Compress(src []byte) ([]byte, error) {
+	dst := make([]byte, binary.MaxVarintLen64+lz4.CompressBlockBound(len(src)))
+	n := binary.PutUvarint(dst, uint64(len(src)))
+	size, err := lz4.CompressBlock(src, dst[n:], nil)
+	if err != nil {
+		return nil, err
+	}
+	if size == 0 {
+		// Dữ liệu không nén được
+		n = binary.PutUvarint(dst, 0)
+		return append(dst[:n], src...), nil
+	}
+	return dst[:n+size], nil
+}
+
+func (lz4Compressor) Decompress(src []byte) ([]byte, error) {
+	size, n := binary.Uvarint(src)
+	if n <= 0 {
+		return nil, errors.New("lz4: invalid block size")
+	}
+	if size > MaxDecompressedSize {
+		return nil, errTooLarge
+	}
+	if size == 0 {
+		return append([]byte(nil), src[n:]...), nil
+	}
+
+	dst := make([]byte, size)
+	written, err := lz4.UncompressBlock(src[n:], dst)
+	if err != nil {
+		return nil, err
+	}
+	if written != int(size) {
+		return nil, errors.New("lz4: decompressed size mismatch")
+	}
+	return dst, nil
+}
@@ -0,0 +1,77 @@
+package compression_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.fork.vn/cache/compression"
+)
+
+func TestCompressors(t *testing.T) {
+	data := []byte(strings.Repeat(`{"name":"cache","tags":["a","b"]}`, 64))
+
+	for _, name := range compression.Names() {
+		t.Run(name, func(t *testing.T) {
+			c, err := compression.Get(name)
+			require.NoError(t, err)
+			assert.Equal(t, name, c.Name())
+
+			compressed, err := c.Compress(data)
+			require.NoError(t, err)
+			assert.Less(t, len(compressed), len(data))
+
+			decompressed, err := c.Decompress(compressed)
+			require.NoError(t, err)
+			assert.Equal(t, data, decompressed)
+
+			byID, err := compression.ByID(c.ID())
+			require.NoError(t, err)
+			assert.Equal(t, name, byID.Name())
+		})
+	}
+}
+
+func TestCompressorsRejectCorruptData(t *testing.T) {
+	for _, name := range compression.Names() {
+		t.Run(name, func(t *testing.T) {
+			c, err := compression.Get(name)
+			require.NoError(t, err)
+
+			_, err = c.Decompress([]byte{0xff, 0xff, 0xff, 0xff, 0x0f, 0x01, 0x02})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLZ4IncompressibleData(t *testing.T) {
+	c, err := compression.Get(compression.LZ4)
+	require.NoError(t, err)
+
+	data := []byte("abc")
+	compressed, err := c.Compress(data)
+	require.NoError(t, err)
+
+	decompressed, err := c.Decompress(compressed)
+	require.NoError(t, err)
+	assert.Equal(t, data, decompressed)
+}
+
+func TestGet(t *testing.T) {
+	t.Run("none disables compression", func(t *testing.T) {
+		for _, name := range []string{"", compression.None} {
+			c, err := compression.Get(name)
+			assert.NoError(t, err)
+			assert.Nil(t, c)
+		}
+	})
+
+	t.Run("unknown algorithm", func(t *testing.T) {
+		_, err := compression.Get("brotli")
+		assert.ErrorIs(t, err, compression.ErrUnknownCompressor)
+
+		_, err = compression.ByID(compression.IDNone)
+		assert.ErrorIs(t, err, compression.ErrUnknownCompressor)
+	})
+}
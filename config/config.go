@@ -20,6 +20,10 @@ type Config struct {
 
 	// Drivers chứa cấu hình cho từng driver
 	Drivers DriversConfig `mapstructure:"drivers" yaml:"drivers"`
+
+	// Encryption là cấu hình mã hóa giá trị khi lưu trữ, áp dụng cho file, redis và mongodb
+	// driver không có cấu hình Encryption riêng
+	Encryption EncryptionConfig `mapstructure:"encryption" yaml:"encryption"`
 }
 
 // DriversConfig chứa cấu hình cho tất cả các driver.
@@ -35,6 +39,9 @@ type DriversConfig struct {
 
 	// MongoDB driver configuration
 	MongoDB *DriverMongodbConfig `mapstructure:"mongodb" yaml:"mongodb"`
+
+	// Tiered driver configuration
+	Tiered *DriverTieredConfig `mapstructure:"tiered" yaml:"tiered"`
 }
 
 // DriverMemoryConfig là cấu hình cho memory driver.
@@ -49,6 +56,20 @@ type DriverMemoryConfig struct {
 
 	// MaxItems là số lượng item tối đa trong memory cache (0 = unlimited)
 	MaxItems int `mapstructure:"max_items" yaml:"max_items"`
+
+	// EvictionPolicy là chính sách loại bỏ item khi vượt quá MaxItems
+	// (lru, lfu, fifo, random; mặc định lru)
+	EvictionPolicy string `mapstructure:"eviction_policy" yaml:"eviction_policy"`
+
+	// MaxBytes là dung lượng tối đa ước lượng của memory cache tính theo byte (0 = unlimited)
+	MaxBytes int64 `mapstructure:"max_bytes" yaml:"max_bytes"`
+
+	// Shards là số phân vùng của memory cache, mỗi phân vùng có lock riêng (0 = 1 shard)
+	Shards int `mapstructure:"shards" yaml:"shards"`
+
+	// SlidingTTL là thời gian sống (giây) được gia hạn mỗi lần Get trúng key có thời hạn,
+	// thời điểm hết hạn chỉ được kéo dài, không bị rút ngắn (0 = tắt sliding expiration)
+	SlidingTTL int `mapstructure:"sliding_ttl" yaml:"sliding_ttl"`
 }
 
 // DriverFileConfig là cấu hình cho file driver.
@@ -66,6 +87,36 @@ type DriverFileConfig struct {
 
 	// CleanupInterval là khoảng thời gian dọn dẹp các file hết hạn (giây)
 	CleanupInterval int `mapstructure:"cleanup_interval" yaml:"cleanup_interval"`
+
+	// SlidingTTL là thời gian sống (giây) được gia hạn mỗi lần Get trúng key có thời hạn,
+	// thời điểm hết hạn chỉ được kéo dài, không bị rút ngắn (0 = tắt sliding expiration)
+	SlidingTTL int `mapstructure:"sliding_ttl" yaml:"sliding_ttl"`
+
+	// Fsync xác định có đồng bộ file tạm xuống đĩa trước khi đổi tên thành file cache không;
+	// tắt để ghi nhanh hơn khi không cần giữ dữ liệu qua sự cố mất điện
+	Fsync bool `mapstructure:"fsync" yaml:"fsync"`
+
+	// Depth là số cấp thư mục con lồng nhau theo hash của key, ví dụ "ab/cd/abcd…" với
+	// Depth = 2 và FanOut = 256 (0 = lưu mọi file trong một thư mục phẳng)
+	Depth int `mapstructure:"depth" yaml:"depth"`
+
+	// FanOut là số thư mục con ở mỗi cấp: 16, 256 hoặc 4096 (mặc định 256)
+	FanOut int `mapstructure:"fan_out" yaml:"fan_out"`
+
+	// MaxSizeBytes là tổng dung lượng tối đa của các file cache tính theo byte; khi vượt
+	// quá, các entry ít được truy cập gần đây nhất bị loại bỏ (0 = unlimited)
+	MaxSizeBytes int64 `mapstructure:"max_size_bytes" yaml:"max_size_bytes"`
+
+	// MaxFiles là số file cache tối đa; khi vượt quá, các entry ít được truy cập gần đây
+	// nhất bị loại bỏ (0 = unlimited)
+	MaxFiles int `mapstructure:"max_files" yaml:"max_files"`
+
+	// Codec là tên codec mã hóa giá trị trong file cache: gob, json, msgpack hoặc codec
+	// đã đăng ký bằng codec.Register (mặc định gob)
+	Codec string `mapstructure:"codec" yaml:"codec"`
+
+	// Encryption là cấu hình mã hóa giá trị của driver; nil sử dụng Config.Encryption
+	Encryption *EncryptionConfig `mapstructure:"encryption" yaml:"encryption,omitempty"`
 }
 
 // DriverRedisConfig là cấu hình cho redis driver.
@@ -76,8 +127,25 @@ type DriverRedisConfig struct {
 	// DefaultTTL là thời gian hết hạn mặc định cho Redis cache (giây)
 	DefaultTTL int `mapstructure:"default_ttl" yaml:"default_ttl"`
 
-	// Serializer là định dạng serialization: json, gob, msgpack
+	// Serializer là tên codec mã hóa giá trị: json, gob, msgpack hoặc codec đã đăng ký
+	// bằng codec.Register (tên không hợp lệ hoặc rỗng sử dụng json)
 	Serializer string `mapstructure:"serializer" yaml:"serializer"`
+
+	// RememberLock là cấu hình khóa phân tán cho Remember
+	RememberLock RememberLockConfig `mapstructure:"remember_lock" yaml:"remember_lock"`
+
+	// Invalidation là cấu hình kênh pub/sub vô hiệu hóa cache cục bộ giữa các instance
+	Invalidation InvalidationConfig `mapstructure:"invalidation" yaml:"invalidation"`
+
+	// SlidingTTL là thời gian sống (giây) được gia hạn mỗi lần Get trúng key có thời hạn,
+	// thời điểm hết hạn chỉ được kéo dài, không bị rút ngắn (0 = tắt sliding expiration)
+	SlidingTTL int `mapstructure:"sliding_ttl" yaml:"sliding_ttl"`
+
+	// Compression là cấu hình nén giá trị trước khi ghi vào Redis
+	Compression CompressionConfig `mapstructure:"compression" yaml:"compression"`
+
+	// Encryption là cấu hình mã hóa giá trị của driver; nil sử dụng Config.Encryption
+	Encryption *EncryptionConfig `mapstructure:"encryption" yaml:"encryption,omitempty"`
 }
 
 // DriverMongodbConfig là cấu hình cho mongodb driver.
@@ -99,6 +167,113 @@ type DriverMongodbConfig struct {
 
 	// Misses là số lần cache miss (readonly, được quản lý bởi driver)
 	Misses int64 `mapstructure:"misses" yaml:"misses"`
+
+	// RememberLock là cấu hình khóa phân tán cho Remember
+	RememberLock RememberLockConfig `mapstructure:"remember_lock" yaml:"remember_lock"`
+
+	// SlidingTTL là thời gian sống (giây) được gia hạn mỗi lần Get trúng key có thời hạn,
+	// thời điểm hết hạn chỉ được kéo dài, không bị rút ngắn (0 = tắt sliding expiration)
+	SlidingTTL int `mapstructure:"sliding_ttl" yaml:"sliding_ttl"`
+
+	// Codec là tên codec (json, gob, msgpack hoặc codec đã đăng ký bằng codec.Register)
+	// mã hóa giá trị thành dữ liệu nhị phân trong document; để trống để lưu giá trị dưới
+	// dạng BSON gốc
+	Codec string `mapstructure:"codec" yaml:"codec"`
+
+	// Compression là cấu hình nén giá trị đã được codec mã hóa (yêu cầu Codec)
+	Compression CompressionConfig `mapstructure:"compression" yaml:"compression"`
+
+	// Encryption là cấu hình mã hóa giá trị đã được codec mã hóa (yêu cầu Codec);
+	// nil sử dụng Config.Encryption
+	Encryption *EncryptionConfig `mapstructure:"encryption" yaml:"encryption,omitempty"`
+}
+
+// DriverTieredConfig là cấu hình cho tiered driver.
+//
+// Tiered driver xếp chồng các driver đã được cấu hình thành nhiều tầng (ví dụ L1 memory,
+// L2 redis): đọc từ tầng trên xuống và ghi vào mọi tầng.
+type DriverTieredConfig struct {
+	// Enabled xác định có kích hoạt Tiered driver không
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+
+	// Tiers là danh sách tên driver theo thứ tự từ tầng nhanh nhất (L1) đến tầng chậm nhất
+	Tiers []string `mapstructure:"tiers" yaml:"tiers"`
+
+	// BackfillTTL là thời gian sống (giây) của giá trị được chép ngược lên các tầng trên
+	// khi đọc trúng ở tầng dưới
+	BackfillTTL int `mapstructure:"backfill_ttl" yaml:"backfill_ttl"`
+}
+
+// InvalidationConfig là cấu hình invalidation bus qua Redis pub/sub.
+//
+// Khi được bật, mỗi thao tác ghi hoặc xóa qua redis driver phát một sự kiện trên kênh,
+// và mọi instance xóa các key tương ứng khỏi các memory driver cục bộ đã đăng ký.
+type InvalidationConfig struct {
+	// Enabled xác định có sử dụng invalidation bus không
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+
+	// Channel là tên kênh Redis pub/sub dùng để phát sự kiện
+	Channel string `mapstructure:"channel" yaml:"channel"`
+}
+
+// CompressionConfig là cấu hình nén giá trị của redis và mongodb driver.
+//
+// Dữ liệu đã được codec mã hóa được nén trước khi ghi nếu có kích thước từ MinSize byte
+// trở lên và nhỏ đi sau khi nén. Thuật toán nén được lưu cùng mỗi entry, nên entry đã nén
+// và chưa nén có thể cùng tồn tại khi bật, tắt hoặc đổi thuật toán.
+type CompressionConfig struct {
+	// Algorithm là thuật toán nén: gzip, zstd, snappy hoặc lz4 (rỗng hoặc none = tắt nén)
+	Algorithm string `mapstructure:"algorithm" yaml:"algorithm"`
+
+	// MinSize là kích thước tối thiểu (byte) của giá trị được nén, mặc định 1024
+	MinSize int `mapstructure:"min_size" yaml:"min_size"`
+}
+
+// EncryptionConfig là cấu hình mã hóa giá trị cache khi lưu trữ.
+//
+// Entry mới được mã hóa bằng khóa ActiveKey; các khóa còn lại chỉ dùng để giải mã entry
+// đã ghi trước khi xoay vòng khóa. ID khóa được lưu cùng mỗi entry, nên có thể đổi
+// ActiveKey và giữ khóa cũ trong Keys cho tới khi mọi entry đã được mã hóa lại.
+type EncryptionConfig struct {
+	// Enabled xác định có mã hóa giá trị không
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+
+	// ActiveKey là ID của khóa dùng để mã hóa entry mới
+	ActiveKey string `mapstructure:"active_key" yaml:"active_key"`
+
+	// Keys là danh sách khóa, gồm khóa đang hoạt động và các khóa cũ chỉ dùng để giải mã
+	Keys []EncryptionKeyConfig `mapstructure:"keys" yaml:"keys"`
+}
+
+// EncryptionKeyConfig là cấu hình một khóa mã hóa.
+type EncryptionKeyConfig struct {
+	// ID là định danh của khóa được lưu cùng mỗi entry (tối đa 255 byte)
+	ID string `mapstructure:"id" yaml:"id"`
+
+	// Algorithm là thuật toán của khóa: aes-gcm hoặc xchacha20-poly1305 (mặc định aes-gcm)
+	Algorithm string `mapstructure:"algorithm" yaml:"algorithm"`
+
+	// Key là giá trị khóa mã hóa base64: 16, 24 hoặc 32 byte với aes-gcm, 32 byte với
+	// xchacha20-poly1305
+	Key string `mapstructure:"key" yaml:"key"`
+}
+
+// RememberLockConfig là cấu hình khóa phân tán cho Remember của redis và mongodb driver.
+//
+// Khi được bật, chỉ một instance trong toàn hệ thống thực thi callback cho cùng một key
+// tại một thời điểm; các instance khác chờ giá trị được ghi vào cache.
+type RememberLockConfig struct {
+	// Enabled xác định có sử dụng khóa phân tán cho Remember không
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+
+	// TTL là thời gian giữ khóa tối đa (giây), tránh khóa bị giữ mãi khi instance gặp sự cố
+	TTL int `mapstructure:"ttl" yaml:"ttl"`
+
+	// WaitTimeout là thời gian tối đa chờ instance giữ khóa ghi giá trị (giây)
+	WaitTimeout int `mapstructure:"wait_timeout" yaml:"wait_timeout"`
+
+	// RetryInterval là khoảng thời gian giữa các lần kiểm tra lại khi chờ (milliseconds)
+	RetryInterval int `mapstructure:"retry_interval" yaml:"retry_interval"`
 }
 
 // DefaultConfig trả về cấu hình mặc định cho cache.
@@ -117,17 +292,31 @@ func DefaultConfig() *Config {
 				DefaultTTL:      3600, // 1 hour
 				CleanupInterval: 600,  // 10 minutes
 				MaxItems:        10000,
+				EvictionPolicy:  "lru",
+				Shards:          16,
 			},
 			File: &DriverFileConfig{
 				Path:            "./storage/cache",
 				DefaultTTL:      3600, // 1 hour
 				Extension:       ".cache",
 				CleanupInterval: 600, // 10 minutes
+				Fsync:           true,
+				Depth:           2,
+				FanOut:          256,
+				Codec:           "gob",
 			},
 			Redis: &DriverRedisConfig{
 				Enabled:    true,
 				DefaultTTL: 3600, // 1 hour
 				Serializer: "json",
+				RememberLock: RememberLockConfig{
+					TTL:           10,
+					WaitTimeout:   5,
+					RetryInterval: 50,
+				},
+				Invalidation: InvalidationConfig{
+					Channel: "cache:invalidation",
+				},
 			},
 			MongoDB: &DriverMongodbConfig{
 				Enabled:    true,
@@ -136,6 +325,15 @@ func DefaultConfig() *Config {
 				DefaultTTL: 3600, // 1 hour
 				Hits:       0,
 				Misses:     0,
+				RememberLock: RememberLockConfig{
+					TTL:           10,
+					WaitTimeout:   5,
+					RetryInterval: 50,
+				},
+			},
+			Tiered: &DriverTieredConfig{
+				Tiers:       []string{"memory", "redis"},
+				BackfillTTL: 60, // 1 minute
 			},
 		},
 	}
@@ -181,6 +379,28 @@ func (f *DriverFileConfig) GetFileCleanupInterval() time.Duration {
 	return time.Duration(f.CleanupInterval) * time.Second
 }
 
+// GetFanOut trả về số thư mục con ở mỗi cấp của file driver, mặc định 256.
+//
+// Returns:
+//   - int: Số thư mục con ở mỗi cấp
+func (f *DriverFileConfig) GetFanOut() int {
+	if f.FanOut == 0 {
+		return 256
+	}
+	return f.FanOut
+}
+
+// GetCodec trả về tên codec của file driver, mặc định gob.
+//
+// Returns:
+//   - string: Tên codec
+func (f *DriverFileConfig) GetCodec() string {
+	if f.Codec == "" {
+		return "gob"
+	}
+	return f.Codec
+}
+
 // GetRedisDefaultExpiration trả về thời gian hết hạn mặc định cho redis driver.
 //
 // Returns:
@@ -196,3 +416,101 @@ func (r *DriverRedisConfig) GetDefaultExpiration() time.Duration {
 func (m *DriverMongodbConfig) GetDefaultExpiration() time.Duration {
 	return time.Duration(m.DefaultTTL) * time.Second
 }
+
+// GetSlidingTTL trả về thời gian sống được gia hạn sau mỗi lần Get trúng cho memory driver.
+//
+// Returns:
+//   - time.Duration: Thời gian gia hạn, 0 nếu sliding expiration bị tắt
+func (m *DriverMemoryConfig) GetSlidingTTL() time.Duration {
+	return time.Duration(m.SlidingTTL) * time.Second
+}
+
+// GetSlidingTTL trả về thời gian sống được gia hạn sau mỗi lần Get trúng cho file driver.
+//
+// Returns:
+//   - time.Duration: Thời gian gia hạn, 0 nếu sliding expiration bị tắt
+func (f *DriverFileConfig) GetSlidingTTL() time.Duration {
+	return time.Duration(f.SlidingTTL) * time.Second
+}
+
+// GetSlidingTTL trả về thời gian sống được gia hạn sau mỗi lần Get trúng cho redis driver.
+//
+// Returns:
+//   - time.Duration: Thời gian gia hạn, 0 nếu sliding expiration bị tắt
+func (r *DriverRedisConfig) GetSlidingTTL() time.Duration {
+	return time.Duration(r.SlidingTTL) * time.Second
+}
+
+// GetSlidingTTL trả về thời gian sống được gia hạn sau mỗi lần Get trúng cho mongodb driver.
+//
+// Returns:
+//   - time.Duration: Thời gian gia hạn, 0 nếu sliding expiration bị tắt
+func (m *DriverMongodbConfig) GetSlidingTTL() time.Duration {
+	return time.Duration(m.SlidingTTL) * time.Second
+}
+
+// GetBackfillTTL trả về thời gian sống của giá trị được chép lên các tầng trên, mặc định 60 giây.
+//
+// Returns:
+//   - time.Duration: Thời gian sống của giá trị được chép lên
+func (t *DriverTieredConfig) GetBackfillTTL() time.Duration {
+	if t.BackfillTTL <= 0 {
+		return 60 * time.Second
+	}
+	return time.Duration(t.BackfillTTL) * time.Second
+}
+
+// GetChannel trả về tên kênh pub/sub, mặc định "cache:invalidation".
+//
+// Returns:
+//   - string: Tên kênh pub/sub
+func (i *InvalidationConfig) GetChannel() string {
+	if i.Channel == "" {
+		return "cache:invalidation"
+	}
+	return i.Channel
+}
+
+// GetMinSize trả về kích thước tối thiểu của giá trị được nén, mặc định 1024 byte.
+//
+// Returns:
+//   - int: Kích thước tối thiểu (byte)
+func (c *CompressionConfig) GetMinSize() int {
+	if c.MinSize <= 0 {
+		return 1024
+	}
+	return c.MinSize
+}
+
+// GetTTL trả về thời gian giữ khóa tối đa, mặc định 10 giây.
+//
+// Returns:
+//   - time.Duration: Thời gian giữ khóa tối đa
+func (l *RememberLockConfig) GetTTL() time.Duration {
+	if l.TTL <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(l.TTL) * time.Second
+}
+
+// GetWaitTimeout trả về thời gian chờ tối đa, mặc định 5 giây.
+//
+// Returns:
+//   - time.Duration: Thời gian chờ tối đa
+func (l *RememberLockConfig) GetWaitTimeout() time.Duration {
+	if l.WaitTimeout <= 0 {
+		return 5 * time.Second
+	}
+	return time.Duration(l.WaitTimeout) * time.Second
+}
+
+// GetRetryInterval trả về khoảng thời gian giữa các lần kiểm tra lại, mặc định 50 milliseconds.
+//
+// Returns:
+//   - time.Duration: Khoảng thời gian giữa các lần kiểm tra lại
+func (l *RememberLockConfig) GetRetryInterval() time.Duration {
+	if l.RetryInterval <= 0 {
+		return 50 * time.Millisecond
+	}
+	return time.Duration(l.RetryInterval) * time.Millisecond
+}
@@ -36,6 +36,8 @@ func TestDefaultConfig(t *testing.T) {
 		assert.Equal(t, 3600, memory.DefaultTTL)
 		assert.Equal(t, 600, memory.CleanupInterval)
 		assert.Equal(t, 10000, memory.MaxItems)
+		assert.Equal(t, "lru", memory.EvictionPolicy)
+		assert.Equal(t, 16, memory.Shards)
 	})
 
 	t.Run("file driver has correct default values", func(t *testing.T) {
@@ -48,6 +50,9 @@ func TestDefaultConfig(t *testing.T) {
 		assert.Equal(t, 3600, file.DefaultTTL)
 		assert.Equal(t, ".cache", file.Extension)
 		assert.Equal(t, 600, file.CleanupInterval)
+		assert.True(t, file.Fsync)
+		assert.Equal(t, 2, file.Depth)
+		assert.Equal(t, 256, file.FanOut)
 	})
 
 	t.Run("redis driver has correct default values", func(t *testing.T) {
@@ -59,6 +64,10 @@ func TestDefaultConfig(t *testing.T) {
 		assert.True(t, redis.Enabled)
 		assert.Equal(t, 3600, redis.DefaultTTL)
 		assert.Equal(t, "json", redis.Serializer)
+		assert.False(t, redis.RememberLock.Enabled)
+		assert.Equal(t, 10, redis.RememberLock.TTL)
+		assert.False(t, redis.Invalidation.Enabled)
+		assert.Equal(t, "cache:invalidation", redis.Invalidation.Channel)
 	})
 
 	t.Run("mongodb driver has correct default values", func(t *testing.T) {
@@ -73,6 +82,19 @@ func TestDefaultConfig(t *testing.T) {
 		assert.Equal(t, 3600, mongodb.DefaultTTL)
 		assert.Equal(t, int64(0), mongodb.Hits)
 		assert.Equal(t, int64(0), mongodb.Misses)
+		assert.False(t, mongodb.RememberLock.Enabled)
+		assert.Equal(t, 5, mongodb.RememberLock.WaitTimeout)
+	})
+
+	t.Run("tiered driver config", func(t *testing.T) {
+		// Act
+		config := DefaultConfig()
+
+		// Assert
+		tiered := config.Drivers.Tiered
+		assert.False(t, tiered.Enabled)
+		assert.Equal(t, []string{"memory", "redis"}, tiered.Tiers)
+		assert.Equal(t, 60, tiered.BackfillTTL)
 	})
 }
 
@@ -200,6 +222,26 @@ func TestDriverFileConfigMethods(t *testing.T) {
 		// Assert
 		assert.Equal(t, -time.Second, duration)
 	})
+
+	t.Run("GetFanOut defaults to 256", func(t *testing.T) {
+		// Arrange
+		defaulted := &DriverFileConfig{}
+		configured := &DriverFileConfig{FanOut: 16}
+
+		// Act & Assert
+		assert.Equal(t, 256, defaulted.GetFanOut())
+		assert.Equal(t, 16, configured.GetFanOut())
+	})
+
+	t.Run("GetCodec defaults to gob", func(t *testing.T) {
+		// Arrange
+		defaulted := &DriverFileConfig{}
+		configured := &DriverFileConfig{Codec: "msgpack"}
+
+		// Act & Assert
+		assert.Equal(t, "gob", defaulted.GetCodec())
+		assert.Equal(t, "msgpack", configured.GetCodec())
+	})
 }
 
 // TestDriverRedisConfigMethods tests DriverRedisConfig methods
@@ -252,6 +294,108 @@ func TestDriverMongodbConfigMethods(t *testing.T) {
 	})
 }
 
+// TestRememberLockConfigMethods tests RememberLockConfig methods
+func TestRememberLockConfigMethods(t *testing.T) {
+	t.Run("returns configured durations", func(t *testing.T) {
+		// Arrange
+		config := &RememberLockConfig{TTL: 30, WaitTimeout: 2, RetryInterval: 100}
+
+		// Act & Assert
+		assert.Equal(t, 30*time.Second, config.GetTTL())
+		assert.Equal(t, 2*time.Second, config.GetWaitTimeout())
+		assert.Equal(t, 100*time.Millisecond, config.GetRetryInterval())
+	})
+
+	t.Run("zero values fall back to defaults", func(t *testing.T) {
+		// Arrange
+		config := &RememberLockConfig{}
+
+		// Act & Assert
+		assert.Equal(t, 10*time.Second, config.GetTTL())
+		assert.Equal(t, 5*time.Second, config.GetWaitTimeout())
+		assert.Equal(t, 50*time.Millisecond, config.GetRetryInterval())
+	})
+}
+
+// TestCompressionConfigMethods tests CompressionConfig methods
+func TestCompressionConfigMethods(t *testing.T) {
+	t.Run("returns configured min size", func(t *testing.T) {
+		config := &CompressionConfig{Algorithm: "zstd", MinSize: 256}
+		assert.Equal(t, 256, config.GetMinSize())
+	})
+
+	t.Run("zero min size falls back to default", func(t *testing.T) {
+		config := &CompressionConfig{}
+		assert.Equal(t, 1024, config.GetMinSize())
+	})
+}
+
+// TestInvalidationConfigMethods tests InvalidationConfig methods
+func TestInvalidationConfigMethods(t *testing.T) {
+	t.Run("GetChannel", func(t *testing.T) {
+		// Arrange
+		config := &InvalidationConfig{Channel: "app:cache:events"}
+
+		// Act & Assert
+		assert.Equal(t, "app:cache:events", config.GetChannel())
+	})
+
+	t.Run("GetChannel falls back to default", func(t *testing.T) {
+		// Arrange
+		config := &InvalidationConfig{}
+
+		// Act & Assert
+		assert.Equal(t, "cache:invalidation", config.GetChannel())
+	})
+}
+
+// TestDriverTieredConfigMethods tests DriverTieredConfig methods
+func TestDriverTieredConfigMethods(t *testing.T) {
+	t.Run("GetBackfillTTL", func(t *testing.T) {
+		// Arrange
+		config := &DriverTieredConfig{BackfillTTL: 30}
+
+		// Act & Assert
+		assert.Equal(t, 30*time.Second, config.GetBackfillTTL())
+	})
+
+	t.Run("GetBackfillTTL falls back to default", func(t *testing.T) {
+		// Arrange
+		config := &DriverTieredConfig{}
+
+		// Act & Assert
+		assert.Equal(t, 60*time.Second, config.GetBackfillTTL())
+	})
+}
+
+// TestSlidingTTLMethods tests GetSlidingTTL of driver configs
+func TestSlidingTTLMethods(t *testing.T) {
+	t.Run("GetSlidingTTL returns configured duration", func(t *testing.T) {
+		// Arrange
+		memory := &DriverMemoryConfig{SlidingTTL: 60}
+		file := &DriverFileConfig{SlidingTTL: 120}
+		redis := &DriverRedisConfig{SlidingTTL: 1800}
+		mongodb := &DriverMongodbConfig{SlidingTTL: 3600}
+
+		// Act & Assert
+		assert.Equal(t, time.Minute, memory.GetSlidingTTL())
+		assert.Equal(t, 2*time.Minute, file.GetSlidingTTL())
+		assert.Equal(t, 30*time.Minute, redis.GetSlidingTTL())
+		assert.Equal(t, time.Hour, mongodb.GetSlidingTTL())
+	})
+
+	t.Run("sliding expiration is disabled by default", func(t *testing.T) {
+		// Arrange
+		cfg := DefaultConfig()
+
+		// Act & Assert
+		assert.Zero(t, cfg.Drivers.Memory.GetSlidingTTL())
+		assert.Zero(t, cfg.Drivers.File.GetSlidingTTL())
+		assert.Zero(t, cfg.Drivers.Redis.GetSlidingTTL())
+		assert.Zero(t, cfg.Drivers.MongoDB.GetSlidingTTL())
+	})
+}
+
 // TestConfigStructValidation tests config struct validation
 func TestConfigStructValidation(t *testing.T) {
 	t.Run("empty config struct", func(t *testing.T) {
@@ -22,6 +22,9 @@
 //	├── manager.go              # Manager interface và DefaultManager implementation
 //	├── provider.go             # ServiceProvider cho DI integration
 //	├── doc.go                  # Package documentation
+//	├── codec/                  # Codec interface và registry dùng chung cho các driver
+//	├── compression/            # Thuật toán nén giá trị (gzip, zstd, snappy, lz4)
+//	├── encryption/             # Mã hóa giá trị (AES-GCM, XChaCha20-Poly1305) với keyring xoay vòng khóa
 //	├── config/
 //	│   ├── config.go           # Configuration structs và loading
 //	│   └── config_test.go      # Configuration tests
@@ -31,6 +34,7 @@
 //	│   ├── file.go             # File-based cache driver
 //	│   ├── redis.go            # Redis cache driver (v9+)
 //	│   └── mongodb.go          # MongoDB cache driver
+//	├── ratelimit/              # Rate limiter trên cache driver
 //	├── mocks/                  # Auto-generated mocks cho testing
 //	└── configs/                # Sample configuration files
 //
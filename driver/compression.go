@@ -0,0 +1,121 @@
+package driver
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"go.fork.vn/cache/compression"
+	"go.fork.vn/cache/config"
+)
+
+// valueCompressor nén dữ liệu đã được codec mã hóa trước khi driver ghi vào storage.
+//
+// Dữ liệu nhỏ hơn minSize hoặc không nhỏ đi sau khi nén được ghi nguyên vẹn. Các bộ đếm
+// tính trên mọi giá trị được ghi kể từ khi driver khởi tạo và được dùng cho Stats.
+type valueCompressor struct {
+	compressor   compression.Compressor // Thuật toán nén, nil nếu tắt nén
+	minSize      int                    // Kích thước tối thiểu của giá trị được nén
+	bytesIn      atomic.Int64           // Tổng kích thước các giá trị trước khi nén
+	bytesOut     atomic.Int64           // Tổng kích thước các giá trị được ghi
+	compressed   atomic.Int64           // Số giá trị đã được nén
+	uncompressed atomic.Int64           // Số giá trị được ghi nguyên vẹn
+}
+
+// newValueCompressor tạo stage nén theo cấu hình.
+//
+// Params:
+//   - cfg: Cấu hình nén
+//
+// Returns:
+//   - *valueCompressor: Stage nén (không nén gì nếu thuật toán rỗng hoặc none)
+//   - error: Lỗi nếu thuật toán không được hỗ trợ
+func newValueCompressor(cfg config.CompressionConfig) (*valueCompressor, error) {
+	c, err := compression.Get(cfg.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	return &valueCompressor{compressor: c, minSize: cfg.GetMinSize()}, nil
+}
+
+// enabled kiểm tra stage có nén giá trị hay không.
+func (v *valueCompressor) enabled() bool {
+	return v != nil && v.compressor != nil
+}
+
+// compress nén dữ liệu nếu dữ liệu đủ lớn và nhỏ đi sau khi nén.
+//
+// Lỗi của thuật toán nén không làm thao tác ghi thất bại: dữ liệu được ghi nguyên vẹn.
+//
+// Params:
+//   - data: Dữ liệu đã được codec mã hóa
+//
+// Returns:
+//   - []byte: Dữ liệu cần ghi
+//   - byte: ID của thuật toán đã nén dữ liệu, compression.IDNone nếu không nén
+func (v *valueCompressor) compress(data []byte) ([]byte, byte) {
+	if !v.enabled() {
+		return data, compression.IDNone
+	}
+	v.bytesIn.Add(int64(len(data)))
+
+	if len(data) >= v.minSize {
+		if out, err := v.compressor.Compress(data); err == nil && len(out) < len(data) {
+			v.compressed.Add(1)
+			v.bytesOut.Add(int64(len(out)))
+			return out, v.compressor.ID()
+		}
+	}
+	v.uncompressed.Add(1)
+	v.bytesOut.Add(int64(len(data)))
+	return data, compression.IDNone
+}
+
+// decompressValue giải nén dữ liệu đọc từ storage bằng thuật toán đã nén nó.
+//
+// Params:
+//   - data: Dữ liệu đọc từ storage
+//   - id: ID của thuật toán đã nén dữ liệu, compression.IDNone nếu không nén
+//
+// Returns:
+//   - []byte: Dữ liệu của codec
+//   - error: Lỗi nếu ID không hợp lệ hoặc dữ liệu hỏng
+func decompressValue(data []byte, id byte) ([]byte, error) {
+	if id == compression.IDNone {
+		return data, nil
+	}
+	c, err := compression.ByID(id)
+	if err != nil {
+		return nil, err
+	}
+	out, err := c.Decompress(data)
+	if err != nil {
+		return nil, fmt.Errorf("could not decompress value: %w", err)
+	}
+	return out, nil
+}
+
+// addStats thêm thống kê nén vào kết quả Stats của driver.
+//
+// compression_ratio là tổng kích thước trước khi nén chia cho tổng kích thước được ghi
+// (1 nếu chưa ghi giá trị nào hoặc tắt nén).
+//
+// Params:
+//   - stats: Kết quả Stats của driver
+func (v *valueCompressor) addStats(stats map[string]interface{}) {
+	if !v.enabled() {
+		stats["compression"] = compression.None
+		return
+	}
+
+	in, out := v.bytesIn.Load(), v.bytesOut.Load()
+	ratio := 1.0
+	if out > 0 {
+		ratio = float64(in) / float64(out)
+	}
+	stats["compression"] = v.compressor.Name()
+	stats["compression_ratio"] = ratio
+	stats["compressed_values"] = v.compressed.Load()
+	stats["uncompressed_values"] = v.uncompressed.Load()
+	stats["bytes_before_compression"] = in
+	stats["bytes_after_compression"] = out
+}
@@ -0,0 +1,95 @@
+package driver
+
+import (
+	"math"
+	"sync"
+)
+
+// toInt64 chuyển giá trị đã lưu của một bộ đếm thành int64.
+//
+// Số thực chỉ được chấp nhận khi có giá trị nguyên, vì các serializer như JSON
+// giải mã mọi số thành float64.
+//
+// Params:
+//   - value: Giá trị đọc từ cache
+//
+// Returns:
+//   - int64: Giá trị số nguyên
+//   - bool: true nếu value là số nguyên
+func toInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint:
+		return int64(v), v <= math.MaxInt64
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		return int64(v), v <= math.MaxInt64
+	case float32:
+		return toInt64(float64(v))
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	}
+	return 0, false
+}
+
+// toFloat64 chuyển giá trị đã lưu của một bộ đếm thành float64.
+//
+// Params:
+//   - value: Giá trị đọc từ cache
+//
+// Returns:
+//   - float64: Giá trị số thực
+//   - bool: true nếu value là số
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	if n, ok := toInt64(value); ok {
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// keyLockStripes là số mutex dùng để khóa theo key.
+const keyLockStripes = 64
+
+// keyLocks cung cấp khóa theo key cho các thao tác đọc-sửa-ghi.
+//
+// Các key được ánh xạ vào một số mutex cố định theo hash, nên hai key khác nhau
+// có thể dùng chung mutex nhưng một key luôn dùng cùng một mutex.
+type keyLocks struct {
+	stripes [keyLockStripes]sync.Mutex
+}
+
+// lock khóa mutex của key và trả về hàm mở khóa.
+//
+// Params:
+//   - key: Cache key cần khóa
+//
+// Returns:
+//   - func(): Hàm mở khóa
+func (l *keyLocks) lock(key string) func() {
+	mu := &l.stripes[shardIndex(key, keyLockStripes)]
+	mu.Lock()
+	return mu.Unlock
+}
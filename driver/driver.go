@@ -10,6 +10,9 @@ package driver
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
 	"time"
 )
 
@@ -141,6 +144,258 @@ type Driver interface {
 	//   - error: Lỗi nếu có trong quá trình thực hiện hoặc từ callback
 	Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error)
 
+	// RememberStale lấy một giá trị từ cache theo cơ chế stale-while-revalidate.
+	//
+	// Giá trị được coi là còn mới trong ttl và tiếp tục được phục vụ trong grace sau đó.
+	// Trong khoảng grace, giá trị cũ được trả về ngay và callback được thực thi trong nền
+	// để làm mới. Khi key không có trong cache, phương thức hoạt động giống Remember.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa cần tìm hoặc lưu vào cache
+	//   - ttl: Thời gian giá trị được coi là còn mới (0 để sử dụng mặc định)
+	//   - grace: Thời gian giá trị cũ tiếp tục được phục vụ sau ttl
+	//   - callback: Hàm được gọi để lấy dữ liệu
+	//
+	// Returns:
+	//   - interface{}: Giá trị từ cache hoặc từ callback
+	//   - error: Lỗi nếu có trong quá trình thực hiện hoặc từ callback
+	RememberStale(ctx context.Context, key string, ttl, grace time.Duration, callback func() (interface{}, error)) (interface{}, error)
+
+	// Add lưu giá trị chỉ khi key chưa tồn tại (hoặc đã hết hạn).
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa để lưu giá trị
+	//   - value: Giá trị cần lưu trữ
+	//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - bool: true nếu giá trị được lưu, false nếu key đã tồn tại
+	//   - error: Lỗi nếu có trong quá trình lưu trữ
+	Add(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
+
+	// Replace thay giá trị chỉ khi key đang tồn tại và chưa hết hạn.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa cần thay giá trị
+	//   - value: Giá trị mới
+	//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - bool: true nếu giá trị được thay, false nếu key không tồn tại
+	//   - error: Lỗi nếu có trong quá trình lưu trữ
+	Replace(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
+
+	// GetAndSet lưu giá trị mới và trả về giá trị cũ trong cùng một thao tác nguyên tử.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa để lưu giá trị
+	//   - value: Giá trị mới
+	//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - interface{}: Giá trị cũ (nil nếu không có)
+	//   - bool: true nếu key tồn tại trước đó
+	//   - error: Lỗi nếu có trong quá trình lưu trữ
+	GetAndSet(ctx context.Context, key string, value interface{}, ttl time.Duration) (interface{}, bool, error)
+
+	// GetAndDelete lấy giá trị và xóa key trong cùng một thao tác nguyên tử (pull).
+	//
+	// Khi nhiều caller cùng gọi trên một key, chỉ một caller nhận được giá trị.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa cần lấy và xóa
+	//
+	// Returns:
+	//   - interface{}: Giá trị đã xóa (nil nếu không có)
+	//   - bool: true nếu key tồn tại và chưa hết hạn
+	//   - error: Lỗi nếu có trong quá trình xóa
+	GetAndDelete(ctx context.Context, key string) (interface{}, bool, error)
+
+	// GetWithVersion lấy giá trị cùng token phiên bản của entry.
+	//
+	// Token là chuỗi không trong suốt, thay đổi mỗi khi entry được ghi, và chỉ có ý nghĩa
+	// khi truyền lại cho CompareAndSwap trên cùng driver.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa cần tìm
+	//
+	// Returns:
+	//   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
+	//   - string: Token phiên bản của entry (rỗng nếu không tìm thấy)
+	//   - bool: true nếu tìm thấy key và chưa hết hạn
+	GetWithVersion(ctx context.Context, key string) (interface{}, string, bool)
+
+	// CompareAndSwap ghi giá trị mới chỉ khi entry chưa thay đổi kể từ lần đọc trả về token.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa cần ghi
+	//   - token: Token phiên bản nhận được từ GetWithVersion
+	//   - value: Giá trị mới
+	//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - bool: true nếu giá trị được ghi, false nếu key không tồn tại hoặc đã bị thay đổi
+	//   - error: Lỗi nếu có trong quá trình lưu trữ
+	CompareAndSwap(ctx context.Context, key, token string, value interface{}, ttl time.Duration) (bool, error)
+
+	// TTL trả về thời gian sống còn lại của key.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa cần kiểm tra
+	//
+	// Returns:
+	//   - time.Duration: Thời gian sống còn lại, NoExpiration nếu entry không hết hạn
+	//   - bool: true nếu tìm thấy key và chưa hết hạn
+	TTL(ctx context.Context, key string) (time.Duration, bool)
+
+	// Touch đặt lại thời gian sống của key mà không ghi lại giá trị.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa cần gia hạn
+	//   - ttl: Thời gian sống mới tính từ bây giờ (0 để sử dụng mặc định, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - bool: true nếu key tồn tại và đã được cập nhật, false nếu key không tồn tại
+	//   - error: Lỗi nếu có trong quá trình cập nhật
+	Touch(ctx context.Context, key string, ttl time.Duration) (bool, error)
+
+	// Increment tăng giá trị số nguyên của key một cách nguyên tử.
+	//
+	// Nếu key chưa tồn tại hoặc đã hết hạn, giá trị bắt đầu từ 0 và entry mới nhận
+	// ttl. Nếu key đã tồn tại, thời điểm hết hạn của entry được giữ nguyên.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa của bộ đếm
+	//   - delta: Giá trị cần cộng thêm (có thể âm)
+	//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - int64: Giá trị sau khi tăng
+	//   - error: ErrNotNumeric nếu giá trị hiện tại không phải số nguyên, hoặc lỗi từ storage backend
+	Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+
+	// Decrement giảm giá trị số nguyên của key một cách nguyên tử.
+	//
+	// Tương đương Increment với -delta.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa của bộ đếm
+	//   - delta: Giá trị cần trừ đi
+	//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - int64: Giá trị sau khi giảm
+	//   - error: ErrNotNumeric nếu giá trị hiện tại không phải số nguyên, hoặc lỗi từ storage backend
+	Decrement(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+
+	// IncrementFloat tăng giá trị số thực của key một cách nguyên tử.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa của bộ đếm
+	//   - delta: Giá trị cần cộng thêm (có thể âm)
+	//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - float64: Giá trị sau khi tăng
+	//   - error: ErrNotNumeric nếu giá trị hiện tại không phải số, hoặc lỗi từ storage backend
+	IncrementFloat(ctx context.Context, key string, delta float64, ttl time.Duration) (float64, error)
+
+	// SetTagged đặt một giá trị vào cache và gắn các tag cho entry.
+	//
+	// Các tag cho phép xóa một nhóm entry liên quan bằng FlushTags mà không cần
+	// biết từng key. Ghi đè một key bằng Set hoặc SetTagged sẽ thay thế toàn bộ
+	// tag cũ của entry.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa để lưu giá trị
+	//   - value: Giá trị cần lưu trữ
+	//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+	//   - tags: Danh sách tag gắn với entry
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình lưu trữ
+	SetTagged(ctx context.Context, key string, value interface{}, ttl time.Duration, tags []string) error
+
+	// FlushTags xóa tất cả các entry mang ít nhất một trong các tag được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - tags: Danh sách tag cần xóa
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình xóa
+	FlushTags(ctx context.Context, tags []string) error
+
+	// Keys trả về các key còn hạn khớp với pattern.
+	//
+	// Pattern là glob kiểu Redis: "*" khớp chuỗi bất kỳ, "?" khớp một ký tự, "[abc]",
+	// "[^abc]" và "[a-z]" khớp một ký tự trong (hoặc ngoài) lớp, "\" thoát ký tự đặc
+	// biệt; pattern rỗng khớp mọi key. Các key nội bộ bắt đầu bằng "__" không được trả về.
+	// Thứ tự các key không được đảm bảo.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - pattern: Glob pattern của key
+	//
+	// Returns:
+	//   - []string: Các key khớp pattern
+	//   - error: Lỗi nếu pattern không hợp lệ hoặc lỗi từ storage backend
+	Keys(ctx context.Context, pattern string) ([]string, error)
+
+	// Scan duyệt lần lượt các key còn hạn khớp với pattern mà không tải toàn bộ vào bộ nhớ.
+	//
+	// fn được gọi cho từng key, trả về false để dừng quét. Key được ghi hoặc xóa trong lúc
+	// quét có thể được trả về hoặc không, và với một số driver (redis) một key có thể được
+	// trả về nhiều lần. fn có thể gọi các phương thức khác của driver, kể cả Delete.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - pattern: Glob pattern của key, cùng cú pháp với Keys
+	//   - fn: Hàm được gọi cho từng key
+	//
+	// Returns:
+	//   - error: Lỗi nếu pattern không hợp lệ, ctx kết thúc hoặc lỗi từ storage backend
+	Scan(ctx context.Context, pattern string, fn func(key string) bool) error
+
+	// DeleteMatching xóa tất cả các entry có key khớp với pattern.
+	//
+	// Pattern có cùng cú pháp với Keys; các key nội bộ bắt đầu bằng "__" không bị xóa.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - pattern: Glob pattern của key
+	//
+	// Returns:
+	//   - int: Số entry đã bị xóa, có thể gồm cả entry đã hết hạn nhưng chưa được dọn
+	//   - error: Lỗi nếu pattern không hợp lệ hoặc lỗi từ storage backend
+	DeleteMatching(ctx context.Context, pattern string) (int, error)
+
+	// DeleteByPrefix xóa tất cả các entry có key bắt đầu bằng prefix.
+	//
+	// Tương đương DeleteMatching với prefix (đã thoát các ký tự đặc biệt) theo sau bởi "*".
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - prefix: Tiền tố của key
+	//
+	// Returns:
+	//   - int: Số entry đã bị xóa
+	//   - error: Lỗi từ storage backend
+	DeleteByPrefix(ctx context.Context, prefix string) (int, error)
+
 	// Stats trả về thông tin thống kê về cache.
 	//
 	// Phương thức này thu thập và trả về các thông tin thống kê về trạng thái
@@ -162,3 +417,87 @@ type Driver interface {
 	//   - error: Lỗi nếu có trong quá trình giải phóng tài nguyên
 	Close() error
 }
+
+// ErrTypeMismatch được trả về (dưới dạng wrapped error) khi giá trị trong cache
+// không thể được giải mã hoặc gán vào kiểu đích mà caller yêu cầu.
+var ErrTypeMismatch = errors.New("cache value type mismatch")
+
+// ErrNotNumeric được trả về khi Increment, Decrement hoặc IncrementFloat được gọi
+// trên một key có giá trị không phải số.
+var ErrNotNumeric = errors.New("cache value is not numeric")
+
+// TypedGetter là interface tùy chọn cho các driver có khả năng giải mã giá trị
+// trực tiếp vào kiểu đích của caller.
+//
+// Các driver có serialization (redis, file, mongodb) giải mã dữ liệu đã lưu thẳng
+// vào target thay vì vào interface{}, còn memory driver kiểm tra kiểu của giá trị
+// đã lưu. Tất cả các driver có sẵn đều cài đặt interface này.
+type TypedGetter interface {
+	// GetInto lấy một giá trị từ cache và giải mã vào target.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa cần tìm trong cache
+	//   - target: Con trỏ (khác nil) tới biến nhận giá trị
+	//
+	// Returns:
+	//   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
+	//   - error: Lỗi wrap ErrTypeMismatch nếu giá trị không phù hợp với kiểu đích,
+	//     hoặc lỗi từ storage backend
+	GetInto(ctx context.Context, key string, target interface{}) (bool, error)
+}
+
+// assignValue gán một giá trị đã được giải mã vào con trỏ target.
+//
+// Hàm này được dùng bởi các driver lưu trữ giá trị dưới dạng Go value (memory, file)
+// để kiểm tra kiểu trước khi gán.
+//
+// Params:
+//   - target: Con trỏ tới biến nhận giá trị
+//   - value: Giá trị cần gán
+//
+// Returns:
+//   - error: Lỗi wrap ErrTypeMismatch nếu kiểu của value không gán được cho target
+func assignValue(target interface{}, value interface{}) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("target must be a non-nil pointer, got %T", target)
+	}
+	elem := rv.Elem()
+
+	if value == nil {
+		switch elem.Kind() {
+		case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+			elem.Set(reflect.Zero(elem.Type()))
+			return nil
+		}
+		return fmt.Errorf("%w: cannot assign nil to %s", ErrTypeMismatch, elem.Type())
+	}
+
+	val := reflect.ValueOf(value)
+	if !val.Type().AssignableTo(elem.Type()) {
+		return fmt.Errorf("%w: cannot assign %s to %s", ErrTypeMismatch, val.Type(), elem.Type())
+	}
+	elem.Set(val)
+	return nil
+}
+
+// updateOp là thao tác mà một phép đọc-sửa-ghi thực hiện sau khi đọc entry hiện tại.
+type updateOp int
+
+const (
+	updateKeep   updateOp = iota // Giữ nguyên entry
+	updateStore                  // Lưu entry mới
+	updateDelete                 // Xóa entry
+)
+
+// copyTags trả về bản sao của danh sách tag, nil nếu danh sách rỗng.
+//
+// Driver lưu bản sao để thay đổi slice của caller sau khi gọi SetTagged
+// không ảnh hưởng tới dữ liệu đã lưu.
+func copyTags(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	return append([]string(nil), tags...)
+}
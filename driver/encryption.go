@@ -0,0 +1,167 @@
+package driver
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"go.fork.vn/cache/config"
+	"go.fork.vn/cache/encryption"
+)
+
+// Reencrypter là interface tùy chọn cho các driver mã hóa giá trị khi lưu trữ.
+//
+// Sau khi xoay vòng khóa (đổi ActiveKey và giữ khóa cũ trong Keys), Reencrypt ghi lại các
+// entry còn được mã hóa bằng khóa cũ để khóa cũ có thể được gỡ khỏi cấu hình. File, redis
+// và mongodb driver cài đặt interface này.
+type Reencrypter interface {
+	// Reencrypt mã hóa lại bằng khóa đang hoạt động mọi entry được mã hóa bằng khóa khác.
+	//
+	// Entry không mã hóa và entry không giải mã được bị bỏ qua; thời hạn, tag và phiên bản
+	// của entry được giữ nguyên. Reencrypt có thể chạy trong goroutine nền song song với
+	// các thao tác khác: entry bị ghi đè trong lúc mã hóa lại không bị ghi lại giá trị cũ.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi và dừng thao tác
+	//
+	// Returns:
+	//   - int: Số entry đã được mã hóa lại
+	//   - error: Lỗi nếu driver không bật mã hóa, ctx bị hủy hoặc lỗi từ storage backend
+	Reencrypt(ctx context.Context) (int, error)
+}
+
+// ErrEncryptionDisabled được trả về bởi Reencrypt khi driver không bật mã hóa.
+var ErrEncryptionDisabled = errors.New("cache encryption is not enabled")
+
+// valueEncryptor mã hóa dữ liệu đã được codec mã hóa (và nén) trước khi driver ghi vào
+// storage, và đếm số entry không giải mã được.
+type valueEncryptor struct {
+	keyring  *encryption.Keyring // Keyring, nil nếu tắt mã hóa
+	failures atomic.Int64        // Số lần đọc entry không giải mã được
+}
+
+// newValueEncryptor tạo stage mã hóa theo cấu hình.
+//
+// Params:
+//   - cfg: Cấu hình mã hóa, nil nếu không cấu hình
+//
+// Returns:
+//   - *valueEncryptor: Stage mã hóa (không mã hóa gì nếu cfg là nil hoặc không được bật)
+//   - error: Lỗi nếu khóa không hợp lệ
+func newValueEncryptor(cfg *config.EncryptionConfig) (*valueEncryptor, error) {
+	if cfg == nil || !cfg.Enabled {
+		return &valueEncryptor{}, nil
+	}
+
+	keys := make([]encryption.Key, 0, len(cfg.Keys))
+	for _, k := range cfg.Keys {
+		secret, err := base64.StdEncoding.DecodeString(k.Key)
+		if err != nil {
+			return nil, fmt.Errorf("%w '%s': key is not valid base64", encryption.ErrInvalidKey, k.ID)
+		}
+		keys = append(keys, encryption.Key{ID: k.ID, Algorithm: k.Algorithm, Secret: secret})
+	}
+	keyring, err := encryption.NewKeyring(cfg.ActiveKey, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return &valueEncryptor{keyring: keyring}, nil
+}
+
+// enabled kiểm tra stage có mã hóa giá trị hay không.
+func (v *valueEncryptor) enabled() bool {
+	return v != nil && v.keyring != nil
+}
+
+// encrypt mã hóa dữ liệu bằng khóa đang hoạt động.
+//
+// Params:
+//   - data: Dữ liệu cần ghi
+//   - key: Key của entry trong storage, được xác thực cùng dữ liệu để entry không thể bị
+//     chép sang key khác
+//
+// Returns:
+//   - []byte: Dữ liệu đã mã hóa, data nếu tắt mã hóa
+//   - string: ID của khóa đã mã hóa dữ liệu, rỗng nếu tắt mã hóa
+//   - error: Lỗi nếu không mã hóa được dữ liệu
+func (v *valueEncryptor) encrypt(data []byte, key string) ([]byte, string, error) {
+	if !v.enabled() {
+		return data, "", nil
+	}
+	out, err := v.keyring.Encrypt(data, []byte(key))
+	if err != nil {
+		return nil, "", err
+	}
+	return out, v.keyring.ActiveKeyID(), nil
+}
+
+// decrypt giải mã dữ liệu đọc từ storage.
+//
+// Entry được mã hóa đọc khi tắt mã hóa cũng là lỗi: không có khóa nào để giải mã nó.
+//
+// Params:
+//   - data: Dữ liệu đọc từ storage
+//   - key: Key của entry trong storage đã truyền cho encrypt
+//   - encrypted: true nếu entry được mã hóa
+//
+// Returns:
+//   - []byte: Dữ liệu đã giải mã
+//   - error: Lỗi wrap encryption.ErrUnknownKey hoặc encryption.ErrAuthentication
+func (v *valueEncryptor) decrypt(data []byte, key string, encrypted bool) ([]byte, error) {
+	if !encrypted {
+		return data, nil
+	}
+	var out []byte
+	var err error
+	if v.enabled() {
+		out, err = v.keyring.Decrypt(data, []byte(key))
+	} else {
+		id, _ := encryption.KeyID(data)
+		err = fmt.Errorf("%w '%s'", encryption.ErrUnknownKey, id)
+	}
+	if err != nil {
+		v.failures.Add(1)
+		return nil, err
+	}
+	return out, nil
+}
+
+// stale kiểm tra entry được mã hóa bằng khóa keyID có cần được mã hóa lại không.
+func (v *valueEncryptor) stale(keyID string) bool {
+	return keyID != "" && keyID != v.keyring.ActiveKeyID()
+}
+
+// addStats thêm thống kê mã hóa vào kết quả Stats của driver.
+//
+// Params:
+//   - stats: Kết quả Stats của driver
+func (v *valueEncryptor) addStats(stats map[string]interface{}) {
+	if v.enabled() {
+		stats["encryption_key"] = v.keyring.ActiveKeyID()
+	}
+	stats["decryption_failures"] = v.failures.Load()
+}
+
+// isDecryptionError kiểm tra err có phải lỗi giải mã giá trị đã mã hóa không.
+//
+// Entry không giải mã được là lỗi được trả về cho caller, không phải cache miss: đọc lại
+// giá trị từ nguồn và ghi đè sẽ che giấu dữ liệu bị sửa hoặc khóa bị cấu hình sai.
+func isDecryptionError(err error) bool {
+	return errors.Is(err, encryption.ErrAuthentication) || errors.Is(err, encryption.ErrUnknownKey)
+}
+
+// valueError chuyển lỗi giải mã giá trị đã lưu thành lỗi trả về cho caller.
+//
+// Params:
+//   - err: Lỗi khi giải mã giá trị
+//
+// Returns:
+//   - error: err nếu là lỗi giải mã, ngược lại lỗi wrap ErrTypeMismatch
+func valueError(err error) error {
+	if isDecryptionError(err) {
+		return err
+	}
+	return fmt.Errorf("%w: %v", ErrTypeMismatch, err)
+}
@@ -0,0 +1,266 @@
+package driver
+
+import (
+	"container/heap"
+	"container/list"
+	"math/rand"
+)
+
+// Các chính sách eviction được hỗ trợ bởi memory driver.
+const (
+	// EvictionLRU loại bỏ item ít được truy cập gần đây nhất (Least Recently Used)
+	EvictionLRU = "lru"
+	// EvictionLFU loại bỏ item có số lần truy cập ít nhất (Least Frequently Used)
+	EvictionLFU = "lfu"
+	// EvictionFIFO loại bỏ item được thêm vào sớm nhất (First In First Out)
+	EvictionFIFO = "fifo"
+	// EvictionRandom loại bỏ một item ngẫu nhiên
+	EvictionRandom = "random"
+)
+
+// evictionPolicy theo dõi các key trong memory cache và chọn key cần loại bỏ
+// khi cache vượt quá giới hạn.
+//
+// Các phương thức không thread-safe; memory driver gọi chúng khi đang giữ lock.
+type evictionPolicy interface {
+	// name trả về tên của chính sách
+	name() string
+	// add ghi nhận một key mới được thêm vào cache
+	add(key string)
+	// access ghi nhận một lần truy cập hoặc ghi đè key đã tồn tại
+	access(key string)
+	// remove ngừng theo dõi một key
+	remove(key string)
+	// victim trả về key cần loại bỏ tiếp theo
+	victim() (string, bool)
+	// reset xóa toàn bộ trạng thái của chính sách
+	reset()
+	// tracksAccess cho biết chính sách có cần được thông báo khi Get hay không
+	tracksAccess() bool
+}
+
+// newEvictionPolicy tạo chính sách eviction theo tên.
+//
+// Tên không hợp lệ hoặc rỗng sẽ sử dụng LRU.
+//
+// Params:
+//   - name: Tên chính sách (lru, lfu, fifo, random)
+//
+// Returns:
+//   - evictionPolicy: Chính sách eviction đã được khởi tạo
+func newEvictionPolicy(name string) evictionPolicy {
+	switch name {
+	case EvictionLFU:
+		return newLFUPolicy()
+	case EvictionFIFO:
+		return newListPolicy(EvictionFIFO, false)
+	case EvictionRandom:
+		return newRandomPolicy()
+	default:
+		return newListPolicy(EvictionLRU, true)
+	}
+}
+
+// listPolicy cài đặt LRU và FIFO bằng danh sách liên kết kép.
+//
+// Phần tử ở đầu danh sách là key bị loại bỏ trước. Với LRU, mỗi lần truy cập
+// sẽ chuyển key về cuối danh sách; với FIFO, thứ tự chỉ phụ thuộc vào lúc thêm.
+type listPolicy struct {
+	policy    string                   // Tên chính sách
+	moveOnUse bool                     // Chuyển key về cuối danh sách khi truy cập
+	order     *list.List               // Thứ tự loại bỏ
+	elements  map[string]*list.Element // Vị trí của key trong danh sách
+}
+
+func newListPolicy(name string, moveOnUse bool) *listPolicy {
+	return &listPolicy{
+		policy:    name,
+		moveOnUse: moveOnUse,
+		order:     list.New(),
+		elements:  make(map[string]*list.Element),
+	}
+}
+
+func (p *listPolicy) name() string { return p.policy }
+
+func (p *listPolicy) add(key string) {
+	if el, ok := p.elements[key]; ok {
+		p.order.MoveToBack(el)
+		return
+	}
+	p.elements[key] = p.order.PushBack(key)
+}
+
+func (p *listPolicy) access(key string) {
+	if !p.moveOnUse {
+		return
+	}
+	if el, ok := p.elements[key]; ok {
+		p.order.MoveToBack(el)
+	}
+}
+
+func (p *listPolicy) remove(key string) {
+	if el, ok := p.elements[key]; ok {
+		p.order.Remove(el)
+		delete(p.elements, key)
+	}
+}
+
+func (p *listPolicy) victim() (string, bool) {
+	el := p.order.Front()
+	if el == nil {
+		return "", false
+	}
+	return el.Value.(string), true
+}
+
+func (p *listPolicy) reset() {
+	p.order.Init()
+	p.elements = make(map[string]*list.Element)
+}
+
+func (p *listPolicy) tracksAccess() bool { return p.moveOnUse }
+
+// lfuEntry là một phần tử trong heap của LFU.
+type lfuEntry struct {
+	key   string // Cache key
+	freq  uint64 // Số lần truy cập
+	seq   uint64 // Thứ tự thêm vào, dùng để phá hòa (key cũ hơn bị loại trước)
+	index int    // Vị trí trong heap
+}
+
+// lfuHeap là min-heap theo tần suất truy cập.
+type lfuHeap []*lfuEntry
+
+func (h lfuHeap) Len() int { return len(h) }
+
+func (h lfuHeap) Less(i, j int) bool {
+	if h[i].freq != h[j].freq {
+		return h[i].freq < h[j].freq
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h lfuHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *lfuHeap) Push(x interface{}) {
+	entry := x.(*lfuEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *lfuHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return entry
+}
+
+// lfuPolicy cài đặt LFU bằng min-heap, mỗi thao tác có độ phức tạp O(log n).
+type lfuPolicy struct {
+	entries map[string]*lfuEntry // Tra cứu entry theo key
+	heap    lfuHeap              // Min-heap theo tần suất
+	seq     uint64               // Bộ đếm thứ tự thêm vào
+}
+
+func newLFUPolicy() *lfuPolicy {
+	return &lfuPolicy{entries: make(map[string]*lfuEntry)}
+}
+
+func (p *lfuPolicy) name() string { return EvictionLFU }
+
+func (p *lfuPolicy) add(key string) {
+	if _, ok := p.entries[key]; ok {
+		p.access(key)
+		return
+	}
+	p.seq++
+	entry := &lfuEntry{key: key, freq: 1, seq: p.seq}
+	p.entries[key] = entry
+	heap.Push(&p.heap, entry)
+}
+
+func (p *lfuPolicy) access(key string) {
+	if entry, ok := p.entries[key]; ok {
+		entry.freq++
+		heap.Fix(&p.heap, entry.index)
+	}
+}
+
+func (p *lfuPolicy) remove(key string) {
+	if entry, ok := p.entries[key]; ok {
+		heap.Remove(&p.heap, entry.index)
+		delete(p.entries, key)
+	}
+}
+
+func (p *lfuPolicy) victim() (string, bool) {
+	if len(p.heap) == 0 {
+		return "", false
+	}
+	return p.heap[0].key, true
+}
+
+func (p *lfuPolicy) reset() {
+	p.entries = make(map[string]*lfuEntry)
+	p.heap = nil
+}
+
+func (p *lfuPolicy) tracksAccess() bool { return true }
+
+// randomPolicy chọn ngẫu nhiên một key để loại bỏ.
+//
+// Các key được lưu trong slice để chọn ngẫu nhiên với xác suất đều trong O(1).
+type randomPolicy struct {
+	keys    []string       // Danh sách key
+	indexes map[string]int // Vị trí của key trong slice
+}
+
+func newRandomPolicy() *randomPolicy {
+	return &randomPolicy{indexes: make(map[string]int)}
+}
+
+func (p *randomPolicy) name() string { return EvictionRandom }
+
+func (p *randomPolicy) add(key string) {
+	if _, ok := p.indexes[key]; ok {
+		return
+	}
+	p.indexes[key] = len(p.keys)
+	p.keys = append(p.keys, key)
+}
+
+func (p *randomPolicy) access(key string) {}
+
+func (p *randomPolicy) remove(key string) {
+	i, ok := p.indexes[key]
+	if !ok {
+		return
+	}
+	last := len(p.keys) - 1
+	p.keys[i] = p.keys[last]
+	p.indexes[p.keys[i]] = i
+	p.keys = p.keys[:last]
+	delete(p.indexes, key)
+}
+
+func (p *randomPolicy) victim() (string, bool) {
+	if len(p.keys) == 0 {
+		return "", false
+	}
+	return p.keys[rand.Intn(len(p.keys))], true
+}
+
+func (p *randomPolicy) reset() {
+	p.keys = nil
+	p.indexes = make(map[string]int)
+}
+
+func (p *randomPolicy) tracksAccess() bool { return false }
@@ -3,14 +3,18 @@ package driver
 import (
 	"context"
 	"crypto/sha1"
-	"encoding/gob"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.fork.vn/cache/codec"
 	"go.fork.vn/cache/config"
 )
 
@@ -26,23 +30,53 @@ type FileDriver interface {
 // cho các ứng dụng cần persistence và có thể phục hồi dữ liệu cache sau khi khởi động lại.
 // Nó cũng hỗ trợ TTL (Time To Live) và tự động dọn dẹp các entry đã hết hạn.
 type fileDriver struct {
-	directory         string        // Đường dẫn thư mục lưu trữ cache
-	defaultExpiration time.Duration // Thời gian sống mặc định cho các entry không chỉ định TTL
-	mu                sync.RWMutex  // Mutex cho các thao tác thread-safe
-	janitorInterval   time.Duration // Khoảng thời gian giữa các lần dọn dẹp
-	stopJanitor       chan bool     // Channel để dừng goroutine dọn dẹp
-	janitorRunning    bool          // Flag đánh dấu goroutine dọn dẹp đang chạy
-	hits              int64         // Số lần cache hit
-	misses            int64         // Số lần cache miss
+	directory         string          // Đường dẫn thư mục lưu trữ cache
+	extension         string          // Phần mở rộng của file cache ("" nếu không có)
+	depth             int             // Số cấp thư mục con theo hash của key (0 = phẳng)
+	width             int             // Số ký tự hex của hash trong tên thư mục mỗi cấp
+	defaultExpiration time.Duration   // Thời gian sống mặc định cho các entry không chỉ định TTL
+	mu                sync.RWMutex    // Mutex cho các thao tác thread-safe
+	janitorInterval   time.Duration   // Khoảng thời gian giữa các lần dọn dẹp
+	stopJanitor       chan bool       // Channel để dừng goroutine dọn dẹp
+	janitorRunning    bool            // Flag đánh dấu goroutine dọn dẹp đang chạy
+	hits              int64           // Số lần cache hit
+	misses            int64           // Số lần cache miss
+	tags              tagIndex        // Index từ tag tới các file cache mang tag đó
+	keys              fileKeyIndex    // Index từ file cache tới key và thời điểm hết hạn của entry
+	flights           flightGroup     // Gộp các lần gọi Remember đồng thời
+	locks             keyLocks        // Khóa theo key cho các thao tác đọc-sửa-ghi
+	versions          atomic.Uint64   // Nguồn phiên bản cho các entry được ghi
+	slidingTTL        time.Duration   // Thời gian gia hạn sau mỗi lần Get trúng (0 = tắt)
+	fsync             bool            // Fsync file tạm trước khi đổi tên thành file cache
+	maxSizeBytes      int64           // Tổng dung lượng tối đa của các file cache (0 = unlimited)
+	maxFiles          int             // Số file cache tối đa (0 = unlimited)
+	usage             *fileUsage      // Index dung lượng và thứ tự truy cập của các file cache
+	evictMu           sync.Mutex      // Mutex bảo vệ evictQueue
+	evictQueue        []string        // Các file bị chọn loại bỏ, chờ được xóa khi khóa của key được nhả
+	codec             codec.Codec     // Codec mã hóa giá trị của các entry được ghi
+	encryptor         *valueEncryptor // Stage mã hóa giá trị khi lưu trữ
+	// flocks là file khóa tư vấn của từng phân vùng khóa, được mở khi cần; phần tử thứ i
+	// chỉ được truy cập khi giữ mutex của phân vùng i trong locks
+	flocks [keyLockStripes]*os.File
 }
 
-// FileCache là cấu trúc lưu trữ dữ liệu trong file.
+// FileCache là một entry của file driver.
 //
-// Cấu trúc này được dùng để serialize và deserialize dữ liệu cache
-// khi lưu trữ và đọc từ file. Nó chứa giá trị cần cache và thời gian hết hạn.
+// Trong file, các trường metadata được lưu trong header và Value được mã hóa thành
+// payload có checksum (xem file_format.go). Các phiên bản cũ mã hóa toàn bộ cấu trúc
+// này bằng gob; các file đó vẫn được đọc và được chuyển sang định dạng mới.
 type FileCache struct {
 	Value      interface{} // Giá trị được lưu trong cache
 	Expiration int64       // Thời điểm hết hạn (UnixNano), 0 nếu không hết hạn
+	Tags       []string    // Các tag gắn với entry
+	// SoftExpiration là thời điểm giá trị hết còn mới (UnixNano), 0 nếu không có
+	SoftExpiration int64
+	// Version là phiên bản của entry, được gán mới mỗi lần file được ghi
+	Version uint64
+	// Key là cache key của entry; tên file là hash của key nên Keys và Scan đọc key từ đây
+	Key string
+	// CreatedAt là thời điểm entry được tạo (UnixNano)
+	CreatedAt int64
 }
 
 // NewFileDriver tạo một file driver mới với các tùy chọn mặc định.
@@ -55,8 +89,35 @@ type FileCache struct {
 //
 // Returns:
 //   - *FileDriver: Driver đã được khởi tạo
-//   - error: Lỗi nếu không thể tạo thư mục cache
+//   - error: Lỗi nếu không thể tạo thư mục cache, phần mở rộng không hợp lệ, codec
+//     chưa được đăng ký hoặc khóa mã hóa không hợp lệ
 func NewFileDriver(cfg config.DriverFileConfig) (FileDriver, error) {
+	if cfg.Depth < 0 || cfg.Depth > fileMaxDepth {
+		return nil, fmt.Errorf("invalid cache directory depth %d: must be between 0 and %d", cfg.Depth, fileMaxDepth)
+	}
+	width, err := fanOutWidth(cfg.GetFanOut())
+	if err != nil {
+		return nil, err
+	}
+	extension := cfg.Extension
+	if extension != "" && !strings.HasPrefix(extension, ".") {
+		extension = "." + extension
+	}
+	if extension == lockFileSuffix || strings.ContainsAny(extension, `/\`) {
+		return nil, fmt.Errorf("invalid cache file extension '%s'", cfg.Extension)
+	}
+	if cfg.MaxSizeBytes < 0 || cfg.MaxFiles < 0 {
+		return nil, fmt.Errorf("invalid cache quota: max_size_bytes and max_files must not be negative")
+	}
+	valueCodec, err := codec.Get(cfg.GetCodec())
+	if err != nil {
+		return nil, err
+	}
+	encryptor, err := newValueEncryptor(cfg.Encryption)
+	if err != nil {
+		return nil, err
+	}
+
 	// Tạo thư mục nếu không tồn tại
 	if err := os.MkdirAll(cfg.Path, 0755); err != nil {
 		return nil, fmt.Errorf("unable to create cache directory: %w", err)
@@ -64,10 +125,32 @@ func NewFileDriver(cfg config.DriverFileConfig) (FileDriver, error) {
 
 	driver := &fileDriver{
 		directory:         cfg.Path,
+		extension:         extension,
+		depth:             cfg.Depth,
+		width:             width,
 		defaultExpiration: time.Duration(cfg.DefaultTTL) * time.Second,
 		janitorInterval:   time.Duration(cfg.CleanupInterval) * time.Second,
 		stopJanitor:       make(chan bool),
+		tags:              make(tagIndex),
+		keys:              make(fileKeyIndex),
+		slidingTTL:        cfg.GetSlidingTTL(),
+		fsync:             cfg.Fsync,
+		maxSizeBytes:      cfg.MaxSizeBytes,
+		maxFiles:          cfg.MaxFiles,
+		usage:             newFileUsage(cfg.MaxSizeBytes > 0 || cfg.MaxFiles > 0),
+		codec:             valueCodec,
+		encryptor:         encryptor,
 	}
+	// Phiên bản bắt đầu từ thời điểm khởi tạo để không trùng với phiên bản do
+	// các lần chạy trước ghi vào file
+	driver.versions.Store(uint64(time.Now().UnixNano()))
+
+	// Đổi tên file của phiên bản cũ rồi khôi phục index tag, index key và index dung
+	// lượng từ các file cache đã có
+	driver.migrateLayout()
+	driver.loadIndex()
+	driver.enforceQuota("")
+	driver.evictPending()
 
 	// Chỉ chạy janitor nếu có khoảng thời gian dọn dẹp > 0
 	if cfg.CleanupInterval > 0 {
@@ -80,16 +163,37 @@ func NewFileDriver(cfg config.DriverFileConfig) (FileDriver, error) {
 
 // keyToFilename chuyển đổi key thành tên file an toàn.
 //
-// Phương thức này chuyển đổi một cache key thành đường dẫn file đầy đủ trong thư mục cache.
-// Trong thực tế, nên sử dụng một hàm hash như md5 hoặc sha1 để tránh các vấn đề với ký tự đặc biệt
-// và đảm bảo tên file hợp lệ trên hệ thống file.
+// Tên file là hash SHA-1 dạng hex của key cộng với phần mở rộng đã cấu hình, nên key
+// có thể chứa ký tự đặc biệt mà vẫn cho tên file hợp lệ trên hệ thống file.
 //
 // Params:
 //   - key: Cache key cần chuyển đổi
 //
 // Returns:
 //   - string: Đường dẫn đầy đủ đến file cache
+//   - error: Lỗi nếu key không hợp lệ
 func (d *fileDriver) keyToFilename(key string) (string, error) {
+	hash, err := keyHash(key)
+	if err != nil {
+		return "", err
+	}
+	return d.hashFilename(hash), nil
+}
+
+// isCacheFile kiểm tra một tên file trong thư mục cache có phải file cache hay không.
+func (d *fileDriver) isCacheFile(name string) bool {
+	return !isLockFile(name) && !isTempFile(name) && strings.HasSuffix(name, d.extension)
+}
+
+// keyHash kiểm tra key và trả về hash SHA-1 dạng hex của key.
+//
+// Params:
+//   - key: Cache key hoặc tên khóa
+//
+// Returns:
+//   - string: Hash của key
+//   - error: Lỗi nếu key rỗng hoặc chứa ký tự không được phép
+func keyHash(key string) (string, error) {
 	if key == "" {
 		return "", fmt.Errorf("invalid key: key is empty")
 	}
@@ -103,8 +207,7 @@ func (d *fileDriver) keyToFilename(key string) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("invalid key: %w", err)
 	}
-	hash := hex.EncodeToString(h.Sum(nil))
-	return filepath.Join(d.directory, hash), nil
+	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
 // Get lấy một giá trị từ cache.
@@ -113,6 +216,9 @@ func (d *fileDriver) keyToFilename(key string) (string, error) {
 // Nếu file không tồn tại hoặc đã hết hạn, phương thức sẽ trả về false và cập nhật
 // bộ đếm miss. Nếu tìm thấy và còn hạn, phương thức trả về giá trị và cập nhật bộ đếm hit.
 //
+// Khi sliding expiration được bật, thời điểm hết hạn của entry có thời hạn được kéo dài
+// thêm slidingTTL tính từ lần đọc này.
+//
 // Params:
 //   - ctx: Context để kiểm soát thời gian thực thi của thao tác
 //   - key: Cache key cần lấy
@@ -121,55 +227,243 @@ func (d *fileDriver) keyToFilename(key string) (string, error) {
 //   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
 //   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
 func (d *fileDriver) Get(ctx context.Context, key string) (interface{}, bool) {
-	filename, err := d.keyToFilename(key)
+	cache, found, _ := d.get(key)
+	return cache.Value, found
+}
+
+// get đọc entry còn hạn của key và gia hạn entry khi sliding expiration được bật.
+//
+// Params:
+//   - key: Cache key cần tìm
+//
+// Returns:
+//   - FileCache: Entry đọc được
+//   - bool: true nếu tìm thấy key và chưa hết hạn
+//   - error: Lỗi nếu entry không giải mã được bằng các khóa đã cấu hình
+func (d *fileDriver) get(key string) (FileCache, bool, error) {
+	var value interface{}
+	cache, found, err := d.getInto(key, &value)
 	if err != nil {
-		d.mu.Lock()
-		d.misses++
-		d.mu.Unlock()
-		return nil, false
+		return FileCache{}, false, d.valueMiss(err)
 	}
+	cache.Value = value
+	return cache, found, nil
+}
 
-	// Kiểm tra xem file có tồn tại không
-	if _, err := os.Stat(filename); os.IsNotExist(err) {
-		d.mu.Lock()
-		d.misses++
-		d.mu.Unlock()
-		return nil, false
+// getInto đọc entry còn hạn của key, giải mã giá trị vào target và gia hạn entry khi
+// sliding expiration được bật.
+//
+// Params:
+//   - key: Cache key cần tìm
+//   - target: Con trỏ tới biến nhận giá trị
+//
+// Returns:
+//   - FileCache: Metadata của entry, chưa có Value
+//   - bool: true nếu tìm thấy key và chưa hết hạn
+//   - error: Lỗi nếu entry không giải mã được bằng các khóa đã cấu hình hoặc không giải
+//     mã được vào target
+func (d *fileDriver) getInto(key string, target interface{}) (FileCache, bool, error) {
+	cache, found, err := d.readInto(key, target)
+	if !found {
+		return FileCache{}, false, err
 	}
+	if d.slidingTTL > 0 && cache.Expiration > 0 {
+		_, _ = d.touch(key, func(expiration int64) (int64, bool) {
+			return slidingExpiration(expiration, d.slidingTTL)
+		})
+	}
+	return cache, true, nil
+}
 
-	// Mở file
-	file, err := os.Open(filename)
+// read đọc entry còn hạn của key từ file và cập nhật bộ đếm hit/miss.
+//
+// File đã hết hạn sẽ bị xóa. Entry không giải mã được không được tính là hit hay miss
+// mà được đếm trong decryption_failures.
+//
+// Params:
+//   - key: Cache key cần tìm
+//
+// Returns:
+//   - FileCache: Entry đọc được
+//   - bool: true nếu tìm thấy key và chưa hết hạn
+//   - error: Lỗi nếu entry không giải mã được bằng các khóa đã cấu hình
+func (d *fileDriver) read(key string) (FileCache, bool, error) {
+	var value interface{}
+	cache, found, err := d.readInto(key, &value)
 	if err != nil {
-		d.mu.Lock()
-		d.misses++
-		d.mu.Unlock()
-		return nil, false
+		return FileCache{}, false, d.valueMiss(err)
+	}
+	cache.Value = value
+	return cache, found, nil
+}
+
+// valueMiss chuyển lỗi giải mã giá trị vào interface{} thành cache miss.
+//
+// Lỗi giải mã bằng khóa được giữ lại để khóa bị cấu hình sai không bị che giấu; giá
+// trị mà codec không giải mã được được đếm là miss như file hỏng.
+//
+// Params:
+//   - err: Lỗi từ readInto hoặc getInto
+//
+// Returns:
+//   - error: err nếu là lỗi giải mã bằng khóa, nil nếu ngược lại
+func (d *fileDriver) valueMiss(err error) error {
+	if isDecryptionError(err) {
+		return err
 	}
-	defer file.Close()
+	d.mu.Lock()
+	d.misses++
+	d.mu.Unlock()
+	return nil
+}
 
-	// Giải mã dữ liệu
-	var cache FileCache
-	decoder := gob.NewDecoder(file)
-	if err = decoder.Decode(&cache); err != nil {
+// readInto đọc entry còn hạn của key từ file, giải mã giá trị vào target và cập nhật bộ
+// đếm hit/miss.
+//
+// Params:
+//   - key: Cache key cần tìm
+//   - target: Con trỏ tới biến nhận giá trị
+//
+// Returns:
+//   - FileCache: Metadata của entry, chưa có Value
+//   - bool: true nếu tìm thấy key và chưa hết hạn
+//   - error: Lỗi nếu entry không giải mã được bằng các khóa đã cấu hình hoặc không giải
+//     mã được vào target; khi đó không tính hit hay miss
+func (d *fileDriver) readInto(key string, target interface{}) (FileCache, bool, error) {
+	filename, err := d.keyToFilename(key)
+	if err != nil {
 		d.mu.Lock()
 		d.misses++
 		d.mu.Unlock()
-		return nil, false
+		return FileCache{}, false, nil
 	}
 
-	// Kiểm tra xem đã hết hạn chưa
-	if cache.Expiration > 0 && time.Now().UnixNano() > cache.Expiration {
+	cache, legacy, found, err := d.loadInto(key, filename, target)
+	if err != nil {
+		return FileCache{}, false, err
+	}
+	if !found {
 		d.mu.Lock()
 		d.misses++
 		d.mu.Unlock()
-		os.Remove(filename) // Xóa file đã hết hạn
-		return nil, false
+		return FileCache{}, false, nil
+	}
+	if legacy {
+		d.migrate(key, filename)
 	}
+	d.usage.access(filename)
 
 	d.mu.Lock()
 	d.hits++
 	d.mu.Unlock()
-	return cache.Value, true
+	return cache, true, nil
+}
+
+// load đọc và giải mã entry còn hạn từ file cache mà không cập nhật bộ đếm hit/miss.
+//
+// Caller phải giữ khóa của key.
+//
+// File đã hết hạn hoặc có checksum không khớp sẽ bị xóa. File không giải mã được bằng các
+// khóa đã cấu hình được giữ lại và trả về lỗi, để khóa bị cấu hình sai không làm mất dữ
+// liệu và file bị sửa không bị che giấu như một cache miss.
+//
+// Params:
+//   - filename: Đường dẫn file cache
+//
+// Returns:
+//   - FileCache: Entry đọc được
+//   - bool: true nếu file có định dạng cũ
+//   - bool: true nếu file tồn tại, giải mã được và chưa hết hạn
+//   - error: Lỗi nếu entry không giải mã được bằng các khóa đã cấu hình
+func (d *fileDriver) load(filename string) (FileCache, bool, bool, error) {
+	var value interface{}
+	cache, legacy, found, err := d.loadInto("", filename, &value)
+	if err != nil {
+		if isDecryptionError(err) {
+			return FileCache{}, false, false, err
+		}
+		return FileCache{}, false, false, nil
+	}
+	cache.Value = value
+	return cache, legacy, found, nil
+}
+
+// loadInto đọc entry còn hạn từ file cache và giải mã giá trị vào target.
+//
+// Giá trị chỉ được giải mã sau khi kiểm tra thời hạn trong header, nên target không bị
+// ghi khi entry đã hết hạn. Nếu caller chưa giữ khóa của key, file hết hạn hoặc hỏng chỉ
+// bị xóa qua removeEntry, để entry vừa được ghi lại không bị xóa nhầm.
+//
+// Params:
+//   - key: Cache key của entry nếu caller chưa giữ khóa của key, rỗng nếu đã giữ
+//   - filename: Đường dẫn file cache
+//   - target: Con trỏ tới biến nhận giá trị
+//
+// Returns:
+//   - FileCache: Metadata của entry, chưa có Value
+//   - bool: true nếu file có định dạng cũ
+//   - bool: true nếu file tồn tại, đọc được và chưa hết hạn
+//   - error: Lỗi nếu entry không giải mã được bằng các khóa đã cấu hình hoặc không giải
+//     mã được vào target
+func (d *fileDriver) loadInto(key, filename string, target interface{}) (FileCache, bool, bool, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return FileCache{}, false, false, nil
+	}
+
+	entry, err := parseFileCache(data)
+	if err != nil {
+		if errors.Is(err, errFileChecksum) {
+			// Xóa file bị hỏng
+			d.discard(key, filename, func(fileCacheHeader) bool {
+				data, err := os.ReadFile(filename)
+				if err != nil {
+					return false
+				}
+				_, err = parseFileCache(data)
+				return errors.Is(err, errFileChecksum)
+			})
+		}
+		return FileCache{}, false, false, nil
+	}
+
+	// Kiểm tra xem đã hết hạn chưa
+	if fileExpired(entry.header, time.Now().UnixNano()) {
+		// Xóa file đã hết hạn
+		d.discard(key, filename, func(header fileCacheHeader) bool {
+			return fileExpired(header, time.Now().UnixNano())
+		})
+		return FileCache{}, false, false, nil
+	}
+
+	if err := entry.decodeValue(d.encryptor, target); err != nil {
+		return FileCache{}, false, false, err
+	}
+	return entry.cache(), entry.legacy, true, nil
+}
+
+// GetInto lấy một giá trị từ cache và giải mã trực tiếp vào target.
+//
+// Khác với Get (giải mã vào interface{}, ví dụ JSON object thành map[string]interface{}),
+// phương thức này dùng codec đã ghi entry để giải mã thẳng vào kiểu của target. Với gob,
+// giá trị được giải mã vào interface{} rồi kiểm tra kiểu trước khi gán, nên kiểu cụ thể
+// cần được đăng ký bằng gob.Register.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm
+//   - target: Con trỏ tới biến nhận giá trị
+//
+// Returns:
+//   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
+//   - error: Lỗi wrap ErrTypeMismatch nếu kiểu không phù hợp, hoặc lỗi giải mã nếu entry
+//     không giải mã được bằng các khóa đã cấu hình
+func (d *fileDriver) GetInto(ctx context.Context, key string, target interface{}) (bool, error) {
+	_, found, err := d.getInto(key, target)
+	if err != nil {
+		return true, valueError(err)
+	}
+	return found, nil
 }
 
 // Set đặt một giá trị vào cache với TTL tùy chọn.
@@ -187,36 +481,525 @@ func (d *fileDriver) Get(ctx context.Context, key string) (interface{}, bool) {
 // Returns:
 //   - error: Lỗi nếu có trong quá trình tạo, mã hóa hoặc ghi file
 func (d *fileDriver) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
-	filename, err := d.keyToFilename(key)
+	_, err := d.write(key, value, ttl, 0, nil)
+	return err
+}
+
+// SetTagged đặt một giá trị vào cache và gắn các tag cho entry.
+//
+// Các tag được lưu trong file cùng với giá trị và được ghi nhận vào index tag
+// trong bộ nhớ. Index được xây dựng lại từ các file khi driver khởi động, nên chỉ
+// phản ánh các entry được ghi bởi tiến trình hiện tại sau thời điểm đó.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+//   - tags: Danh sách tag gắn với entry
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình tạo, mã hóa hoặc ghi file
+func (d *fileDriver) SetTagged(ctx context.Context, key string, value interface{}, ttl time.Duration, tags []string) error {
+	filename, err := d.write(key, value, ttl, 0, copyTags(tags))
 	if err != nil {
 		return err
 	}
-	var exp int64
 
-	if ttl == 0 {
-		if d.defaultExpiration > 0 {
-			exp = time.Now().Add(d.defaultExpiration).UnixNano()
-		}
-	} else if ttl > 0 {
-		exp = time.Now().Add(ttl).UnixNano()
+	d.mu.Lock()
+	d.tags.add(filename, tags)
+	d.mu.Unlock()
+	return nil
+}
+
+// write mã hóa và ghi một entry vào file cache tương ứng với key.
+//
+// Params:
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+//   - grace: Thời gian giá trị cũ tiếp tục được phục vụ sau ttl (0 nếu không có)
+//   - tags: Danh sách tag gắn với entry (nil nếu không có)
+//
+// Returns:
+//   - string: Đường dẫn file đã ghi
+//   - error: Lỗi nếu có trong quá trình tạo, mã hóa hoặc ghi file
+func (d *fileDriver) write(key string, value interface{}, ttl, grace time.Duration, tags []string) (string, error) {
+	filename, err := d.keyToFilename(key)
+	if err != nil {
+		return "", err
 	}
+	exp, softExp := staleExpirations(ttl, grace, d.defaultExpiration)
 
 	// Tạo cấu trúc cache
 	cache := FileCache{
-		Value:      value,
-		Expiration: exp,
+		Value:          value,
+		Expiration:     exp,
+		Tags:           tags,
+		SoftExpiration: softExp,
+		Key:            key,
+	}
+
+	unlock := d.lockKey(key)
+	defer unlock()
+	return filename, d.store(filename, cache)
+}
+
+// store mã hóa và ghi một entry vào file cache với một phiên bản mới.
+//
+// Params:
+//   - filename: Đường dẫn file cache
+//   - cache: Entry cần ghi
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình tạo, mã hóa hoặc ghi file
+func (d *fileDriver) store(filename string, cache FileCache) error {
+	cache.Version = d.versions.Add(1)
+	if cache.CreatedAt == 0 {
+		cache.CreatedAt = time.Now().UnixNano()
 	}
+	return d.save(filename, cache)
+}
 
-	// Mở file để ghi
-	file, err := os.Create(filename)
+// save mã hóa và ghi một entry vào file cache, giữ nguyên phiên bản của entry.
+//
+// Sau khi ghi, các file ít được truy cập gần đây nhất bị loại bỏ nếu thư mục cache
+// vượt quá max_size_bytes hoặc max_files.
+//
+// Params:
+//   - filename: Đường dẫn file cache
+//   - cache: Entry cần ghi
+//
+// Returns:
+//   - error: ErrValueTooLarge nếu file lớn hơn max_size_bytes, hoặc lỗi nếu có trong quá
+//     trình tạo, mã hóa hoặc ghi file
+func (d *fileDriver) save(filename string, cache FileCache) error {
+	data, err := encodeFileCache(cache, d.codec, d.encryptor)
 	if err != nil {
-		return fmt.Errorf("could not create cache file: %w", err)
+		return err
+	}
+	return d.writeData(filename, fileKeyEntry{key: cache.Key, expiration: cache.Expiration}, data)
+}
+
+// writeData ghi nguyên tử nội dung đã mã hóa của một file cache và cập nhật index
+// dung lượng và index key.
+//
+// Params:
+//   - filename: Đường dẫn file cache
+//   - entry: Key và thời điểm hết hạn ghi trong header của file
+//   - data: Nội dung file
+//
+// Returns:
+//   - error: ErrValueTooLarge nếu file lớn hơn max_size_bytes, hoặc lỗi khi ghi file
+func (d *fileDriver) writeData(filename string, entry fileKeyEntry, data []byte) error {
+	if d.maxSizeBytes > 0 && int64(len(data)) > d.maxSizeBytes {
+		return fmt.Errorf("%w: cache file is %d bytes, max_size_bytes is %d", ErrValueTooLarge, len(data), d.maxSizeBytes)
+	}
+	if err := writeFileAtomic(filename, data, d.fsync); err != nil {
+		return err
 	}
-	defer file.Close()
+	d.usage.set(filename, int64(len(data)))
+	d.indexKey(filename, entry)
+	d.enforceQuota(filename)
+	return nil
+}
 
-	// Mã hóa và ghi vào file
-	encoder := gob.NewEncoder(file)
-	return encoder.Encode(cache)
+// Increment tăng giá trị số nguyên của key một cách nguyên tử.
+//
+// Thao tác đọc và ghi file được thực hiện dưới khóa của key, nên các lần gọi đồng thời
+// trong cùng tiến trình không làm mất cập nhật. Giá trị mới được lưu dưới dạng int64.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (có thể âm)
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - int64: Giá trị sau khi tăng
+//   - error: ErrNotNumeric nếu giá trị hiện tại không phải số nguyên, hoặc lỗi khi ghi file
+func (d *fileDriver) Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	var result int64
+	err := d.update(key, ttl, func(value interface{}, found bool) (interface{}, error) {
+		if found {
+			current, ok := toInt64(value)
+			if !ok {
+				return nil, ErrNotNumeric
+			}
+			result = current
+		}
+		result += delta
+		return result, nil
+	})
+	return result, err
+}
+
+// Decrement giảm giá trị số nguyên của key một cách nguyên tử.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần trừ đi
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - int64: Giá trị sau khi giảm
+//   - error: ErrNotNumeric nếu giá trị hiện tại không phải số nguyên, hoặc lỗi khi ghi file
+func (d *fileDriver) Decrement(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	return d.Increment(ctx, key, -delta, ttl)
+}
+
+// IncrementFloat tăng giá trị số thực của key một cách nguyên tử.
+//
+// Giá trị mới được lưu dưới dạng float64.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (có thể âm)
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - float64: Giá trị sau khi tăng
+//   - error: ErrNotNumeric nếu giá trị hiện tại không phải số, hoặc lỗi khi ghi file
+func (d *fileDriver) IncrementFloat(ctx context.Context, key string, delta float64, ttl time.Duration) (float64, error) {
+	var result float64
+	err := d.update(key, ttl, func(value interface{}, found bool) (interface{}, error) {
+		if found {
+			current, ok := toFloat64(value)
+			if !ok {
+				return nil, ErrNotNumeric
+			}
+			result = current
+		}
+		result += delta
+		return result, nil
+	})
+	return result, err
+}
+
+// update thay giá trị của key bằng kết quả của fn dưới khóa của key.
+//
+// Entry đã tồn tại giữ nguyên thời điểm hết hạn và tag; entry mới nhận ttl.
+//
+// Params:
+//   - key: Cache key
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//   - fn: Hàm nhận giá trị hiện tại (found = false nếu không có) và trả về giá trị mới
+//
+// Returns:
+//   - error: Lỗi từ fn hoặc lỗi khi ghi file
+func (d *fileDriver) update(key string, ttl time.Duration, fn func(value interface{}, found bool) (interface{}, error)) error {
+	return d.modify(key, func(cache FileCache, found bool) (FileCache, updateOp, error) {
+		value, err := fn(cache.Value, found)
+		if err != nil {
+			return FileCache{}, updateKeep, err
+		}
+		if !found {
+			cache = d.newEntry(nil, ttl)
+		}
+		cache.Value = value
+		return cache, updateStore, nil
+	})
+}
+
+// modify đọc entry hiện tại của key và thực hiện thao tác do fn quyết định dưới khóa của key.
+//
+// Params:
+//   - key: Cache key
+//   - fn: Hàm nhận entry hiện tại (found = false nếu không có hoặc đã hết hạn),
+//     trả về entry và thao tác cần thực hiện
+//
+// Returns:
+//   - error: Lỗi từ fn, lỗi khi ghi, xóa file, hoặc lỗi giải mã nếu entry hiện tại không
+//     giải mã được bằng các khóa đã cấu hình
+func (d *fileDriver) modify(key string, fn func(cache FileCache, found bool) (FileCache, updateOp, error)) error {
+	filename, err := d.keyToFilename(key)
+	if err != nil {
+		return err
+	}
+
+	unlock := d.lockKey(key)
+	defer unlock()
+
+	cache, _, found, err := d.load(filename)
+	if err != nil {
+		return err
+	}
+	cache, op, err := fn(cache, found)
+	if err != nil {
+		return err
+	}
+	switch op {
+	case updateStore:
+		cache.Key = key
+		return d.store(filename, cache)
+	case updateDelete:
+		return d.removeFile(filename)
+	}
+	return nil
+}
+
+// newEntry tạo entry cho một giá trị sắp được ghi.
+//
+// Params:
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - FileCache: Entry đã được tính thời điểm hết hạn
+func (d *fileDriver) newEntry(value interface{}, ttl time.Duration) FileCache {
+	exp, _ := staleExpirations(ttl, 0, d.defaultExpiration)
+	return FileCache{Value: value, Expiration: exp}
+}
+
+// Add lưu giá trị chỉ khi key chưa tồn tại hoặc đã hết hạn.
+//
+// Việc kiểm tra và ghi file được thực hiện dưới khóa của key.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được lưu, false nếu key đã tồn tại
+//   - error: Lỗi nếu có trong quá trình ghi file
+func (d *fileDriver) Add(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	added := false
+	err := d.modify(key, func(_ FileCache, found bool) (FileCache, updateOp, error) {
+		if found {
+			return FileCache{}, updateKeep, nil
+		}
+		added = true
+		return d.newEntry(value, ttl), updateStore, nil
+	})
+	return added && err == nil, err
+}
+
+// Replace thay giá trị chỉ khi key đang tồn tại và chưa hết hạn.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần thay giá trị
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được thay, false nếu key không tồn tại
+//   - error: Lỗi nếu có trong quá trình ghi file
+func (d *fileDriver) Replace(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	replaced := false
+	err := d.modify(key, func(_ FileCache, found bool) (FileCache, updateOp, error) {
+		if !found {
+			return FileCache{}, updateKeep, nil
+		}
+		replaced = true
+		return d.newEntry(value, ttl), updateStore, nil
+	})
+	return replaced && err == nil, err
+}
+
+// GetAndSet ghi giá trị mới và trả về giá trị cũ dưới khóa của key.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - interface{}: Giá trị cũ (nil nếu không có)
+//   - bool: true nếu key tồn tại trước đó
+//   - error: Lỗi nếu có trong quá trình ghi file
+func (d *fileDriver) GetAndSet(ctx context.Context, key string, value interface{}, ttl time.Duration) (interface{}, bool, error) {
+	var old interface{}
+	var existed bool
+	err := d.modify(key, func(current FileCache, found bool) (FileCache, updateOp, error) {
+		old, existed = current.Value, found
+		return d.newEntry(value, ttl), updateStore, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return old, existed, nil
+}
+
+// GetAndDelete đọc giá trị và xóa file cache dưới khóa của key.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần lấy và xóa
+//
+// Returns:
+//   - interface{}: Giá trị đã xóa (nil nếu không có)
+//   - bool: true nếu key tồn tại và chưa hết hạn
+//   - error: Lỗi nếu không xóa được file
+func (d *fileDriver) GetAndDelete(ctx context.Context, key string) (interface{}, bool, error) {
+	var value interface{}
+	var found bool
+	err := d.modify(key, func(current FileCache, exists bool) (FileCache, updateOp, error) {
+		value, found = current.Value, exists
+		if !exists {
+			return FileCache{}, updateKeep, nil
+		}
+		return FileCache{}, updateDelete, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return value, found, nil
+}
+
+// GetWithVersion lấy giá trị cùng token phiên bản của entry.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm
+//
+// Returns:
+//   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
+//   - string: Token phiên bản của entry (rỗng nếu không tìm thấy)
+//   - bool: true nếu tìm thấy key và chưa hết hạn
+func (d *fileDriver) GetWithVersion(ctx context.Context, key string) (interface{}, string, bool) {
+	cache, found, _ := d.read(key)
+	if !found {
+		return nil, "", false
+	}
+	return cache.Value, strconv.FormatUint(cache.Version, 10), true
+}
+
+// CompareAndSwap ghi giá trị mới chỉ khi phiên bản của entry khớp với token.
+//
+// Việc so sánh và ghi file được thực hiện dưới khóa của key.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần ghi
+//   - token: Token phiên bản nhận được từ GetWithVersion
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được ghi, false nếu key không tồn tại hoặc đã bị thay đổi
+//   - error: Lỗi nếu có trong quá trình ghi file
+func (d *fileDriver) CompareAndSwap(ctx context.Context, key, token string, value interface{}, ttl time.Duration) (bool, error) {
+	swapped := false
+	err := d.modify(key, func(cache FileCache, found bool) (FileCache, updateOp, error) {
+		if !found || strconv.FormatUint(cache.Version, 10) != token {
+			return FileCache{}, updateKeep, nil
+		}
+		swapped = true
+		return d.newEntry(value, ttl), updateStore, nil
+	})
+	return swapped && err == nil, err
+}
+
+// TTL trả về thời gian sống còn lại của entry.
+//
+// Chỉ phần header của file được giải mã, giá trị không được đọc.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần kiểm tra
+//
+// Returns:
+//   - time.Duration: Thời gian sống còn lại, NoExpiration nếu entry không hết hạn
+//   - bool: true nếu tìm thấy key và chưa hết hạn
+func (d *fileDriver) TTL(ctx context.Context, key string) (time.Duration, bool) {
+	filename, err := d.keyToFilename(key)
+	if err != nil {
+		return 0, false
+	}
+	header, err := readFileCacheHeader(filename)
+	if err != nil || (header.Expiration > 0 && time.Now().UnixNano() > header.Expiration) {
+		return 0, false
+	}
+	return remainingTTL(header.Expiration), true
+}
+
+// Touch đặt lại thời điểm hết hạn của entry mà không thay đổi giá trị.
+//
+// Header và giá trị nằm trong cùng một file nên file được ghi lại toàn bộ,
+// nhưng phiên bản của entry được giữ nguyên.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần gia hạn
+//   - ttl: Thời gian sống mới tính từ bây giờ (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu key tồn tại và đã được cập nhật
+//   - error: Lỗi nếu có trong quá trình ghi file
+func (d *fileDriver) Touch(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	exp, _ := staleExpirations(ttl, 0, d.defaultExpiration)
+	return d.touch(key, func(int64) (int64, bool) {
+		return exp, true
+	})
+}
+
+// touch cập nhật thời điểm hết hạn của entry còn hạn dưới khóa của key.
+//
+// Chỉ header được ghi lại: payload được chép nguyên vẹn mà không giải mã, giải mã khóa
+// hay mã hóa lại giá trị. File định dạng cũ được ghi lại toàn bộ theo định dạng hiện tại.
+//
+// Params:
+//   - key: Cache key
+//   - fn: Hàm nhận thời điểm hết hạn hiện tại, trả về thời điểm mới và true nếu cần ghi lại
+//
+// Returns:
+//   - bool: true nếu tìm thấy key và chưa hết hạn
+//   - error: Lỗi nếu có trong quá trình ghi file
+func (d *fileDriver) touch(key string, fn func(expiration int64) (int64, bool)) (bool, error) {
+	filename, err := d.keyToFilename(key)
+	if err != nil {
+		return false, err
+	}
+
+	unlock := d.lockKey(key)
+	defer unlock()
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return false, nil
+	}
+	if isLegacyFileCache(data) {
+		cache, _, found, err := d.load(filename)
+		if !found {
+			return false, err
+		}
+		expiration, ok := fn(cache.Expiration)
+		if !ok {
+			return true, nil
+		}
+		cache.Expiration = expiration
+		cache.Key = key
+		return true, d.save(filename, cache)
+	}
+
+	header, payload, err := splitFileCache(data)
+	if err != nil {
+		if errors.Is(err, errFileChecksum) {
+			d.removeFile(filename) // Xóa file bị hỏng
+		}
+		return false, nil
+	}
+	if header.Expiration > 0 && time.Now().UnixNano() > header.Expiration {
+		d.removeFile(filename) // Xóa file đã hết hạn
+		return false, nil
+	}
+	expiration, ok := fn(header.Expiration)
+	if !ok {
+		return true, nil
+	}
+	header.Expiration = expiration
+	header.Key = key
+	data, err = joinFileCache(header, payload)
+	if err != nil {
+		return true, err
+	}
+	return true, d.writeData(filename, fileKeyEntry{key: key, expiration: expiration}, data)
 }
 
 // Has kiểm tra xem một key có tồn tại trong cache không.
@@ -251,15 +1034,19 @@ func (d *fileDriver) Delete(ctx context.Context, key string) error {
 	if err != nil {
 		return err
 	}
-	if _, err := os.Stat(filename); os.IsNotExist(err) {
-		return nil // File không tồn tại, không cần xóa
+
+	unlock := d.lockKey(key)
+	defer unlock()
+	if err := d.removeFile(filename); err != nil && !os.IsNotExist(err) {
+		return err
 	}
-	return os.Remove(filename)
+	return nil // File không tồn tại, không cần xóa
 }
 
 // Flush xóa tất cả các key khỏi cache.
 //
 // Phương thức này xóa tất cả các file trong thư mục cache, làm trống hoàn toàn bộ nhớ cache.
+// Các file khóa và file tạm của các lần ghi đang diễn ra được giữ lại.
 //
 // Params:
 //   - ctx: Context để kiểm soát thời gian thực thi của thao tác
@@ -267,28 +1054,185 @@ func (d *fileDriver) Delete(ctx context.Context, key string) error {
 // Returns:
 //   - error: Lỗi nếu có trong quá trình xóa files
 func (d *fileDriver) Flush(ctx context.Context) error {
-	dir, err := os.Open(d.directory)
+	d.mu.Lock()
+	d.tags = make(tagIndex)
+	d.mu.Unlock()
+
+	var errs []error
+	err := d.walk(func(dir string, entry os.DirEntry) bool {
+		name := entry.Name()
+		if isLockFile(name) || isTempFile(name) {
+			return true
+		}
+		if err := d.removeFile(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, fmt.Errorf("file '%s': %w", name, err))
+		}
+		return true
+	})
 	if err != nil {
 		return err
 	}
-	defer dir.Close()
+	if len(errs) > 0 {
+		return fmt.Errorf("Flush errors: %v", errs)
+	}
+	return nil
+}
+
+// FlushTags xóa tất cả các entry mang ít nhất một trong các tag được chỉ định.
+//
+// Phương thức này tra cứu các file qua index tag, đọc lại header của từng file dưới khóa
+// của key để xác nhận entry vẫn mang tag (entry có thể đã bị ghi đè không có tag) rồi
+// mới xóa.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - tags: Danh sách tag cần xóa
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình xóa files
+func (d *fileDriver) FlushTags(ctx context.Context, tags []string) error {
+	candidates := make(map[string]struct{})
+
+	d.mu.Lock()
+	for _, tag := range tags {
+		for filename := range d.tags[tag] {
+			candidates[filename] = struct{}{}
+		}
+		delete(d.tags, tag)
+	}
+	d.mu.Unlock()
+
+	flushed := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		flushed[tag] = struct{}{}
+	}
+
+	var errs []error
+	for filename := range candidates {
+		header, removed, err := d.removeCacheFile(filename, func(header fileCacheHeader) bool {
+			return hasAnyTag(header.Tags, flushed)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("file '%s': %w", filepath.Base(filename), err))
+			continue
+		}
+		if !removed {
+			continue
+		}
+
+		d.mu.Lock()
+		d.tags.remove(filename, header.Tags)
+		d.mu.Unlock()
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("FlushTags errors: %v", errs)
+	}
+	return nil
+}
+
+// Keys trả về các key còn hạn khớp với pattern.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - pattern: Glob pattern của key
+//
+// Returns:
+//   - []string: Các key khớp pattern
+//   - error: Lỗi nếu pattern không hợp lệ, ctx kết thúc hoặc không đọc được thư mục cache
+func (d *fileDriver) Keys(ctx context.Context, pattern string) ([]string, error) {
+	return scanKeys(ctx, d, pattern)
+}
 
-	names, err := dir.Readdirnames(-1)
+// Scan duyệt lần lượt các key còn hạn khớp với pattern.
+//
+// Tên file là hash SHA-1 của key nên không thể lọc theo tên; Scan duyệt index key trong
+// bộ nhớ (xem fileKeyIndex) mà không mở file nào. Key do process khác ghi vào cùng thư
+// mục được liệt kê sau lượt janitor tiếp theo. File được ghi bởi phiên bản cũ chưa có
+// key trong header sẽ được bỏ qua cho đến khi được đọc hoặc ghi lại.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - pattern: Glob pattern của key
+//   - fn: Hàm được gọi cho từng key, trả về false để dừng quét
+//
+// Returns:
+//   - error: Lỗi nếu pattern không hợp lệ, ctx kết thúc hoặc không đọc được thư mục cache
+func (d *fileDriver) Scan(ctx context.Context, pattern string, fn func(key string) bool) error {
+	re, err := compilePattern(pattern)
 	if err != nil {
 		return err
 	}
 
+	for _, key := range d.indexedKeys(re) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !fn(key) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// DeleteMatching xóa tất cả các entry có key khớp với pattern.
+//
+// Các entry được tìm qua index key như Scan, và header của từng file khớp được đọc lại
+// dưới khóa của key để xác nhận key trước khi xóa; file được ghi bởi phiên bản cũ chưa có
+// key trong header không bị xóa.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - pattern: Glob pattern của key
+//
+// Returns:
+//   - int: Số entry đã bị xóa
+//   - error: Lỗi nếu pattern không hợp lệ, ctx kết thúc, không đọc được thư mục cache
+//     hoặc không xóa được file
+func (d *fileDriver) DeleteMatching(ctx context.Context, pattern string) (int, error) {
+	re, err := compilePattern(pattern)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
 	var errs []error
-	for _, name := range names {
-		err = os.Remove(filepath.Join(d.directory, name))
+	for filename, key := range d.indexedKeys(re) {
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+		header, removed, err := d.removeEntry(key, filename, func(header fileCacheHeader) bool {
+			return header.Key == key
+		})
 		if err != nil {
-			errs = append(errs, fmt.Errorf("file '%s': %w", name, err))
+			errs = append(errs, fmt.Errorf("file '%s': %w", filepath.Base(filename), err))
+			continue
+		}
+		if !removed {
+			continue
 		}
+		deleted++
+
+		d.mu.Lock()
+		d.tags.remove(filename, header.Tags)
+		d.mu.Unlock()
 	}
 	if len(errs) > 0 {
-		return fmt.Errorf("Flush errors: %v", errs)
+		return deleted, fmt.Errorf("DeleteMatching errors: %v", errs)
 	}
-	return nil
+	return deleted, nil
+}
+
+// DeleteByPrefix xóa tất cả các entry có key bắt đầu bằng prefix.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - prefix: Tiền tố của key
+//
+// Returns:
+//   - int: Số entry đã bị xóa
+//   - error: Lỗi khi đọc thư mục cache hoặc xóa file
+func (d *fileDriver) DeleteByPrefix(ctx context.Context, prefix string) (int, error) {
+	return d.DeleteMatching(ctx, prefixPattern(prefix))
 }
 
 // GetMultiple lấy nhiều giá trị từ cache.
@@ -366,6 +1310,9 @@ func (d *fileDriver) DeleteMultiple(ctx context.Context, keys []string) error {
 // trả về giá trị tương ứng. Nếu key không tồn tại hoặc đã hết hạn, phương thức
 // sẽ gọi hàm callback để lấy dữ liệu, lưu kết quả vào cache và trả về giá trị đó.
 //
+// Các lần gọi đồng thời cho cùng một key trong process được gộp lại: chỉ một
+// goroutine thực thi callback, các goroutine khác chờ và nhận cùng kết quả.
+//
 // Params:
 //   - ctx: Context để kiểm soát thời gian thực thi của thao tác
 //   - key: Cache key cần tìm hoặc lưu vào cache
@@ -374,27 +1321,67 @@ func (d *fileDriver) DeleteMultiple(ctx context.Context, keys []string) error {
 //
 // Returns:
 //   - interface{}: Giá trị từ cache hoặc từ callback
-//   - error: Lỗi nếu có trong quá trình thực hiện hoặc từ callback
+//   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc lỗi giải mã nếu entry
+//     không giải mã được bằng các khóa đã cấu hình (callback không được gọi)
 func (d *fileDriver) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
-	value, found := d.Get(ctx, key)
+	cache, found, err := d.get(key)
+	if err != nil {
+		return nil, err
+	}
 	if found {
-		return value, nil
+		return cache.Value, nil
 	}
 
 	if callback == nil {
 		return nil, fmt.Errorf("callback function is required")
 	}
 
-	value, err := callback()
-	if err != nil {
-		return nil, err
+	return d.flights.do(key, func() (interface{}, error) {
+		value, err := callback()
+		if err != nil {
+			return nil, err
+		}
+
+		err = d.Set(ctx, key, value, ttl)
+		if err != nil {
+			return nil, err
+		}
+		return value, nil
+	})
+}
+
+// RememberStale lấy một giá trị từ cache theo cơ chế stale-while-revalidate.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - ttl: Thời gian giá trị được coi là còn mới (0 để sử dụng mặc định)
+//   - grace: Thời gian giá trị cũ tiếp tục được phục vụ sau ttl
+//   - callback: Hàm được gọi để lấy dữ liệu
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi nếu có trong quá trình thực hiện hoặc từ callback
+func (d *fileDriver) RememberStale(ctx context.Context, key string, ttl, grace time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	if callback == nil {
+		return nil, fmt.Errorf("callback function is required")
 	}
+	return rememberStale(ctx, d, &d.flights, nil, key, ttl, grace, callback)
+}
 
-	err = d.Set(ctx, key, value, ttl)
-	if err != nil {
-		return nil, err
+// getStale lấy giá trị cùng thời điểm hết hạn mềm của entry.
+func (d *fileDriver) getStale(ctx context.Context, key string) (interface{}, int64, bool, error) {
+	cache, found, err := d.read(key)
+	if !found {
+		return nil, 0, false, err
 	}
-	return value, nil
+	return cache.Value, cache.SoftExpiration, true, nil
+}
+
+// setStale lưu giá trị với thời điểm hết hạn mềm sau ttl và hết hạn cứng sau ttl + grace.
+func (d *fileDriver) setStale(ctx context.Context, key string, value interface{}, ttl, grace time.Duration) error {
+	_, err := d.write(key, value, ttl, grace, nil)
+	return err
 }
 
 // Stats trả về thông tin thống kê về cache.
@@ -408,32 +1395,26 @@ func (d *fileDriver) Remember(ctx context.Context, key string, ttl time.Duration
 // Returns:
 //   - map[string]interface{}: Map chứa các thông tin thống kê
 func (d *fileDriver) Stats(ctx context.Context) map[string]interface{} {
-	var itemCount int
-	var size int64
-
-	// Đếm số lượng file và kích thước
-	_ = filepath.Walk(d.directory, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if !info.IsDir() {
-			itemCount++
-			size += info.Size()
-		}
-		return nil
-	})
+	// Số lượng file và kích thước lấy từ index dung lượng, không duyệt thư mục
+	itemCount, size, evictions, bytesEvicted := d.usage.stats()
 
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 
-	return map[string]interface{}{
-		"count":  itemCount,
-		"size":   size,
-		"hits":   d.hits,
-		"misses": d.misses,
-		"type":   "file",
-		"path":   d.directory,
+	stats := map[string]interface{}{
+		"count":          itemCount,
+		"size":           size,
+		"hits":           d.hits,
+		"misses":         d.misses,
+		"evictions":      evictions,
+		"bytes_evicted":  bytesEvicted,
+		"max_size_bytes": d.maxSizeBytes,
+		"max_files":      d.maxFiles,
+		"type":           "file",
+		"path":           d.directory,
 	}
+	d.encryptor.addStats(stats)
+	return stats
 }
 
 // Close giải phóng tài nguyên của driver.
@@ -447,6 +1428,7 @@ func (d *fileDriver) Close() error {
 	if d.janitorRunning {
 		d.stopJanitor <- true
 	}
+	d.closeStripeLocks()
 	return nil
 }
 
@@ -471,37 +1453,88 @@ func (d *fileDriver) startJanitor() {
 // deleteExpired xóa tất cả các file đã hết hạn.
 //
 // Phương thức này quét qua tất cả các file trong thư mục cache,
-// đọc thông tin thời gian hết hạn và xóa file nếu đã quá hạn.
+// đọc thông tin thời gian hết hạn và xóa file nếu đã quá hạn. File tạm bị bỏ lại
+// bởi các lần ghi bị gián đoạn cũng được dọn. Các thư mục được đọc lần lượt theo
+// từng lô, nên thư mục cache lớn không bị tải toàn bộ vào bộ nhớ.
+//
+// Index dung lượng và index key được đồng bộ lại với các file còn lại, để tính cả file
+// do process khác ghi hoặc xóa, rồi giới hạn dung lượng được áp dụng lại.
 func (d *fileDriver) deleteExpired() {
-	now := time.Now().UnixNano()
-	dir, err := os.Open(d.directory)
-	if err != nil {
-		return
-	}
-	defer dir.Close()
+	start := time.Now()
+	now := start.UnixNano()
 
-	names, err := dir.Readdirnames(-1)
+	var entries []fileUsageEntry
+	keys := make(map[string]fileKeyEntry)
+	err := d.walk(func(dir string, entry os.DirEntry) bool {
+		name := entry.Name()
+		filename := filepath.Join(dir, name)
+		if isTempFile(name) {
+			removeStaleTempFile(filename, start)
+			return true
+		}
+		if !d.isCacheFile(name) {
+			return true
+		}
+		// File hết hạn được xóa dưới khóa của key, để entry vừa được ghi lại không bị xóa nhầm
+		header, removed, _ := d.removeCacheFile(filename, func(header fileCacheHeader) bool {
+			return fileExpired(header, now)
+		})
+		if removed {
+			return true
+		}
+		if info, err := entry.Info(); err == nil {
+			entries = append(entries, fileUsageEntry{filename: filename, size: info.Size(), modTime: info.ModTime()})
+		}
+		if header.Key != "" {
+			keys[filename] = fileKeyEntry{key: header.Key, expiration: header.Expiration}
+		}
+		return true
+	})
 	if err != nil {
 		return
 	}
+	d.usage.sync(entries)
+	d.syncKeys(keys)
+	d.enforceQuota("")
+	d.evictPending()
+}
 
-	for _, name := range names {
-		filename := filepath.Join(d.directory, name)
-		file, err := os.Open(filename)
-		if err != nil {
-			continue
+// loadIndex xây dựng index tag, index key và index dung lượng từ các file cache đã có
+// trong thư mục.
+//
+// Phương thức này chỉ đọc header của từng file; các file không đọc được hoặc
+// đã hết hạn không được đưa vào index tag và index key nhưng vẫn được tính vào dung
+// lượng cho đến khi bị xóa.
+func (d *fileDriver) loadIndex() {
+	now := time.Now().UnixNano()
+	var entries []fileUsageEntry
+	_ = d.walk(func(dir string, entry os.DirEntry) bool {
+		if !d.isCacheFile(entry.Name()) {
+			return true
 		}
-
-		var cache FileCache
-		decoder := gob.NewDecoder(file)
-		if err = decoder.Decode(&cache); err != nil {
-			file.Close()
-			continue
+		filename := filepath.Join(dir, entry.Name())
+		if info, err := entry.Info(); err == nil {
+			entries = append(entries, fileUsageEntry{filename: filename, size: info.Size(), modTime: info.ModTime()})
+		}
+		header, err := readFileCacheHeader(filename)
+		if err != nil || (header.Expiration > 0 && now > header.Expiration) {
+			return true
 		}
-		file.Close()
+		if header.Key != "" {
+			d.keys[filename] = fileKeyEntry{key: header.Key, expiration: header.Expiration}
+		}
+		d.tags.add(filename, header.Tags)
+		return true
+	})
+	d.usage.load(entries)
+}
 
-		if cache.Expiration > 0 && now > cache.Expiration {
-			_ = os.Remove(filename) // Ignore error, continue
+// hasAnyTag kiểm tra danh sách tag có chứa ít nhất một tag trong tập cho trước.
+func hasAnyTag(tags []string, set map[string]struct{}) bool {
+	for _, tag := range tags {
+		if _, ok := set[tag]; ok {
+			return true
 		}
 	}
+	return false
 }
@@ -0,0 +1,94 @@
+package driver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+)
+
+// Reencrypt mã hóa lại bằng khóa đang hoạt động mọi file cache được mã hóa bằng khóa khác.
+//
+// ID khóa được đọc từ header của file nên chỉ các file cần mã hóa lại bị giải mã. Mỗi file
+// được đọc lại và ghi đè dưới khóa của key, giữ nguyên thời hạn, tag và phiên bản; file đã
+// được ghi lại bởi thao tác khác trong lúc chờ khóa không bị ghi đè.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi và dừng thao tác
+//
+// Returns:
+//   - int: Số file đã được mã hóa lại
+//   - error: ErrEncryptionDisabled nếu driver không bật mã hóa, lỗi nếu ctx kết thúc,
+//     không đọc được thư mục cache hoặc không ghi được file
+func (d *fileDriver) Reencrypt(ctx context.Context) (int, error) {
+	if !d.encryptor.enabled() {
+		return 0, ErrEncryptionDisabled
+	}
+
+	type staleFile struct {
+		key      string
+		filename string
+	}
+	var files []staleFile
+	var ctxErr error
+	err := d.walk(func(dir string, entry os.DirEntry) bool {
+		if ctxErr = ctx.Err(); ctxErr != nil {
+			return false
+		}
+		if !d.isCacheFile(entry.Name()) {
+			return true
+		}
+		filename := filepath.Join(dir, entry.Name())
+		header, err := readFileCacheHeader(filename)
+		if err == nil && header.Key != "" && d.encryptor.stale(header.EncryptionKey) {
+			files = append(files, staleFile{key: header.Key, filename: filename})
+		}
+		return true
+	})
+	if ctxErr != nil {
+		return 0, ctxErr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, f := range files {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+		rewritten, err := d.reencrypt(f.key, f.filename)
+		if err != nil {
+			return count, err
+		}
+		if rewritten {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// reencrypt ghi lại file cache của key bằng khóa đang hoạt động dưới khóa của key.
+//
+// Params:
+//   - key: Cache key của entry
+//   - filename: Đường dẫn file cache
+//
+// Returns:
+//   - bool: true nếu file đã được ghi lại
+//   - error: Lỗi nếu không ghi được file
+func (d *fileDriver) reencrypt(key, filename string) (bool, error) {
+	unlock := d.lockKey(key)
+	defer unlock()
+
+	// File có thể đã được ghi lại hoặc bị xóa trong lúc chờ khóa
+	header, err := readFileCacheHeader(filename)
+	if err != nil || header.Key != key || !d.encryptor.stale(header.EncryptionKey) {
+		return false, nil
+	}
+	cache, _, found, err := d.load(filename)
+	if err != nil || !found {
+		// Entry không giải mã được được giữ nguyên để báo lỗi khi đọc
+		return false, nil
+	}
+	return true, d.save(filename, cache)
+}
@@ -0,0 +1,16 @@
+//go:build !unix
+
+package driver
+
+import "os"
+
+// flockFile không làm gì trên nền tảng không có flock; các thao tác ghi chỉ được tuần
+// tự hóa trong cùng process.
+func flockFile(file *os.File) error {
+	return nil
+}
+
+// funlockFile không làm gì trên nền tảng không có flock.
+func funlockFile(file *os.File) error {
+	return nil
+}
@@ -0,0 +1,35 @@
+//go:build unix
+
+package driver
+
+import (
+	"os"
+	"syscall"
+)
+
+// flockFile giữ khóa tư vấn (advisory lock) độc quyền trên file, chờ đến khi giành được.
+//
+// Params:
+//   - file: File khóa đã được mở
+//
+// Returns:
+//   - error: Lỗi nếu hệ thống file không hỗ trợ flock
+func flockFile(file *os.File) error {
+	for {
+		err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX)
+		if err != syscall.EINTR {
+			return err
+		}
+	}
+}
+
+// funlockFile nhả khóa tư vấn đã giữ bằng flockFile.
+//
+// Params:
+//   - file: File khóa đang được giữ
+//
+// Returns:
+//   - error: Lỗi từ flock
+func funlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
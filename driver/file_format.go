@@ -0,0 +1,376 @@
+package driver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"go.fork.vn/cache/codec"
+)
+
+// Định dạng file cache:
+//
+//	magic (4 byte) | phiên bản định dạng (1 byte) | độ dài header (uint32 big-endian)
+//	header (JSON) | payload (giá trị được mã hóa bằng codec trong header)
+//
+// Khi bật mã hóa, payload là giá trị đã được codec mã hóa rồi được mã hóa bằng khóa có ID
+// ghi trong header, với cache key là associated data.
+//
+// Header là JSON để có thể đọc key và thời điểm hết hạn của một file khi gỡ lỗi mà
+// không cần giải mã giá trị. File được ghi bởi phiên bản cũ chỉ chứa FileCache mã hóa
+// bằng gob; chúng được nhận diện vì không bắt đầu bằng magic.
+const (
+	// fileMagic mở đầu mọi file cache; một luồng gob không bao giờ bắt đầu bằng byte 0
+	fileMagic = "\x00GFC"
+
+	// fileFormatVersion là phiên bản hiện tại của định dạng file cache
+	fileFormatVersion byte = 1
+
+	// filePrefixSize là kích thước phần cố định trước header
+	filePrefixSize = len(fileMagic) + 1 + 4
+
+	// fileMaxHeaderSize là kích thước header tối đa được chấp nhận khi đọc
+	fileMaxHeaderSize = 1 << 20
+)
+
+// errFileChecksum được trả về khi checksum của payload không khớp với header.
+var errFileChecksum = errors.New("cache file checksum mismatch")
+
+// fileCacheHeader là header của file cache.
+//
+// Header chứa toàn bộ metadata của entry, nên các thao tác chỉ cần metadata (Scan, TTL,
+// janitor, index tag) không phải giải mã payload và không phụ thuộc codec của giá trị.
+type fileCacheHeader struct {
+	Key            string   `json:"key"`                       // Cache key, rỗng với file định dạng cũ không lưu key
+	Expiration     int64    `json:"expiration,omitempty"`      // Thời điểm hết hạn (UnixNano), 0 nếu không hết hạn
+	SoftExpiration int64    `json:"soft_expiration,omitempty"` // Thời điểm giá trị hết còn mới (UnixNano), 0 nếu không có
+	CreatedAt      int64    `json:"created_at,omitempty"`      // Thời điểm entry được tạo (UnixNano)
+	Version        uint64   `json:"version,omitempty"`         // Phiên bản của entry
+	Tags           []string `json:"tags,omitempty"`            // Các tag gắn với entry
+	Codec          string   `json:"codec,omitempty"`           // Codec của payload
+	EncryptionKey  string   `json:"encryption_key,omitempty"`  // ID khóa đã mã hóa payload, rỗng nếu không mã hóa
+	Checksum       uint32   `json:"checksum"`                  // CRC-32 (IEEE) của payload
+}
+
+// legacyFileCacheHeader chứa các trường metadata của FileCache trong file định dạng cũ.
+//
+// Gob bỏ qua các trường không có trong kiểu đích, nên việc giải mã file vào cấu trúc
+// này không cần giải mã Value.
+type legacyFileCacheHeader struct {
+	Expiration int64    // Thời điểm hết hạn (UnixNano), 0 nếu không hết hạn
+	Tags       []string // Các tag gắn với entry
+	Key        string   // Cache key của entry
+}
+
+// filePayload bọc giá trị để gob ghi được kiểu cụ thể của interface{}.
+//
+// Chỉ payload của codec gob được bọc; các codec khác nhận thẳng giá trị của ứng dụng.
+type filePayload struct {
+	Value interface{}
+}
+
+// marshalFileValue mã hóa giá trị của entry bằng codec.
+//
+// Giá trị được truyền thẳng cho codec như redis và mongodb driver, nên codec tùy chỉnh
+// nhận đúng giá trị của ứng dụng và cùng một giá trị cho cùng dữ liệu ở mọi driver.
+// Riêng gob không giải mã được vào interface{} một giá trị được ghi ở cấp cao nhất,
+// nên giá trị được bọc trong filePayload.
+//
+// Params:
+//   - c: Codec mã hóa giá trị
+//   - value: Giá trị cần mã hóa
+//
+// Returns:
+//   - []byte: Payload đã mã hóa
+//   - error: Lỗi nếu codec không mã hóa được giá trị
+func marshalFileValue(c codec.Codec, value interface{}) ([]byte, error) {
+	if c.Name() == codec.Gob {
+		return c.Marshal(filePayload{Value: value})
+	}
+	return c.Marshal(value)
+}
+
+// unmarshalFileValue giải mã payload được ghi bởi marshalFileValue vào target.
+//
+// Params:
+//   - c: Codec đã ghi payload
+//   - payload: Payload đã được giải mã bằng khóa (nếu có)
+//   - target: Con trỏ tới biến nhận giá trị
+//
+// Returns:
+//   - error: Lỗi nếu codec không giải mã được payload, hoặc lỗi wrap ErrTypeMismatch nếu
+//     giá trị gob không gán được cho target
+func unmarshalFileValue(c codec.Codec, payload []byte, target interface{}) error {
+	if c.Name() != codec.Gob {
+		return c.Unmarshal(payload, target)
+	}
+	var p filePayload
+	if err := c.Unmarshal(payload, &p); err != nil {
+		return err
+	}
+	return assignValue(target, p.Value)
+}
+
+// encodeFileCache mã hóa một entry theo định dạng file cache hiện tại.
+//
+// Params:
+//   - cache: Entry cần mã hóa
+//   - c: Codec mã hóa payload; tên codec được ghi vào header
+//   - enc: Stage mã hóa payload; ID khóa được ghi vào header
+//
+// Returns:
+//   - []byte: Nội dung file
+//   - error: Lỗi nếu không mã hóa được giá trị
+func encodeFileCache(cache FileCache, c codec.Codec, enc *valueEncryptor) ([]byte, error) {
+	payload, err := marshalFileValue(c, cache.Value)
+	if err != nil {
+		return nil, err
+	}
+	payload, keyID, err := enc.encrypt(payload, cache.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return joinFileCache(fileCacheHeader{
+		Key:            cache.Key,
+		Expiration:     cache.Expiration,
+		SoftExpiration: cache.SoftExpiration,
+		CreatedAt:      cache.CreatedAt,
+		Version:        cache.Version,
+		Tags:           cache.Tags,
+		Codec:          c.Name(),
+		EncryptionKey:  keyID,
+		Checksum:       crc32.ChecksumIEEE(payload),
+	}, payload)
+}
+
+// joinFileCache ghép header và payload thành nội dung file cache định dạng hiện tại.
+//
+// Params:
+//   - header: Header của entry; Checksum phải khớp với payload
+//   - payload: Payload đã được mã hóa bằng codec (và mã hóa bằng khóa) ghi trong header
+//
+// Returns:
+//   - []byte: Nội dung file
+//   - error: Lỗi nếu không mã hóa được header
+func joinFileCache(header fileCacheHeader, payload []byte) ([]byte, error) {
+	encoded, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0, filePrefixSize+len(encoded)+len(payload))
+	data = append(data, fileMagic...)
+	data = append(data, fileFormatVersion)
+	data = binary.BigEndian.AppendUint32(data, uint32(len(encoded)))
+	data = append(data, encoded...)
+	return append(data, payload...), nil
+}
+
+// splitFileCache tách header và payload của một file cache định dạng hiện tại mà không
+// giải mã payload.
+//
+// Params:
+//   - data: Nội dung file, bắt đầu bằng fileMagic
+//
+// Returns:
+//   - fileCacheHeader: Header của entry
+//   - []byte: Payload
+//   - error: Lỗi nếu header hỏng hoặc checksum của payload chưa mã hóa không khớp
+func splitFileCache(data []byte) (fileCacheHeader, []byte, error) {
+	r := bytes.NewReader(data)
+	header, err := decodeFileCacheHeader(r)
+	if err != nil {
+		return header, nil, err
+	}
+	payload := data[len(data)-r.Len():]
+	if header.EncryptionKey == "" && crc32.ChecksumIEEE(payload) != header.Checksum {
+		return header, nil, errFileChecksum
+	}
+	return header, payload, nil
+}
+
+// isLegacyFileCache kiểm tra nội dung file có thuộc định dạng cũ (gob) hay không.
+func isLegacyFileCache(data []byte) bool {
+	return !bytes.HasPrefix(data, []byte(fileMagic))
+}
+
+// fileCacheEntry là nội dung một file cache đã được tách header nhưng chưa giải mã giá trị.
+type fileCacheEntry struct {
+	header  fileCacheHeader // Metadata của entry
+	payload []byte          // Payload, được mã hóa bằng codec (và khóa) ghi trong header
+	legacy  bool            // true nếu file có định dạng cũ
+	value   interface{}     // Giá trị của file định dạng cũ, đã được gob giải mã cùng metadata
+}
+
+// parseFileCache tách metadata và payload của nội dung một file cache mà không giải mã
+// giá trị, để entry đã hết hạn bị bỏ qua trước khi giải mã.
+//
+// Params:
+//   - data: Nội dung file
+//
+// Returns:
+//   - fileCacheEntry: Entry chưa giải mã giá trị
+//   - error: Lỗi nếu file hỏng hoặc checksum của payload chưa mã hóa không khớp
+func parseFileCache(data []byte) (fileCacheEntry, error) {
+	if isLegacyFileCache(data) {
+		var cache FileCache
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&cache); err != nil {
+			return fileCacheEntry{}, err
+		}
+		return fileCacheEntry{
+			header: fileCacheHeader{
+				Key:            cache.Key,
+				Expiration:     cache.Expiration,
+				SoftExpiration: cache.SoftExpiration,
+				CreatedAt:      cache.CreatedAt,
+				Version:        cache.Version,
+				Tags:           cache.Tags,
+			},
+			legacy: true,
+			value:  cache.Value,
+		}, nil
+	}
+
+	header, payload, err := splitFileCache(data)
+	if err != nil {
+		return fileCacheEntry{}, err
+	}
+	return fileCacheEntry{header: header, payload: payload}, nil
+}
+
+// decodeValue giải mã giá trị của entry vào target.
+//
+// Payload được giải mã bằng codec ghi trong header, không phụ thuộc codec đang được
+// cấu hình, nên file ghi trước khi đổi codec vẫn đọc được, và được giải mã thẳng vào
+// kiểu của target như redis và mongodb driver. Payload đã mã hóa được xác thực bởi thuật
+// toán mã hóa thay cho checksum, nên file bị sửa trả về lỗi xác thực.
+//
+// Params:
+//   - enc: Stage giải mã payload đã mã hóa
+//   - target: Con trỏ tới biến nhận giá trị
+//
+// Returns:
+//   - error: Lỗi giải mã nếu payload đã mã hóa không xác thực được, hoặc lỗi nếu codec
+//     chưa được đăng ký hoặc không giải mã được giá trị vào target
+func (e fileCacheEntry) decodeValue(enc *valueEncryptor, target interface{}) error {
+	if e.legacy {
+		return assignValue(target, e.value)
+	}
+
+	payload, err := enc.decrypt(e.payload, e.header.Key, e.header.EncryptionKey != "")
+	if err != nil {
+		return err
+	}
+	c, err := codec.Get(e.header.Codec)
+	if err != nil {
+		return err
+	}
+	return unmarshalFileValue(c, payload, target)
+}
+
+// cache trả về metadata của entry dưới dạng FileCache, chưa có Value.
+func (e fileCacheEntry) cache() FileCache {
+	return FileCache{
+		Expiration:     e.header.Expiration,
+		Tags:           e.header.Tags,
+		SoftExpiration: e.header.SoftExpiration,
+		Version:        e.header.Version,
+		Key:            e.header.Key,
+		CreatedAt:      e.header.CreatedAt,
+	}
+}
+
+// decodeFileCacheHeader đọc header từ đầu một file cache.
+//
+// Với file định dạng cũ, metadata được giải mã bằng gob mà không giải mã Value.
+// Với file định dạng hiện tại, r dừng ở đầu payload.
+//
+// Params:
+//   - r: Reader đặt ở đầu file
+//
+// Returns:
+//   - fileCacheHeader: Header của entry
+//   - error: Lỗi nếu không đọc hoặc không giải mã được header
+func decodeFileCacheHeader(r io.Reader) (fileCacheHeader, error) {
+	var header fileCacheHeader
+
+	prefix := make([]byte, filePrefixSize)
+	n, err := io.ReadFull(r, prefix)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return header, err
+	}
+	prefix = prefix[:n]
+	if !bytes.HasPrefix(prefix, []byte(fileMagic)) {
+		var legacy legacyFileCacheHeader
+		if err := gob.NewDecoder(io.MultiReader(bytes.NewReader(prefix), r)).Decode(&legacy); err != nil {
+			return header, err
+		}
+		header.Key = legacy.Key
+		header.Expiration = legacy.Expiration
+		header.Tags = legacy.Tags
+		return header, nil
+	}
+	if n < filePrefixSize {
+		return header, io.ErrUnexpectedEOF
+	}
+
+	if version := prefix[len(fileMagic)]; version != fileFormatVersion {
+		return header, fmt.Errorf("unsupported cache file format version %d", version)
+	}
+	size := binary.BigEndian.Uint32(prefix[len(fileMagic)+1:])
+	if size > fileMaxHeaderSize {
+		return header, fmt.Errorf("cache file header too large: %d bytes", size)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return header, err
+	}
+	err = json.Unmarshal(data, &header)
+	return header, err
+}
+
+// fileExpired kiểm tra entry có header đã hết hạn tại thời điểm now (UnixNano) hay không.
+func fileExpired(header fileCacheHeader, now int64) bool {
+	return header.Expiration > 0 && now > header.Expiration
+}
+
+// readFileCacheHeader đọc metadata của một file cache mà không giải mã giá trị.
+//
+// Params:
+//   - filename: Đường dẫn file cache
+//
+// Returns:
+//   - fileCacheHeader: Metadata của entry
+//   - error: Lỗi nếu không thể mở hoặc giải mã header
+func readFileCacheHeader(filename string) (fileCacheHeader, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return fileCacheHeader{}, err
+	}
+	defer file.Close()
+
+	return decodeFileCacheHeader(file)
+}
+
+// migrate ghi lại entry định dạng cũ của key theo định dạng hiện tại dưới khóa của key.
+//
+// Params:
+//   - key: Cache key của entry
+//   - filename: Đường dẫn file cache
+func (d *fileDriver) migrate(key, filename string) {
+	unlock := d.lockKey(key)
+	defer unlock()
+
+	cache, legacy, found, _ := d.load(filename)
+	if found && legacy {
+		cache.Key = key
+		_ = d.save(filename, cache)
+	}
+}
@@ -0,0 +1,122 @@
+package driver
+
+import (
+	"os"
+	"regexp"
+	"time"
+)
+
+// fileKeyEntry là key và thời điểm hết hạn lưu trong header của một file cache.
+type fileKeyEntry struct {
+	key        string // Cache key của entry
+	expiration int64  // Thời điểm hết hạn (UnixNano), 0 nếu không hết hạn
+}
+
+// fileKeyIndex ánh xạ đường dẫn file cache tới key và thời điểm hết hạn của entry.
+//
+// Tên file là hash của key nên không thể lọc key theo tên file; index cho phép Scan và
+// DeleteMatching tìm key mà không mở từng file. Index được xây dựng cùng index tag khi
+// driver khởi động, cập nhật sau mỗi lần ghi và xóa của driver, và được đồng bộ lại với
+// thư mục ở mỗi lượt janitor. Như index tag, file do process khác ghi hoặc xóa chỉ được
+// phản ánh sau lượt janitor tiếp theo. Index được bảo vệ bởi mutex của driver.
+type fileKeyIndex map[string]fileKeyEntry
+
+// indexKey ghi nhận key của file cache vừa được ghi.
+//
+// File định dạng cũ chưa có key trong header không được đưa vào index.
+//
+// Params:
+//   - filename: Đường dẫn file cache
+//   - entry: Key và thời điểm hết hạn của entry
+func (d *fileDriver) indexKey(filename string, entry fileKeyEntry) {
+	if entry.key == "" {
+		return
+	}
+	d.mu.Lock()
+	d.keys[filename] = entry
+	d.mu.Unlock()
+}
+
+// unindexKey bỏ file cache khỏi index key.
+func (d *fileDriver) unindexKey(filename string) {
+	d.mu.Lock()
+	delete(d.keys, filename)
+	d.mu.Unlock()
+}
+
+// syncKeys đồng bộ index key với các file đọc được từ thư mục.
+//
+// Entry đọc được thay entry trong index; entry chưa có trong index chỉ được thêm nếu
+// file vẫn còn, và entry không được đọc thấy chỉ bị bỏ nếu file không còn, để file được
+// ghi hoặc xóa trong lúc duyệt không làm index sai đến lượt đồng bộ sau.
+//
+// Params:
+//   - entries: Key của các file cache đọc được, theo đường dẫn
+func (d *fileDriver) syncKeys(entries map[string]fileKeyEntry) {
+	var added, missing []string
+
+	d.mu.RLock()
+	for filename := range entries {
+		if _, ok := d.keys[filename]; !ok {
+			added = append(added, filename)
+		}
+	}
+	for filename := range d.keys {
+		if _, ok := entries[filename]; !ok {
+			missing = append(missing, filename)
+		}
+	}
+	d.mu.RUnlock()
+
+	// Kiểm tra sự tồn tại của file ngoài khóa của driver
+	for _, filename := range added {
+		if !fileExists(filename) {
+			delete(entries, filename)
+		}
+	}
+	var removed []string
+	for _, filename := range missing {
+		if !fileExists(filename) {
+			removed = append(removed, filename)
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for filename, entry := range entries {
+		d.keys[filename] = entry
+	}
+	for _, filename := range removed {
+		delete(d.keys, filename)
+	}
+}
+
+// indexedKeys trả về các key còn hạn trong index khớp với re, bỏ qua key nội bộ.
+//
+// Params:
+//   - re: Regexp của glob pattern
+//
+// Returns:
+//   - map[string]string: Key khớp pattern theo đường dẫn file cache
+func (d *fileDriver) indexedKeys(re *regexp.Regexp) map[string]string {
+	now := time.Now().UnixNano()
+	matched := make(map[string]string)
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for filename, entry := range d.keys {
+		if isInternalKey(entry.key) || (entry.expiration > 0 && now > entry.expiration) {
+			continue
+		}
+		if re.MatchString(entry.key) {
+			matched[filename] = entry.key
+		}
+	}
+	return matched
+}
+
+// fileExists kiểm tra file có tồn tại hay không.
+func fileExists(filename string) bool {
+	_, err := os.Stat(filename)
+	return !os.IsNotExist(err)
+}
@@ -0,0 +1,196 @@
+package driver
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// fileMaxDepth là số cấp thư mục con tối đa của file driver
+	fileMaxDepth = 4
+
+	// fileWalkBatch là số mục được đọc mỗi lần khi duyệt một thư mục, để việc duyệt
+	// thư mục lớn không tải toàn bộ tên file vào bộ nhớ
+	fileWalkBatch = 256
+)
+
+// fanOutWidth trả về số ký tự hex của hash dùng làm tên thư mục ở mỗi cấp.
+//
+// Params:
+//   - fanOut: Số thư mục con ở mỗi cấp
+//
+// Returns:
+//   - int: Số ký tự hex của tên thư mục
+//   - error: Lỗi nếu fanOut không phải 16, 256 hoặc 4096
+func fanOutWidth(fanOut int) (int, error) {
+	switch fanOut {
+	case 16:
+		return 1, nil
+	case 256:
+		return 2, nil
+	case 4096:
+		return 3, nil
+	}
+	return 0, fmt.Errorf("invalid cache directory fan-out %d: must be 16, 256 or 4096", fanOut)
+}
+
+// isHashName kiểm tra tên có phải hash SHA-1 dạng hex hay không.
+func isHashName(name string) bool {
+	if len(name) != sha1.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(name)
+	return err == nil
+}
+
+// hashFilename trả về đường dẫn file cache của một hash theo cấu trúc thư mục đã cấu hình.
+//
+// Với depth = 2 và fan-out 256, file của hash "abcdef…" nằm ở "ab/cd/abcdef…<ext>".
+//
+// Params:
+//   - hash: Hash SHA-1 dạng hex của key
+//
+// Returns:
+//   - string: Đường dẫn đầy đủ đến file cache
+func (d *fileDriver) hashFilename(hash string) string {
+	parts := make([]string, 0, d.depth+2)
+	parts = append(parts, d.directory)
+	for i := 0; i < d.depth; i++ {
+		parts = append(parts, hash[i*d.width:(i+1)*d.width])
+	}
+	return filepath.Join(append(parts, hash+d.extension)...)
+}
+
+// walk gọi fn cho từng file trong các thư mục lá của thư mục cache.
+//
+// Mỗi thư mục được đọc theo từng lô fileWalkBatch mục, nên bộ nhớ dùng cho việc duyệt
+// không tăng theo số file. Thư mục con bị xóa trong lúc duyệt được bỏ qua. Với cấu trúc
+// phẳng, thư mục lá là thư mục gốc và fn cũng nhận các file khóa.
+//
+// Params:
+//   - fn: Hàm nhận thư mục và mục file, trả về false để dừng duyệt
+//
+// Returns:
+//   - error: Lỗi nếu không đọc được thư mục
+func (d *fileDriver) walk(fn func(dir string, entry os.DirEntry) bool) error {
+	_, err := walkDir(d.directory, d.depth, fn)
+	return err
+}
+
+// walkDir duyệt dir và các thư mục con tới độ sâu depth.
+//
+// Returns:
+//   - bool: false nếu fn yêu cầu dừng
+//   - error: Lỗi nếu không đọc được thư mục
+func walkDir(dir string, depth int, fn func(dir string, entry os.DirEntry) bool) (bool, error) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	for {
+		entries, err := f.ReadDir(fileWalkBatch)
+		for _, entry := range entries {
+			if depth == 0 {
+				if !entry.IsDir() && !fn(dir, entry) {
+					return false, nil
+				}
+				continue
+			}
+			if !entry.IsDir() {
+				continue
+			}
+			more, err := walkDir(filepath.Join(dir, entry.Name()), depth-1, fn)
+			if err != nil && !os.IsNotExist(err) {
+				return false, err
+			}
+			if err == nil && !more {
+				return false, nil
+			}
+		}
+		if err == io.EOF {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+// migrateLayout chuyển các file cache ở thư mục gốc vào vị trí theo cấu trúc thư mục
+// đã cấu hình.
+//
+// File ở thư mục gốc là file của cấu trúc phẳng hoặc file của phiên bản cũ (hash không
+// có phần mở rộng). Chỉ tên và vị trí file được thay đổi: nội dung file định dạng cũ
+// được chuyển sang định dạng hiện tại khi entry được đọc hoặc ghi lại lần đầu, lúc các
+// kiểu giá trị của ứng dụng đã được đăng ký với gob. File bị bỏ nếu file ở vị trí mới
+// đã tồn tại.
+func (d *fileDriver) migrateLayout() {
+	// Thứ tự đọc thư mục không được đảm bảo khi file bị đổi tên trong lúc đọc, nên
+	// lặp lại cho đến khi không còn file nào cần chuyển
+	for d.migrateRootFiles() > 0 {
+	}
+}
+
+// migrateRootFiles thực hiện một lượt của migrateLayout.
+//
+// Returns:
+//   - int: Số file đã được chuyển hoặc bỏ
+func (d *fileDriver) migrateRootFiles() int {
+	root, err := os.Open(d.directory)
+	if err != nil {
+		return 0
+	}
+	defer root.Close()
+
+	moved := 0
+	for {
+		entries, err := root.ReadDir(fileWalkBatch)
+		for _, entry := range entries {
+			hash, ok := d.rootFileHash(entry)
+			if !ok {
+				continue
+			}
+			filename := filepath.Join(d.directory, entry.Name())
+			target := d.hashFilename(hash)
+			if target == filename {
+				continue
+			}
+			if _, err := os.Stat(target); err == nil {
+				if os.Remove(filename) == nil {
+					moved++
+				}
+				continue
+			}
+			if os.MkdirAll(filepath.Dir(target), 0755) == nil && os.Rename(filename, target) == nil {
+				moved++
+			}
+		}
+		if err != nil {
+			return moved
+		}
+	}
+}
+
+// rootFileHash trả về hash của một file cache nằm ở thư mục gốc.
+//
+// Returns:
+//   - string: Hash của key
+//   - bool: true nếu mục là file cache của cấu trúc phẳng hoặc của phiên bản cũ
+func (d *fileDriver) rootFileHash(entry os.DirEntry) (string, bool) {
+	name := entry.Name()
+	if entry.IsDir() || isLockFile(name) || isTempFile(name) {
+		return "", false
+	}
+	if isHashName(name) {
+		return name, true
+	}
+	hash, ok := strings.CutSuffix(name, d.extension)
+	return hash, ok && isHashName(hash)
+}
@@ -0,0 +1,198 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// lockFileSuffix là phần mở rộng của các file khóa trong thư mục cache.
+//
+// File khóa nằm cạnh các file cache nhưng không phải entry của cache: Flush và Stats
+// bỏ qua chúng.
+const lockFileSuffix = ".lock"
+
+// isLockFile kiểm tra một tên file trong thư mục cache có phải file khóa hay không.
+func isLockFile(name string) bool {
+	return strings.HasSuffix(name, lockFileSuffix)
+}
+
+// lockFilename chuyển đổi tên khóa thành đường dẫn file khóa.
+//
+// Params:
+//   - name: Tên khóa
+//
+// Returns:
+//   - string: Đường dẫn đầy đủ đến file khóa
+//   - error: Lỗi nếu tên khóa không hợp lệ
+func (d *fileDriver) lockFilename(name string) (string, error) {
+	hash, err := keyHash(name)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d.directory, hash+lockFileSuffix), nil
+}
+
+// TryLock thử giành khóa bằng cách tạo file khóa với O_EXCL.
+//
+// Việc tạo file với O_EXCL là nguyên tử trên hệ thống file, nên chỉ một process giành
+// được khóa còn trống. File khóa chứa thời điểm hết hạn và owner; khóa đã hết hạn được
+// ghi đè bởi process giành lại. Thao tác được thực hiện dưới lockKey của file khóa, nên
+// việc giành lại khóa đã hết hạn, RefreshLock và Unlock được tuần tự hóa cả giữa các
+// process dùng chung thư mục. File khóa chưa ghi xong hoặc bị hỏng được coi là đang
+// được giữ cho đến khi cũ hơn ttl.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - name: Tên khóa
+//   - owner: Token định danh chủ sở hữu
+//   - ttl: Thời gian giữ khóa tối đa (<= 0 để khóa không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giành được khóa
+//   - error: Lỗi nếu tên khóa không hợp lệ hoặc không thể ghi file khóa
+func (d *fileDriver) TryLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	filename, err := d.lockFilename(name)
+	if err != nil {
+		return false, err
+	}
+
+	unlock := d.lockKey(filename)
+	defer unlock()
+
+	lock := localLock{owner: owner, expiration: lockExpiration(ttl)}
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err == nil {
+		_, err = file.WriteString(formatFileLock(lock))
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(filename)
+			return false, fmt.Errorf("unable to write lock file: %w", err)
+		}
+		return true, nil
+	}
+	if !os.IsExist(err) {
+		return false, fmt.Errorf("unable to create lock file: %w", err)
+	}
+
+	current, ok := readFileLock(filename)
+	if ok && !current.expired(time.Now().UnixNano()) {
+		return false, nil
+	}
+	if !ok && !abandonedLockFile(filename, ttl) {
+		return false, nil
+	}
+
+	if err := os.WriteFile(filename, []byte(formatFileLock(lock)), 0644); err != nil {
+		return false, fmt.Errorf("unable to write lock file: %w", err)
+	}
+	return true, nil
+}
+
+// RefreshLock ghi lại thời điểm hết hạn của file khóa nếu owner vẫn giữ khóa.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - name: Tên khóa
+//   - owner: Token định danh chủ sở hữu
+//   - ttl: Thời gian giữ khóa mới (<= 0 để khóa không hết hạn)
+//
+// Returns:
+//   - bool: true nếu khóa được gia hạn
+//   - error: Lỗi nếu tên khóa không hợp lệ hoặc không thể ghi file khóa
+func (d *fileDriver) RefreshLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	filename, err := d.lockFilename(name)
+	if err != nil {
+		return false, err
+	}
+
+	unlock := d.lockKey(filename)
+	defer unlock()
+
+	current, ok := readFileLock(filename)
+	if !ok || current.owner != owner || current.expired(time.Now().UnixNano()) {
+		return false, nil
+	}
+
+	current.expiration = lockExpiration(ttl)
+	if err := os.WriteFile(filename, []byte(formatFileLock(current)), 0644); err != nil {
+		return false, fmt.Errorf("unable to write lock file: %w", err)
+	}
+	return true, nil
+}
+
+// Unlock xóa file khóa nếu owner đang giữ khóa.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - name: Tên khóa
+//   - owner: Token định danh chủ sở hữu
+//
+// Returns:
+//   - bool: true nếu khóa được giải phóng
+//   - error: Lỗi nếu tên khóa không hợp lệ hoặc không thể xóa file khóa
+func (d *fileDriver) Unlock(ctx context.Context, name, owner string) (bool, error) {
+	filename, err := d.lockFilename(name)
+	if err != nil {
+		return false, err
+	}
+
+	unlock := d.lockKey(filename)
+	defer unlock()
+
+	current, ok := readFileLock(filename)
+	if !ok || current.owner != owner {
+		return false, nil
+	}
+	if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("unable to remove lock file: %w", err)
+	}
+	return !current.expired(time.Now().UnixNano()), nil
+}
+
+// formatFileLock mã hóa khóa thành nội dung file khóa "<expiration> <owner>".
+func formatFileLock(lock localLock) string {
+	return strconv.FormatInt(lock.expiration, 10) + " " + lock.owner
+}
+
+// readFileLock đọc và giải mã nội dung file khóa.
+//
+// Params:
+//   - filename: Đường dẫn file khóa
+//
+// Returns:
+//   - localLock: Khóa đọc được
+//   - bool: true nếu file tồn tại và có nội dung hợp lệ
+func readFileLock(filename string) (localLock, bool) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return localLock{}, false
+	}
+	expiration, owner, found := strings.Cut(string(data), " ")
+	if !found || owner == "" {
+		return localLock{}, false
+	}
+	exp, err := strconv.ParseInt(expiration, 10, 64)
+	if err != nil {
+		return localLock{}, false
+	}
+	return localLock{owner: owner, expiration: exp}, true
+}
+
+// abandonedLockFile kiểm tra một file khóa không đọc được có bị bỏ lại hay không.
+//
+// File được coi là bị bỏ lại khi lần ghi cuối cũ hơn ttl; với ttl <= 0 file không bao
+// giờ được coi là bị bỏ lại.
+func abandonedLockFile(filename string, ttl time.Duration) bool {
+	if ttl <= 0 {
+		return false
+	}
+	info, err := os.Stat(filename)
+	return err == nil && time.Since(info.ModTime()) > ttl
+}
@@ -0,0 +1,263 @@
+package driver
+
+import (
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// fileUsage theo dõi dung lượng đĩa và thứ tự truy cập của các file cache.
+//
+// Index được xây dựng từ thư mục khi driver khởi động, cập nhật sau mỗi lần ghi, đọc
+// và xóa của driver, và được đồng bộ lại với thư mục ở mỗi lượt janitor, nên Stats
+// không cần duyệt thư mục. Thứ tự LRU chỉ được theo dõi khi có giới hạn dung lượng
+// hoặc số file; file có sẵn lúc khởi động được xếp theo thời điểm sửa đổi.
+type fileUsage struct {
+	mu           sync.Mutex
+	sizes        map[string]int64 // Kích thước của từng file cache theo đường dẫn
+	order        evictionPolicy   // Thứ tự loại bỏ theo đường dẫn (nil nếu không giới hạn)
+	bytes        int64            // Tổng kích thước các file cache
+	evictions    int64            // Số file đã bị loại bỏ do vượt giới hạn
+	bytesEvicted int64            // Tổng kích thước các file đã bị loại bỏ
+}
+
+// fileUsageEntry là thông tin một file cache đọc được khi duyệt thư mục.
+type fileUsageEntry struct {
+	filename string    // Đường dẫn file cache
+	size     int64     // Kích thước file
+	modTime  time.Time // Thời điểm sửa đổi, dùng để xếp thứ tự LRU ban đầu
+}
+
+// newFileUsage tạo index dung lượng rỗng.
+//
+// Params:
+//   - limited: true nếu driver có giới hạn dung lượng hoặc số file
+//
+// Returns:
+//   - *fileUsage: Index đã được khởi tạo
+func newFileUsage(limited bool) *fileUsage {
+	u := &fileUsage{sizes: make(map[string]int64)}
+	if limited {
+		u.order = newListPolicy(EvictionLRU, true)
+	}
+	return u
+}
+
+// load thay nội dung index bằng các file đọc được từ thư mục.
+//
+// Params:
+//   - entries: Các file cache trong thư mục
+func (u *fileUsage) load(entries []fileUsageEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.resetLocked()
+	for _, entry := range entries {
+		u.setLocked(entry.filename, entry.size)
+	}
+}
+
+// sync đồng bộ index với các file đọc được từ thư mục, giữ thứ tự truy cập của các file
+// đã biết.
+//
+// File do process khác ghi được thêm vào như vừa được truy cập; file không còn trong
+// thư mục bị bỏ khỏi index. File được ghi trong lúc duyệt có thể bị bỏ sót đến lượt
+// đồng bộ sau.
+//
+// Params:
+//   - entries: Các file cache trong thư mục
+func (u *fileUsage) sync(entries []fileUsageEntry) {
+	seen := make(map[string]struct{}, len(entries))
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for _, entry := range entries {
+		seen[entry.filename] = struct{}{}
+		if size, ok := u.sizes[entry.filename]; !ok || size != entry.size {
+			u.setLocked(entry.filename, entry.size)
+		}
+	}
+	for filename := range u.sizes {
+		if _, ok := seen[filename]; !ok {
+			u.removeLocked(filename)
+		}
+	}
+}
+
+// set ghi nhận file vừa được ghi với kích thước size.
+func (u *fileUsage) set(filename string, size int64) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.setLocked(filename, size)
+}
+
+// setLocked ghi nhận kích thước của file và đánh dấu file vừa được truy cập.
+func (u *fileUsage) setLocked(filename string, size int64) {
+	if old, ok := u.sizes[filename]; ok {
+		u.bytes -= old
+		if u.order != nil {
+			u.order.access(filename)
+		}
+	} else if u.order != nil {
+		u.order.add(filename)
+	}
+	u.sizes[filename] = size
+	u.bytes += size
+}
+
+// access đánh dấu file vừa được đọc.
+func (u *fileUsage) access(filename string) {
+	if u.order == nil {
+		return
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if _, ok := u.sizes[filename]; ok {
+		u.order.access(filename)
+	}
+}
+
+// has kiểm tra file có trong index hay không.
+func (u *fileUsage) has(filename string) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	_, ok := u.sizes[filename]
+	return ok
+}
+
+// remove bỏ file khỏi index.
+func (u *fileUsage) remove(filename string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.removeLocked(filename)
+}
+
+// removeLocked bỏ file khỏi index khi đang giữ khóa.
+func (u *fileUsage) removeLocked(filename string) {
+	size, ok := u.sizes[filename]
+	if !ok {
+		return
+	}
+	delete(u.sizes, filename)
+	u.bytes -= size
+	if u.order != nil {
+		u.order.remove(filename)
+	}
+}
+
+// reset xóa toàn bộ index.
+func (u *fileUsage) reset() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.resetLocked()
+}
+
+// resetLocked xóa toàn bộ index khi đang giữ khóa.
+func (u *fileUsage) resetLocked() {
+	u.sizes = make(map[string]int64)
+	u.bytes = 0
+	if u.order != nil {
+		u.order.reset()
+	}
+}
+
+// victims chọn và bỏ khỏi index các file cần loại bỏ để tổng dung lượng và số file
+// không vượt quá giới hạn.
+//
+// Params:
+//   - maxBytes: Tổng dung lượng tối đa (0 = unlimited)
+//   - maxFiles: Số file tối đa (0 = unlimited)
+//   - keep: File không được chọn (file vừa được ghi)
+//
+// Returns:
+//   - []string: Đường dẫn các file cần xóa
+func (u *fileUsage) victims(maxBytes int64, maxFiles int, keep string) []string {
+	if u.order == nil {
+		return nil
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	var victims []string
+	for (maxBytes > 0 && u.bytes > maxBytes) || (maxFiles > 0 && len(u.sizes) > maxFiles) {
+		filename, ok := u.order.victim()
+		if !ok {
+			break
+		}
+		if filename == keep {
+			// File vừa ghi không bị loại bỏ; chuyển nó về cuối thứ tự để xét file tiếp theo
+			u.order.access(filename)
+			if len(u.sizes) == 1 {
+				break
+			}
+			continue
+		}
+		u.evictions++
+		u.bytesEvicted += u.sizes[filename]
+		u.removeLocked(filename)
+		victims = append(victims, filename)
+	}
+	return victims
+}
+
+// stats trả về số file, tổng dung lượng, số file đã bị loại bỏ và tổng dung lượng đã bị
+// loại bỏ.
+func (u *fileUsage) stats() (int, int64, int64, int64) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return len(u.sizes), u.bytes, u.evictions, u.bytesEvicted
+}
+
+// enforceQuota chọn các file ít được truy cập gần đây nhất cần loại bỏ để thư mục cache
+// không vượt quá max_size_bytes và max_files.
+//
+// enforceQuota được gọi khi đang giữ khóa của key vừa ghi, còn mỗi file chỉ được xóa dưới
+// khóa của key của nó; xóa ngay sẽ tự khóa chết khi hai key dùng chung phân vùng. Vì vậy
+// các file bị chọn được đưa vào hàng đợi và bị xóa bởi evictPending khi khóa được nhả.
+//
+// Params:
+//   - keep: File vừa được ghi, không bị loại bỏ
+func (d *fileDriver) enforceQuota(keep string) {
+	victims := d.usage.victims(d.maxSizeBytes, d.maxFiles, keep)
+	if len(victims) == 0 {
+		return
+	}
+	d.evictMu.Lock()
+	d.evictQueue = append(d.evictQueue, victims...)
+	d.evictMu.Unlock()
+}
+
+// evictPending xóa các file trong hàng đợi loại bỏ, mỗi file dưới khóa của key của nó.
+//
+// Phương thức này không được gọi khi đang giữ khóa của một key. File được ghi lại sau khi
+// bị chọn đã được đưa trở lại index dung lượng nên không bị xóa.
+func (d *fileDriver) evictPending() {
+	d.evictMu.Lock()
+	victims := d.evictQueue
+	d.evictQueue = nil
+	d.evictMu.Unlock()
+
+	for _, filename := range victims {
+		_, _, _ = d.removeCacheFile(filename, func(fileCacheHeader) bool {
+			return !d.usage.has(filename)
+		})
+	}
+}
+
+// removeFile xóa một file cache và bỏ file khỏi index dung lượng và index key.
+//
+// Params:
+//   - filename: Đường dẫn file cache
+//
+// Returns:
+//   - error: Lỗi từ os.Remove
+func (d *fileDriver) removeFile(filename string) error {
+	d.usage.remove(filename)
+	d.unindexKey(filename)
+	return os.Remove(filename)
+}
@@ -1,21 +1,30 @@
 package driver_test
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/gob"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.fork.vn/cache/codec"
 	"go.fork.vn/cache/config"
 	"go.fork.vn/cache/driver"
+	"go.fork.vn/cache/encryption"
 	cacheMocks "go.fork.vn/cache/mocks"
 )
 
@@ -993,3 +1002,1269 @@ func TestFileDriverComprehensive(t *testing.T) {
 		assert.Equal(t, callbackValue, result) // Should be cached value, not different value
 	})
 }
+
+// fileTypedProfile là kiểu dữ liệu dùng để test GetInto với gob
+type fileTypedProfile struct {
+	Name string
+	Age  int
+}
+
+func TestFileDriverGetInto(t *testing.T) {
+	ctx := context.Background()
+	gob.Register(fileTypedProfile{})
+
+	tempDir, err := os.MkdirTemp("", "cache_typed_test_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	fileDriver, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300})
+	assert.NoError(t, err)
+	defer fileDriver.Close()
+
+	getter, ok := fileDriver.(driver.TypedGetter)
+	assert.True(t, ok, "file driver should implement TypedGetter")
+
+	t.Run("decodes_registered_type", func(t *testing.T) {
+		err := fileDriver.Set(ctx, "typed:profile", fileTypedProfile{Name: "Binh", Age: 41}, 0)
+		assert.NoError(t, err)
+
+		var result fileTypedProfile
+		found, err := getter.GetInto(ctx, "typed:profile", &result)
+
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, fileTypedProfile{Name: "Binh", Age: 41}, result)
+	})
+
+	t.Run("returns_type_mismatch_error", func(t *testing.T) {
+		err := fileDriver.Set(ctx, "typed:number", 7, 0)
+		assert.NoError(t, err)
+
+		var result fileTypedProfile
+		found, err := getter.GetInto(ctx, "typed:number", &result)
+
+		assert.True(t, found)
+		assert.ErrorIs(t, err, driver.ErrTypeMismatch)
+	})
+}
+
+func TestFileDriverTags(t *testing.T) {
+	ctx := context.Background()
+
+	tempDir, err := os.MkdirTemp("", "cache_tags_test_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	cfg := config.DriverFileConfig{Path: tempDir, DefaultTTL: 300}
+
+	fileDriver, err := driver.NewFileDriver(cfg)
+	assert.NoError(t, err)
+
+	assert.NoError(t, fileDriver.SetTagged(ctx, "user:42", "An", 0, []string{"user:42", "org:7"}))
+	assert.NoError(t, fileDriver.SetTagged(ctx, "user:43", "Binh", 0, []string{"org:7"}))
+	assert.NoError(t, fileDriver.SetTagged(ctx, "user:44", "Chi", 0, []string{"org:8"}))
+	assert.NoError(t, fileDriver.SetTagged(ctx, "retagged", 1, 0, []string{"org:7"}))
+	assert.NoError(t, fileDriver.Set(ctx, "retagged", 2, 0))
+	assert.NoError(t, fileDriver.Close())
+
+	t.Run("index_is_rebuilt_from_disk", func(t *testing.T) {
+		fileDriver, err := driver.NewFileDriver(cfg)
+		assert.NoError(t, err)
+		defer fileDriver.Close()
+
+		assert.NoError(t, fileDriver.FlushTags(ctx, []string{"org:7"}))
+
+		assert.False(t, fileDriver.Has(ctx, "user:42"))
+		assert.False(t, fileDriver.Has(ctx, "user:43"))
+		assert.True(t, fileDriver.Has(ctx, "user:44"))
+		assert.True(t, fileDriver.Has(ctx, "retagged"))
+	})
+
+	t.Run("flush_tags_in_same_process", func(t *testing.T) {
+		fileDriver, err := driver.NewFileDriver(cfg)
+		assert.NoError(t, err)
+		defer fileDriver.Close()
+
+		assert.NoError(t, fileDriver.SetTagged(ctx, "report", "weekly", 0, []string{"reports"}))
+		assert.NoError(t, fileDriver.FlushTags(ctx, []string{"reports", "unknown"}))
+
+		assert.False(t, fileDriver.Has(ctx, "report"))
+		assert.True(t, fileDriver.Has(ctx, "user:44"))
+	})
+}
+
+func TestFileDriverRemovalKeepsRewrittenEntry(t *testing.T) {
+	ctx := context.Background()
+
+	tempDir, err := os.MkdirTemp("", "cache_removal_test_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	fileDriver, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300})
+	assert.NoError(t, err)
+	defer fileDriver.Close()
+
+	// Entry được ghi lại ngay trước lúc xóa không được bị xóa theo header cũ
+	race := func(t *testing.T, key string, stale func(), remove func()) {
+		done := make(chan struct{})
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					remove()
+				}
+			}
+		}()
+
+		for i := 0; i < 300; i++ {
+			stale()
+			assert.NoError(t, fileDriver.Set(ctx, key, "fresh", time.Minute))
+			if !assert.True(t, fileDriver.Has(ctx, key)) {
+				break
+			}
+		}
+		close(done)
+		wg.Wait()
+	}
+
+	t.Run("FlushTags", func(t *testing.T) {
+		race(t, "report", func() {
+			assert.NoError(t, fileDriver.SetTagged(ctx, "report", "stale", 0, []string{"reports"}))
+		}, func() {
+			assert.NoError(t, fileDriver.FlushTags(ctx, []string{"reports"}))
+		})
+	})
+
+	t.Run("ExpiredRead", func(t *testing.T) {
+		race(t, "session", func() {
+			assert.NoError(t, fileDriver.Set(ctx, "session", "stale", time.Nanosecond))
+		}, func() {
+			fileDriver.Get(ctx, "session")
+		})
+	})
+}
+
+func TestFileDriverRememberSingleFlight(t *testing.T) {
+	ctx := context.Background()
+
+	tempDir, err := os.MkdirTemp("", "cache_singleflight_test_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	fileDriver, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300})
+	assert.NoError(t, err)
+	defer fileDriver.Close()
+
+	var calls int32
+	var mu sync.Mutex
+	callback := func() (interface{}, error) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		time.Sleep(50 * time.Millisecond)
+		return "fresh", nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := fileDriver.Remember(ctx, "hot", time.Minute, callback)
+			assert.NoError(t, err)
+			assert.Equal(t, "fresh", value)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls)
+}
+
+func TestFileDriverRememberStale(t *testing.T) {
+	ctx := context.Background()
+
+	tempDir, err := os.MkdirTemp("", "cache_stale_test_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	cfg := config.DriverFileConfig{Path: tempDir, DefaultTTL: 300}
+	fileDriver, err := driver.NewFileDriver(cfg)
+	assert.NoError(t, err)
+
+	value, err := fileDriver.RememberStale(ctx, "report", 20*time.Millisecond, time.Minute, func() (interface{}, error) {
+		return "v1", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", value)
+	assert.NoError(t, fileDriver.Close())
+	time.Sleep(30 * time.Millisecond)
+
+	// Thời điểm hết hạn mềm được lưu trong file nên vẫn có hiệu lực sau khi khởi động lại
+	fileDriver, err = driver.NewFileDriver(cfg)
+	assert.NoError(t, err)
+	defer fileDriver.Close()
+
+	value, err = fileDriver.RememberStale(ctx, "report", 20*time.Millisecond, time.Minute, func() (interface{}, error) {
+		return "v2", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", value)
+	assert.Eventually(t, func() bool {
+		value, _ := fileDriver.Get(ctx, "report")
+		return value == "v2"
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestFileDriverIncrement(t *testing.T) {
+	ctx := context.Background()
+
+	tempDir, err := os.MkdirTemp("", "cache_increment_test_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	fileDriver, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300})
+	assert.NoError(t, err)
+	defer fileDriver.Close()
+
+	value, err := fileDriver.Increment(ctx, "views", 3, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), value)
+
+	value, err = fileDriver.Decrement(ctx, "views", 1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), value)
+
+	score, err := fileDriver.IncrementFloat(ctx, "views", 0.25, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2.25, score)
+
+	assert.NoError(t, fileDriver.Set(ctx, "name", "An", time.Minute))
+	_, err = fileDriver.Increment(ctx, "name", 1, 0)
+	assert.ErrorIs(t, err, driver.ErrNotNumeric)
+
+	// Tag của entry được giữ nguyên sau khi tăng
+	assert.NoError(t, fileDriver.SetTagged(ctx, "tagged", 1, time.Minute, []string{"group"}))
+	_, err = fileDriver.Increment(ctx, "tagged", 1, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, fileDriver.FlushTags(ctx, []string{"group"}))
+	assert.False(t, fileDriver.Has(ctx, "tagged"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				_, _ = fileDriver.Increment(ctx, "hits", 1, 0)
+			}
+		}()
+	}
+	wg.Wait()
+
+	hits, found := fileDriver.Get(ctx, "hits")
+	assert.True(t, found)
+	assert.Equal(t, int64(200), hits)
+}
+
+func TestFileDriverConditionalWrites(t *testing.T) {
+	ctx := context.Background()
+
+	tempDir, err := os.MkdirTemp("", "cache_conditional_test_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	fileDriver, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300})
+	assert.NoError(t, err)
+	defer fileDriver.Close()
+
+	added, err := fileDriver.Add(ctx, "idem", "first", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, added)
+	added, err = fileDriver.Add(ctx, "idem", "second", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, added)
+
+	replaced, err := fileDriver.Replace(ctx, "missing", "value", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, replaced)
+	replaced, err = fileDriver.Replace(ctx, "idem", "replaced", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, replaced)
+
+	old, found, err := fileDriver.GetAndSet(ctx, "idem", "swapped", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "replaced", old)
+
+	value, found, err := fileDriver.GetAndDelete(ctx, "idem")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "swapped", value)
+	assert.False(t, fileDriver.Has(ctx, "idem"))
+
+	_, found, err = fileDriver.GetAndDelete(ctx, "idem")
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	// Chỉ một goroutine giành được key
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	winners := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if added, _ := fileDriver.Add(ctx, "lease", i, time.Minute); added {
+				mu.Lock()
+				winners++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 1, winners)
+}
+
+func TestFileDriverCompareAndSwap(t *testing.T) {
+	ctx := context.Background()
+
+	tempDir, err := os.MkdirTemp("", "cache_cas_test_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	fileDriver, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300})
+	assert.NoError(t, err)
+	defer fileDriver.Close()
+
+	swapped, err := fileDriver.CompareAndSwap(ctx, "config", "0", "v1", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, swapped)
+
+	assert.NoError(t, fileDriver.Set(ctx, "config", "v1", time.Minute))
+	value, token, found := fileDriver.GetWithVersion(ctx, "config")
+	assert.True(t, found)
+	assert.Equal(t, "v1", value)
+
+	swapped, err = fileDriver.CompareAndSwap(ctx, "config", token, "v2", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, swapped)
+
+	swapped, err = fileDriver.CompareAndSwap(ctx, "config", token, "v3", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, swapped)
+	value, _ = fileDriver.Get(ctx, "config")
+	assert.Equal(t, "v2", value)
+
+	// Các thao tác đọc-sửa-ghi khác cũng tạo phiên bản mới
+	_, token, _ = fileDriver.GetWithVersion(ctx, "config")
+	_, err = fileDriver.Replace(ctx, "config", "v2", time.Minute)
+	assert.NoError(t, err)
+	swapped, err = fileDriver.CompareAndSwap(ctx, "config", token, "v3", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, swapped)
+
+	// Phiên bản được lưu trong file nên vẫn dùng được sau khi khởi tạo lại driver
+	_, token, _ = fileDriver.GetWithVersion(ctx, "config")
+	reopened, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300})
+	assert.NoError(t, err)
+	defer reopened.Close()
+	swapped, err = reopened.CompareAndSwap(ctx, "config", token, "v4", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, swapped)
+}
+
+func TestFileDriverTTLAndTouch(t *testing.T) {
+	ctx := context.Background()
+
+	tempDir, err := os.MkdirTemp("", "cache_touch_test_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	fileDriver, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300})
+	assert.NoError(t, err)
+	defer fileDriver.Close()
+
+	_, found := fileDriver.TTL(ctx, "missing")
+	assert.False(t, found)
+	touched, err := fileDriver.Touch(ctx, "missing", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, touched)
+
+	assert.NoError(t, fileDriver.SetTagged(ctx, "session", "data", time.Minute, []string{"sessions"}))
+	ttl, found := fileDriver.TTL(ctx, "session")
+	assert.True(t, found)
+	assert.InDelta(t, time.Minute, ttl, float64(time.Second))
+
+	_, token, _ := fileDriver.GetWithVersion(ctx, "session")
+	touched, err = fileDriver.Touch(ctx, "session", time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, touched)
+	ttl, _ = fileDriver.TTL(ctx, "session")
+	assert.InDelta(t, time.Hour, ttl, float64(time.Second))
+
+	// Touch giữ nguyên giá trị, phiên bản và tag của entry
+	value, touchedToken, _ := fileDriver.GetWithVersion(ctx, "session")
+	assert.Equal(t, "data", value)
+	assert.Equal(t, token, touchedToken)
+
+	touched, err = fileDriver.Touch(ctx, "session", -1)
+	assert.NoError(t, err)
+	assert.True(t, touched)
+	ttl, _ = fileDriver.TTL(ctx, "session")
+	assert.Equal(t, driver.NoExpiration, ttl)
+
+	assert.NoError(t, fileDriver.FlushTags(ctx, []string{"sessions"}))
+	assert.False(t, fileDriver.Has(ctx, "session"))
+}
+
+func TestFileDriverSlidingExpiration(t *testing.T) {
+	ctx := context.Background()
+
+	tempDir, err := os.MkdirTemp("", "cache_sliding_test_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	fileDriver, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300, SlidingTTL: 60})
+	assert.NoError(t, err)
+	defer fileDriver.Close()
+
+	assert.NoError(t, fileDriver.Set(ctx, "session", "data", time.Second))
+	assert.NoError(t, fileDriver.Set(ctx, "long", "data", time.Hour))
+
+	value, found := fileDriver.Get(ctx, "session")
+	assert.True(t, found)
+	assert.Equal(t, "data", value)
+	_, _ = fileDriver.Get(ctx, "long")
+
+	ttl, _ := fileDriver.TTL(ctx, "session")
+	assert.InDelta(t, time.Minute, ttl, float64(time.Second))
+	ttl, _ = fileDriver.TTL(ctx, "long")
+	assert.InDelta(t, time.Hour, ttl, float64(time.Second))
+}
+
+func TestFileDriverSlidingExpirationKeepsPayload(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	fileDriver, err := driver.NewFileDriver(config.DriverFileConfig{
+		Path:       dir,
+		DefaultTTL: 300,
+		SlidingTTL: 60,
+		Encryption: encryptionConfig("k1", "k1"),
+	})
+	require.NoError(t, err)
+	defer fileDriver.Close()
+
+	require.NoError(t, fileDriver.Set(ctx, "session", "data", time.Second))
+	filename := findCacheFile(t, dir, "session")
+	before, err := os.ReadFile(filename)
+	require.NoError(t, err)
+
+	value, found := fileDriver.Get(ctx, "session")
+	assert.True(t, found)
+	assert.Equal(t, "data", value)
+
+	// Payload mã hóa với nonce ngẫu nhiên được chép nguyên vẹn, không được mã hóa lại
+	after, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	payloadSize := 48
+	assert.Equal(t, before[len(before)-payloadSize:], after[len(after)-payloadSize:])
+	assert.NotEqual(t, before, after)
+
+	ttl, _ := fileDriver.TTL(ctx, "session")
+	assert.InDelta(t, time.Minute, ttl, float64(time.Second))
+}
+
+func TestFileDriverLock(t *testing.T) {
+	ctx := context.Background()
+
+	tempDir, err := os.MkdirTemp("", "cache_lock_test_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	fileDriver, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300})
+	assert.NoError(t, err)
+	defer fileDriver.Close()
+	locker := fileDriver.(driver.Locker)
+
+	acquired, err := locker.TryLock(ctx, "job", "owner-a", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	// Một driver khác trên cùng thư mục mô phỏng process thứ hai
+	other, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300})
+	assert.NoError(t, err)
+	defer other.Close()
+	acquired, err = other.(driver.Locker).TryLock(ctx, "job", "owner-b", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+
+	// Flush và Stats bỏ qua file khóa
+	assert.NoError(t, fileDriver.Set(ctx, "entry", "value", time.Minute))
+	assert.Equal(t, 1, fileDriver.Stats(ctx)["count"])
+	assert.NoError(t, fileDriver.Flush(ctx))
+	assert.Equal(t, 0, fileDriver.Stats(ctx)["count"])
+	acquired, _ = locker.TryLock(ctx, "job", "owner-b", time.Minute)
+	assert.False(t, acquired)
+
+	refreshed, err := locker.RefreshLock(ctx, "job", "owner-a", 20*time.Millisecond)
+	assert.NoError(t, err)
+	assert.True(t, refreshed)
+
+	// Khóa hết hạn được giành lại bởi owner khác
+	time.Sleep(40 * time.Millisecond)
+	acquired, err = other.(driver.Locker).TryLock(ctx, "job", "owner-b", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	released, _ := locker.Unlock(ctx, "job", "owner-a")
+	assert.False(t, released)
+	released, err = locker.Unlock(ctx, "job", "owner-b")
+	assert.NoError(t, err)
+	assert.True(t, released)
+
+	_, err = locker.TryLock(ctx, "bad/name", "owner-a", time.Minute)
+	assert.Error(t, err)
+}
+
+func TestFileDriverLockReclaimIsExclusive(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	// Mỗi driver trên cùng thư mục mô phỏng một process
+	lockers := make([]driver.Locker, 8)
+	for i := range lockers {
+		fileDriver, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300})
+		require.NoError(t, err)
+		defer fileDriver.Close()
+		lockers[i] = fileDriver.(driver.Locker)
+	}
+
+	for round := 0; round < 50; round++ {
+		acquired, err := lockers[0].TryLock(ctx, "job", "expired", time.Millisecond)
+		require.NoError(t, err)
+		require.True(t, acquired)
+		time.Sleep(2 * time.Millisecond)
+
+		var winners atomic.Int32
+		var wg sync.WaitGroup
+		for i, locker := range lockers {
+			wg.Add(1)
+			go func(i int, locker driver.Locker) {
+				defer wg.Done()
+				if acquired, _ := locker.TryLock(ctx, "job", fmt.Sprintf("owner-%d", i), time.Minute); acquired {
+					winners.Add(1)
+				}
+			}(i, locker)
+		}
+		wg.Wait()
+		require.Equal(t, int32(1), winners.Load(), "round %d", round)
+
+		for i, locker := range lockers {
+			_, _ = locker.Unlock(ctx, "job", fmt.Sprintf("owner-%d", i))
+		}
+	}
+}
+
+func TestFileDriverKeys(t *testing.T) {
+	ctx := context.Background()
+
+	tempDir, err := os.MkdirTemp("", "cache_keys_test_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	fileDriver, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300})
+	assert.NoError(t, err)
+	defer fileDriver.Close()
+
+	assert.NoError(t, fileDriver.Set(ctx, "user:1", "v", 0))
+	assert.NoError(t, fileDriver.SetTagged(ctx, "user:2", "v", 0, []string{"users"}))
+	_, err = fileDriver.Increment(ctx, "counter:visits", 1, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, fileDriver.Set(ctx, "__internal", "v", 0))
+	assert.NoError(t, fileDriver.Set(ctx, "user:expired", "v", time.Millisecond))
+	_, err = fileDriver.(driver.Locker).TryLock(ctx, "user:lock", "owner", time.Minute)
+	assert.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+
+	keys, err := fileDriver.Keys(ctx, "*")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"user:1", "user:2", "counter:visits"}, keys)
+
+	keys, err = fileDriver.Keys(ctx, "user:*")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"user:1", "user:2"}, keys)
+
+	// Key được giữ lại sau khi gia hạn
+	_, err = fileDriver.Touch(ctx, "user:1", time.Hour)
+	assert.NoError(t, err)
+	keys, _ = fileDriver.Keys(ctx, "user:1")
+	assert.Equal(t, []string{"user:1"}, keys)
+
+	visited := 0
+	err = fileDriver.Scan(ctx, "*", func(key string) bool {
+		visited++
+		return false
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, visited)
+
+	_, err = fileDriver.Keys(ctx, `user:\`)
+	assert.Error(t, err)
+}
+
+func TestFileDriverKeyIndex(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	writer, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300})
+	assert.NoError(t, err)
+	defer writer.Close()
+	assert.NoError(t, writer.Set(ctx, "user:early", "v", 0))
+
+	// Index key của driver mới được xây dựng từ các file đã có
+	reader, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300, CleanupInterval: 1})
+	assert.NoError(t, err)
+	defer reader.Close()
+
+	keys, err := reader.Keys(ctx, "user:*")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user:early"}, keys)
+
+	// Lần ghi và xóa của driver khác được phản ánh sau lượt janitor
+	assert.NoError(t, writer.Set(ctx, "user:late", "v", 0))
+	assert.NoError(t, writer.Delete(ctx, "user:early"))
+	assert.Eventually(t, func() bool {
+		keys, err := reader.Keys(ctx, "user:*")
+		return err == nil && len(keys) == 1 && keys[0] == "user:late"
+	}, 5*time.Second, 100*time.Millisecond)
+
+	deleted, err := reader.DeleteMatching(ctx, "user:*")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.False(t, writer.Has(ctx, "user:late"))
+}
+
+func TestFileDriverDeleteMatching(t *testing.T) {
+	ctx := context.Background()
+
+	tempDir, err := os.MkdirTemp("", "cache_delete_matching_test_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	fileDriver, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300})
+	assert.NoError(t, err)
+	defer fileDriver.Close()
+
+	assert.NoError(t, fileDriver.Set(ctx, "user:42:profile", "v", 0))
+	assert.NoError(t, fileDriver.SetTagged(ctx, "user:42:posts", "v", 0, []string{"posts"}))
+	assert.NoError(t, fileDriver.Set(ctx, "user:43:profile", "v", 0))
+	assert.NoError(t, fileDriver.Set(ctx, "__user:42:state", "v", 0))
+	_, err = fileDriver.(driver.Locker).TryLock(ctx, "user:42:lock", "owner", time.Minute)
+	assert.NoError(t, err)
+
+	deleted, err := fileDriver.DeleteByPrefix(ctx, "user:42:")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	assert.False(t, fileDriver.Has(ctx, "user:42:profile"))
+	assert.False(t, fileDriver.Has(ctx, "user:42:posts"))
+	assert.True(t, fileDriver.Has(ctx, "user:43:profile"))
+	assert.True(t, fileDriver.Has(ctx, "__user:42:state"))
+
+	// File khóa không bị xóa
+	acquired, _ := fileDriver.(driver.Locker).TryLock(ctx, "user:42:lock", "other", time.Minute)
+	assert.False(t, acquired)
+
+	deleted, err = fileDriver.DeleteMatching(ctx, "user:4?:*")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+}
+
+func TestFileDriverFileFormat(t *testing.T) {
+	ctx := context.Background()
+	hashOf := func(key string) string {
+		sum := sha1.Sum([]byte(key))
+		return hex.EncodeToString(sum[:])
+	}
+
+	tempDir, err := os.MkdirTemp("", "cache_file_format_test_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	// Legacy file: FileCache mã hóa bằng gob, tên file không có phần mở rộng
+	legacyFile, err := os.Create(filepath.Join(tempDir, hashOf("legacy:1")))
+	assert.NoError(t, err)
+	assert.NoError(t, gob.NewEncoder(legacyFile).Encode(driver.FileCache{Value: "old value", Tags: []string{"old"}}))
+	assert.NoError(t, legacyFile.Close())
+
+	_, err = driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, Extension: ".lock"})
+	assert.Error(t, err)
+
+	fileDriver, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300, Extension: "cache"})
+	assert.NoError(t, err)
+	defer fileDriver.Close()
+
+	t.Run("Header", func(t *testing.T) {
+		assert.NoError(t, fileDriver.Set(ctx, "user:1", "Alice", time.Minute))
+
+		data, err := os.ReadFile(filepath.Join(tempDir, hashOf("user:1")+".cache"))
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(data), "\x00GFC"))
+		assert.Contains(t, string(data), `"key":"user:1"`)
+		assert.Contains(t, string(data), `"codec":"gob"`)
+		assert.Contains(t, string(data), `"created_at":`)
+
+		value, found := fileDriver.Get(ctx, "user:1")
+		assert.True(t, found)
+		assert.Equal(t, "Alice", value)
+	})
+
+	t.Run("Checksum", func(t *testing.T) {
+		assert.NoError(t, fileDriver.Set(ctx, "user:2", "Bob", time.Minute))
+		filename := filepath.Join(tempDir, hashOf("user:2")+".cache")
+		data, err := os.ReadFile(filename)
+		assert.NoError(t, err)
+		data[len(data)-1] ^= 0xff
+		assert.NoError(t, os.WriteFile(filename, data, 0644))
+
+		_, found := fileDriver.Get(ctx, "user:2")
+		assert.False(t, found)
+		assert.NoFileExists(t, filename)
+	})
+
+	t.Run("LegacyMigration", func(t *testing.T) {
+		filename := filepath.Join(tempDir, hashOf("legacy:1")+".cache")
+		assert.NoFileExists(t, filepath.Join(tempDir, hashOf("legacy:1")))
+		assert.FileExists(t, filename)
+
+		// File cũ không lưu key nên chưa được liệt kê
+		keys, err := fileDriver.Keys(ctx, "legacy:*")
+		assert.NoError(t, err)
+		assert.Empty(t, keys)
+
+		value, found := fileDriver.Get(ctx, "legacy:1")
+		assert.True(t, found)
+		assert.Equal(t, "old value", value)
+
+		// File được ghi lại theo định dạng mới cùng key khi được đọc
+		data, err := os.ReadFile(filename)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(data), "\x00GFC"))
+		keys, err = fileDriver.Keys(ctx, "legacy:*")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"legacy:1"}, keys)
+
+		assert.NoError(t, fileDriver.FlushTags(ctx, []string{"old"}))
+		assert.False(t, fileDriver.Has(ctx, "legacy:1"))
+	})
+}
+
+func TestFileDriverAtomicWrites(t *testing.T) {
+	ctx := context.Background()
+
+	tempDir, err := os.MkdirTemp("", "cache_atomic_write_test_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	cfg := config.DriverFileConfig{Path: tempDir, DefaultTTL: 300, Fsync: true}
+	first, err := driver.NewFileDriver(cfg)
+	assert.NoError(t, err)
+	defer first.Close()
+	second, err := driver.NewFileDriver(cfg)
+	assert.NoError(t, err)
+	defer second.Close()
+
+	t.Run("ReadersNeverSeePartialFiles", func(t *testing.T) {
+		large := strings.Repeat("x", 256*1024)
+		assert.NoError(t, first.Set(ctx, "report", large, 0))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 20; j++ {
+					assert.NoError(t, first.Set(ctx, "report", large, 0))
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 20; j++ {
+					value, found := second.Get(ctx, "report")
+					assert.True(t, found)
+					assert.Equal(t, large, value)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("InstancesAreSerialized", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("advisory file locks are not used on windows")
+		}
+
+		var wg sync.WaitGroup
+		for _, d := range []driver.FileDriver{first, second} {
+			wg.Add(1)
+			go func(d driver.FileDriver) {
+				defer wg.Done()
+				for i := 0; i < 50; i++ {
+					_, err := d.Increment(ctx, "counter", 1, 0)
+					assert.NoError(t, err)
+				}
+			}(d)
+		}
+		wg.Wait()
+
+		value, found := first.Get(ctx, "counter")
+		assert.True(t, found)
+		assert.Equal(t, int64(100), value)
+	})
+
+	t.Run("NoTempFilesLeft", func(t *testing.T) {
+		names, err := filepath.Glob(filepath.Join(tempDir, ".tmp-*"))
+		assert.NoError(t, err)
+		assert.Empty(t, names)
+
+		keys, err := first.Keys(ctx, "*")
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"report", "counter"}, keys)
+	})
+
+	t.Run("JanitorRemovesStaleTempFiles", func(t *testing.T) {
+		stale := filepath.Join(tempDir, ".tmp-stale")
+		fresh := filepath.Join(tempDir, ".tmp-fresh")
+		assert.NoError(t, os.WriteFile(stale, []byte("partial"), 0644))
+		assert.NoError(t, os.WriteFile(fresh, []byte("partial"), 0644))
+		old := time.Now().Add(-time.Hour)
+		assert.NoError(t, os.Chtimes(stale, old, old))
+
+		janitor, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300, CleanupInterval: 1})
+		assert.NoError(t, err)
+		defer janitor.Close()
+
+		assert.Eventually(t, func() bool {
+			_, err := os.Stat(stale)
+			return os.IsNotExist(err)
+		}, 3*time.Second, 50*time.Millisecond)
+		assert.FileExists(t, fresh)
+		assert.Equal(t, 2, janitor.Stats(ctx)["count"])
+	})
+}
+
+func TestFileDriverNestedLayout(t *testing.T) {
+	ctx := context.Background()
+	hashOf := func(key string) string {
+		sum := sha1.Sum([]byte(key))
+		return hex.EncodeToString(sum[:])
+	}
+
+	tempDir, err := os.MkdirTemp("", "cache_nested_layout_test_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	_, err = driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, Depth: 5})
+	assert.Error(t, err)
+	_, err = driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, Depth: 2, FanOut: 100})
+	assert.Error(t, err)
+
+	// Thư mục phẳng của cấu hình trước
+	flat, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300, Extension: ".cache"})
+	assert.NoError(t, err)
+	assert.NoError(t, flat.Set(ctx, "user:1", "Alice", 0))
+	assert.NoError(t, flat.SetTagged(ctx, "user:2", "Bob", 0, []string{"users"}))
+	assert.NoError(t, flat.Close())
+
+	nested, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300, Extension: ".cache", Depth: 2, FanOut: 256})
+	assert.NoError(t, err)
+	defer nested.Close()
+
+	hash := hashOf("user:1")
+	assert.NoFileExists(t, filepath.Join(tempDir, hash+".cache"))
+	assert.FileExists(t, filepath.Join(tempDir, hash[0:2], hash[2:4], hash+".cache"))
+
+	value, found := nested.Get(ctx, "user:1")
+	assert.True(t, found)
+	assert.Equal(t, "Alice", value)
+
+	assert.NoError(t, nested.Set(ctx, "user:3", "Carol", 0))
+	hash = hashOf("user:3")
+	assert.FileExists(t, filepath.Join(tempDir, hash[0:2], hash[2:4], hash+".cache"))
+
+	keys, err := nested.Keys(ctx, "user:*")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"user:1", "user:2", "user:3"}, keys)
+	assert.Equal(t, 3, nested.Stats(ctx)["count"])
+
+	// Index tag được khôi phục từ thư mục lồng nhau
+	assert.NoError(t, nested.FlushTags(ctx, []string{"users"}))
+	assert.False(t, nested.Has(ctx, "user:2"))
+
+	deleted, err := nested.DeleteByPrefix(ctx, "user:3")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	assert.NoError(t, nested.Flush(ctx))
+	assert.Equal(t, 0, nested.Stats(ctx)["count"])
+	assert.False(t, nested.Has(ctx, "user:1"))
+}
+
+func TestFileDriverQuota(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("MaxFiles_EvictsLeastRecentlyUsed", func(t *testing.T) {
+		tempDir := t.TempDir()
+		d, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300, MaxFiles: 3})
+		assert.NoError(t, err)
+		defer d.Close()
+
+		assert.NoError(t, d.Set(ctx, "a", 1, 0))
+		assert.NoError(t, d.Set(ctx, "b", 2, 0))
+		assert.NoError(t, d.Set(ctx, "c", 3, 0))
+
+		// Đọc "a" để "b" trở thành entry ít được truy cập gần đây nhất
+		_, found := d.Get(ctx, "a")
+		assert.True(t, found)
+		assert.NoError(t, d.Set(ctx, "d", 4, 0))
+
+		assert.False(t, d.Has(ctx, "b"))
+		assert.True(t, d.Has(ctx, "a"))
+		assert.True(t, d.Has(ctx, "c"))
+		assert.True(t, d.Has(ctx, "d"))
+
+		stats := d.Stats(ctx)
+		assert.Equal(t, 3, stats["count"])
+		assert.Equal(t, int64(1), stats["evictions"])
+		assert.Greater(t, stats["bytes_evicted"].(int64), int64(0))
+		assert.Equal(t, 3, stats["max_files"])
+	})
+
+	t.Run("MaxSizeBytes_KeepsUsageWithinLimit", func(t *testing.T) {
+		tempDir := t.TempDir()
+		d, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300, MaxSizeBytes: 4096})
+		assert.NoError(t, err)
+		defer d.Close()
+
+		value := strings.Repeat("x", 500)
+		for i := 0; i < 20; i++ {
+			assert.NoError(t, d.Set(ctx, fmt.Sprintf("key:%d", i), value, 0))
+		}
+
+		stats := d.Stats(ctx)
+		assert.LessOrEqual(t, stats["size"].(int64), int64(4096))
+		assert.Greater(t, stats["evictions"].(int64), int64(0))
+		assert.True(t, d.Has(ctx, "key:19"), "entry vừa ghi không bị loại bỏ")
+		assert.False(t, d.Has(ctx, "key:0"))
+
+		// Kích thước trong Stats khớp với dung lượng thực tế trên đĩa
+		var size int64
+		count := 0
+		err = filepath.WalkDir(tempDir, func(path string, entry os.DirEntry, err error) error {
+			if err != nil || entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				return err
+			}
+			info, err := entry.Info()
+			if err == nil {
+				size += info.Size()
+				count++
+			}
+			return err
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, size, stats["size"])
+		assert.Equal(t, count, stats["count"])
+
+		// Entry lớn hơn toàn bộ giới hạn bị từ chối
+		err = d.Set(ctx, "huge", strings.Repeat("x", 8192), 0)
+		assert.ErrorIs(t, err, driver.ErrValueTooLarge)
+		assert.False(t, d.Has(ctx, "huge"))
+	})
+
+	t.Run("StatsTracksDeletes", func(t *testing.T) {
+		tempDir := t.TempDir()
+		d, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300})
+		assert.NoError(t, err)
+		defer d.Close()
+
+		assert.NoError(t, d.Set(ctx, "a", 1, 0))
+		assert.NoError(t, d.Set(ctx, "b", 2, 0))
+		assert.NoError(t, d.Set(ctx, "a", 3, 0))
+		assert.Equal(t, 2, d.Stats(ctx)["count"])
+
+		assert.NoError(t, d.Delete(ctx, "a"))
+		stats := d.Stats(ctx)
+		assert.Equal(t, 1, stats["count"])
+		assert.Equal(t, int64(0), stats["evictions"])
+
+		assert.NoError(t, d.Flush(ctx))
+		stats = d.Stats(ctx)
+		assert.Equal(t, 0, stats["count"])
+		assert.Equal(t, int64(0), stats["size"])
+	})
+
+	t.Run("ExistingFilesAreEvictedOnStartup", func(t *testing.T) {
+		tempDir := t.TempDir()
+		unlimited, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300})
+		assert.NoError(t, err)
+		for i := 0; i < 5; i++ {
+			assert.NoError(t, unlimited.Set(ctx, fmt.Sprintf("key:%d", i), i, 0))
+		}
+		assert.NoError(t, unlimited.Close())
+
+		limited, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300, MaxFiles: 2})
+		assert.NoError(t, err)
+		defer limited.Close()
+
+		stats := limited.Stats(ctx)
+		assert.Equal(t, 2, stats["count"])
+		assert.Equal(t, int64(3), stats["evictions"])
+		keys, err := limited.Keys(ctx, "*")
+		assert.NoError(t, err)
+		assert.Len(t, keys, 2)
+	})
+
+	t.Run("NegativeLimitsAreRejected", func(t *testing.T) {
+		_, err := driver.NewFileDriver(config.DriverFileConfig{Path: t.TempDir(), MaxFiles: -1})
+		assert.Error(t, err)
+	})
+}
+
+func TestFileDriverCodec(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("SwitchingCodecKeepsOldEntriesReadable", func(t *testing.T) {
+		tempDir := t.TempDir()
+		for _, name := range []string{codec.JSON, codec.MsgPack} {
+			d, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300, Codec: name})
+			assert.NoError(t, err)
+			assert.NoError(t, d.Set(ctx, "key:"+name, "value:"+name, 0))
+			d.Close()
+		}
+
+		// Driver dùng codec mặc định vẫn đọc được các entry do codec khác ghi
+		d, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300})
+		assert.NoError(t, err)
+		defer d.Close()
+
+		for _, name := range []string{codec.JSON, codec.MsgPack} {
+			value, found := d.Get(ctx, "key:"+name)
+			assert.True(t, found, name)
+			assert.Equal(t, "value:"+name, value)
+		}
+	})
+
+	t.Run("UnknownCodecIsRejected", func(t *testing.T) {
+		_, err := driver.NewFileDriver(config.DriverFileConfig{Path: t.TempDir(), Codec: "unknown"})
+		assert.ErrorIs(t, err, codec.ErrUnknownCodec)
+	})
+
+	t.Run("CustomCodecReceivesRawValue", func(t *testing.T) {
+		codec.Register(fileTextCodec{})
+
+		tempDir := t.TempDir()
+		d, err := driver.NewFileDriver(config.DriverFileConfig{Path: tempDir, DefaultTTL: 300, Codec: "file-text"})
+		assert.NoError(t, err)
+		defer d.Close()
+
+		assert.NoError(t, d.Set(ctx, "greeting", "hello", 0))
+		value, found := d.Get(ctx, "greeting")
+		assert.True(t, found)
+		assert.Equal(t, "hello", value)
+
+		// Payload ở cuối file là đúng dữ liệu do codec ghi, không bị bọc
+		data, err := os.ReadFile(findCacheFile(t, tempDir, "greeting"))
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasSuffix(data, []byte("text:hello")))
+	})
+}
+
+// fileTextCodec là codec tùy chỉnh chỉ mã hóa được chuỗi, dùng để kiểm tra file driver
+// truyền thẳng giá trị cho codec.
+type fileTextCodec struct{}
+
+func (fileTextCodec) Name() string { return "file-text" }
+
+func (fileTextCodec) Marshal(v interface{}) ([]byte, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("file-text codec cannot encode %T", v)
+	}
+	return []byte("text:" + s), nil
+}
+
+func (fileTextCodec) Unmarshal(data []byte, v interface{}) error {
+	s := strings.TrimPrefix(string(data), "text:")
+	switch target := v.(type) {
+	case *string:
+		*target = s
+	case *interface{}:
+		*target = s
+	default:
+		return fmt.Errorf("file-text codec cannot decode into %T", v)
+	}
+	return nil
+}
+
+// encryptionConfig tạo cấu hình mã hóa với các khóa AES-256 có ID cho trước.
+func encryptionConfig(active string, ids ...string) *config.EncryptionConfig {
+	cfg := &config.EncryptionConfig{Enabled: true, ActiveKey: active}
+	for _, id := range ids {
+		cfg.Keys = append(cfg.Keys, config.EncryptionKeyConfig{
+			ID:  id,
+			Key: base64.StdEncoding.EncodeToString([]byte(strings.Repeat(id[:1], 32))),
+		})
+	}
+	return cfg
+}
+
+// findCacheFile trả về đường dẫn file cache có key cho trước trong header.
+func findCacheFile(t *testing.T, dir, key string) string {
+	var found string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err == nil && strings.Contains(string(data), `"key":"`+key+`"`) {
+			found = path
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, found)
+	return found
+}
+
+func TestFileDriverEncryption(t *testing.T) {
+	ctx := context.Background()
+
+	newDriver := func(t *testing.T, dir string, enc *config.EncryptionConfig) driver.FileDriver {
+		d, err := driver.NewFileDriver(config.DriverFileConfig{Path: dir, DefaultTTL: 300, Codec: codec.JSON, Encryption: enc})
+		assert.NoError(t, err)
+		t.Cleanup(func() { d.Close() })
+		return d
+	}
+
+	t.Run("ValueIsNotStoredInPlaintext", func(t *testing.T) {
+		dir := t.TempDir()
+		d := newDriver(t, dir, encryptionConfig("k1", "k1"))
+		assert.NoError(t, d.Set(ctx, "user:1", "secret-value", 0))
+
+		data, err := os.ReadFile(findCacheFile(t, dir, "user:1"))
+		assert.NoError(t, err)
+		assert.NotContains(t, string(data), "secret-value")
+		assert.Contains(t, string(data), `"encryption_key":"k1"`)
+
+		value, found := d.Get(ctx, "user:1")
+		assert.True(t, found)
+		assert.Equal(t, "secret-value", value)
+		assert.Equal(t, "k1", d.Stats(ctx)["encryption_key"])
+	})
+
+	t.Run("RotationAndReencrypt", func(t *testing.T) {
+		dir := t.TempDir()
+		d := newDriver(t, dir, encryptionConfig("k1", "k1"))
+		assert.NoError(t, d.Set(ctx, "a", "value-a", 0))
+		assert.NoError(t, d.Set(ctx, "b", "value-b", 0))
+		d.Close()
+
+		// Khóa mới hoạt động, khóa cũ chỉ dùng để giải mã
+		d = newDriver(t, dir, encryptionConfig("k2", "k1", "k2"))
+		value, found := d.Get(ctx, "a")
+		assert.True(t, found)
+		assert.Equal(t, "value-a", value)
+
+		assert.NoError(t, d.Set(ctx, "c", "value-c", 0))
+		count, err := d.(driver.Reencrypter).Reencrypt(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		count, err = d.(driver.Reencrypter).Reencrypt(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+		d.Close()
+
+		// Gỡ khóa cũ: mọi entry vẫn đọc được
+		d = newDriver(t, dir, encryptionConfig("k2", "k2"))
+		for _, key := range []string{"a", "b", "c"} {
+			value, found := d.Get(ctx, key)
+			assert.True(t, found, key)
+			assert.Equal(t, "value-"+key, value)
+		}
+	})
+
+	t.Run("TamperedEntryIsAnError", func(t *testing.T) {
+		dir := t.TempDir()
+		d := newDriver(t, dir, encryptionConfig("k1", "k1"))
+		assert.NoError(t, d.Set(ctx, "user:1", "secret-value", 0))
+
+		filename := findCacheFile(t, dir, "user:1")
+		data, err := os.ReadFile(filename)
+		assert.NoError(t, err)
+		data[len(data)-1] ^= 0x01
+		assert.NoError(t, os.WriteFile(filename, data, 0644))
+
+		_, found := d.Get(ctx, "user:1")
+		assert.False(t, found)
+
+		var target string
+		_, err = d.(driver.TypedGetter).GetInto(ctx, "user:1", &target)
+		assert.ErrorIs(t, err, encryption.ErrAuthentication)
+
+		called := false
+		_, err = d.Remember(ctx, "user:1", time.Minute, func() (interface{}, error) {
+			called = true
+			return "fresh", nil
+		})
+		assert.ErrorIs(t, err, encryption.ErrAuthentication)
+		assert.False(t, called)
+
+		_, err = d.RememberStale(ctx, "user:1", time.Minute, time.Minute, func() (interface{}, error) {
+			called = true
+			return "fresh", nil
+		})
+		assert.ErrorIs(t, err, encryption.ErrAuthentication)
+		assert.False(t, called)
+
+		// File không bị xóa và lỗi không được tính là miss
+		assert.FileExists(t, filename)
+		stats := d.Stats(ctx)
+		assert.Equal(t, int64(4), stats["decryption_failures"])
+		assert.Equal(t, int64(0), stats["misses"])
+	})
+
+	t.Run("UnknownKeyIsAnError", func(t *testing.T) {
+		dir := t.TempDir()
+		d := newDriver(t, dir, encryptionConfig("k1", "k1"))
+		assert.NoError(t, d.Set(ctx, "user:1", "secret-value", 0))
+		d.Close()
+
+		for _, enc := range []*config.EncryptionConfig{nil, encryptionConfig("k2", "k2")} {
+			d = newDriver(t, dir, enc)
+			var target string
+			_, err := d.(driver.TypedGetter).GetInto(ctx, "user:1", &target)
+			assert.ErrorIs(t, err, encryption.ErrUnknownKey)
+			d.Close()
+		}
+	})
+
+	t.Run("InvalidConfiguration", func(t *testing.T) {
+		enc := encryptionConfig("k1", "k1")
+		enc.Keys[0].Key = "not base64!"
+		_, err := driver.NewFileDriver(config.DriverFileConfig{Path: t.TempDir(), Encryption: enc})
+		assert.ErrorIs(t, err, encryption.ErrInvalidKey)
+
+		_, err = driver.NewFileDriver(config.DriverFileConfig{Path: t.TempDir(), Encryption: encryptionConfig("missing", "k1")})
+		assert.ErrorIs(t, err, encryption.ErrInvalidKey)
+
+		d := newDriver(t, t.TempDir(), nil)
+		_, err = d.(driver.Reencrypter).Reencrypt(ctx)
+		assert.ErrorIs(t, err, driver.ErrEncryptionDisabled)
+	})
+}
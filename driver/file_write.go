@@ -0,0 +1,237 @@
+package driver
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// fileTempPrefix là tiền tố tên của file tạm được ghi trước khi đổi tên thành file cache
+	fileTempPrefix = ".tmp-"
+
+	// fileStaleTempAge là tuổi tối thiểu để janitor coi một file tạm là bị bỏ lại sau sự cố
+	fileStaleTempAge = 10 * time.Minute
+)
+
+// isTempFile kiểm tra một tên file trong thư mục cache có phải file tạm hay không.
+func isTempFile(name string) bool {
+	return strings.HasPrefix(name, fileTempPrefix)
+}
+
+// stripeLockFilename trả về đường dẫn file khóa tư vấn của một phân vùng khóa.
+//
+// File bắt đầu bằng "." và kết thúc bằng lockFileSuffix nên được Flush, Stats và Scan
+// bỏ qua như file khóa của TryLock.
+func (d *fileDriver) stripeLockFilename(stripe int) string {
+	return filepath.Join(d.directory, fmt.Sprintf(".stripe-%02d%s", stripe, lockFileSuffix))
+}
+
+// lockKey khóa key trong process và giữa các process dùng chung thư mục cache.
+//
+// Key được khóa theo phân vùng như keyLocks: mutex của phân vùng tuần tự hóa các
+// goroutine, sau đó khóa tư vấn (flock) trên file khóa của phân vùng tuần tự hóa các
+// process. Nếu không mở hoặc không khóa được file, key vẫn được khóa trong process.
+// Sau khi mở khóa, các file bị chọn loại bỏ trong lúc giữ khóa được xóa (xem
+// evictPending).
+//
+// Params:
+//   - key: Cache key cần khóa
+//
+// Returns:
+//   - func(): Hàm mở khóa
+func (d *fileDriver) lockKey(key string) func() {
+	unlock := d.lockStripe(key)
+	return func() {
+		unlock()
+		d.evictPending()
+	}
+}
+
+// lockStripe khóa phân vùng của key bằng mutex và flock của phân vùng.
+//
+// Params:
+//   - key: Cache key cần khóa
+//
+// Returns:
+//   - func(): Hàm mở khóa
+func (d *fileDriver) lockStripe(key string) func() {
+	unlock := d.locks.lock(key)
+
+	// File của phân vùng chỉ được truy cập khi giữ mutex của phân vùng
+	stripe := shardIndex(key, keyLockStripes)
+	file := d.flocks[stripe]
+	if file == nil {
+		var err error
+		file, err = os.OpenFile(d.stripeLockFilename(stripe), os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			return unlock
+		}
+		d.flocks[stripe] = file
+	}
+	if err := flockFile(file); err != nil {
+		return unlock
+	}
+
+	return func() {
+		_ = funlockFile(file)
+		unlock()
+	}
+}
+
+// removeEntry xóa file cache của key dưới khóa của key nếu entry vẫn thỏa cond.
+//
+// Header được đọc lại dưới khóa, nên entry được goroutine hoặc process khác ghi lại
+// giữa lần kiểm tra của caller và lúc xóa không bị xóa nhầm.
+//
+// Params:
+//   - key: Cache key của entry
+//   - filename: Đường dẫn file cache
+//   - cond: Điều kiện trên header để file bị xóa
+//
+// Returns:
+//   - fileCacheHeader: Header đọc được dưới khóa
+//   - bool: true nếu file đã bị xóa
+//   - error: Lỗi nếu không xóa được file
+func (d *fileDriver) removeEntry(key, filename string, cond func(header fileCacheHeader) bool) (fileCacheHeader, bool, error) {
+	unlock := d.lockKey(key)
+	defer unlock()
+
+	header, err := readFileCacheHeader(filename)
+	if err != nil || (header.Key != "" && header.Key != key) || !cond(header) {
+		return header, false, nil
+	}
+	if err := d.removeFile(filename); err != nil {
+		if os.IsNotExist(err) {
+			return header, false, nil
+		}
+		return header, false, err
+	}
+	return header, true, nil
+}
+
+// discard xóa file cache hết hạn hoặc bị hỏng mà loadInto phát hiện.
+//
+// Params:
+//   - key: Cache key của entry nếu caller chưa giữ khóa của key, rỗng nếu đã giữ
+//   - filename: Đường dẫn file cache
+//   - cond: Điều kiện trên header để file bị xóa khi phải lấy khóa của key
+func (d *fileDriver) discard(key, filename string, cond func(header fileCacheHeader) bool) {
+	if key == "" {
+		_ = d.removeFile(filename)
+		return
+	}
+	_, _, _ = d.removeEntry(key, filename, cond)
+}
+
+// removeCacheFile xóa một file cache như removeEntry, với key đọc từ header của file.
+//
+// File định dạng cũ chưa có key trong header không thể được khóa theo key nên bị xóa
+// mà không giữ khóa.
+//
+// Params:
+//   - filename: Đường dẫn file cache
+//   - cond: Điều kiện trên header để file bị xóa
+//
+// Returns:
+//   - fileCacheHeader: Header của entry
+//   - bool: true nếu file đã bị xóa
+//   - error: Lỗi nếu không xóa được file
+func (d *fileDriver) removeCacheFile(filename string, cond func(header fileCacheHeader) bool) (fileCacheHeader, bool, error) {
+	header, err := readFileCacheHeader(filename)
+	if err != nil || !cond(header) {
+		return header, false, nil
+	}
+	if header.Key != "" {
+		return d.removeEntry(header.Key, filename, cond)
+	}
+	if err := d.removeFile(filename); err != nil {
+		if os.IsNotExist(err) {
+			return header, false, nil
+		}
+		return header, false, err
+	}
+	return header, true, nil
+}
+
+// closeStripeLocks đóng các file khóa tư vấn đã mở.
+func (d *fileDriver) closeStripeLocks() {
+	for stripe := range d.flocks {
+		mu := &d.locks.stripes[stripe]
+		mu.Lock()
+		if file := d.flocks[stripe]; file != nil {
+			_ = file.Close()
+			d.flocks[stripe] = nil
+		}
+		mu.Unlock()
+	}
+}
+
+// writeFileAtomic ghi data vào filename qua một file tạm trong cùng thư mục.
+//
+// File tạm được ghi đầy đủ (và fsync nếu sync = true) rồi mới được đổi tên thành
+// filename, nên reader đồng thời hoặc reader sau sự cố chỉ thấy nội dung cũ hoặc nội
+// dung mới đầy đủ, không bao giờ thấy file bị cắt ngang.
+//
+// Params:
+//   - filename: Đường dẫn file đích
+//   - data: Nội dung cần ghi
+//   - sync: true để fsync file tạm và thư mục
+//
+// Returns:
+//   - error: Lỗi nếu không tạo, ghi hoặc đổi tên được file tạm
+func writeFileAtomic(filename string, data []byte, sync bool) error {
+	dir := filepath.Dir(filename)
+	file, err := os.CreateTemp(dir, fileTempPrefix+"*")
+	if os.IsNotExist(err) {
+		// Thư mục con của cấu trúc lồng nhau được tạo khi cần
+		if err = os.MkdirAll(dir, 0755); err == nil {
+			file, err = os.CreateTemp(dir, fileTempPrefix+"*")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("could not create cache file: %w", err)
+	}
+	tmp := file.Name()
+
+	err = file.Chmod(0644)
+	if err == nil {
+		_, err = file.Write(data)
+	}
+	if err == nil && sync {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, filename)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("could not write cache file: %w", err)
+	}
+
+	if sync {
+		// Đồng bộ thư mục để việc đổi tên được giữ lại; không hỗ trợ trên mọi nền tảng
+		if dirFile, err := os.Open(dir); err == nil {
+			_ = dirFile.Sync()
+			dirFile.Close()
+		}
+	}
+	return nil
+}
+
+// removeStaleTempFile xóa file tạm bị bỏ lại bởi một lần ghi bị gián đoạn.
+//
+// Params:
+//   - filename: Đường dẫn file tạm
+//   - now: Thời điểm hiện tại
+func removeStaleTempFile(filename string, now time.Time) {
+	info, err := os.Stat(filename)
+	if err == nil && now.Sub(info.ModTime()) > fileStaleTempAge {
+		_ = os.Remove(filename)
+	}
+}
@@ -0,0 +1,166 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrLockNotSupported được trả về khi driver không cài đặt Locker.
+var ErrLockNotSupported = errors.New("cache driver does not support locks")
+
+// Locker là interface tùy chọn cho các driver cung cấp được khóa phân tán.
+//
+// Khóa được xác định bởi tên và thuộc về chủ sở hữu giữ owner token đã dùng để giành
+// khóa; chỉ chủ sở hữu mới gia hạn hoặc giải phóng được khóa. Khóa được lưu tách biệt
+// với các entry của cache, nên không xuất hiện trong Get và không bị xóa bởi Flush.
+// Tất cả các driver có sẵn đều cài đặt interface này.
+type Locker interface {
+	// TryLock thử giành khóa một lần, không chờ.
+	//
+	// Khóa đã hết hạn được coi là tự do và có thể được giành lại.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - name: Tên khóa
+	//   - owner: Token định danh chủ sở hữu
+	//   - ttl: Thời gian giữ khóa tối đa (<= 0 để khóa không hết hạn)
+	//
+	// Returns:
+	//   - bool: true nếu giành được khóa
+	//   - error: Lỗi từ storage backend
+	TryLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error)
+
+	// RefreshLock đặt lại thời gian giữ khóa tính từ bây giờ nếu owner vẫn giữ khóa.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - name: Tên khóa
+	//   - owner: Token định danh chủ sở hữu
+	//   - ttl: Thời gian giữ khóa mới (<= 0 để khóa không hết hạn)
+	//
+	// Returns:
+	//   - bool: true nếu owner vẫn giữ khóa và khóa đã được gia hạn
+	//   - error: Lỗi từ storage backend
+	RefreshLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error)
+
+	// Unlock giải phóng khóa nếu owner đang giữ khóa.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - name: Tên khóa
+	//   - owner: Token định danh chủ sở hữu
+	//
+	// Returns:
+	//   - bool: true nếu khóa được giải phóng, false nếu owner không giữ khóa
+	//   - error: Lỗi từ storage backend
+	Unlock(ctx context.Context, name, owner string) (bool, error)
+}
+
+// NewLockOwner tạo owner token ngẫu nhiên cho một khóa.
+//
+// Returns:
+//   - string: Token dạng hex
+//   - error: Lỗi nếu không đọc được dữ liệu ngẫu nhiên
+func NewLockOwner() (string, error) {
+	return newLockToken()
+}
+
+// lockExpiration tính thời điểm hết hạn của khóa.
+//
+// Params:
+//   - ttl: Thời gian giữ khóa (<= 0 để khóa không hết hạn)
+//
+// Returns:
+//   - int64: Thời điểm hết hạn (UnixNano), 0 nếu khóa không hết hạn
+func lockExpiration(ttl time.Duration) int64 {
+	if ttl <= 0 {
+		return 0
+	}
+	return time.Now().Add(ttl).UnixNano()
+}
+
+// localLock là một khóa được giữ trong bộ nhớ của process.
+type localLock struct {
+	owner      string // Token định danh chủ sở hữu
+	expiration int64  // Thời điểm hết hạn (UnixNano), 0 nếu không hết hạn
+}
+
+// expired kiểm tra khóa đã hết hạn tại thời điểm now (UnixNano) hay chưa.
+func (l localLock) expired(now int64) bool {
+	return l.expiration > 0 && now > l.expiration
+}
+
+// localLocks là bảng khóa trong bộ nhớ dùng cho memory driver.
+//
+// Giá trị zero của localLocks sẵn sàng để sử dụng.
+type localLocks struct {
+	mu    sync.Mutex           // Mutex bảo vệ locks
+	locks map[string]localLock // Các khóa đang được giữ theo tên
+}
+
+// tryLock thử giành khóa, thay thế khóa đã hết hạn.
+//
+// Params:
+//   - name: Tên khóa
+//   - owner: Token định danh chủ sở hữu
+//   - ttl: Thời gian giữ khóa tối đa (<= 0 để khóa không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giành được khóa
+func (t *localLocks) tryLock(name, owner string, ttl time.Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.locks == nil {
+		t.locks = make(map[string]localLock)
+	}
+	if current, ok := t.locks[name]; ok && !current.expired(time.Now().UnixNano()) {
+		return false
+	}
+	t.locks[name] = localLock{owner: owner, expiration: lockExpiration(ttl)}
+	return true
+}
+
+// refresh đặt lại thời điểm hết hạn của khóa nếu owner đang giữ khóa.
+//
+// Params:
+//   - name: Tên khóa
+//   - owner: Token định danh chủ sở hữu
+//   - ttl: Thời gian giữ khóa mới (<= 0 để khóa không hết hạn)
+//
+// Returns:
+//   - bool: true nếu khóa được gia hạn
+func (t *localLocks) refresh(name, owner string, ttl time.Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	current, ok := t.locks[name]
+	if !ok || current.owner != owner || current.expired(time.Now().UnixNano()) {
+		return false
+	}
+	current.expiration = lockExpiration(ttl)
+	t.locks[name] = current
+	return true
+}
+
+// unlock giải phóng khóa nếu owner đang giữ khóa.
+//
+// Params:
+//   - name: Tên khóa
+//   - owner: Token định danh chủ sở hữu
+//
+// Returns:
+//   - bool: true nếu khóa được giải phóng
+func (t *localLocks) unlock(name, owner string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	current, ok := t.locks[name]
+	if !ok || current.owner != owner {
+		return false
+	}
+	delete(t.locks, name)
+	return !current.expired(time.Now().UnixNano())
+}
@@ -2,7 +2,8 @@ package driver
 
 import (
 	"context"
-	"sync"
+	"errors"
+	"strconv"
 	"time"
 
 	"go.fork.vn/cache/config"
@@ -15,8 +16,18 @@ import (
 type Item struct {
 	Value      interface{} // Giá trị được lưu trong cache
 	Expiration int64       // Thời điểm hết hạn (UnixNano), 0 nếu không hết hạn
+	// SoftExpiration là thời điểm giá trị hết còn mới (UnixNano), 0 nếu không có.
+	// Giữa SoftExpiration và Expiration, RememberStale trả về giá trị cũ và làm mới trong nền.
+	SoftExpiration int64
+	Size           int64    // Kích thước ước lượng (byte), chỉ được tính khi cấu hình max_bytes
+	Tags           []string // Các tag gắn với item
+	Version        uint64   // Phiên bản của item, tăng mỗi lần item được ghi vào shard
 }
 
+// ErrValueTooLarge được trả về khi một entry lớn hơn toàn bộ ngân sách max_bytes
+// của memory driver.
+var ErrValueTooLarge = errors.New("cache value exceeds max_bytes")
+
 // Expired kiểm tra xem item đã hết hạn hay chưa.
 //
 // Phương thức này so sánh thời điểm hết hạn của item với thời gian hiện tại
@@ -41,15 +52,23 @@ type MemoryDriver interface {
 // Driver này cung cấp khả năng truy xuất dữ liệu nhanh nhất nhưng dữ liệu sẽ
 // bị mất khi ứng dụng khởi động lại. Nó hỗ trợ TTL và tự động dọn dẹp
 // các entry đã hết hạn.
+//
+// Dữ liệu được chia thành nhiều shard theo hash của key, mỗi shard có map và
+// lock riêng, nên các goroutine truy cập những key khác nhau hầu như không
+// tranh chấp lock. Các bộ đếm thống kê là atomic và không cần lock.
 type memoryDriver struct {
-	items             map[string]Item // Map lưu trữ các cache item
-	mu                sync.RWMutex    // Mutex cho các thao tác thread-safe
-	janitorInterval   time.Duration   // Khoảng thời gian giữa các lần dọn dẹp
-	stopJanitor       chan bool       // Channel để dừng goroutine dọn dẹp
-	janitorRunning    bool            // Flag đánh dấu goroutine dọn dẹp đang chạy
-	defaultExpiration time.Duration   // Thời gian sống mặc định cho các entry không chỉ định TTL
-	hits              int64           // Số lần cache hit
-	misses            int64           // Số lần cache miss
+	shards            []*memoryShard // Các phân vùng của cache
+	stats             *memoryStats   // Bộ đếm thống kê dùng chung
+	janitorInterval   time.Duration  // Khoảng thời gian giữa các lần dọn dẹp
+	stopJanitor       chan bool      // Channel để dừng goroutine dọn dẹp
+	janitorRunning    bool           // Flag đánh dấu goroutine dọn dẹp đang chạy
+	defaultExpiration time.Duration  // Thời gian sống mặc định cho các entry không chỉ định TTL
+	maxItems          int            // Số lượng item tối đa (0 = không giới hạn)
+	maxBytes          int64          // Dung lượng tối đa tính theo byte (0 = không giới hạn)
+	evictionPolicy    string         // Tên chính sách eviction, rỗng nếu không giới hạn
+	flights           flightGroup    // Gộp các lần gọi Remember đồng thời
+	slidingTTL        time.Duration  // Thời gian gia hạn sau mỗi lần Get trúng (0 = tắt)
+	lockTable         localLocks     // Các khóa đang được giữ, tách biệt với entry của cache
 }
 
 // NewMemoryDriver tạo một memory driver mới với các tùy chọn mặc định.
@@ -57,16 +76,53 @@ type memoryDriver struct {
 // Phương thức này khởi tạo một MemoryDriver mới với các giá trị mặc định cho
 // defaultExpiration (5 phút) và cleanupInterval (10 phút).
 //
+// Nếu cfg.MaxItems > 0, driver sẽ loại bỏ item theo chính sách cfg.EvictionPolicy
+// (lru, lfu, fifo, random; mặc định lru) khi số lượng item vượt quá giới hạn.
+// Tương tự, nếu cfg.MaxBytes > 0, kích thước mỗi entry được ước lượng (hoặc lấy từ
+// Sizer) và item bị loại bỏ khi tổng dung lượng vượt quá ngân sách.
+//
+// Cache được chia thành cfg.Shards shard (0 = 1 shard). Các giới hạn MaxItems và
+// MaxBytes được chia đều cho các shard và chính sách eviction áp dụng trong từng
+// shard, nên thứ tự loại bỏ chỉ chính xác trong phạm vi một shard. Số shard không
+// vượt quá MaxItems để mỗi shard chứa được ít nhất một item. Entry chỉ bị từ chối
+// khi lớn hơn toàn bộ MaxBytes; entry lớn hơn phần của shard làm shard loại bỏ các
+// item khác của nó.
+//
 // Returns:
 //   - *MemoryDriver: Driver đã được khởi tạo
 func NewMemoryDriver(cfg config.DriverMemoryConfig) MemoryDriver {
+	shardCount := cfg.Shards
+	if shardCount < 1 {
+		shardCount = 1
+	}
+	if cfg.MaxItems > 0 && shardCount > cfg.MaxItems {
+		shardCount = cfg.MaxItems
+	}
+
 	driver := &memoryDriver{
-		items:             make(map[string]Item),
+		shards:            make([]*memoryShard, shardCount),
+		stats:             &memoryStats{},
 		janitorInterval:   time.Duration(cfg.CleanupInterval) * time.Second,
 		defaultExpiration: time.Duration(cfg.DefaultTTL) * time.Second,
+		slidingTTL:        cfg.GetSlidingTTL(),
 		stopJanitor:       make(chan bool),
 	}
 
+	if cfg.MaxItems > 0 || cfg.MaxBytes > 0 {
+		driver.maxItems = cfg.MaxItems
+		driver.maxBytes = cfg.MaxBytes
+		driver.evictionPolicy = newEvictionPolicy(cfg.EvictionPolicy).name()
+	}
+
+	for i := range driver.shards {
+		driver.shards[i] = newMemoryShard(
+			int(splitLimit(int64(cfg.MaxItems), shardCount, i)),
+			splitLimit(cfg.MaxBytes, shardCount, i),
+			cfg.EvictionPolicy,
+			driver.stats,
+		)
+	}
+
 	// Chỉ chạy janitor nếu có khoảng thời gian dọn dẹp > 0
 	if cfg.CleanupInterval > 0 {
 		go driver.startJanitor()
@@ -83,6 +139,9 @@ func NewMemoryDriver(cfg config.DriverMemoryConfig) MemoryDriver {
 // và cập nhật bộ đếm miss. Nếu tìm thấy và còn hạn, phương thức trả về giá trị và
 // cập nhật bộ đếm hit.
 //
+// Khi sliding expiration được bật, thời điểm hết hạn của item có thời hạn được kéo dài
+// thêm slidingTTL tính từ lần đọc này.
+//
 // Params:
 //   - ctx: Context để kiểm soát thời gian thực thi của thao tác
 //   - key: Cache key cần tìm
@@ -91,31 +150,42 @@ func NewMemoryDriver(cfg config.DriverMemoryConfig) MemoryDriver {
 //   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
 //   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
 func (d *memoryDriver) Get(ctx context.Context, key string) (interface{}, bool) {
-	d.mu.RLock()
-	item, found := d.items[key]
-	d.mu.RUnlock()
-
+	item, found := d.shard(key).get(key)
 	if !found {
-		d.mu.Lock()
-		d.misses++
-		d.mu.Unlock()
+		d.stats.misses.Add(1)
 		return nil, false
 	}
 
-	if item.Expired() {
-		d.mu.Lock()
-		d.misses++
-		delete(d.items, key)
-		d.mu.Unlock()
-		return nil, false
+	d.stats.hits.Add(1)
+	if d.slidingTTL > 0 && item.Expiration > 0 {
+		d.shard(key).touch(key, func(expiration int64) (int64, bool) {
+			return slidingExpiration(expiration, d.slidingTTL)
+		})
 	}
-
-	d.mu.Lock()
-	d.hits++
-	d.mu.Unlock()
 	return item.Value, true
 }
 
+// GetInto lấy một giá trị từ cache và gán vào target.
+//
+// Memory driver lưu giá trị dưới dạng Go value nên phương thức này chỉ kiểm tra
+// kiểu của giá trị đã lưu có gán được cho target hay không.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm
+//   - target: Con trỏ tới biến nhận giá trị
+//
+// Returns:
+//   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
+//   - error: Lỗi wrap ErrTypeMismatch nếu kiểu không phù hợp
+func (d *memoryDriver) GetInto(ctx context.Context, key string, target interface{}) (bool, error) {
+	value, found := d.Get(ctx, key)
+	if !found {
+		return false, nil
+	}
+	return true, assignValue(target, value)
+}
+
 // Set đặt một giá trị vào cache với TTL tùy chọn.
 //
 // Phương thức này lưu trữ một cặp key-value vào cache với thời gian sống
@@ -128,26 +198,443 @@ func (d *memoryDriver) Get(ctx context.Context, key string) (interface{}, bool)
 //   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
 //
 // Returns:
-//   - error: Luôn trả về nil trong memory driver
+//   - error: ErrValueTooLarge nếu entry lớn hơn max_bytes, nil nếu thành công
 func (d *memoryDriver) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
-	var exp int64
+	return d.set(key, value, ttl, 0, nil)
+}
+
+// SetTagged đặt một giá trị vào cache và gắn các tag cho entry.
+//
+// Mỗi shard duy trì một index từ tag tới các key, được cập nhật cùng lúc với
+// item trong cùng critical section.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+//   - tags: Danh sách tag gắn với entry
+//
+// Returns:
+//   - error: ErrValueTooLarge nếu entry lớn hơn max_bytes, nil nếu thành công
+func (d *memoryDriver) SetTagged(ctx context.Context, key string, value interface{}, ttl time.Duration, tags []string) error {
+	return d.set(key, value, ttl, 0, tags)
+}
 
-	if ttl == 0 {
-		if d.defaultExpiration > 0 {
-			exp = time.Now().Add(d.defaultExpiration).UnixNano()
+// set lưu một item với các tag vào shard tương ứng.
+//
+// Params:
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+//   - grace: Thời gian giá trị cũ tiếp tục được phục vụ sau ttl (0 nếu không có)
+//   - tags: Danh sách tag gắn với entry (nil nếu không có)
+//
+// Returns:
+//   - error: ErrValueTooLarge nếu entry lớn hơn max_bytes, nil nếu thành công
+func (d *memoryDriver) set(key string, value interface{}, ttl, grace time.Duration, tags []string) error {
+	shard := d.shard(key)
+	item, err := d.newItem(key, value, ttl, grace, tags)
+	if err != nil {
+		return err
+	}
+
+	shard.set(key, item)
+	return nil
+}
+
+// newItem tạo item cho một giá trị sắp được lưu vào shard.
+//
+// Params:
+//   - key: Cache key
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+//   - grace: Thời gian giá trị cũ tiếp tục được phục vụ sau ttl (0 nếu không có)
+//   - tags: Danh sách tag gắn với entry (nil nếu không có)
+//
+// Returns:
+//   - Item: Item đã được tính thời điểm hết hạn và kích thước
+//   - error: ErrValueTooLarge nếu entry lớn hơn max_bytes
+//
+// Entry lớn hơn phần max_bytes của shard nhưng không lớn hơn max_bytes vẫn được lưu:
+// shard loại bỏ các item khác của nó để nhường chỗ.
+func (d *memoryDriver) newItem(key string, value interface{}, ttl, grace time.Duration, tags []string) (Item, error) {
+	exp, softExp := staleExpirations(ttl, grace, d.defaultExpiration)
+
+	var size int64
+	if d.maxBytes > 0 {
+		size = estimateSize(key, value)
+		if size > d.maxBytes {
+			return Item{}, ErrValueTooLarge
 		}
-	} else if ttl > 0 {
-		exp = time.Now().Add(ttl).UnixNano()
 	}
 
-	d.mu.Lock()
-	defer d.mu.Unlock()
+	return Item{
+		Value:          value,
+		Expiration:     exp,
+		SoftExpiration: softExp,
+		Size:           size,
+		Tags:           copyTags(tags),
+	}, nil
+}
+
+// Increment tăng giá trị số nguyên của key một cách nguyên tử.
+//
+// Thao tác đọc và ghi được thực hiện dưới write lock của shard chứa key.
+// Giá trị mới được lưu dưới dạng int64.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (có thể âm)
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - int64: Giá trị sau khi tăng
+//   - error: ErrNotNumeric nếu giá trị hiện tại không phải số nguyên
+func (d *memoryDriver) Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	var result int64
+	err := d.update(key, ttl, func(value interface{}, found bool) (interface{}, error) {
+		if found {
+			current, ok := toInt64(value)
+			if !ok {
+				return nil, ErrNotNumeric
+			}
+			result = current
+		}
+		result += delta
+		return result, nil
+	})
+	return result, err
+}
 
-	d.items[key] = Item{
-		Value:      value,
-		Expiration: exp,
+// Decrement giảm giá trị số nguyên của key một cách nguyên tử.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần trừ đi
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - int64: Giá trị sau khi giảm
+//   - error: ErrNotNumeric nếu giá trị hiện tại không phải số nguyên
+func (d *memoryDriver) Decrement(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	return d.Increment(ctx, key, -delta, ttl)
+}
+
+// IncrementFloat tăng giá trị số thực của key một cách nguyên tử.
+//
+// Giá trị mới được lưu dưới dạng float64.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (có thể âm)
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - float64: Giá trị sau khi tăng
+//   - error: ErrNotNumeric nếu giá trị hiện tại không phải số
+func (d *memoryDriver) IncrementFloat(ctx context.Context, key string, delta float64, ttl time.Duration) (float64, error) {
+	var result float64
+	err := d.update(key, ttl, func(value interface{}, found bool) (interface{}, error) {
+		if found {
+			current, ok := toFloat64(value)
+			if !ok {
+				return nil, ErrNotNumeric
+			}
+			result = current
+		}
+		result += delta
+		return result, nil
+	})
+	return result, err
+}
+
+// update thay giá trị của key bằng kết quả của fn dưới write lock của shard.
+//
+// Entry đã tồn tại giữ nguyên thời điểm hết hạn và tag; entry mới nhận ttl.
+//
+// Params:
+//   - key: Cache key
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//   - fn: Hàm nhận giá trị hiện tại (found = false nếu không có) và trả về giá trị mới
+//
+// Returns:
+//   - error: Lỗi từ fn, hoặc ErrValueTooLarge nếu entry lớn hơn max_bytes
+func (d *memoryDriver) update(key string, ttl time.Duration, fn func(value interface{}, found bool) (interface{}, error)) error {
+	shard := d.shard(key)
+	return shard.update(key, func(item Item, found bool) (Item, updateOp, error) {
+		value, err := fn(item.Value, found)
+		if err != nil {
+			return Item{}, updateKeep, err
+		}
+		if !found {
+			exp, _ := staleExpirations(ttl, 0, d.defaultExpiration)
+			item = Item{Expiration: exp}
+		}
+
+		item.Value = value
+		if d.maxBytes > 0 {
+			item.Size = estimateSize(key, value)
+			if item.Size > d.maxBytes {
+				return Item{}, updateKeep, ErrValueTooLarge
+			}
+		}
+		return item, updateStore, nil
+	})
+}
+
+// Add lưu giá trị chỉ khi key chưa tồn tại hoặc đã hết hạn.
+//
+// Việc kiểm tra và ghi được thực hiện dưới write lock của shard chứa key.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được lưu, false nếu key đã tồn tại
+//   - error: ErrValueTooLarge nếu entry lớn hơn max_bytes
+func (d *memoryDriver) Add(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	shard := d.shard(key)
+	item, err := d.newItem(key, value, ttl, 0, nil)
+	if err != nil {
+		return false, err
 	}
-	return nil
+
+	added := false
+	err = shard.update(key, func(_ Item, found bool) (Item, updateOp, error) {
+		if found {
+			return Item{}, updateKeep, nil
+		}
+		added = true
+		return item, updateStore, nil
+	})
+	return added, err
+}
+
+// Replace thay giá trị chỉ khi key đang tồn tại và chưa hết hạn.
+//
+// Giống Set, entry mới thay thế cả thời điểm hết hạn và tag của entry cũ.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần thay giá trị
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được thay, false nếu key không tồn tại
+//   - error: ErrValueTooLarge nếu entry lớn hơn max_bytes
+func (d *memoryDriver) Replace(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	shard := d.shard(key)
+	item, err := d.newItem(key, value, ttl, 0, nil)
+	if err != nil {
+		return false, err
+	}
+
+	replaced := false
+	err = shard.update(key, func(_ Item, found bool) (Item, updateOp, error) {
+		if !found {
+			return Item{}, updateKeep, nil
+		}
+		replaced = true
+		return item, updateStore, nil
+	})
+	return replaced, err
+}
+
+// GetAndSet lưu giá trị mới và trả về giá trị cũ dưới write lock của shard.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - interface{}: Giá trị cũ (nil nếu không có)
+//   - bool: true nếu key tồn tại trước đó
+//   - error: ErrValueTooLarge nếu entry lớn hơn max_bytes
+func (d *memoryDriver) GetAndSet(ctx context.Context, key string, value interface{}, ttl time.Duration) (interface{}, bool, error) {
+	shard := d.shard(key)
+	item, err := d.newItem(key, value, ttl, 0, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var old interface{}
+	var existed bool
+	err = shard.update(key, func(current Item, found bool) (Item, updateOp, error) {
+		old, existed = current.Value, found
+		return item, updateStore, nil
+	})
+	return old, existed, err
+}
+
+// GetAndDelete lấy giá trị và xóa key dưới write lock của shard.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần lấy và xóa
+//
+// Returns:
+//   - interface{}: Giá trị đã xóa (nil nếu không có)
+//   - bool: true nếu key tồn tại và chưa hết hạn
+//   - error: Luôn trả về nil trong memory driver
+func (d *memoryDriver) GetAndDelete(ctx context.Context, key string) (interface{}, bool, error) {
+	var value interface{}
+	var found bool
+	err := d.shard(key).update(key, func(current Item, exists bool) (Item, updateOp, error) {
+		value, found = current.Value, exists
+		if !exists {
+			return Item{}, updateKeep, nil
+		}
+		return Item{}, updateDelete, nil
+	})
+	return value, found, err
+}
+
+// GetWithVersion lấy giá trị cùng token phiên bản của item.
+//
+// Token là phiên bản của item trong shard dưới dạng chuỗi thập phân.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm
+//
+// Returns:
+//   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
+//   - string: Token phiên bản của item (rỗng nếu không tìm thấy)
+//   - bool: true nếu tìm thấy key và chưa hết hạn
+func (d *memoryDriver) GetWithVersion(ctx context.Context, key string) (interface{}, string, bool) {
+	item, found := d.shard(key).get(key)
+	if !found {
+		d.stats.misses.Add(1)
+		return nil, "", false
+	}
+
+	d.stats.hits.Add(1)
+	return item.Value, strconv.FormatUint(item.Version, 10), true
+}
+
+// CompareAndSwap ghi giá trị mới chỉ khi phiên bản của item khớp với token.
+//
+// Việc so sánh và ghi được thực hiện dưới write lock của shard chứa key.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần ghi
+//   - token: Token phiên bản nhận được từ GetWithVersion
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được ghi, false nếu key không tồn tại hoặc đã bị thay đổi
+//   - error: ErrValueTooLarge nếu entry lớn hơn max_bytes
+func (d *memoryDriver) CompareAndSwap(ctx context.Context, key, token string, value interface{}, ttl time.Duration) (bool, error) {
+	shard := d.shard(key)
+	item, err := d.newItem(key, value, ttl, 0, nil)
+	if err != nil {
+		return false, err
+	}
+
+	swapped := false
+	err = shard.update(key, func(current Item, found bool) (Item, updateOp, error) {
+		if !found || strconv.FormatUint(current.Version, 10) != token {
+			return Item{}, updateKeep, nil
+		}
+		swapped = true
+		return item, updateStore, nil
+	})
+	return swapped, err
+}
+
+// TTL trả về thời gian sống còn lại của item.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần kiểm tra
+//
+// Returns:
+//   - time.Duration: Thời gian sống còn lại, NoExpiration nếu item không hết hạn
+//   - bool: true nếu tìm thấy key và chưa hết hạn
+func (d *memoryDriver) TTL(ctx context.Context, key string) (time.Duration, bool) {
+	item, found := d.shard(key).get(key)
+	if !found {
+		return 0, false
+	}
+	return remainingTTL(item.Expiration), true
+}
+
+// Touch cập nhật Item.Expiration của key mà không ghi lại giá trị.
+//
+// Phiên bản của item không thay đổi, nên token từ GetWithVersion vẫn còn hiệu lực.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần gia hạn
+//   - ttl: Thời gian sống mới tính từ bây giờ (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu key tồn tại và đã được cập nhật
+//   - error: Luôn trả về nil trong memory driver
+func (d *memoryDriver) Touch(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	exp, _ := staleExpirations(ttl, 0, d.defaultExpiration)
+	touched := d.shard(key).touch(key, func(int64) (int64, bool) {
+		return exp, true
+	})
+	return touched, nil
+}
+
+// TryLock thử giành khóa trong bộ nhớ của process.
+//
+// Khóa chỉ có hiệu lực trong process hiện tại và không bị xóa bởi Flush.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - name: Tên khóa
+//   - owner: Token định danh chủ sở hữu
+//   - ttl: Thời gian giữ khóa tối đa (<= 0 để khóa không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giành được khóa
+//   - error: Luôn nil
+func (d *memoryDriver) TryLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	return d.lockTable.tryLock(name, owner, ttl), nil
+}
+
+// RefreshLock đặt lại thời gian giữ khóa nếu owner vẫn giữ khóa.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - name: Tên khóa
+//   - owner: Token định danh chủ sở hữu
+//   - ttl: Thời gian giữ khóa mới (<= 0 để khóa không hết hạn)
+//
+// Returns:
+//   - bool: true nếu khóa được gia hạn
+//   - error: Luôn nil
+func (d *memoryDriver) RefreshLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	return d.lockTable.refresh(name, owner, ttl), nil
+}
+
+// Unlock giải phóng khóa nếu owner đang giữ khóa.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - name: Tên khóa
+//   - owner: Token định danh chủ sở hữu
+//
+// Returns:
+//   - bool: true nếu khóa được giải phóng
+//   - error: Luôn nil
+func (d *memoryDriver) Unlock(ctx context.Context, name, owner string) (bool, error) {
+	return d.lockTable.unlock(name, owner), nil
 }
 
 // Has kiểm tra xem một key có tồn tại trong cache không.
@@ -178,10 +665,7 @@ func (d *memoryDriver) Has(ctx context.Context, key string) bool {
 // Returns:
 //   - error: Luôn trả về nil trong memory driver
 func (d *memoryDriver) Delete(ctx context.Context, key string) error {
-	d.mu.Lock()
-	defer d.mu.Unlock()
-
-	delete(d.items, key)
+	d.shard(key).delete(key)
 	return nil
 }
 
@@ -195,13 +679,107 @@ func (d *memoryDriver) Delete(ctx context.Context, key string) error {
 // Returns:
 //   - error: Luôn trả về nil trong memory driver
 func (d *memoryDriver) Flush(ctx context.Context) error {
-	d.mu.Lock()
-	defer d.mu.Unlock()
+	for _, shard := range d.shards {
+		shard.flush()
+	}
+	return nil
+}
+
+// FlushTags xóa tất cả các entry mang ít nhất một trong các tag được chỉ định.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - tags: Danh sách tag cần xóa
+//
+// Returns:
+//   - error: Luôn trả về nil trong memory driver
+func (d *memoryDriver) FlushTags(ctx context.Context, tags []string) error {
+	for _, shard := range d.shards {
+		shard.flushTags(tags)
+	}
+	return nil
+}
 
-	d.items = make(map[string]Item)
+// Keys trả về các key còn hạn khớp với pattern.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - pattern: Glob pattern của key
+//
+// Returns:
+//   - []string: Các key khớp pattern
+//   - error: Lỗi nếu pattern không hợp lệ hoặc ctx kết thúc
+func (d *memoryDriver) Keys(ctx context.Context, pattern string) ([]string, error) {
+	return scanKeys(ctx, d, pattern)
+}
+
+// Scan duyệt lần lượt các key còn hạn khớp với pattern.
+//
+// Các shard được quét lần lượt; key của mỗi shard được chụp lại dưới read lock của shard
+// đó rồi mới được truyền cho fn, nên fn có thể thay đổi cache mà không gây deadlock.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - pattern: Glob pattern của key
+//   - fn: Hàm được gọi cho từng key, trả về false để dừng quét
+//
+// Returns:
+//   - error: Lỗi nếu pattern không hợp lệ hoặc ctx kết thúc
+func (d *memoryDriver) Scan(ctx context.Context, pattern string, fn func(key string) bool) error {
+	re, err := compilePattern(pattern)
+	if err != nil {
+		return err
+	}
+	for _, shard := range d.shards {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		for _, key := range shard.keys(re) {
+			if !fn(key) {
+				return nil
+			}
+		}
+	}
 	return nil
 }
 
+// DeleteMatching xóa tất cả các entry có key khớp với pattern.
+//
+// Mỗi shard được quét dưới write lock của chính nó, nên entry được ghi vào shard sau khi
+// shard đó đã được quét không bị xóa.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - pattern: Glob pattern của key
+//
+// Returns:
+//   - int: Số entry đã bị xóa
+//   - error: Lỗi nếu pattern không hợp lệ
+func (d *memoryDriver) DeleteMatching(ctx context.Context, pattern string) (int, error) {
+	re, err := compilePattern(pattern)
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	for _, shard := range d.shards {
+		deleted += shard.deleteMatching(re)
+	}
+	return deleted, nil
+}
+
+// DeleteByPrefix xóa tất cả các entry có key bắt đầu bằng prefix.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - prefix: Tiền tố của key
+//
+// Returns:
+//   - int: Số entry đã bị xóa
+//   - error: Luôn trả về nil trong memory driver
+func (d *memoryDriver) DeleteByPrefix(ctx context.Context, prefix string) (int, error) {
+	return d.DeleteMatching(ctx, prefixPattern(prefix))
+}
+
 // GetMultiple lấy nhiều giá trị từ cache.
 //
 // Phương thức này lấy các giá trị tương ứng với nhiều key trong một lần gọi.
@@ -277,6 +855,9 @@ func (d *memoryDriver) DeleteMultiple(ctx context.Context, keys []string) error
 // trả về giá trị tương ứng. Nếu key không tồn tại hoặc đã hết hạn, phương thức
 // sẽ gọi hàm callback để lấy dữ liệu, lưu kết quả vào cache và trả về giá trị đó.
 //
+// Các lần gọi đồng thời cho cùng một key trong process được gộp lại: chỉ một
+// goroutine thực thi callback, các goroutine khác chờ và nhận cùng kết quả.
+//
 // Params:
 //   - ctx: Context để kiểm soát thời gian thực thi của thao tác
 //   - key: Cache key cần tìm hoặc lưu vào cache
@@ -293,15 +874,49 @@ func (d *memoryDriver) Remember(ctx context.Context, key string, ttl time.Durati
 		return value, nil
 	}
 
-	// Không tìm thấy, gọi callback
-	value, err := callback()
-	if err != nil {
-		return nil, err
+	// Không tìm thấy, gọi callback một lần cho mọi goroutine đang chờ key này
+	return d.flights.do(key, func() (interface{}, error) {
+		value, err := callback()
+		if err != nil {
+			return nil, err
+		}
+
+		// Lưu kết quả vào cache
+		return value, d.Set(ctx, key, value, ttl)
+	})
+}
+
+// RememberStale lấy một giá trị từ cache theo cơ chế stale-while-revalidate.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - ttl: Thời gian giá trị được coi là còn mới (0 để sử dụng mặc định)
+//   - grace: Thời gian giá trị cũ tiếp tục được phục vụ sau ttl
+//   - callback: Hàm được gọi để lấy dữ liệu
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi nếu có trong quá trình thực hiện hoặc từ callback
+func (d *memoryDriver) RememberStale(ctx context.Context, key string, ttl, grace time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	return rememberStale(ctx, d, &d.flights, nil, key, ttl, grace, callback)
+}
+
+// getStale lấy giá trị cùng thời điểm hết hạn mềm của entry.
+func (d *memoryDriver) getStale(ctx context.Context, key string) (interface{}, int64, bool, error) {
+	item, found := d.shard(key).get(key)
+	if !found {
+		d.stats.misses.Add(1)
+		return nil, 0, false, nil
 	}
 
-	// Lưu kết quả vào cache
-	err = d.Set(ctx, key, value, ttl)
-	return value, err
+	d.stats.hits.Add(1)
+	return item.Value, item.SoftExpiration, true, nil
+}
+
+// setStale lưu giá trị với thời điểm hết hạn mềm sau ttl và hết hạn cứng sau ttl + grace.
+func (d *memoryDriver) setStale(ctx context.Context, key string, value interface{}, ttl, grace time.Duration) error {
+	return d.set(key, value, ttl, grace, nil)
 }
 
 // Stats trả về thông tin thống kê về cache.
@@ -315,15 +930,26 @@ func (d *memoryDriver) Remember(ctx context.Context, key string, ttl time.Durati
 // Returns:
 //   - map[string]interface{}: Map chứa các thông tin thống kê
 func (d *memoryDriver) Stats(ctx context.Context) map[string]interface{} {
-	d.mu.RLock()
-	defer d.mu.RUnlock()
+	itemCount := 0
+	for _, shard := range d.shards {
+		itemCount += shard.count()
+	}
 
-	itemCount := len(d.items)
 	stats := map[string]interface{}{
-		"count":  itemCount,
-		"hits":   d.hits,
-		"misses": d.misses,
-		"type":   "memory",
+		"count":         itemCount,
+		"hits":          d.stats.hits.Load(),
+		"misses":        d.stats.misses.Load(),
+		"evictions":     d.stats.evictions.Load(),
+		"max_items":     d.maxItems,
+		"bytes":         d.stats.bytes.Load(),
+		"peak_bytes":    d.stats.peakBytes.Load(),
+		"bytes_evicted": d.stats.bytesEvicted.Load(),
+		"max_bytes":     d.maxBytes,
+		"shards":        len(d.shards),
+		"type":          "memory",
+	}
+	if d.evictionPolicy != "" {
+		stats["eviction_policy"] = d.evictionPolicy
 	}
 
 	return stats
@@ -363,17 +989,21 @@ func (d *memoryDriver) startJanitor() {
 
 // deleteExpired xóa tất cả các mục đã hết hạn.
 //
-// Phương thức này quét qua tất cả các item trong cache map,
-// kiểm tra thời gian hết hạn và xóa những item đã quá hạn.
+// Phương thức này quét lần lượt từng shard, chỉ giữ lock của shard đang được
+// quét, nên janitor không bao giờ chặn toàn bộ cache.
 func (d *memoryDriver) deleteExpired() {
-	now := time.Now().UnixNano()
-
-	d.mu.Lock()
-	defer d.mu.Unlock()
-
-	for k, v := range d.items {
-		if v.Expiration > 0 && now > v.Expiration {
-			delete(d.items, k)
-		}
+	for _, shard := range d.shards {
+		shard.deleteExpired(time.Now().UnixNano())
 	}
 }
+
+// shard trả về shard chứa key.
+//
+// Params:
+//   - key: Cache key
+//
+// Returns:
+//   - *memoryShard: Shard tương ứng với key
+func (d *memoryDriver) shard(key string) *memoryShard {
+	return d.shards[shardIndex(key, len(d.shards))]
+}
@@ -0,0 +1,444 @@
+package driver
+
+import (
+	"regexp"
+	"sync"
+	"sync/atomic"
+)
+
+// memoryStats chứa các bộ đếm dùng chung giữa các shard của memory driver.
+//
+// Tất cả các bộ đếm đều là atomic nên có thể cập nhật mà không cần giữ lock.
+type memoryStats struct {
+	hits         atomic.Int64 // Số lần cache hit
+	misses       atomic.Int64 // Số lần cache miss
+	evictions    atomic.Int64 // Số item bị loại bỏ do vượt giới hạn
+	bytes        atomic.Int64 // Dung lượng hiện tại tính theo byte
+	peakBytes    atomic.Int64 // Dung lượng cao nhất từng đạt
+	bytesEvicted atomic.Int64 // Tổng dung lượng các item bị loại bỏ
+}
+
+// addBytes cộng delta vào dung lượng hiện tại và cập nhật dung lượng cao nhất.
+func (s *memoryStats) addBytes(delta int64) {
+	current := s.bytes.Add(delta)
+	for {
+		peak := s.peakBytes.Load()
+		if current <= peak || s.peakBytes.CompareAndSwap(peak, current) {
+			return
+		}
+	}
+}
+
+// memoryShard là một phân vùng độc lập của memory cache.
+//
+// Mỗi shard có map, lock, chính sách eviction và giới hạn riêng, nhờ đó các
+// thao tác trên những key thuộc shard khác nhau không tranh chấp lock với nhau.
+type memoryShard struct {
+	mu       sync.RWMutex    // Mutex bảo vệ dữ liệu của shard
+	items    map[string]Item // Map lưu trữ các cache item
+	policy   evictionPolicy  // Chính sách eviction, nil nếu không giới hạn
+	maxItems int             // Số lượng item tối đa của shard (0 = không giới hạn)
+	maxBytes int64           // Dung lượng tối đa của shard (0 = không giới hạn)
+	bytes    int64           // Dung lượng hiện tại của shard
+	tags     tagIndex        // Index từ tag tới các key mang tag đó
+	stats    *memoryStats    // Bộ đếm dùng chung của driver
+	version  uint64          // Phiên bản được gán cho lần ghi gần nhất
+}
+
+// tagIndex ánh xạ mỗi tag tới tập các key mang tag đó.
+type tagIndex map[string]map[string]struct{}
+
+// add ghi nhận key mang các tag.
+func (idx tagIndex) add(key string, tags []string) {
+	for _, tag := range tags {
+		keys, ok := idx[tag]
+		if !ok {
+			keys = make(map[string]struct{})
+			idx[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+}
+
+// remove xóa key khỏi các tag.
+func (idx tagIndex) remove(key string, tags []string) {
+	for _, tag := range tags {
+		if keys, ok := idx[tag]; ok {
+			delete(keys, key)
+			if len(keys) == 0 {
+				delete(idx, tag)
+			}
+		}
+	}
+}
+
+// newMemoryShard tạo một shard mới.
+//
+// Params:
+//   - maxItems: Số lượng item tối đa của shard (0 = không giới hạn)
+//   - maxBytes: Dung lượng tối đa của shard (0 = không giới hạn)
+//   - policy: Tên chính sách eviction
+//   - stats: Bộ đếm dùng chung của driver
+//
+// Returns:
+//   - *memoryShard: Shard đã được khởi tạo
+func newMemoryShard(maxItems int, maxBytes int64, policy string, stats *memoryStats) *memoryShard {
+	shard := &memoryShard{
+		items:    make(map[string]Item),
+		tags:     make(tagIndex),
+		maxItems: maxItems,
+		maxBytes: maxBytes,
+		stats:    stats,
+	}
+	if maxItems > 0 || maxBytes > 0 {
+		shard.policy = newEvictionPolicy(policy)
+	}
+	return shard
+}
+
+// get tìm một item còn hạn trong shard.
+//
+// Nếu chính sách eviction cần theo dõi truy cập, write lock được giữ một lần cho
+// toàn bộ thao tác; ngược lại chỉ cần read lock, trừ khi phải xóa item hết hạn.
+//
+// Params:
+//   - key: Cache key cần tìm
+//
+// Returns:
+//   - Item: Item tìm thấy
+//   - bool: true nếu tìm thấy key và chưa hết hạn
+func (s *memoryShard) get(key string) (Item, bool) {
+	if s.policy != nil && s.policy.tracksAccess() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		item, found := s.items[key]
+		if !found {
+			return Item{}, false
+		}
+		if item.Expired() {
+			s.removeItem(key)
+			return Item{}, false
+		}
+		s.policy.access(key)
+		return item, true
+	}
+
+	s.mu.RLock()
+	item, found := s.items[key]
+	s.mu.RUnlock()
+
+	if !found {
+		return Item{}, false
+	}
+	if item.Expired() {
+		s.mu.Lock()
+		if current, ok := s.items[key]; ok && current.Expired() {
+			s.removeItem(key)
+		}
+		s.mu.Unlock()
+		return Item{}, false
+	}
+	return item, true
+}
+
+// set lưu một item vào shard, loại bỏ item khác nếu cần.
+//
+// Params:
+//   - key: Cache key
+//   - item: Item cần lưu
+func (s *memoryShard) set(key string, item Item) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.policy != nil {
+		s.makeRoom(key, item.Size)
+	}
+	s.storeItem(key, item)
+}
+
+// update đọc item hiện tại và thực hiện thao tác do fn quyết định trong cùng một
+// critical section.
+//
+// Item đã hết hạn được coi như không tồn tại. Nếu fn trả về lỗi, shard không bị thay đổi
+// (ngoài việc xóa item đã hết hạn).
+//
+// Params:
+//   - key: Cache key
+//   - fn: Hàm nhận item hiện tại (found = false nếu không có), trả về item và thao tác cần thực hiện
+//
+// Returns:
+//   - error: Lỗi trả về từ fn
+func (s *memoryShard) update(key string, fn func(item Item, found bool) (Item, updateOp, error)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, found := s.items[key]
+	if found && item.Expired() {
+		s.removeItem(key)
+		item, found = Item{}, false
+	}
+
+	item, op, err := fn(item, found)
+	if err != nil {
+		return err
+	}
+	switch op {
+	case updateStore:
+		if s.policy != nil {
+			s.makeRoom(key, item.Size)
+		}
+		s.storeItem(key, item)
+	case updateDelete:
+		s.removeItem(key)
+	}
+	return nil
+}
+
+// touch cập nhật thời điểm hết hạn của một item còn hạn mà không thay đổi giá trị,
+// phiên bản hay vị trí của item trong chính sách eviction.
+//
+// Params:
+//   - key: Cache key
+//   - fn: Hàm nhận thời điểm hết hạn hiện tại, trả về thời điểm mới và true nếu cần cập nhật
+//
+// Returns:
+//   - bool: true nếu tìm thấy key và chưa hết hạn
+func (s *memoryShard) touch(key string, fn func(expiration int64) (int64, bool)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, found := s.items[key]
+	if !found || item.Expired() {
+		return false
+	}
+	if expiration, ok := fn(item.Expiration); ok {
+		item.Expiration = expiration
+		s.items[key] = item
+	}
+	return true
+}
+
+// delete xóa một key khỏi shard.
+//
+// Params:
+//   - key: Cache key cần xóa
+func (s *memoryShard) delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeItem(key)
+}
+
+// flush xóa toàn bộ item của shard.
+func (s *memoryShard) flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stats.addBytes(-s.bytes)
+	s.items = make(map[string]Item)
+	s.tags = make(tagIndex)
+	s.bytes = 0
+	if s.policy != nil {
+		s.policy.reset()
+	}
+}
+
+// flushTags xóa các item mang ít nhất một trong các tag.
+//
+// Params:
+//   - tags: Danh sách tag cần xóa
+func (s *memoryShard) flushTags(tags []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, tag := range tags {
+		for key := range s.tags[tag] {
+			s.removeItem(key)
+		}
+	}
+}
+
+// count trả về số lượng item trong shard, bao gồm cả item đã hết hạn chưa được dọn.
+func (s *memoryShard) count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.items)
+}
+
+// keys trả về các key còn hạn, không phải key nội bộ, khớp với pattern.
+//
+// Params:
+//   - pattern: Regexp của pattern
+//
+// Returns:
+//   - []string: Các key khớp pattern
+func (s *memoryShard) keys(pattern *regexp.Regexp) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var keys []string
+	for key, item := range s.items {
+		if !item.Expired() && !isInternalKey(key) && pattern.MatchString(key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// deleteMatching xóa các item không phải item nội bộ có key khớp với pattern.
+//
+// Params:
+//   - pattern: Regexp của pattern
+//
+// Returns:
+//   - int: Số item đã bị xóa
+func (s *memoryShard) deleteMatching(pattern *regexp.Regexp) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := 0
+	for key := range s.items {
+		if !isInternalKey(key) && pattern.MatchString(key) {
+			s.removeItem(key)
+			deleted++
+		}
+	}
+	return deleted
+}
+
+// deleteExpired xóa các item đã hết hạn của shard.
+//
+// Params:
+//   - now: Thời điểm hiện tại (UnixNano)
+func (s *memoryShard) deleteExpired(now int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k, v := range s.items {
+		if v.Expiration > 0 && now > v.Expiration {
+			s.removeItem(k)
+		}
+	}
+}
+
+// removeItem xóa một key khỏi map và khỏi chính sách eviction.
+//
+// Phương thức này phải được gọi khi đang giữ write lock.
+func (s *memoryShard) removeItem(key string) {
+	item, ok := s.items[key]
+	if !ok {
+		if s.policy != nil {
+			s.policy.remove(key)
+		}
+		return
+	}
+	delete(s.items, key)
+	s.tags.remove(key, item.Tags)
+	s.bytes -= item.Size
+	s.stats.addBytes(-item.Size)
+	if s.policy != nil {
+		s.policy.remove(key)
+	}
+}
+
+// storeItem lưu item vào map, cập nhật chính sách eviction và dung lượng.
+//
+// Mỗi lần lưu, item nhận một phiên bản mới tăng dần trong shard, nên một key bị xóa
+// rồi tạo lại cũng không dùng lại phiên bản cũ.
+// Phương thức này phải được gọi khi đang giữ write lock.
+func (s *memoryShard) storeItem(key string, item Item) {
+	s.version++
+	item.Version = s.version
+	delta := item.Size
+	if old, exists := s.items[key]; exists {
+		delta -= old.Size
+		s.tags.remove(key, old.Tags)
+		if s.policy != nil {
+			s.policy.access(key)
+		}
+	} else if s.policy != nil {
+		s.policy.add(key)
+	}
+
+	s.items[key] = item
+	s.tags.add(key, item.Tags)
+	s.bytes += delta
+	s.stats.addBytes(delta)
+}
+
+// makeRoom loại bỏ item theo chính sách eviction cho đến khi có đủ chỗ để lưu
+// key với kích thước size mà không vượt quá giới hạn của shard.
+//
+// Giá trị cũ của chính key đó không được tính vào giới hạn vì nó sẽ bị ghi đè.
+// Các item đã hết hạn không được ưu tiên riêng; chúng sẽ được janitor dọn dẹp.
+// Phương thức này phải được gọi khi đang giữ write lock.
+func (s *memoryShard) makeRoom(key string, size int64) {
+	for {
+		count, bytes := len(s.items), s.bytes
+		if old, exists := s.items[key]; exists {
+			count--
+			bytes -= old.Size
+		}
+
+		overItems := s.maxItems > 0 && count >= s.maxItems
+		overBytes := s.maxBytes > 0 && bytes+size > s.maxBytes
+		if !overItems && !overBytes {
+			return
+		}
+
+		victim, ok := s.policy.victim()
+		if !ok {
+			return
+		}
+		s.stats.bytesEvicted.Add(s.items[victim].Size)
+		s.stats.evictions.Add(1)
+		s.removeItem(victim)
+	}
+}
+
+// shardIndex chọn shard cho một key bằng hàm băm FNV-1a.
+//
+// Params:
+//   - key: Cache key
+//   - n: Số lượng shard
+//
+// Returns:
+//   - int: Chỉ số shard trong khoảng [0, n)
+func shardIndex(key string, n int) int {
+	if n == 1 {
+		return 0
+	}
+	hash := uint32(2166136261)
+	for i := 0; i < len(key); i++ {
+		hash ^= uint32(key[i])
+		hash *= 16777619
+	}
+	return int(hash % uint32(n))
+}
+
+// splitLimit chia một giới hạn tổng cho n shard sao cho tổng các phần bằng giới hạn.
+//
+// Mỗi shard nhận ít nhất 1 để giới hạn nhỏ hơn số shard không bị hiểu là không giới hạn.
+//
+// Params:
+//   - total: Giới hạn tổng (0 = không giới hạn)
+//   - n: Số lượng shard
+//   - i: Chỉ số shard
+//
+// Returns:
+//   - int64: Giới hạn của shard thứ i
+func splitLimit(total int64, n, i int) int64 {
+	if total <= 0 {
+		return 0
+	}
+	part := total / int64(n)
+	if int64(i) < total%int64(n) {
+		part++
+	}
+	if part == 0 {
+		part = 1
+	}
+	return part
+}
@@ -3,6 +3,9 @@ package driver_test
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -530,3 +533,1052 @@ func BenchmarkMemoryDriver(b *testing.B) {
 		}
 	})
 }
+
+func TestMemoryDriverGetInto(t *testing.T) {
+	ctx := context.Background()
+	memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+	defer memoryDriver.Close()
+
+	getter, ok := memoryDriver.(driver.TypedGetter)
+	assert.True(t, ok, "memory driver should implement TypedGetter")
+
+	type profile struct {
+		Name string
+		Age  int
+	}
+
+	t.Run("assigns_value_of_matching_type", func(t *testing.T) {
+		err := memoryDriver.Set(ctx, "typed:profile", profile{Name: "An", Age: 30}, 0)
+		assert.NoError(t, err)
+
+		var result profile
+		found, err := getter.GetInto(ctx, "typed:profile", &result)
+
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, profile{Name: "An", Age: 30}, result)
+	})
+
+	t.Run("returns_type_mismatch_error", func(t *testing.T) {
+		err := memoryDriver.Set(ctx, "typed:string", "not a profile", 0)
+		assert.NoError(t, err)
+
+		var result profile
+		found, err := getter.GetInto(ctx, "typed:string", &result)
+
+		assert.True(t, found)
+		assert.ErrorIs(t, err, driver.ErrTypeMismatch)
+	})
+
+	t.Run("returns_not_found_for_missing_key", func(t *testing.T) {
+		var result profile
+		found, err := getter.GetInto(ctx, "typed:missing", &result)
+
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("rejects_non_pointer_target", func(t *testing.T) {
+		err := memoryDriver.Set(ctx, "typed:int", 42, 0)
+		assert.NoError(t, err)
+
+		found, err := getter.GetInto(ctx, "typed:int", 0)
+
+		assert.True(t, found)
+		assert.Error(t, err)
+	})
+}
+
+func TestMemoryDriverEviction(t *testing.T) {
+	ctx := context.Background()
+
+	newDriver := func(t *testing.T, policy string, maxItems int) driver.MemoryDriver {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{
+			DefaultTTL:     300,
+			MaxItems:       maxItems,
+			EvictionPolicy: policy,
+		})
+		t.Cleanup(func() { _ = memoryDriver.Close() })
+		return memoryDriver
+	}
+
+	t.Run("lru_evicts_least_recently_used", func(t *testing.T) {
+		memoryDriver := newDriver(t, driver.EvictionLRU, 3)
+		_ = memoryDriver.Set(ctx, "a", 1, 0)
+		_ = memoryDriver.Set(ctx, "b", 2, 0)
+		_ = memoryDriver.Set(ctx, "c", 3, 0)
+
+		// Truy cập "a" để "b" trở thành item ít được dùng gần đây nhất
+		memoryDriver.Get(ctx, "a")
+		_ = memoryDriver.Set(ctx, "d", 4, 0)
+
+		assert.True(t, memoryDriver.Has(ctx, "a"))
+		assert.False(t, memoryDriver.Has(ctx, "b"))
+		assert.True(t, memoryDriver.Has(ctx, "c"))
+		assert.True(t, memoryDriver.Has(ctx, "d"))
+	})
+
+	t.Run("lfu_evicts_least_frequently_used", func(t *testing.T) {
+		memoryDriver := newDriver(t, driver.EvictionLFU, 3)
+		_ = memoryDriver.Set(ctx, "a", 1, 0)
+		_ = memoryDriver.Set(ctx, "b", 2, 0)
+		_ = memoryDriver.Set(ctx, "c", 3, 0)
+
+		memoryDriver.Get(ctx, "a")
+		memoryDriver.Get(ctx, "a")
+		memoryDriver.Get(ctx, "b")
+		memoryDriver.Get(ctx, "c")
+		memoryDriver.Get(ctx, "c")
+		_ = memoryDriver.Set(ctx, "d", 4, 0)
+
+		assert.True(t, memoryDriver.Has(ctx, "a"))
+		assert.False(t, memoryDriver.Has(ctx, "b"))
+		assert.True(t, memoryDriver.Has(ctx, "c"))
+		assert.True(t, memoryDriver.Has(ctx, "d"))
+	})
+
+	t.Run("fifo_evicts_oldest_insert_regardless_of_access", func(t *testing.T) {
+		memoryDriver := newDriver(t, driver.EvictionFIFO, 3)
+		_ = memoryDriver.Set(ctx, "a", 1, 0)
+		_ = memoryDriver.Set(ctx, "b", 2, 0)
+		_ = memoryDriver.Set(ctx, "c", 3, 0)
+
+		memoryDriver.Get(ctx, "a")
+		_ = memoryDriver.Set(ctx, "d", 4, 0)
+
+		assert.False(t, memoryDriver.Has(ctx, "a"))
+		assert.True(t, memoryDriver.Has(ctx, "b"))
+		assert.True(t, memoryDriver.Has(ctx, "c"))
+		assert.True(t, memoryDriver.Has(ctx, "d"))
+	})
+
+	t.Run("random_keeps_item_count_within_limit", func(t *testing.T) {
+		memoryDriver := newDriver(t, driver.EvictionRandom, 10)
+		for i := 0; i < 100; i++ {
+			_ = memoryDriver.Set(ctx, fmt.Sprintf("key:%d", i), i, 0)
+		}
+
+		stats := memoryDriver.Stats(ctx)
+		assert.Equal(t, 10, stats["count"])
+		assert.Equal(t, int64(90), stats["evictions"])
+		assert.True(t, memoryDriver.Has(ctx, "key:99"))
+	})
+
+	t.Run("overwrite_does_not_evict", func(t *testing.T) {
+		memoryDriver := newDriver(t, driver.EvictionLRU, 2)
+		_ = memoryDriver.Set(ctx, "a", 1, 0)
+		_ = memoryDriver.Set(ctx, "b", 2, 0)
+		_ = memoryDriver.Set(ctx, "a", 3, 0)
+
+		value, found := memoryDriver.Get(ctx, "a")
+		assert.True(t, found)
+		assert.Equal(t, 3, value)
+		assert.True(t, memoryDriver.Has(ctx, "b"))
+		assert.Equal(t, int64(0), memoryDriver.Stats(ctx)["evictions"])
+	})
+
+	t.Run("deleted_and_flushed_keys_are_not_victims", func(t *testing.T) {
+		memoryDriver := newDriver(t, driver.EvictionLRU, 2)
+		_ = memoryDriver.Set(ctx, "a", 1, 0)
+		_ = memoryDriver.Set(ctx, "b", 2, 0)
+		_ = memoryDriver.Delete(ctx, "a")
+		_ = memoryDriver.Set(ctx, "c", 3, 0)
+
+		assert.True(t, memoryDriver.Has(ctx, "b"))
+		assert.True(t, memoryDriver.Has(ctx, "c"))
+
+		_ = memoryDriver.Flush(ctx)
+		_ = memoryDriver.Set(ctx, "d", 4, 0)
+		_ = memoryDriver.Set(ctx, "e", 5, 0)
+
+		assert.Equal(t, 2, memoryDriver.Stats(ctx)["count"])
+		assert.Equal(t, int64(0), memoryDriver.Stats(ctx)["evictions"])
+	})
+
+	t.Run("stats_report_limit_and_policy", func(t *testing.T) {
+		memoryDriver := newDriver(t, "unknown", 5)
+
+		stats := memoryDriver.Stats(ctx)
+		assert.Equal(t, 5, stats["max_items"])
+		assert.Equal(t, driver.EvictionLRU, stats["eviction_policy"])
+	})
+
+	t.Run("zero_max_items_is_unlimited", func(t *testing.T) {
+		memoryDriver := newDriver(t, driver.EvictionLRU, 0)
+		for i := 0; i < 50; i++ {
+			_ = memoryDriver.Set(ctx, fmt.Sprintf("key:%d", i), i, 0)
+		}
+
+		stats := memoryDriver.Stats(ctx)
+		assert.Equal(t, 50, stats["count"])
+		assert.Equal(t, int64(0), stats["evictions"])
+	})
+}
+
+// sizedValue là giá trị tự báo kích thước thông qua driver.Sizer
+type sizedValue struct {
+	size int64
+}
+
+func (v sizedValue) CacheSize() int64 {
+	return v.size
+}
+
+func TestMemoryDriverMaxBytes(t *testing.T) {
+	ctx := context.Background()
+
+	newDriver := func(t *testing.T, maxBytes int64) driver.MemoryDriver {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{
+			DefaultTTL:     300,
+			MaxBytes:       maxBytes,
+			EvictionPolicy: driver.EvictionLRU,
+		})
+		t.Cleanup(func() { _ = memoryDriver.Close() })
+		return memoryDriver
+	}
+
+	t.Run("evicts_until_budget_is_respected", func(t *testing.T) {
+		memoryDriver := newDriver(t, 1000)
+		for i := 0; i < 5; i++ {
+			err := memoryDriver.Set(ctx, fmt.Sprintf("k%d", i), sizedValue{size: 300}, 0)
+			assert.NoError(t, err)
+		}
+
+		stats := memoryDriver.Stats(ctx)
+		assert.LessOrEqual(t, stats["bytes"].(int64), int64(1000))
+		assert.Equal(t, 3, stats["count"])
+		assert.Equal(t, int64(2), stats["evictions"])
+		assert.Greater(t, stats["bytes_evicted"].(int64), int64(600))
+		assert.Equal(t, int64(1000), stats["max_bytes"])
+		assert.False(t, memoryDriver.Has(ctx, "k0"))
+		assert.True(t, memoryDriver.Has(ctx, "k4"))
+	})
+
+	t.Run("one_large_value_evicts_many_small_ones", func(t *testing.T) {
+		memoryDriver := newDriver(t, 1000)
+		for i := 0; i < 10; i++ {
+			_ = memoryDriver.Set(ctx, fmt.Sprintf("small%d", i), sizedValue{size: 50}, 0)
+		}
+		err := memoryDriver.Set(ctx, "large", sizedValue{size: 800}, 0)
+		assert.NoError(t, err)
+
+		stats := memoryDriver.Stats(ctx)
+		assert.LessOrEqual(t, stats["bytes"].(int64), int64(1000))
+		assert.True(t, memoryDriver.Has(ctx, "large"))
+		assert.Greater(t, stats["evictions"].(int64), int64(5))
+	})
+
+	t.Run("rejects_value_larger_than_budget", func(t *testing.T) {
+		memoryDriver := newDriver(t, 1000)
+		_ = memoryDriver.Set(ctx, "keep", sizedValue{size: 100}, 0)
+
+		err := memoryDriver.Set(ctx, "huge", sizedValue{size: 5000}, 0)
+
+		assert.ErrorIs(t, err, driver.ErrValueTooLarge)
+		assert.False(t, memoryDriver.Has(ctx, "huge"))
+		assert.True(t, memoryDriver.Has(ctx, "keep"))
+	})
+
+	t.Run("tracks_current_and_peak_bytes", func(t *testing.T) {
+		memoryDriver := newDriver(t, 10000)
+		_ = memoryDriver.Set(ctx, "a", sizedValue{size: 400}, 0)
+		_ = memoryDriver.Set(ctx, "b", sizedValue{size: 400}, 0)
+		peak := memoryDriver.Stats(ctx)["bytes"].(int64)
+
+		_ = memoryDriver.Delete(ctx, "a")
+		_ = memoryDriver.Set(ctx, "b", sizedValue{size: 100}, 0)
+
+		stats := memoryDriver.Stats(ctx)
+		assert.Equal(t, peak, stats["peak_bytes"])
+		assert.Less(t, stats["bytes"].(int64), peak)
+
+		_ = memoryDriver.Flush(ctx)
+		assert.Equal(t, int64(0), memoryDriver.Stats(ctx)["bytes"])
+	})
+
+	t.Run("estimates_size_of_plain_values", func(t *testing.T) {
+		memoryDriver := newDriver(t, 1<<20)
+		_ = memoryDriver.Set(ctx, "small", "x", 0)
+		small := memoryDriver.Stats(ctx)["bytes"].(int64)
+
+		_ = memoryDriver.Set(ctx, "big", strings.Repeat("x", 10000), 0)
+		_ = memoryDriver.Set(ctx, "slice", make([]int64, 1000), 0)
+		_ = memoryDriver.Set(ctx, "map", map[string]interface{}{"name": strings.Repeat("y", 500)}, 0)
+
+		total := memoryDriver.Stats(ctx)["bytes"].(int64)
+		assert.Greater(t, small, int64(0))
+		assert.Greater(t, total-small, int64(10000+8000+500))
+	})
+
+	t.Run("handles_cyclic_values", func(t *testing.T) {
+		type node struct {
+			Next *node
+			Data string
+		}
+		n := &node{Data: "cycle"}
+		n.Next = n
+
+		memoryDriver := newDriver(t, 1<<20)
+		err := memoryDriver.Set(ctx, "cycle", n, 0)
+
+		assert.NoError(t, err)
+		assert.True(t, memoryDriver.Has(ctx, "cycle"))
+	})
+}
+
+func TestMemoryDriverSharding(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("zero_shards_defaults_to_one", func(t *testing.T) {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+		defer memoryDriver.Close()
+
+		assert.Equal(t, 1, memoryDriver.Stats(ctx)["shards"])
+	})
+
+	t.Run("shard_count_is_capped_by_max_items", func(t *testing.T) {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{
+			DefaultTTL: 300,
+			MaxItems:   4,
+			Shards:     16,
+		})
+		defer memoryDriver.Close()
+
+		assert.Equal(t, 4, memoryDriver.Stats(ctx)["shards"])
+	})
+
+	t.Run("limits_are_enforced_across_shards", func(t *testing.T) {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{
+			DefaultTTL: 300,
+			MaxItems:   64,
+			Shards:     8,
+		})
+		defer memoryDriver.Close()
+
+		for i := 0; i < 1000; i++ {
+			_ = memoryDriver.Set(ctx, fmt.Sprintf("key:%d", i), i, 0)
+		}
+
+		stats := memoryDriver.Stats(ctx)
+		assert.LessOrEqual(t, stats["count"].(int), 64)
+		assert.Equal(t, int64(1000-stats["count"].(int)), stats["evictions"])
+	})
+
+	t.Run("value_larger_than_shard_budget_fits_max_bytes", func(t *testing.T) {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{
+			DefaultTTL: 300,
+			MaxBytes:   1 << 20,
+			Shards:     16,
+		})
+		defer memoryDriver.Close()
+
+		// 100KB lớn hơn 1MB/16 nhưng nhỏ hơn max_bytes
+		err := memoryDriver.Set(ctx, "large", sizedValue{size: 100 << 10}, 0)
+		assert.NoError(t, err)
+		assert.True(t, memoryDriver.Has(ctx, "large"))
+
+		err = memoryDriver.Set(ctx, "huge", sizedValue{size: 2 << 20}, 0)
+		assert.ErrorIs(t, err, driver.ErrValueTooLarge)
+	})
+
+	t.Run("concurrent_access_keeps_counters_consistent", func(t *testing.T) {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{
+			DefaultTTL:      300,
+			CleanupInterval: 1,
+			MaxItems:        500,
+			Shards:          16,
+		})
+		defer memoryDriver.Close()
+
+		var wg sync.WaitGroup
+		for g := 0; g < 16; g++ {
+			wg.Add(1)
+			go func(id int) {
+				defer wg.Done()
+				for j := 0; j < 200; j++ {
+					key := fmt.Sprintf("key:%d:%d", id, j%50)
+					_ = memoryDriver.Set(ctx, key, j, time.Millisecond*time.Duration(j%3))
+					memoryDriver.Get(ctx, key)
+					if j%10 == 0 {
+						_ = memoryDriver.Delete(ctx, key)
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		stats := memoryDriver.Stats(ctx)
+		assert.Equal(t, int64(16*200), stats["hits"].(int64)+stats["misses"].(int64))
+		assert.LessOrEqual(t, stats["count"].(int), 500)
+	})
+
+	t.Run("flush_clears_every_shard", func(t *testing.T) {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300, Shards: 8})
+		defer memoryDriver.Close()
+
+		for i := 0; i < 100; i++ {
+			_ = memoryDriver.Set(ctx, fmt.Sprintf("key:%d", i), i, 0)
+		}
+		assert.NoError(t, memoryDriver.Flush(ctx))
+
+		assert.Equal(t, 0, memoryDriver.Stats(ctx)["count"])
+	})
+}
+
+func BenchmarkMemoryDriverGetParallel(b *testing.B) {
+	ctx := context.Background()
+	for _, shards := range []int{1, 16} {
+		b.Run(fmt.Sprintf("shards_%d", shards), func(b *testing.B) {
+			memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{
+				DefaultTTL: 300,
+				MaxItems:   100000,
+				Shards:     shards,
+			})
+			defer memoryDriver.Close()
+
+			keys := make([]string, 1024)
+			for i := range keys {
+				keys[i] = fmt.Sprintf("key:%d", i)
+				_ = memoryDriver.Set(ctx, keys[i], i, 0)
+			}
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					memoryDriver.Get(ctx, keys[i%len(keys)])
+					i++
+				}
+			})
+		})
+	}
+}
+
+func TestMemoryDriverTags(t *testing.T) {
+	ctx := context.Background()
+
+	newDriver := func() driver.MemoryDriver {
+		return driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300, Shards: 4})
+	}
+
+	t.Run("flush_removes_only_tagged_entries", func(t *testing.T) {
+		memoryDriver := newDriver()
+		defer memoryDriver.Close()
+
+		assert.NoError(t, memoryDriver.SetTagged(ctx, "user:42", "An", 0, []string{"user:42", "org:7"}))
+		assert.NoError(t, memoryDriver.SetTagged(ctx, "user:43", "Binh", 0, []string{"user:43", "org:7"}))
+		assert.NoError(t, memoryDriver.SetTagged(ctx, "user:44", "Chi", 0, []string{"user:44", "org:8"}))
+		assert.NoError(t, memoryDriver.Set(ctx, "plain", "value", 0))
+
+		assert.NoError(t, memoryDriver.FlushTags(ctx, []string{"org:7"}))
+
+		assert.False(t, memoryDriver.Has(ctx, "user:42"))
+		assert.False(t, memoryDriver.Has(ctx, "user:43"))
+		assert.True(t, memoryDriver.Has(ctx, "user:44"))
+		assert.True(t, memoryDriver.Has(ctx, "plain"))
+	})
+
+	t.Run("overwrite_replaces_tags", func(t *testing.T) {
+		memoryDriver := newDriver()
+		defer memoryDriver.Close()
+
+		assert.NoError(t, memoryDriver.SetTagged(ctx, "report", 1, 0, []string{"old"}))
+		assert.NoError(t, memoryDriver.SetTagged(ctx, "report", 2, 0, []string{"new"}))
+
+		assert.NoError(t, memoryDriver.FlushTags(ctx, []string{"old"}))
+		assert.True(t, memoryDriver.Has(ctx, "report"))
+
+		assert.NoError(t, memoryDriver.FlushTags(ctx, []string{"new"}))
+		assert.False(t, memoryDriver.Has(ctx, "report"))
+	})
+
+	t.Run("untagged_set_drops_previous_tags", func(t *testing.T) {
+		memoryDriver := newDriver()
+		defer memoryDriver.Close()
+
+		assert.NoError(t, memoryDriver.SetTagged(ctx, "report", 1, 0, []string{"daily"}))
+		assert.NoError(t, memoryDriver.Set(ctx, "report", 2, 0))
+
+		assert.NoError(t, memoryDriver.FlushTags(ctx, []string{"daily"}))
+		assert.True(t, memoryDriver.Has(ctx, "report"))
+	})
+
+	t.Run("tags_are_released_on_delete_and_flush", func(t *testing.T) {
+		memoryDriver := newDriver()
+		defer memoryDriver.Close()
+
+		assert.NoError(t, memoryDriver.SetTagged(ctx, "a", 1, 0, []string{"group"}))
+		assert.NoError(t, memoryDriver.Delete(ctx, "a"))
+		assert.NoError(t, memoryDriver.Set(ctx, "a", 2, 0))
+
+		assert.NoError(t, memoryDriver.FlushTags(ctx, []string{"group"}))
+		assert.True(t, memoryDriver.Has(ctx, "a"))
+
+		assert.NoError(t, memoryDriver.SetTagged(ctx, "b", 1, 0, []string{"group"}))
+		assert.NoError(t, memoryDriver.Flush(ctx))
+		assert.NoError(t, memoryDriver.Set(ctx, "b", 2, 0))
+
+		assert.NoError(t, memoryDriver.FlushTags(ctx, []string{"group"}))
+		assert.True(t, memoryDriver.Has(ctx, "b"))
+	})
+}
+
+func TestMemoryDriverRememberSingleFlight(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("concurrent_callers_share_one_callback", func(t *testing.T) {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+		defer memoryDriver.Close()
+
+		var calls atomic.Int32
+		callback := func() (interface{}, error) {
+			calls.Add(1)
+			time.Sleep(50 * time.Millisecond)
+			return "fresh", nil
+		}
+
+		var wg sync.WaitGroup
+		results := make([]interface{}, 20)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				value, err := memoryDriver.Remember(ctx, "hot", time.Minute, callback)
+				assert.NoError(t, err)
+				results[i] = value
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), calls.Load())
+		for _, value := range results {
+			assert.Equal(t, "fresh", value)
+		}
+	})
+
+	t.Run("error_is_shared_and_not_cached", func(t *testing.T) {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+		defer memoryDriver.Close()
+
+		var calls atomic.Int32
+		callback := func() (interface{}, error) {
+			calls.Add(1)
+			time.Sleep(50 * time.Millisecond)
+			return nil, fmt.Errorf("database unavailable")
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := memoryDriver.Remember(ctx, "hot", time.Minute, callback)
+				assert.EqualError(t, err, "database unavailable")
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), calls.Load())
+		assert.False(t, memoryDriver.Has(ctx, "hot"))
+	})
+
+	t.Run("panic_releases_waiters", func(t *testing.T) {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+		defer memoryDriver.Close()
+
+		assert.Panics(t, func() {
+			_, _ = memoryDriver.Remember(ctx, "hot", time.Minute, func() (interface{}, error) {
+				panic("boom")
+			})
+		})
+
+		value, err := memoryDriver.Remember(ctx, "hot", time.Minute, func() (interface{}, error) {
+			return "recovered", nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "recovered", value)
+	})
+}
+
+func TestMemoryDriverRememberStale(t *testing.T) {
+	ctx := context.Background()
+
+	memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+	defer memoryDriver.Close()
+
+	var calls atomic.Int32
+	callback := func() (interface{}, error) {
+		n := calls.Add(1)
+		return fmt.Sprintf("v%d", n), nil
+	}
+
+	t.Run("miss_runs_callback", func(t *testing.T) {
+		value, err := memoryDriver.RememberStale(ctx, "report", 50*time.Millisecond, time.Minute, callback)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "v1", value)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("fresh_value_is_returned_without_callback", func(t *testing.T) {
+		value, err := memoryDriver.RememberStale(ctx, "report", 50*time.Millisecond, time.Minute, callback)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "v1", value)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("stale_value_is_returned_and_refreshed_in_background", func(t *testing.T) {
+		time.Sleep(60 * time.Millisecond)
+
+		value, err := memoryDriver.RememberStale(ctx, "report", 50*time.Millisecond, time.Minute, callback)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "v1", value)
+		assert.Eventually(t, func() bool {
+			value, _ := memoryDriver.Get(ctx, "report")
+			return value == "v2"
+		}, time.Second, 5*time.Millisecond)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("value_expires_after_grace", func(t *testing.T) {
+		_, err := memoryDriver.RememberStale(ctx, "grace", 10*time.Millisecond, 10*time.Millisecond, callback)
+		assert.NoError(t, err)
+		time.Sleep(30 * time.Millisecond)
+
+		assert.False(t, memoryDriver.Has(ctx, "grace"))
+	})
+
+	t.Run("background_refresh_error_keeps_stale_value", func(t *testing.T) {
+		_, err := memoryDriver.RememberStale(ctx, "failing", 10*time.Millisecond, time.Minute, func() (interface{}, error) {
+			return "kept", nil
+		})
+		assert.NoError(t, err)
+		time.Sleep(20 * time.Millisecond)
+
+		refreshed := make(chan struct{})
+		value, err := memoryDriver.RememberStale(ctx, "failing", 10*time.Millisecond, time.Minute, func() (interface{}, error) {
+			defer close(refreshed)
+			return nil, fmt.Errorf("database unavailable")
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "kept", value)
+
+		<-refreshed
+		value, found := memoryDriver.Get(ctx, "failing")
+		assert.True(t, found)
+		assert.Equal(t, "kept", value)
+	})
+}
+
+func TestMemoryDriverIncrement(t *testing.T) {
+	ctx := context.Background()
+	memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+	defer memoryDriver.Close()
+
+	t.Run("missing_key_starts_from_zero", func(t *testing.T) {
+		value, err := memoryDriver.Increment(ctx, "views", 5, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), value)
+
+		value, err = memoryDriver.Decrement(ctx, "views", 2, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), value)
+
+		stored, found := memoryDriver.Get(ctx, "views")
+		assert.True(t, found)
+		assert.Equal(t, int64(3), stored)
+	})
+
+	t.Run("existing_numeric_values_are_accepted", func(t *testing.T) {
+		assert.NoError(t, memoryDriver.Set(ctx, "int", 10, time.Minute))
+		value, err := memoryDriver.Increment(ctx, "int", 1, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(11), value)
+
+		// Số thực có giá trị nguyên, ví dụ sau khi giải mã JSON, được coi là số nguyên
+		assert.NoError(t, memoryDriver.Set(ctx, "json", float64(7), time.Minute))
+		value, err = memoryDriver.Increment(ctx, "json", 1, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(8), value)
+
+		price, err := memoryDriver.IncrementFloat(ctx, "int", 0.5, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 11.5, price)
+	})
+
+	t.Run("non_numeric_value_is_rejected", func(t *testing.T) {
+		assert.NoError(t, memoryDriver.Set(ctx, "name", "An", time.Minute))
+		_, err := memoryDriver.Increment(ctx, "name", 1, 0)
+		assert.ErrorIs(t, err, driver.ErrNotNumeric)
+
+		assert.NoError(t, memoryDriver.Set(ctx, "ratio", 1.5, time.Minute))
+		_, err = memoryDriver.Increment(ctx, "ratio", 1, 0)
+		assert.ErrorIs(t, err, driver.ErrNotNumeric)
+
+		value, _ := memoryDriver.Get(ctx, "name")
+		assert.Equal(t, "An", value)
+	})
+
+	t.Run("ttl_applies_only_on_creation", func(t *testing.T) {
+		_, err := memoryDriver.Increment(ctx, "window", 1, 30*time.Millisecond)
+		assert.NoError(t, err)
+		_, err = memoryDriver.Increment(ctx, "window", 1, time.Hour)
+		assert.NoError(t, err)
+
+		time.Sleep(40 * time.Millisecond)
+		assert.False(t, memoryDriver.Has(ctx, "window"))
+
+		// Bộ đếm đã hết hạn bắt đầu lại từ 0
+		value, err := memoryDriver.Increment(ctx, "window", 1, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), value)
+	})
+
+	t.Run("concurrent_increments_are_not_lost", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 20; j++ {
+					_, _ = memoryDriver.Increment(ctx, "hits", 1, 0)
+				}
+			}()
+		}
+		wg.Wait()
+
+		value, err := memoryDriver.Increment(ctx, "hits", 0, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1000), value)
+	})
+}
+
+func TestMemoryDriverConditionalWrites(t *testing.T) {
+	ctx := context.Background()
+	memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+	defer memoryDriver.Close()
+
+	t.Run("add_only_when_absent", func(t *testing.T) {
+		added, err := memoryDriver.Add(ctx, "idem:1", "first", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, added)
+
+		added, err = memoryDriver.Add(ctx, "idem:1", "second", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, added)
+
+		value, _ := memoryDriver.Get(ctx, "idem:1")
+		assert.Equal(t, "first", value)
+
+		// Key đã hết hạn được coi như không tồn tại
+		assert.NoError(t, memoryDriver.Set(ctx, "idem:2", "old", 10*time.Millisecond))
+		time.Sleep(20 * time.Millisecond)
+		added, err = memoryDriver.Add(ctx, "idem:2", "new", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, added)
+	})
+
+	t.Run("replace_only_when_present", func(t *testing.T) {
+		replaced, err := memoryDriver.Replace(ctx, "missing", "value", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, replaced)
+		assert.False(t, memoryDriver.Has(ctx, "missing"))
+
+		assert.NoError(t, memoryDriver.SetTagged(ctx, "config", "v1", time.Minute, []string{"configs"}))
+		replaced, err = memoryDriver.Replace(ctx, "config", "v2", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, replaced)
+
+		// Giống Set, Replace thay cả tag của entry
+		assert.NoError(t, memoryDriver.FlushTags(ctx, []string{"configs"}))
+		value, _ := memoryDriver.Get(ctx, "config")
+		assert.Equal(t, "v2", value)
+	})
+
+	t.Run("get_and_set_returns_previous_value", func(t *testing.T) {
+		old, found, err := memoryDriver.GetAndSet(ctx, "token", "t1", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, found)
+		assert.Nil(t, old)
+
+		old, found, err = memoryDriver.GetAndSet(ctx, "token", "t2", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "t1", old)
+
+		value, _ := memoryDriver.Get(ctx, "token")
+		assert.Equal(t, "t2", value)
+	})
+
+	t.Run("get_and_delete_is_claimed_once", func(t *testing.T) {
+		assert.NoError(t, memoryDriver.Set(ctx, "job", "payload", time.Minute))
+
+		var claimed atomic.Int32
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if value, found, _ := memoryDriver.GetAndDelete(ctx, "job"); found {
+					assert.Equal(t, "payload", value)
+					claimed.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), claimed.Load())
+		assert.False(t, memoryDriver.Has(ctx, "job"))
+	})
+
+	t.Run("max_bytes_is_enforced", func(t *testing.T) {
+		limited := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300, MaxBytes: 64})
+		defer limited.Close()
+
+		_, err := limited.Add(ctx, "big", strings.Repeat("x", 128), time.Minute)
+		assert.ErrorIs(t, err, driver.ErrValueTooLarge)
+	})
+}
+
+func TestMemoryDriverCompareAndSwap(t *testing.T) {
+	ctx := context.Background()
+	memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+	defer memoryDriver.Close()
+
+	_, token, found := memoryDriver.GetWithVersion(ctx, "config")
+	assert.False(t, found)
+	assert.Empty(t, token)
+
+	swapped, err := memoryDriver.CompareAndSwap(ctx, "config", "", "v1", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, swapped)
+
+	assert.NoError(t, memoryDriver.Set(ctx, "config", "v1", time.Minute))
+	value, token, found := memoryDriver.GetWithVersion(ctx, "config")
+	assert.True(t, found)
+	assert.Equal(t, "v1", value)
+	assert.NotEmpty(t, token)
+
+	swapped, err = memoryDriver.CompareAndSwap(ctx, "config", token, "v2", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, swapped)
+
+	// Token cũ không còn hợp lệ sau khi entry đã được ghi
+	swapped, err = memoryDriver.CompareAndSwap(ctx, "config", token, "v3", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, swapped)
+	value, _ = memoryDriver.Get(ctx, "config")
+	assert.Equal(t, "v2", value)
+
+	// Xóa rồi tạo lại key với cùng giá trị vẫn làm thay đổi phiên bản
+	_, token, _ = memoryDriver.GetWithVersion(ctx, "config")
+	assert.NoError(t, memoryDriver.Delete(ctx, "config"))
+	assert.NoError(t, memoryDriver.Set(ctx, "config", "v2", time.Minute))
+	swapped, err = memoryDriver.CompareAndSwap(ctx, "config", token, "v3", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, swapped)
+
+	t.Run("concurrent_swaps_allow_one_winner", func(t *testing.T) {
+		assert.NoError(t, memoryDriver.Set(ctx, "shared", 0, time.Minute))
+		_, token, _ := memoryDriver.GetWithVersion(ctx, "shared")
+
+		var wg sync.WaitGroup
+		var winners atomic.Int32
+		for i := 1; i <= 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				if swapped, _ := memoryDriver.CompareAndSwap(ctx, "shared", token, i, time.Minute); swapped {
+					winners.Add(1)
+				}
+			}(i)
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), winners.Load())
+	})
+}
+
+func TestMemoryDriverTTLAndTouch(t *testing.T) {
+	ctx := context.Background()
+	memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+	defer memoryDriver.Close()
+
+	_, found := memoryDriver.TTL(ctx, "missing")
+	assert.False(t, found)
+	touched, err := memoryDriver.Touch(ctx, "missing", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, touched)
+
+	assert.NoError(t, memoryDriver.Set(ctx, "session", "data", time.Minute))
+	ttl, found := memoryDriver.TTL(ctx, "session")
+	assert.True(t, found)
+	assert.InDelta(t, time.Minute, ttl, float64(time.Second))
+
+	_, token, _ := memoryDriver.GetWithVersion(ctx, "session")
+	touched, err = memoryDriver.Touch(ctx, "session", time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, touched)
+	ttl, _ = memoryDriver.TTL(ctx, "session")
+	assert.InDelta(t, time.Hour, ttl, float64(time.Second))
+
+	// Touch không thay đổi giá trị hay phiên bản của item
+	value, touchedToken, _ := memoryDriver.GetWithVersion(ctx, "session")
+	assert.Equal(t, "data", value)
+	assert.Equal(t, token, touchedToken)
+
+	touched, err = memoryDriver.Touch(ctx, "session", -1)
+	assert.NoError(t, err)
+	assert.True(t, touched)
+	ttl, _ = memoryDriver.TTL(ctx, "session")
+	assert.Equal(t, driver.NoExpiration, ttl)
+
+	// Touch với ttl = 0 dùng TTL mặc định của driver
+	touched, err = memoryDriver.Touch(ctx, "session", 0)
+	assert.NoError(t, err)
+	assert.True(t, touched)
+	ttl, _ = memoryDriver.TTL(ctx, "session")
+	assert.InDelta(t, 300*time.Second, ttl, float64(time.Second))
+
+	assert.NoError(t, memoryDriver.Set(ctx, "short", "data", 10*time.Millisecond))
+	time.Sleep(20 * time.Millisecond)
+	touched, err = memoryDriver.Touch(ctx, "short", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, touched)
+}
+
+func TestMemoryDriverSlidingExpiration(t *testing.T) {
+	ctx := context.Background()
+	memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300, SlidingTTL: 60})
+	defer memoryDriver.Close()
+
+	assert.NoError(t, memoryDriver.Set(ctx, "session", "data", time.Second))
+	assert.NoError(t, memoryDriver.Set(ctx, "long", "data", time.Hour))
+	assert.NoError(t, memoryDriver.Set(ctx, "forever", "data", -1))
+
+	for _, key := range []string{"session", "long", "forever"} {
+		_, found := memoryDriver.Get(ctx, key)
+		assert.True(t, found)
+	}
+
+	ttl, _ := memoryDriver.TTL(ctx, "session")
+	assert.InDelta(t, time.Minute, ttl, float64(time.Second))
+
+	// Sliding expiration không rút ngắn thời hạn dài hơn và không thêm thời hạn cho entry vĩnh viễn
+	ttl, _ = memoryDriver.TTL(ctx, "long")
+	assert.InDelta(t, time.Hour, ttl, float64(time.Second))
+	ttl, _ = memoryDriver.TTL(ctx, "forever")
+	assert.Equal(t, driver.NoExpiration, ttl)
+}
+
+func TestMemoryDriverLock(t *testing.T) {
+	ctx := context.Background()
+	memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+	defer memoryDriver.Close()
+	locker := memoryDriver.(driver.Locker)
+
+	acquired, err := locker.TryLock(ctx, "job", "owner-a", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+	acquired, _ = locker.TryLock(ctx, "job", "owner-b", time.Minute)
+	assert.False(t, acquired)
+
+	// Khóa tách biệt với entry của cache
+	assert.False(t, memoryDriver.Has(ctx, "job"))
+	assert.NoError(t, memoryDriver.Flush(ctx))
+	acquired, _ = locker.TryLock(ctx, "job", "owner-b", time.Minute)
+	assert.False(t, acquired)
+
+	refreshed, _ := locker.RefreshLock(ctx, "job", "owner-b", time.Minute)
+	assert.False(t, refreshed)
+	refreshed, _ = locker.RefreshLock(ctx, "job", "owner-a", 20*time.Millisecond)
+	assert.True(t, refreshed)
+
+	// Khóa hết hạn được giành lại bởi owner khác
+	time.Sleep(40 * time.Millisecond)
+	acquired, _ = locker.TryLock(ctx, "job", "owner-b", -1)
+	assert.True(t, acquired)
+	released, _ := locker.Unlock(ctx, "job", "owner-a")
+	assert.False(t, released)
+	released, _ = locker.Unlock(ctx, "job", "owner-b")
+	assert.True(t, released)
+}
+
+func TestMemoryDriverKeys(t *testing.T) {
+	ctx := context.Background()
+	memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+	defer memoryDriver.Close()
+
+	for _, key := range []string{"user:1", "user:2", "user:10", "post:1", "a*b", "__internal"} {
+		assert.NoError(t, memoryDriver.Set(ctx, key, "v", 0))
+	}
+	assert.NoError(t, memoryDriver.Set(ctx, "user:expired", "v", time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+
+	cases := map[string][]string{
+		"":          {"user:1", "user:2", "user:10", "post:1", "a*b"},
+		"user:*":    {"user:1", "user:2", "user:10"},
+		"user:?":    {"user:1", "user:2"},
+		"user:[12]": {"user:1", "user:2"},
+		"user:[^1]": {"user:2"},
+		"*:1":       {"user:1", "post:1"},
+		`a\*b`:      {"a*b"},
+		"missing*":  {},
+	}
+	for pattern, expected := range cases {
+		keys, err := memoryDriver.Keys(ctx, pattern)
+		assert.NoError(t, err, pattern)
+		assert.ElementsMatch(t, expected, keys, pattern)
+	}
+
+	_, err := memoryDriver.Keys(ctx, "user:[1")
+	assert.Error(t, err)
+
+	// Scan dừng khi fn trả về false và cho phép xóa key trong lúc quét
+	visited := 0
+	err = memoryDriver.Scan(ctx, "user:*", func(key string) bool {
+		visited++
+		assert.NoError(t, memoryDriver.Delete(ctx, key))
+		return visited < 2
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, visited)
+	keys, _ := memoryDriver.Keys(ctx, "user:*")
+	assert.Len(t, keys, 1)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, memoryDriver.Scan(cancelled, "*", func(string) bool { return true }), context.Canceled)
+}
+
+func TestMemoryDriverDeleteMatching(t *testing.T) {
+	ctx := context.Background()
+	memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+	defer memoryDriver.Close()
+
+	for _, key := range []string{"user:42:profile", "user:42:posts", "user:420", "user:43:profile", "user:*", "__user:42:state"} {
+		assert.NoError(t, memoryDriver.Set(ctx, key, "v", 0))
+	}
+
+	deleted, err := memoryDriver.DeleteMatching(ctx, "user:42:*")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	assert.False(t, memoryDriver.Has(ctx, "user:42:profile"))
+	assert.True(t, memoryDriver.Has(ctx, "user:420"))
+
+	// Prefix được hiểu theo nghĩa đen, không phải pattern
+	deleted, err = memoryDriver.DeleteByPrefix(ctx, "user:*")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.True(t, memoryDriver.Has(ctx, "user:43:profile"))
+
+	// Key nội bộ không bị xóa
+	deleted, err = memoryDriver.DeleteMatching(ctx, "*")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	assert.True(t, memoryDriver.Has(ctx, "__user:42:state"))
+
+	_, err = memoryDriver.DeleteMatching(ctx, "[")
+	assert.Error(t, err)
+}
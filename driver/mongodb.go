@@ -2,11 +2,15 @@ package driver
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
+	"go.fork.vn/cache/codec"
 	"go.fork.vn/cache/config"
 	"go.fork.vn/mongodb"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -16,10 +20,37 @@ import (
 // Cấu trúc này lưu trữ dữ liệu cache dưới dạng document trong MongoDB,
 // với các trường cần thiết như key, value, thời gian hết hạn và thời gian tạo.
 type MongoCacheItem struct {
-	Key        string      `bson:"_id"`        // Cache key, sử dụng như primary key
-	Value      interface{} `bson:"value"`      // Giá trị được lưu trong cache
-	Expiration int64       `bson:"expiration"` // Thời điểm hết hạn (UnixNano), 0 nếu không hết hạn
-	CreatedAt  time.Time   `bson:"created_at"` // Thời điểm tạo cache item
+	Key        string      `bson:"_id"`            // Cache key, sử dụng như primary key
+	Value      interface{} `bson:"value"`          // Giá trị được lưu trong cache
+	Expiration int64       `bson:"expiration"`     // Thời điểm hết hạn (UnixNano), 0 nếu không hết hạn
+	CreatedAt  time.Time   `bson:"created_at"`     // Thời điểm tạo cache item
+	Tags       []string    `bson:"tags,omitempty"` // Các tag gắn với cache item
+	// SoftExpiration là thời điểm giá trị hết còn mới (UnixNano), 0 nếu không có
+	SoftExpiration int64 `bson:"soft_expiration,omitempty"`
+	// Version là token phiên bản của document, được tạo mới mỗi lần document được ghi
+	Version string `bson:"version,omitempty"`
+	// Codec là tên codec đã mã hóa Value thành dữ liệu nhị phân, rỗng nếu Value là BSON gốc
+	Codec string `bson:"codec,omitempty"`
+	// Compression là ID thuật toán đã nén dữ liệu của codec, 0 nếu không nén
+	Compression byte `bson:"compression,omitempty"`
+	// EncryptionKey là ID khóa đã mã hóa dữ liệu của codec, rỗng nếu không mã hóa
+	EncryptionKey string `bson:"encryption_key,omitempty"`
+}
+
+// mongoRawItem chứa các trường của document cần để giải mã value thô vào kiểu của caller.
+type mongoRawItem struct {
+	Value         bson.RawValue `bson:"value"`
+	Expiration    int64         `bson:"expiration"`
+	Codec         string        `bson:"codec"`
+	Compression   byte          `bson:"compression"`
+	EncryptionKey string        `bson:"encryption_key"`
+}
+
+// mongoLock là document lưu một khóa phân tán trong MongoDB.
+type mongoLock struct {
+	Name      string     `bson:"_id"`                  // Tên khóa
+	Token     string     `bson:"token"`                // Token định danh chủ sở hữu
+	ExpiresAt *time.Time `bson:"expires_at,omitempty"` // Thời điểm khóa hết hạn, nil nếu không hết hạn
 }
 
 type MongoDBDriver interface {
@@ -39,6 +70,12 @@ type mongoDBDriver struct {
 	config     config.DriverMongodbConfig
 	database   *mongo.Database   // MongoDB database để lưu trữ cache
 	collection *mongo.Collection // MongoDB collection để lưu trữ cache
+	locks      *mongo.Collection // MongoDB collection lưu khóa phân tán
+	flights    flightGroup       // Gộp các lần gọi Remember đồng thời
+	lock       *rememberLock     // Khóa phân tán cho Remember, nil nếu không bật
+	codec      codec.Codec       // Codec mã hóa giá trị, nil để lưu giá trị dưới dạng BSON gốc
+	compressor *valueCompressor  // Nén dữ liệu của codec trước khi ghi
+	encryptor  *valueEncryptor   // Mã hóa dữ liệu của codec trước khi ghi
 }
 
 // NewMongoDBDriver tạo một MongoDB driver mới với cấu hình mặc định.
@@ -52,14 +89,40 @@ type mongoDBDriver struct {
 //
 // Returns:
 //   - *MongoDBDriver: Driver đã được khởi tạo
-//   - error: Lỗi nếu không thể kết nối đến MongoDB hoặc tạo indices
+//   - error: Lỗi nếu codec, thuật toán nén hoặc khóa mã hóa không hợp lệ, hoặc không thể
+//     kết nối đến MongoDB hoặc tạo indices
 func NewMongoDBDriver(cfg config.DriverMongodbConfig, manager mongodb.Manager) (MongoDBDriver, error) {
 	driver := &mongoDBDriver{
 		mongodb:    &manager,
 		config:     cfg,
 		database:   manager.DatabaseWithName(cfg.Database),
 		collection: manager.DatabaseWithName(cfg.Database).Collection(cfg.Collection),
+		locks:      manager.DatabaseWithName(cfg.Database).Collection(cfg.Collection + "_locks"),
+	}
+	driver.lock = newRememberLock(driver, cfg.RememberLock)
+	if cfg.Codec != "" {
+		valueCodec, err := codec.Get(cfg.Codec)
+		if err != nil {
+			return nil, err
+		}
+		driver.codec = valueCodec
+	}
+	compressor, err := newValueCompressor(cfg.Compression)
+	if err != nil {
+		return nil, err
+	}
+	if compressor.enabled() && driver.codec == nil {
+		return nil, fmt.Errorf("mongodb compression requires a codec")
+	}
+	driver.compressor = compressor
+	encryptor, err := newValueEncryptor(cfg.Encryption)
+	if err != nil {
+		return nil, err
+	}
+	if encryptor.enabled() && driver.codec == nil {
+		return nil, fmt.Errorf("mongodb encryption requires a codec")
 	}
+	driver.encryptor = encryptor
 
 	// Tạo indices cần thiết
 	if err := driver.ensureIndexes(context.Background()); err != nil {
@@ -118,7 +181,143 @@ func (d *mongoDBDriver) ensureIndexes(ctx context.Context) error {
 			return err
 		}
 	}
-	return nil
+
+	// Tạo multikey index trên trường tags cho FlushTags
+	tagsIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "tags", Value: 1},
+		},
+		Options: options.Index().
+			SetSparse(true).
+			SetName("cache_tags_index"),
+	}
+
+	_, err = d.collection.Indexes().CreateOne(ctx, tagsIndexModel)
+	if err != nil {
+		return err
+	}
+
+	// Tạo TTL index để MongoDB tự dọn các khóa bị bỏ lại
+	lockIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "expires_at", Value: 1},
+		},
+		Options: options.Index().
+			SetExpireAfterSeconds(0).
+			SetName("cache_lock_expiration_ttl"),
+	}
+
+	_, err = d.locks.Indexes().CreateOne(ctx, lockIndexModel)
+	return err
+}
+
+// TryLock thử giành khóa bằng cách chèn document vào collection khóa.
+//
+// Khóa được lưu trong collection "<collection>_locks" với _id là tên khóa; khóa của
+// Remember dùng tên "remember:<key>". Nếu khóa đã tồn tại nhưng hết hạn, khóa được
+// giành lại bằng một update có điều kiện.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - name: Tên khóa
+//   - owner: Token định danh chủ sở hữu
+//   - ttl: Thời gian giữ khóa tối đa (<= 0 để khóa không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giành được khóa
+//   - error: Lỗi nếu không thể giao tiếp với MongoDB
+func (d *mongoDBDriver) TryLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	_, err := d.locks.InsertOne(ctx, mongoLock{Name: name, Token: owner, ExpiresAt: lockExpiresAt(now, ttl)})
+	if err == nil {
+		return true, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return false, err
+	}
+
+	result, err := d.locks.UpdateOne(ctx,
+		bson.M{"_id": name, "expires_at": bson.M{"$lte": now}},
+		lockExpiresAtUpdate(now, ttl, bson.M{"token": owner}),
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// RefreshLock đặt lại thời gian giữ khóa nếu owner vẫn giữ khóa.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - name: Tên khóa
+//   - owner: Token định danh chủ sở hữu
+//   - ttl: Thời gian giữ khóa mới (<= 0 để khóa không hết hạn)
+//
+// Returns:
+//   - bool: true nếu khóa được gia hạn
+//   - error: Lỗi nếu không thể giao tiếp với MongoDB
+func (d *mongoDBDriver) RefreshLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	result, err := d.locks.UpdateOne(ctx, heldLockFilter(now, name, owner), lockExpiresAtUpdate(now, ttl, bson.M{}))
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount == 1, nil
+}
+
+// Unlock giải phóng khóa nếu owner đang giữ khóa.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - name: Tên khóa
+//   - owner: Token định danh chủ sở hữu
+//
+// Returns:
+//   - bool: true nếu khóa được giải phóng
+//   - error: Lỗi nếu không thể giao tiếp với MongoDB
+func (d *mongoDBDriver) Unlock(ctx context.Context, name, owner string) (bool, error) {
+	result, err := d.locks.DeleteOne(ctx, heldLockFilter(time.Now(), name, owner))
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount == 1, nil
+}
+
+// heldLockFilter tạo filter khớp khóa chưa hết hạn do owner giữ.
+func heldLockFilter(now time.Time, name, owner string) bson.M {
+	return bson.M{
+		"_id":   name,
+		"token": owner,
+		"$or": bson.A{
+			bson.M{"expires_at": bson.M{"$exists": false}},
+			bson.M{"expires_at": bson.M{"$gt": now}},
+		},
+	}
+}
+
+// lockExpiresAt tính thời điểm hết hạn của khóa, nil nếu khóa không hết hạn.
+func lockExpiresAt(now time.Time, ttl time.Duration) *time.Time {
+	if ttl <= 0 {
+		return nil
+	}
+	expiresAt := now.Add(ttl)
+	return &expiresAt
+}
+
+// lockExpiresAtUpdate tạo update đặt các trường trong set cùng thời điểm hết hạn mới
+// của khóa; trường expires_at bị xóa khi khóa không hết hạn.
+func lockExpiresAtUpdate(now time.Time, ttl time.Duration, set bson.M) bson.M {
+	expiresAt := lockExpiresAt(now, ttl)
+	if expiresAt == nil {
+		update := bson.M{"$unset": bson.M{"expires_at": ""}}
+		if len(set) > 0 {
+			update["$set"] = set
+		}
+		return update
+	}
+	set["expires_at"] = *expiresAt
+	return bson.M{"$set": set}
 }
 
 // Get lấy một giá trị từ cache.
@@ -128,6 +327,9 @@ func (d *mongoDBDriver) ensureIndexes(ctx context.Context) error {
 // trả về false ở giá trị thứ hai và cập nhật bộ đếm miss. Nếu tìm thấy và
 // còn hạn, phương thức trả về giá trị và cập nhật bộ đếm hit.
 //
+// Khi sliding expiration được bật, thời điểm hết hạn của document có thời hạn được
+// kéo dài thêm sliding_ttl tính từ lần đọc này.
+//
 // Params:
 //   - ctx: Context để kiểm soát thời gian thực thi của thao tác
 //   - key: Cache key cần tìm
@@ -136,15 +338,59 @@ func (d *mongoDBDriver) ensureIndexes(ctx context.Context) error {
 //   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
 //   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
 func (d *mongoDBDriver) Get(ctx context.Context, key string) (interface{}, bool) {
+	cacheItem, found, _ := d.find(ctx, key)
+	if !found {
+		return nil, false
+	}
+	d.slide(ctx, key, cacheItem.Expiration)
+	return cacheItem.Value, true
+}
+
+// slide kéo dài thời điểm hết hạn của document vừa được đọc trúng khi sliding
+// expiration được bật.
+//
+// Filter chỉ khớp khi thời điểm hết hạn đã lưu sớm hơn thời điểm mới, nên các lần
+// đọc đồng thời không rút ngắn thời hạn của nhau. Lỗi cập nhật được bỏ qua vì lần
+// đọc đã thành công.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key vừa được đọc
+//   - expiration: Thời điểm hết hạn đọc được (UnixNano), 0 nếu không hết hạn
+func (d *mongoDBDriver) slide(ctx context.Context, key string, expiration int64) {
+	extended, ok := slidingExpiration(expiration, d.config.GetSlidingTTL())
+	if !ok {
+		return
+	}
+	_, _ = d.collection.UpdateOne(ctx,
+		bson.M{"_id": key, "expiration": bson.M{"$gt": 0, "$lt": extended}},
+		bson.M{"$set": bson.M{"expiration": extended}},
+	)
+}
+
+// find đọc document còn hạn của key, giải mã giá trị và cập nhật bộ đếm hit/miss.
+//
+// Document không giải mã được bằng các khóa mã hóa đã cấu hình không được tính là hit
+// hay miss mà được đếm trong decryption_failures và trả về lỗi.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm
+//
+// Returns:
+//   - MongoCacheItem: Document đọc được
+//   - bool: true nếu tìm thấy key và chưa hết hạn
+//   - error: Lỗi giải mã nếu giá trị không giải mã được bằng các khóa đã cấu hình
+func (d *mongoDBDriver) find(ctx context.Context, key string) (MongoCacheItem, bool, error) {
 	var cacheItem MongoCacheItem
 	err := d.collection.FindOne(ctx, bson.M{"_id": key}).Decode(&cacheItem)
 
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			d.config.Misses++
-			return nil, false
+			return MongoCacheItem{}, false, nil
 		}
-		return nil, false
+		return MongoCacheItem{}, false, nil
 	}
 
 	// Kiểm tra expiration (TTL index sẽ tự động xóa expired documents,
@@ -152,11 +398,58 @@ func (d *mongoDBDriver) Get(ctx context.Context, key string) (interface{}, bool)
 	if cacheItem.Expiration > 0 && time.Now().UnixNano() > cacheItem.Expiration {
 		d.config.Misses++
 		// TTL index sẽ tự động xóa, không cần xóa thủ công
-		return nil, false
+		return MongoCacheItem{}, false, nil
+	}
+	if err := d.decodeItem(&cacheItem); err != nil {
+		if isDecryptionError(err) {
+			return MongoCacheItem{}, false, err
+		}
+		d.config.Misses++
+		return MongoCacheItem{}, false, nil
 	}
 
 	d.config.Hits++
-	return cacheItem.Value, true
+	return cacheItem, true, nil
+}
+
+// GetInto lấy một giá trị từ cache và giải mã trực tiếp vào target.
+//
+// Trường value của document được giữ dưới dạng BSON thô và được giải mã thẳng
+// vào kiểu của target, thay vì vào các kiểu primitive chung của driver MongoDB. Giá trị
+// được ghi bằng codec được giải mã bằng codec đã ghi nó.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm
+//   - target: Con trỏ tới biến nhận giá trị
+//
+// Returns:
+//   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
+//   - error: Lỗi wrap ErrTypeMismatch nếu không giải mã được, lỗi giải mã nếu giá trị không
+//     giải mã được bằng các khóa mã hóa đã cấu hình, hoặc lỗi từ MongoDB
+func (d *mongoDBDriver) GetInto(ctx context.Context, key string, target interface{}) (bool, error) {
+	var raw mongoRawItem
+	err := d.collection.FindOne(ctx, bson.M{"_id": key}).Decode(&raw)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			d.config.Misses++
+			return false, nil
+		}
+		return false, err
+	}
+
+	if raw.Expiration > 0 && time.Now().UnixNano() > raw.Expiration {
+		d.config.Misses++
+		return false, nil
+	}
+
+	if err := d.unmarshalValue(key, raw, target); err != nil {
+		return true, valueError(err)
+	}
+
+	d.config.Hits++
+	d.slide(ctx, key, raw.Expiration)
+	return true, nil
 }
 
 // Set đặt một giá trị vào cache với TTL tùy chọn.
@@ -174,23 +467,53 @@ func (d *mongoDBDriver) Get(ctx context.Context, key string) (interface{}, bool)
 // Returns:
 //   - error: Lỗi nếu có trong quá trình lưu trữ vào MongoDB
 func (d *mongoDBDriver) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
-	var exp int64
-	now := time.Now()
+	return d.set(ctx, key, value, ttl, 0, nil)
+}
 
-	if ttl == 0 {
-		if d.config.GetDefaultExpiration() > 0 {
-			exp = now.Add(d.config.GetDefaultExpiration()).UnixNano()
-		}
-	} else if ttl > 0 {
-		exp = now.Add(ttl).UnixNano()
-	}
+// SetTagged đặt một giá trị vào cache và gắn các tag cho entry.
+//
+// Các tag được lưu trong trường mảng tags của document, có multikey index để
+// FlushTags xóa các document bằng một truy vấn DeleteMany.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+//   - tags: Danh sách tag gắn với entry
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình lưu trữ vào MongoDB
+func (d *mongoDBDriver) SetTagged(ctx context.Context, key string, value interface{}, ttl time.Duration, tags []string) error {
+	return d.set(ctx, key, value, ttl, 0, tags)
+}
+
+// set tạo hoặc thay thế document của một cache entry.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+//   - grace: Thời gian giá trị cũ tiếp tục được phục vụ sau ttl (0 nếu không có)
+//   - tags: Danh sách tag gắn với entry (nil nếu không có)
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình lưu trữ vào MongoDB
+func (d *mongoDBDriver) set(ctx context.Context, key string, value interface{}, ttl, grace time.Duration, tags []string) error {
+	exp, softExp := staleExpirations(ttl, grace, d.config.GetDefaultExpiration())
 
 	// Tạo cache item
 	cacheItem := MongoCacheItem{
-		Key:        key,
-		Value:      value,
-		Expiration: exp,
-		CreatedAt:  now,
+		Key:            key,
+		Expiration:     exp,
+		CreatedAt:      time.Now(),
+		Tags:           tags,
+		SoftExpiration: softExp,
+		Version:        newMongoVersion(),
+	}
+	if err := d.setValue(&cacheItem, value); err != nil {
+		return err
 	}
 
 	// Nếu có expiration > 0, đặt thời gian hết hạn
@@ -208,6 +531,498 @@ func (d *mongoDBDriver) Set(ctx context.Context, key string, value interface{},
 	return err
 }
 
+// Increment tăng giá trị số nguyên của key bằng một upsert $inc.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (có thể âm)
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - int64: Giá trị sau khi tăng
+//   - error: ErrNotNumeric nếu giá trị hiện tại không phải số nguyên, hoặc lỗi từ MongoDB
+func (d *mongoDBDriver) Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	value, err := d.increment(ctx, key, delta, ttl)
+	if err != nil {
+		return 0, err
+	}
+	result, ok := toInt64(value)
+	if !ok {
+		return 0, ErrNotNumeric
+	}
+	return result, nil
+}
+
+// Decrement giảm giá trị số nguyên của key bằng một upsert $inc với -delta.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần trừ đi
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - int64: Giá trị sau khi giảm
+//   - error: ErrNotNumeric nếu giá trị hiện tại không phải số nguyên, hoặc lỗi từ MongoDB
+func (d *mongoDBDriver) Decrement(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	return d.Increment(ctx, key, -delta, ttl)
+}
+
+// IncrementFloat tăng giá trị số thực của key bằng một upsert $inc.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (có thể âm)
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - float64: Giá trị sau khi tăng
+//   - error: ErrNotNumeric nếu giá trị hiện tại không phải số, hoặc lỗi từ MongoDB
+func (d *mongoDBDriver) IncrementFloat(ctx context.Context, key string, delta float64, ttl time.Duration) (float64, error) {
+	value, err := d.increment(ctx, key, delta, ttl)
+	if err != nil {
+		return 0, err
+	}
+	result, ok := toFloat64(value)
+	if !ok {
+		return 0, ErrNotNumeric
+	}
+	return result, nil
+}
+
+// increment cộng delta vào trường value của document bằng FindOneAndUpdate.
+//
+// Document đã hết hạn nhưng chưa được TTL index dọn được xóa trước, để bộ đếm bắt đầu
+// lại từ 0. Thời điểm hết hạn chỉ được đặt khi document được tạo mới ($setOnInsert).
+// Nếu hai upsert đồng thời cùng tạo document, upsert thua nhận lỗi duplicate key và
+// được thử lại một lần dưới dạng update.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (int64 hoặc float64)
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - interface{}: Giá trị sau khi tăng
+//   - error: ErrNotNumeric nếu giá trị hiện tại không phải số, hoặc lỗi từ MongoDB
+func (d *mongoDBDriver) increment(ctx context.Context, key string, delta interface{}, ttl time.Duration) (interface{}, error) {
+	if err := d.purgeExpired(ctx, key); err != nil {
+		return nil, err
+	}
+
+	exp, _ := staleExpirations(ttl, 0, d.config.GetDefaultExpiration())
+	update := bson.M{
+		"$inc":         bson.M{"value": delta},
+		"$set":         bson.M{"version": newMongoVersion()},
+		"$setOnInsert": bson.M{"expiration": exp, "created_at": time.Now()},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var cacheItem MongoCacheItem
+	err := d.collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, update, opts).Decode(&cacheItem)
+	if mongo.IsDuplicateKeyError(err) {
+		err = d.collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, update, opts).Decode(&cacheItem)
+	}
+	if err != nil {
+		var serverErr mongo.ServerError
+		if errors.As(err, &serverErr) && serverErr.HasErrorCode(mongoTypeMismatchCode) {
+			return nil, fmt.Errorf("%w: %v", ErrNotNumeric, err)
+		}
+		return nil, err
+	}
+	return cacheItem.Value, nil
+}
+
+// mongoTypeMismatchCode là mã lỗi MongoDB khi $inc được áp dụng lên giá trị không phải số.
+const mongoTypeMismatchCode = 14
+
+// purgeExpired xóa document của key nếu đã hết hạn nhưng chưa được TTL index dọn.
+//
+// TTL index của MongoDB chỉ chạy định kỳ, nên các thao tác có điều kiện theo sự tồn tại
+// của key gọi phương thức này trước để document hết hạn không được coi là còn tồn tại.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key
+//
+// Returns:
+//   - error: Lỗi từ MongoDB
+func (d *mongoDBDriver) purgeExpired(ctx context.Context, key string) error {
+	_, err := d.collection.DeleteOne(ctx, bson.M{
+		"_id":        key,
+		"expiration": bson.M{"$gt": 0, "$lt": time.Now().UnixNano()},
+	})
+	return err
+}
+
+// newItem tạo document cho một giá trị sắp được ghi.
+//
+// Params:
+//   - key: Cache key
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - MongoCacheItem: Document đã được tính thời điểm hết hạn
+//   - error: Lỗi nếu codec không mã hóa được giá trị
+func (d *mongoDBDriver) newItem(key string, value interface{}, ttl time.Duration) (MongoCacheItem, error) {
+	exp, _ := staleExpirations(ttl, 0, d.config.GetDefaultExpiration())
+	item := MongoCacheItem{
+		Key:        key,
+		Expiration: exp,
+		CreatedAt:  time.Now(),
+		Version:    newMongoVersion(),
+	}
+	if err := d.setValue(&item, value); err != nil {
+		return MongoCacheItem{}, err
+	}
+	return item, nil
+}
+
+// setValue ghi giá trị vào document, mã hóa bằng codec, nén và mã hóa bằng khóa đang hoạt
+// động nếu được cấu hình.
+//
+// Params:
+//   - item: Document sắp được ghi, đã có Key
+//   - value: Giá trị cần lưu trữ
+//
+// Returns:
+//   - error: Lỗi nếu codec không mã hóa được giá trị hoặc không mã hóa được dữ liệu
+func (d *mongoDBDriver) setValue(item *MongoCacheItem, value interface{}) error {
+	if d.codec == nil {
+		item.Value = value
+		return nil
+	}
+	data, err := d.codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("could not serialize value: %w", err)
+	}
+	data, item.Compression = d.compressor.compress(data)
+	if item.Value, item.EncryptionKey, err = d.encryptor.encrypt(data, item.Key); err != nil {
+		return err
+	}
+	item.Codec = d.codec.Name()
+	return nil
+}
+
+// decodeItem giải mã trường Value của document được ghi bằng codec.
+//
+// Document lưu giá trị dưới dạng BSON gốc (không có trường codec), như bộ đếm của
+// Increment, được giữ nguyên.
+//
+// Params:
+//   - item: Document đọc được
+//
+// Returns:
+//   - error: Lỗi giải mã nếu dữ liệu đã mã hóa không xác thực được, hoặc lỗi nếu codec
+//     chưa được đăng ký, dữ liệu nén bị hỏng hoặc không giải mã được giá trị
+func (d *mongoDBDriver) decodeItem(item *MongoCacheItem) error {
+	if item.Codec == "" {
+		return nil
+	}
+	var data []byte
+	switch v := item.Value.(type) {
+	case primitive.Binary:
+		data = v.Data
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("value encoded with codec '%s' is not binary", item.Codec)
+	}
+
+	data, err := d.encryptor.decrypt(data, item.Key, item.EncryptionKey != "")
+	if err != nil {
+		return err
+	}
+	data, err = decompressValue(data, item.Compression)
+	if err != nil {
+		return err
+	}
+	c, err := codec.Get(item.Codec)
+	if err != nil {
+		return err
+	}
+	var value interface{}
+	if err := c.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	item.Value = value
+	return nil
+}
+
+// unmarshalValue giải mã trường value thô của document vào target.
+//
+// Params:
+//   - key: Cache key của document
+//   - raw: Các trường của document
+//   - target: Con trỏ tới biến nhận giá trị
+//
+// Returns:
+//   - error: Lỗi giải mã nếu dữ liệu đã mã hóa không xác thực được, hoặc lỗi nếu codec
+//     chưa được đăng ký, dữ liệu nén bị hỏng hoặc không giải mã được giá trị
+func (d *mongoDBDriver) unmarshalValue(key string, raw mongoRawItem, target interface{}) error {
+	if raw.Codec == "" {
+		return raw.Value.Unmarshal(target)
+	}
+	_, data, ok := raw.Value.BinaryOK()
+	if !ok {
+		return fmt.Errorf("value encoded with codec '%s' is not binary", raw.Codec)
+	}
+	data, err := d.encryptor.decrypt(data, key, raw.EncryptionKey != "")
+	if err != nil {
+		return err
+	}
+	data, err = decompressValue(data, raw.Compression)
+	if err != nil {
+		return err
+	}
+	c, err := codec.Get(raw.Codec)
+	if err != nil {
+		return err
+	}
+	return c.Unmarshal(data, target)
+}
+
+// newMongoVersion tạo token phiên bản mới cho một document.
+//
+// ObjectID là duy nhất giữa các tiến trình mà không cần đọc phiên bản hiện tại,
+// nên có thể dùng trong các upsert và replace.
+//
+// Returns:
+//   - string: Token phiên bản dạng hex
+func newMongoVersion() string {
+	return primitive.NewObjectID().Hex()
+}
+
+// Add lưu giá trị chỉ khi key chưa tồn tại, bằng InsertOne.
+//
+// Document đã hết hạn được xóa trước; nếu document còn hạn tồn tại, InsertOne thất bại
+// với lỗi duplicate key trên _id.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được lưu, false nếu key đã tồn tại
+//   - error: Lỗi từ MongoDB
+func (d *mongoDBDriver) Add(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	if err := d.purgeExpired(ctx, key); err != nil {
+		return false, err
+	}
+
+	item, err := d.newItem(key, value, ttl)
+	if err != nil {
+		return false, err
+	}
+	_, err = d.collection.InsertOne(ctx, item)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Replace thay document chỉ khi key đang tồn tại và chưa hết hạn.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần thay giá trị
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được thay, false nếu key không tồn tại
+//   - error: Lỗi từ MongoDB
+func (d *mongoDBDriver) Replace(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	item, err := d.newItem(key, value, ttl)
+	if err != nil {
+		return false, err
+	}
+	result, err := d.collection.ReplaceOne(ctx, unexpiredFilter(key), item)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// unexpiredFilter tạo filter khớp document còn hạn của key.
+//
+// Params:
+//   - key: Cache key
+//
+// Returns:
+//   - bson.M: Filter theo _id và thời điểm hết hạn
+func unexpiredFilter(key string) bson.M {
+	return bson.M{
+		"_id": key,
+		"$or": bson.A{
+			bson.M{"expiration": 0},
+			bson.M{"expiration": bson.M{"$gt": time.Now().UnixNano()}},
+		},
+	}
+}
+
+// GetAndSet thay document bằng FindOneAndReplace (upsert) và trả về document cũ.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - interface{}: Giá trị cũ (nil nếu không có)
+//   - bool: true nếu key tồn tại và chưa hết hạn trước đó
+//   - error: Lỗi từ MongoDB
+func (d *mongoDBDriver) GetAndSet(ctx context.Context, key string, value interface{}, ttl time.Duration) (interface{}, bool, error) {
+	opts := options.FindOneAndReplace().SetUpsert(true).SetReturnDocument(options.Before)
+
+	item, err := d.newItem(key, value, ttl)
+	if err != nil {
+		return nil, false, err
+	}
+	var old MongoCacheItem
+	err = d.collection.FindOneAndReplace(ctx, bson.M{"_id": key}, item, opts).Decode(&old)
+	if err == mongo.ErrNoDocuments {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if old.Expiration > 0 && time.Now().UnixNano() > old.Expiration {
+		return nil, false, nil
+	}
+	if err := d.decodeItem(&old); err != nil {
+		return nil, false, err
+	}
+	return old.Value, true, nil
+}
+
+// GetAndDelete lấy và xóa document bằng FindOneAndDelete.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần lấy và xóa
+//
+// Returns:
+//   - interface{}: Giá trị đã xóa (nil nếu không có)
+//   - bool: true nếu key tồn tại và chưa hết hạn
+//   - error: Lỗi từ MongoDB
+func (d *mongoDBDriver) GetAndDelete(ctx context.Context, key string) (interface{}, bool, error) {
+	var old MongoCacheItem
+	err := d.collection.FindOneAndDelete(ctx, bson.M{"_id": key}).Decode(&old)
+	if err == mongo.ErrNoDocuments {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if old.Expiration > 0 && time.Now().UnixNano() > old.Expiration {
+		return nil, false, nil
+	}
+	if err := d.decodeItem(&old); err != nil {
+		return nil, false, err
+	}
+	return old.Value, true, nil
+}
+
+// GetWithVersion lấy giá trị cùng trường version của document.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm
+//
+// Returns:
+//   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
+//   - string: Token phiên bản của document (rỗng nếu không tìm thấy hoặc document được ghi trước khi có version)
+//   - bool: true nếu tìm thấy key và chưa hết hạn
+func (d *mongoDBDriver) GetWithVersion(ctx context.Context, key string) (interface{}, string, bool) {
+	cacheItem, found, _ := d.find(ctx, key)
+	if !found {
+		return nil, "", false
+	}
+	return cacheItem.Value, cacheItem.Version, true
+}
+
+// CompareAndSwap thay document bằng ReplaceOne chỉ khi trường version khớp với token.
+//
+// Token rỗng khớp với document còn hạn được ghi trước khi có trường version.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần ghi
+//   - token: Token phiên bản nhận được từ GetWithVersion
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được ghi, false nếu key không tồn tại hoặc đã bị thay đổi
+//   - error: Lỗi từ MongoDB
+func (d *mongoDBDriver) CompareAndSwap(ctx context.Context, key, token string, value interface{}, ttl time.Duration) (bool, error) {
+	filter := unexpiredFilter(key)
+	if token == "" {
+		filter["version"] = bson.M{"$exists": false}
+	} else {
+		filter["version"] = token
+	}
+
+	item, err := d.newItem(key, value, ttl)
+	if err != nil {
+		return false, err
+	}
+	result, err := d.collection.ReplaceOne(ctx, filter, item)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// TTL trả về thời gian sống còn lại của document.
+//
+// Chỉ trường expiration được đọc và bộ đếm hit/miss không thay đổi.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần kiểm tra
+//
+// Returns:
+//   - time.Duration: Thời gian sống còn lại, NoExpiration nếu document không hết hạn
+//   - bool: true nếu tìm thấy key và chưa hết hạn
+func (d *mongoDBDriver) TTL(ctx context.Context, key string) (time.Duration, bool) {
+	var doc struct {
+		Expiration int64 `bson:"expiration"`
+	}
+	opts := options.FindOne().SetProjection(bson.M{"expiration": 1})
+	if err := d.collection.FindOne(ctx, unexpiredFilter(key), opts).Decode(&doc); err != nil {
+		return 0, false
+	}
+	return remainingTTL(doc.Expiration), true
+}
+
+// Touch đặt lại thời điểm hết hạn của document bằng $set trên trường expiration.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần gia hạn
+//   - ttl: Thời gian sống mới tính từ bây giờ (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu key tồn tại và đã được cập nhật
+//   - error: Lỗi từ MongoDB
+func (d *mongoDBDriver) Touch(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	exp, _ := staleExpirations(ttl, 0, d.config.GetDefaultExpiration())
+	result, err := d.collection.UpdateOne(ctx, unexpiredFilter(key), bson.M{"$set": bson.M{"expiration": exp}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
 // Has kiểm tra xem một key có tồn tại trong cache không.
 //
 // Phương thức này xác định liệu một key có tồn tại trong cache và chưa hết hạn hay không.
@@ -255,6 +1070,127 @@ func (d *mongoDBDriver) Flush(ctx context.Context) error {
 	return err
 }
 
+// FlushTags xóa tất cả các entry mang ít nhất một trong các tag được chỉ định.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - tags: Danh sách tag cần xóa
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình xóa
+func (d *mongoDBDriver) FlushTags(ctx context.Context, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	_, err := d.collection.DeleteMany(ctx, bson.M{"tags": bson.M{"$in": tags}})
+	return err
+}
+
+// Keys trả về các key còn hạn khớp với pattern.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - pattern: Glob pattern của key
+//
+// Returns:
+//   - []string: Các key khớp pattern
+//   - error: Lỗi nếu pattern không hợp lệ hoặc lỗi từ MongoDB
+func (d *mongoDBDriver) Keys(ctx context.Context, pattern string) ([]string, error) {
+	return scanKeys(ctx, d, pattern)
+}
+
+// Scan duyệt lần lượt các key còn hạn khớp với pattern bằng cursor.
+//
+// Pattern được chuyển thành $regex neo ở đầu trên _id; phần tiền tố cố định của pattern
+// (ví dụ "user:" trong "user:*") cho phép MongoDB giới hạn lần quét trên index của _id.
+// Chỉ trường _id được trả về từ server.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - pattern: Glob pattern của key
+//   - fn: Hàm được gọi cho từng key, trả về false để dừng quét
+//
+// Returns:
+//   - error: Lỗi nếu pattern không hợp lệ hoặc lỗi từ MongoDB
+func (d *mongoDBDriver) Scan(ctx context.Context, pattern string, fn func(key string) bool) error {
+	expr, err := patternToRegex(pattern)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{
+		"_id": bson.M{"$regex": primitive.Regex{Pattern: expr, Options: "s"}},
+		"$or": bson.A{
+			bson.M{"expiration": 0},
+			bson.M{"expiration": bson.M{"$gt": time.Now().UnixNano()}},
+		},
+	}
+	cursor, err := d.collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			Key string `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+		if isInternalKey(doc.Key) {
+			continue
+		}
+		if !fn(doc.Key) {
+			return nil
+		}
+	}
+	return cursor.Err()
+}
+
+// DeleteMatching xóa tất cả các entry có key khớp với pattern bằng một lệnh DeleteMany.
+//
+// Filter là $regex neo ở đầu trên _id như Scan, loại trừ các key nội bộ bắt đầu bằng "__".
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - pattern: Glob pattern của key
+//
+// Returns:
+//   - int: Số document đã bị xóa
+//   - error: Lỗi nếu pattern không hợp lệ hoặc lỗi từ MongoDB
+func (d *mongoDBDriver) DeleteMatching(ctx context.Context, pattern string) (int, error) {
+	expr, err := patternToRegex(pattern)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := d.collection.DeleteMany(ctx, bson.M{
+		"_id": bson.M{
+			"$regex": primitive.Regex{Pattern: expr, Options: "s"},
+			"$not":   primitive.Regex{Pattern: "^" + internalKeyPrefix},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(result.DeletedCount), nil
+}
+
+// DeleteByPrefix xóa tất cả các entry có key bắt đầu bằng prefix.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - prefix: Tiền tố của key
+//
+// Returns:
+//   - int: Số document đã bị xóa
+//   - error: Lỗi từ MongoDB
+func (d *mongoDBDriver) DeleteByPrefix(ctx context.Context, prefix string) (int, error) {
+	return d.DeleteMatching(ctx, prefixPattern(prefix))
+}
+
 // GetMultiple lấy nhiều giá trị từ cache.
 //
 // Phương thức này tìm kiếm và trả về nhiều giá trị từ cache dựa trên danh sách key.
@@ -297,6 +1233,9 @@ func (d *mongoDBDriver) GetMultiple(ctx context.Context, keys []string) (map[str
 			missed = append(missed, cacheItem.Key)
 			continue
 		}
+		if err := d.decodeItem(&cacheItem); err != nil {
+			continue
+		}
 
 		results[cacheItem.Key] = cacheItem.Value
 		found[cacheItem.Key] = true
@@ -337,9 +1276,12 @@ func (d *mongoDBDriver) SetMultiple(ctx context.Context, values map[string]inter
 	for key, value := range values {
 		cacheItem := MongoCacheItem{
 			Key:        key,
-			Value:      value,
 			Expiration: exp,
 			CreatedAt:  now,
+			Version:    newMongoVersion(),
+		}
+		if err := d.setValue(&cacheItem, value); err != nil {
+			return err
 		}
 
 		operation := mongo.NewReplaceOneModel().
@@ -382,6 +1324,11 @@ func (d *mongoDBDriver) DeleteMultiple(ctx context.Context, keys []string) error
 // trả về giá trị tương ứng. Nếu key không tồn tại hoặc đã hết hạn, phương thức
 // sẽ gọi hàm callback để lấy dữ liệu, lưu kết quả vào cache và trả về giá trị đó.
 //
+// Các lần gọi đồng thời cho cùng một key trong process được gộp lại: chỉ một
+// goroutine thực thi callback, các goroutine khác chờ và nhận cùng kết quả.
+// Khi remember_lock được bật, khóa phân tán đảm bảo chỉ một instance trong toàn hệ
+// thống thực thi callback; các instance khác chờ giá trị được ghi vào cache.
+//
 // Params:
 //   - ctx: Context để kiểm soát thời gian thực thi của thao tác
 //   - key: Cache key cần tìm hoặc lưu vào cache
@@ -390,23 +1337,65 @@ func (d *mongoDBDriver) DeleteMultiple(ctx context.Context, keys []string) error
 //
 // Returns:
 //   - interface{}: Giá trị từ cache hoặc từ callback
-//   - error: Lỗi nếu có trong quá trình thực hiện hoặc từ callback
+//   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc lỗi giải mã nếu giá
+//     trị không giải mã được bằng các khóa mã hóa đã cấu hình (callback không được gọi)
 func (d *mongoDBDriver) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
 	// Kiểm tra cache trước
-	value, found := d.Get(ctx, key)
+	cacheItem, found, err := d.find(ctx, key)
+	if err != nil {
+		return nil, err
+	}
 	if found {
-		return value, nil
+		d.slide(ctx, key, cacheItem.Expiration)
+		return cacheItem.Value, nil
 	}
 
-	// Không tìm thấy, gọi callback
-	value, err := callback()
-	if err != nil {
-		return nil, err
+	// Không tìm thấy, gọi callback một lần cho mọi goroutine đang chờ key này
+	lookup := func() (interface{}, bool) { return d.Get(ctx, key) }
+	return d.flights.do(key, func() (interface{}, error) {
+		return d.lock.load(ctx, key, lookup, func() (interface{}, error) {
+			value, err := callback()
+			if err != nil {
+				return nil, err
+			}
+
+			// Lưu kết quả vào cache
+			return value, d.Set(ctx, key, value, ttl)
+		})
+	})
+}
+
+// RememberStale lấy một giá trị từ cache theo cơ chế stale-while-revalidate.
+//
+// Khi remember_lock được bật, cả lần lấy giá trị lúc cache miss lẫn lần làm mới
+// trong nền đều được điều phối bằng khóa phân tán.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - ttl: Thời gian giá trị được coi là còn mới (0 để sử dụng mặc định)
+//   - grace: Thời gian giá trị cũ tiếp tục được phục vụ sau ttl
+//   - callback: Hàm được gọi để lấy dữ liệu
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi nếu có trong quá trình thực hiện hoặc từ callback
+func (d *mongoDBDriver) RememberStale(ctx context.Context, key string, ttl, grace time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	return rememberStale(ctx, d, &d.flights, d.lock, key, ttl, grace, callback)
+}
+
+// getStale lấy giá trị cùng thời điểm hết hạn mềm của document.
+func (d *mongoDBDriver) getStale(ctx context.Context, key string) (interface{}, int64, bool, error) {
+	cacheItem, found, err := d.find(ctx, key)
+	if !found {
+		return nil, 0, false, err
 	}
+	return cacheItem.Value, cacheItem.SoftExpiration, true, nil
+}
 
-	// Lưu kết quả vào cache
-	err = d.Set(ctx, key, value, ttl)
-	return value, err
+// setStale lưu giá trị với thời điểm hết hạn mềm sau ttl và hết hạn cứng sau ttl + grace.
+func (d *mongoDBDriver) setStale(ctx context.Context, key string, value interface{}, ttl, grace time.Duration) error {
+	return d.set(ctx, key, value, ttl, grace, nil)
 }
 
 // Stats trả về thông tin thống kê về cache.
@@ -434,13 +1423,16 @@ func (d *mongoDBDriver) Stats(ctx context.Context) map[string]interface{} {
 		stats = bson.M{}
 	}
 
-	return map[string]interface{}{
+	result := map[string]interface{}{
 		"count":  count,
 		"hits":   d.config.Hits,
 		"misses": d.config.Misses,
 		"type":   "mongodb",
 		"stats":  stats,
 	}
+	d.compressor.addStats(result)
+	d.encryptor.addStats(result)
+	return result
 }
 
 // Close giải phóng tài nguyên của driver.
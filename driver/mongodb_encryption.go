@@ -0,0 +1,64 @@
+package driver
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Reencrypt mã hóa lại bằng khóa đang hoạt động mọi document được mã hóa bằng khóa khác.
+//
+// Các document cần mã hóa lại được tìm theo trường encryption_key. Mỗi document được cập
+// nhật bằng UpdateOne với filter theo giá trị đã đọc, nên document bị ghi đè trong lúc
+// mã hóa lại không bị thay bằng giá trị cũ; thời hạn, tag và version được giữ nguyên.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi và dừng thao tác
+//
+// Returns:
+//   - int: Số document đã được mã hóa lại
+//   - error: ErrEncryptionDisabled nếu driver không bật mã hóa, hoặc lỗi từ MongoDB
+func (d *mongoDBDriver) Reencrypt(ctx context.Context) (int, error) {
+	if !d.encryptor.enabled() {
+		return 0, ErrEncryptionDisabled
+	}
+
+	active := d.encryptor.keyring.ActiveKeyID()
+	cursor, err := d.collection.Find(ctx, bson.M{"encryption_key": bson.M{"$exists": true, "$ne": active}})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	count := 0
+	for cursor.Next(ctx) {
+		var item MongoCacheItem
+		if err := cursor.Decode(&item); err != nil {
+			continue
+		}
+		old, ok := item.Value.(primitive.Binary)
+		if !ok {
+			continue
+		}
+
+		plaintext, err := d.encryptor.decrypt(old.Data, item.Key, true)
+		if err != nil {
+			// Document không giải mã được được giữ nguyên để báo lỗi khi đọc
+			continue
+		}
+		sealed, keyID, err := d.encryptor.encrypt(plaintext, item.Key)
+		if err != nil {
+			return count, err
+		}
+		result, err := d.collection.UpdateOne(ctx,
+			bson.M{"_id": item.Key, "value": old},
+			bson.M{"$set": bson.M{"value": sealed, "encryption_key": keyID}},
+		)
+		if err != nil {
+			return count, err
+		}
+		count += int(result.ModifiedCount)
+	}
+	return count, cursor.Err()
+}
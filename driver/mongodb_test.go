@@ -229,6 +229,173 @@ func TestMongoDriverIntegration(t *testing.T) {
 		assert.False(t, callbackCalled) // Callback should not be called
 	})
 
+	t.Run("Increment", func(t *testing.T) {
+		key := "test:counter"
+
+		// Missing key starts from zero
+		value, err := mongoDriver.Increment(ctx, key, 5, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), value)
+
+		value, err = mongoDriver.Decrement(ctx, key, 2, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), value)
+
+		score, err := mongoDriver.IncrementFloat(ctx, "test:score", 1.5, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, 1.5, score)
+
+		// Non-numeric values are rejected
+		assert.NoError(t, mongoDriver.Set(ctx, "test:name", "An", time.Minute))
+		_, err = mongoDriver.Increment(ctx, "test:name", 1, 0)
+		assert.ErrorIs(t, err, driver.ErrNotNumeric)
+	})
+
+	t.Run("Conditional Writes", func(t *testing.T) {
+		key := "test:idempotency"
+
+		added, err := mongoDriver.Add(ctx, key, "first", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, added)
+
+		added, err = mongoDriver.Add(ctx, key, "second", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, added)
+
+		replaced, err := mongoDriver.Replace(ctx, "test:missing", "value", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, replaced)
+
+		replaced, err = mongoDriver.Replace(ctx, key, "replaced", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, replaced)
+
+		old, found, err := mongoDriver.GetAndSet(ctx, key, "swapped", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "replaced", old)
+
+		value, found, err := mongoDriver.GetAndDelete(ctx, key)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "swapped", value)
+		assert.False(t, mongoDriver.Has(ctx, key))
+	})
+
+	t.Run("Compare And Swap", func(t *testing.T) {
+		key := "test:cas"
+		assert.NoError(t, mongoDriver.Set(ctx, key, "v1", time.Minute))
+
+		value, token, found := mongoDriver.GetWithVersion(ctx, key)
+		assert.True(t, found)
+		assert.Equal(t, "v1", value)
+		assert.NotEmpty(t, token)
+
+		swapped, err := mongoDriver.CompareAndSwap(ctx, key, token, "v2", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, swapped)
+
+		swapped, err = mongoDriver.CompareAndSwap(ctx, key, token, "v3", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, swapped)
+
+		value, _ = mongoDriver.Get(ctx, key)
+		assert.Equal(t, "v2", value)
+	})
+
+	t.Run("TTL And Touch", func(t *testing.T) {
+		key := "test:touch"
+		assert.NoError(t, mongoDriver.Set(ctx, key, "data", time.Minute))
+
+		ttl, found := mongoDriver.TTL(ctx, key)
+		assert.True(t, found)
+		assert.InDelta(t, time.Minute, ttl, float64(time.Second))
+
+		touched, err := mongoDriver.Touch(ctx, key, time.Hour)
+		assert.NoError(t, err)
+		assert.True(t, touched)
+		ttl, _ = mongoDriver.TTL(ctx, key)
+		assert.InDelta(t, time.Hour, ttl, float64(time.Second))
+
+		touched, err = mongoDriver.Touch(ctx, key, -1)
+		assert.NoError(t, err)
+		assert.True(t, touched)
+		ttl, _ = mongoDriver.TTL(ctx, key)
+		assert.Equal(t, driver.NoExpiration, ttl)
+
+		touched, err = mongoDriver.Touch(ctx, "test:missing", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, touched)
+	})
+
+	t.Run("Lock", func(t *testing.T) {
+		locker := mongoDriver.(driver.Locker)
+
+		acquired, err := locker.TryLock(ctx, "test:job", "owner-a", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+		acquired, err = locker.TryLock(ctx, "test:job", "owner-b", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, acquired)
+
+		refreshed, err := locker.RefreshLock(ctx, "test:job", "owner-a", 50*time.Millisecond)
+		assert.NoError(t, err)
+		assert.True(t, refreshed)
+
+		// Khóa hết hạn được giành lại bởi owner khác
+		time.Sleep(100 * time.Millisecond)
+		acquired, err = locker.TryLock(ctx, "test:job", "owner-b", -1)
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+
+		released, err := locker.Unlock(ctx, "test:job", "owner-a")
+		assert.NoError(t, err)
+		assert.False(t, released)
+		released, err = locker.Unlock(ctx, "test:job", "owner-b")
+		assert.NoError(t, err)
+		assert.True(t, released)
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		assert.NoError(t, mongoDriver.Set(ctx, "scan:user:1", "v", 0))
+		assert.NoError(t, mongoDriver.Set(ctx, "scan:user:2", "v", 0))
+		assert.NoError(t, mongoDriver.Set(ctx, "scan:post:1", "v", 0))
+		assert.NoError(t, mongoDriver.Set(ctx, "scan:user:expired", "v", time.Millisecond))
+		time.Sleep(5 * time.Millisecond)
+
+		keys, err := mongoDriver.Keys(ctx, "scan:user:*")
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"scan:user:1", "scan:user:2"}, keys)
+
+		keys, err = mongoDriver.Keys(ctx, "scan:*:[1]")
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"scan:user:1", "scan:post:1"}, keys)
+
+		visited := 0
+		err = mongoDriver.Scan(ctx, "scan:*", func(key string) bool {
+			visited++
+			return false
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, visited)
+	})
+
+	t.Run("DeleteMatching", func(t *testing.T) {
+		assert.NoError(t, mongoDriver.Set(ctx, "purge:user:42:profile", "v", 0))
+		assert.NoError(t, mongoDriver.Set(ctx, "purge:user:42:posts", "v", 0))
+		assert.NoError(t, mongoDriver.Set(ctx, "purge:user:43:profile", "v", 0))
+
+		deleted, err := mongoDriver.DeleteByPrefix(ctx, "purge:user:42:")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, deleted)
+		assert.False(t, mongoDriver.Has(ctx, "purge:user:42:profile"))
+		assert.True(t, mongoDriver.Has(ctx, "purge:user:43:profile"))
+
+		deleted, err = mongoDriver.DeleteMatching(ctx, "purge:*")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+	})
+
 	t.Run("Stats", func(t *testing.T) {
 		// Set some test data
 		_ = mongoDriver.Set(ctx, "stats1", "value1", 0)
@@ -0,0 +1,162 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// internalKeyPrefix là tiền tố của các key dành cho dữ liệu nội bộ (index tag, khóa,
+// trạng thái rate limiter). Keys và Scan không trả về các key này.
+const internalKeyPrefix = "__"
+
+// isInternalKey kiểm tra key có phải key nội bộ hay không.
+func isInternalKey(key string) bool {
+	return strings.HasPrefix(key, internalKeyPrefix)
+}
+
+// compilePattern biên dịch một glob pattern kiểu Redis thành regexp.
+//
+// Pattern hỗ trợ "*" (chuỗi bất kỳ), "?" (một ký tự), "[abc]", "[^abc]", "[a-z]" và
+// "\" để thoát ký tự đặc biệt. Pattern rỗng khớp mọi key.
+//
+// Params:
+//   - pattern: Glob pattern
+//
+// Returns:
+//   - *regexp.Regexp: Regexp khớp toàn bộ key
+//   - error: Lỗi nếu pattern không hợp lệ
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	expr, err := patternToRegex(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return regexp.Compile("(?s)" + expr)
+}
+
+// patternToRegex chuyển glob pattern thành biểu thức chính quy neo ở hai đầu.
+//
+// Biểu thức chỉ dùng cú pháp chung của Go regexp và PCRE, nên dùng được cho cả
+// $regex của MongoDB (với option "s"). Tiền tố cố định của pattern được giữ ngay sau
+// "^" để MongoDB dùng được index trên _id.
+//
+// Params:
+//   - pattern: Glob pattern
+//
+// Returns:
+//   - string: Biểu thức chính quy tương đương
+//   - error: Lỗi nếu pattern có "[" không được đóng hoặc kết thúc bằng "\"
+func patternToRegex(pattern string) (string, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch c := runes[i]; c {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '\\':
+			i++
+			if i == len(runes) {
+				return "", fmt.Errorf("invalid pattern '%s': trailing escape", pattern)
+			}
+			b.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case '[':
+			end, class, err := patternClass(runes, i+1)
+			if err != nil {
+				return "", fmt.Errorf("invalid pattern '%s': %w", pattern, err)
+			}
+			b.WriteString(class)
+			i = end
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString(`\z`)
+	return b.String(), nil
+}
+
+// patternClass chuyển một lớp ký tự "[...]" bắt đầu tại start (sau "[") thành regexp.
+//
+// Returns:
+//   - int: Vị trí của "]" đóng lớp
+//   - string: Lớp ký tự của regexp
+//   - error: Lỗi nếu lớp không được đóng hoặc rỗng
+func patternClass(runes []rune, start int) (int, string, error) {
+	var b strings.Builder
+	b.WriteString("[")
+	i := start
+	if i < len(runes) && runes[i] == '^' {
+		b.WriteString("^")
+		i++
+	}
+	first := i
+	for ; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == ']' && i > first:
+			b.WriteString("]")
+			return i, b.String(), nil
+		case c == '\\' && i+1 < len(runes):
+			i++
+			b.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case c == '-' && i > first && i+1 < len(runes) && runes[i+1] != ']':
+			b.WriteString("-")
+		case c == '-':
+			b.WriteString(`\-`)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return 0, "", fmt.Errorf("unterminated character class")
+}
+
+// prefixPattern trả về glob pattern khớp mọi key bắt đầu bằng prefix.
+func prefixPattern(prefix string) string {
+	return escapePattern(prefix) + "*"
+}
+
+// escapePattern thoát các ký tự đặc biệt của glob trong s để s chỉ khớp chính nó.
+func escapePattern(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		switch c {
+		case '*', '?', '[', ']', '\\':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// scanKeys thu thập các key do Scan trả về, bỏ qua key trùng lặp.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - d: Driver cần quét
+//   - pattern: Glob pattern của key
+//
+// Returns:
+//   - []string: Các key khớp pattern
+//   - error: Lỗi từ Scan
+func scanKeys(ctx context.Context, d Driver, pattern string) ([]string, error) {
+	keys := make([]string, 0)
+	seen := make(map[string]struct{})
+	err := d.Scan(ctx, pattern, func(key string) bool {
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			keys = append(keys, key)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
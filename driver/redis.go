@@ -1,15 +1,19 @@
 package driver
 
 import (
-	"bytes"
 	"context"
-	"encoding/gob"
+	"crypto/sha1"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
-	"github.com/vmihailenco/msgpack/v5"
+	"go.fork.vn/cache/codec"
+	"go.fork.vn/cache/compression"
 	"go.fork.vn/cache/config"
 	redisManager "go.fork.vn/redis"
 )
@@ -17,6 +21,31 @@ import (
 type RedisDriver interface {
 	Driver
 	WithSerializer(serializer string) RedisDriver
+
+	// Invalidation trả về invalidation bus của driver.
+	//
+	// Returns:
+	//   - InvalidationBus: nil nếu invalidation không được bật
+	Invalidation() InvalidationBus
+}
+
+// ScriptRunner là interface tùy chọn cho các driver thực thi được Redis Lua script trên
+// các key của cache.
+//
+// Cho phép các package xây dựng trên driver (như ratelimit) thực hiện thao tác đọc-sửa-ghi
+// nguyên tử trong một round trip. Redis driver cài đặt interface này.
+type ScriptRunner interface {
+	// RunScript thực thi script với các key đã được thêm prefix của driver.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - script: Lua script cần thực thi
+	//   - keys: Các cache key truyền vào KEYS của script
+	//   - args: Các tham số truyền vào ARGV của script
+	//
+	// Returns:
+	//   - *redis.Cmd: Kết quả của script
+	RunScript(ctx context.Context, script *redis.Script, keys []string, args ...interface{}) *redis.Cmd
 }
 
 // redisDriver cài đặt cache driver sử dụng Redis.
@@ -26,13 +55,18 @@ type RedisDriver interface {
 // khả năng mở rộng, phân tán cache giữa nhiều instance ứng dụng và khả năng phục hồi
 // sau khi khởi động lại. Nó cũng tận dụng các tính năng của Redis như key expiration.
 type redisDriver struct {
-	client       *redis.Client                     // Redis client để giao tiếp với Redis server
-	prefix       string                            // Tiền tố cho các key cache để tránh xung đột
-	default_ttl  time.Duration                     // Thời gian sống mặc định cho các entry không chỉ định TTL
-	serializer   func(interface{}) ([]byte, error) // Hàm serialization để chuyển đổi giá trị thành dạng binary
-	deserializer func([]byte, interface{}) error   // Hàm deserialization để chuyển đổi từ binary
-	hits         int64                             // Số lần cache hit
-	misses       int64                             // Số lần cache miss
+	client       *redis.Client    // Redis client để giao tiếp với Redis server
+	prefix       string           // Tiền tố cho các key cache để tránh xung đột
+	default_ttl  time.Duration    // Thời gian sống mặc định cho các entry không chỉ định TTL
+	codec        codec.Codec      // Codec mã hóa giá trị của các entry được ghi
+	compressor   *valueCompressor // Nén giá trị trước khi ghi vào Redis
+	encryptor    *valueEncryptor  // Mã hóa giá trị trước khi ghi vào Redis
+	hits         int64            // Số lần cache hit
+	misses       int64            // Số lần cache miss
+	flights      flightGroup      // Gộp các lần gọi Remember đồng thời
+	rememberLock *rememberLock    // Khóa phân tán cho Remember, nil nếu không bật
+	invalidation InvalidationBus  // Invalidation bus, nil nếu không bật
+	slidingTTL   time.Duration    // Thời gian gia hạn sau mỗi lần Get trúng (0 = tắt)
 }
 
 // NewRedisDriver tạo một Redis driver mới với cấu hình mặc định.
@@ -58,56 +92,38 @@ func NewRedisDriver(config config.DriverRedisConfig, redis_manager redisManager.
 		return nil, fmt.Errorf("redis manager cannot be nil")
 	}
 
+	compressor, err := newValueCompressor(config.Compression)
+	if err != nil {
+		return nil, err
+	}
+	encryptor, err := newValueEncryptor(config.Encryption)
+	if err != nil {
+		return nil, err
+	}
+
 	client, err := redis_manager.Client()
 	if err != nil {
 		return nil, fmt.Errorf("could not create Redis client: %w", err)
 	}
 	// Khởi tạo driver
 	driver := &redisDriver{
-		client:       client,
-		prefix:       "cache:", // Tiền tố mặc định
-		default_ttl:  time.Duration(config.DefaultTTL) * time.Second,
-		serializer:   json.Marshal,
-		deserializer: json.Unmarshal,
-		hits:         0,
-		misses:       0,
-	}
-	switch config.Serializer {
-
-	case "gob":
-		driver.serializer = func(v interface{}) ([]byte, error) {
-			var buf bytes.Buffer
-			enc := gob.NewEncoder(&buf)
-			if err := enc.Encode(v); err != nil {
-				return nil, fmt.Errorf("could not serialize value: %w", err)
-			}
-			return buf.Bytes(), nil
-		}
-		driver.deserializer = func(data []byte, v interface{}) error {
-			buf := bytes.NewBuffer(data)
-			dec := gob.NewDecoder(buf)
-			if err := dec.Decode(v); err != nil {
-				return fmt.Errorf("could not deserialize value: %w", err)
-			}
-			return nil
-		}
-	case "msgpack":
-		driver.serializer = func(v interface{}) ([]byte, error) {
-			data, err := msgpack.Marshal(v)
-			if err != nil {
-				return nil, fmt.Errorf("could not serialize value: %w", err)
-			}
-			return data, nil
-		}
-		driver.deserializer = func(data []byte, v interface{}) error {
-			if err := msgpack.Unmarshal(data, v); err != nil {
-				return fmt.Errorf("could not deserialize value: %w", err)
-			}
-			return nil
+		client:      client,
+		prefix:      "cache:", // Tiền tố mặc định
+		default_ttl: time.Duration(config.DefaultTTL) * time.Second,
+		codec:       redisCodec(config.Serializer),
+		compressor:  compressor,
+		encryptor:   encryptor,
+		hits:        0,
+		misses:      0,
+		slidingTTL:  config.GetSlidingTTL(),
+	}
+	driver.rememberLock = newRememberLock(driver, config.RememberLock)
+	if config.Invalidation.Enabled {
+		bus, err := NewInvalidationBus(client, config.Invalidation)
+		if err != nil {
+			return nil, err
 		}
-	default:
-		driver.serializer = json.Marshal
-		driver.deserializer = json.Unmarshal
+		driver.invalidation = bus
 	}
 	return driver, nil
 }
@@ -126,43 +142,232 @@ func (d *redisDriver) prefixKey(key string) string {
 	return d.prefix + key
 }
 
+// tagKey trả về Redis key của sorted set chứa các thành viên của một tag.
+//
+// Các key bắt đầu bằng "__" sau prefix được dành riêng cho dữ liệu nội bộ của driver.
+//
+// Params:
+//   - tag: Tên tag
+//
+// Returns:
+//   - string: Redis key của tag
+func (d *redisDriver) tagKey(tag string) string {
+	return d.prefix + "__tag:" + tag
+}
+
 // Get lấy một giá trị từ cache.
 func (d *redisDriver) Get(ctx context.Context, key string) (interface{}, bool) {
+	value, _, found, _ := d.get(ctx, key)
+	return value, found
+}
+
+// getStale lấy giá trị cùng thời điểm hết hạn mềm của entry.
+func (d *redisDriver) getStale(ctx context.Context, key string) (interface{}, int64, bool, error) {
+	return d.get(ctx, key)
+}
+
+// get đọc và giải mã giá trị của key, cập nhật bộ đếm hit/miss.
+//
+// Giá trị không giải mã được bằng codec được tính là miss. Giá trị không giải mã được
+// bằng các khóa mã hóa đã cấu hình không được tính là hit hay miss mà được đếm trong
+// decryption_failures và trả về lỗi.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm
+//
+// Returns:
+//   - interface{}: Giá trị đã giải mã
+//   - int64: Thời điểm hết hạn mềm (UnixNano), 0 nếu không có
+//   - bool: true nếu tìm thấy key và giải mã được giá trị
+//   - error: Lỗi giải mã nếu giá trị không giải mã được bằng các khóa đã cấu hình
+func (d *redisDriver) get(ctx context.Context, key string) (interface{}, int64, bool, error) {
 	prefixedKey := d.prefixKey(key)
 
 	// Lấy giá trị từ Redis
-	data, err := d.client.Get(ctx, prefixedKey).Bytes()
+	data, err := d.read(ctx, prefixedKey)
 	if err != nil {
 		if err == redis.Nil {
 			// Key không tồn tại
 			d.misses++
-			return nil, false
+			return nil, 0, false, nil
 		}
 		// Lỗi khác
-		return nil, false
+		return nil, 0, false, nil
+	}
+	value, softExpiration, err := d.decode(key, data)
+	if err != nil {
+		if isDecryptionError(err) {
+			return nil, 0, false, err
+		}
+		d.misses++
+		return nil, 0, false, nil
 	}
-	// Giải mã dữ liệu - cần xử lý khác nhau tùy theo serializer
+
+	d.hits++
+	return value, softExpiration, true, nil
+}
+
+// redisSlidingGetScript đọc giá trị và kéo dài TTL của key có thời hạn lên ARGV[1]
+// milliseconds nếu TTL còn lại ngắn hơn, trong cùng một thao tác nguyên tử.
+var redisSlidingGetScript = redis.NewScript(`
+local value = redis.call("GET", KEYS[1])
+if value then
+	local ttl = redis.call("PTTL", KEYS[1])
+	if ttl > 0 and ttl < tonumber(ARGV[1]) then
+		redis.call("PEXPIRE", KEYS[1], ARGV[1])
+	end
+end
+return value
+`)
+
+// read đọc dữ liệu thô của key, kéo dài TTL nếu sliding expiration được bật.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - prefixedKey: Key đã có tiền tố
+//
+// Returns:
+//   - []byte: Dữ liệu đọc được
+//   - error: redis.Nil nếu key không tồn tại, hoặc lỗi từ Redis
+func (d *redisDriver) read(ctx context.Context, prefixedKey string) ([]byte, error) {
+	if d.slidingTTL <= 0 {
+		return d.client.Get(ctx, prefixedKey).Bytes()
+	}
+	data, err := redisSlidingGetScript.Run(ctx, d.client, []string{prefixedKey}, d.slidingTTL.Milliseconds()).Text()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(data), nil
+}
+
+// decode giải mã dữ liệu đọc từ Redis, có thể nằm trong envelope.
+//
+// Params:
+//   - key: Cache key của dữ liệu
+//   - data: Dữ liệu đọc từ Redis
+//
+// Returns:
+//   - interface{}: Giá trị đã giải mã
+//   - int64: Thời điểm hết hạn mềm (UnixNano), 0 nếu không có
+//   - error: Lỗi nếu không giải mã được
+func (d *redisDriver) decode(key string, data []byte) (interface{}, int64, error) {
 	var value interface{}
+	softExpiration, err := d.unmarshal(key, data, &value)
+	if err != nil {
+		return nil, 0, err
+	}
+	return value, softExpiration, nil
+}
 
-	// For GOB and MSGPACK, we need to decode differently
-	if d.deserializer != nil {
-		// Create a buffer to hold the decoded value
-		var decodedValue interface{}
-		if err := d.deserializer(data, &decodedValue); err != nil {
-			d.misses++
-			return nil, false
+// unmarshal giải mã dữ liệu đọc từ Redis vào target bằng codec đã ghi dữ liệu.
+//
+// Dữ liệu không mang tên codec được ghi bởi codec json, hoặc bởi codec đang được cấu
+// hình trước khi tên codec được lưu cùng giá trị; dữ liệu là JSON hợp lệ được giải mã
+// bằng json, còn lại bằng codec đang được cấu hình.
+//
+// Params:
+//   - key: Cache key của dữ liệu, được xác thực cùng dữ liệu đã mã hóa
+//   - data: Dữ liệu đọc từ Redis
+//   - target: Con trỏ tới biến nhận giá trị
+//
+// Returns:
+//   - int64: Thời điểm hết hạn mềm (UnixNano), 0 nếu không có
+//   - error: Lỗi giải mã nếu dữ liệu đã mã hóa không xác thực được, hoặc lỗi nếu codec
+//     chưa được đăng ký, dữ liệu nén bị hỏng hoặc không giải mã được
+func (d *redisDriver) unmarshal(key string, data []byte, target interface{}) (int64, error) {
+	payload, env := decodeRedisEnvelope(data)
+	payload, err := d.encryptor.decrypt(payload, key, env.encrypted)
+	if err != nil {
+		return 0, err
+	}
+	payload, err = decompressValue(payload, env.compression)
+	if err != nil {
+		return 0, err
+	}
+
+	c := d.codec
+	if env.codec != "" {
+		if c, err = codec.Get(env.codec); err != nil {
+			return 0, err
+		}
+	} else if c.Name() != codec.JSON && json.Valid(payload) {
+		c = redisCodec(codec.JSON)
+	}
+
+	if err := c.Unmarshal(payload, target); err != nil {
+		return 0, fmt.Errorf("could not deserialize value: %w", err)
+	}
+	return env.softExpiration, nil
+}
+
+// encode mã hóa giá trị bằng codec đã cấu hình, nén dữ liệu nếu bật compression và mã
+// hóa dữ liệu nếu bật encryption.
+//
+// Giá trị được bọc trong envelope mang tên codec, trừ khi codec là json, dữ liệu không
+// được nén, không được mã hóa và không có thời điểm hết hạn mềm.
+//
+// Params:
+//   - key: Cache key của giá trị, được xác thực cùng dữ liệu đã mã hóa
+//   - value: Giá trị cần mã hóa
+//   - softExpiration: Thời điểm hết hạn mềm (UnixNano), 0 nếu không có
+//
+// Returns:
+//   - []byte: Dữ liệu cần ghi vào Redis
+//   - error: Lỗi nếu không mã hóa được giá trị
+func (d *redisDriver) encode(key string, value interface{}, softExpiration int64) ([]byte, error) {
+	data, err := d.codec.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	env := redisEnvelope{softExpiration: softExpiration, codec: d.codec.Name()}
+	data, env.compression = d.compressor.compress(data)
+	if d.encryptor.enabled() {
+		if data, _, err = d.encryptor.encrypt(data, key); err != nil {
+			return nil, err
 		}
-		value = decodedValue
-	} else {
-		// Fallback to JSON
-		if err := json.Unmarshal(data, &value); err != nil {
+		env.encrypted = true
+	}
+	if env.codec == codec.JSON {
+		if softExpiration == 0 && env.compression == compression.IDNone && !env.encrypted {
+			return data, nil
+		}
+		env.codec = ""
+	}
+	return encodeRedisEnvelope(data, env), nil
+}
+
+// GetInto lấy một giá trị từ cache và giải mã trực tiếp vào target.
+//
+// Khác với Get (giải mã vào interface{}, ví dụ JSON object thành map[string]interface{}),
+// phương thức này dùng codec đã ghi giá trị để giải mã thẳng vào kiểu của target.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm
+//   - target: Con trỏ tới biến nhận giá trị
+//
+// Returns:
+//   - bool: true nếu tìm thấy key, false nếu ngược lại
+//   - error: Lỗi wrap ErrTypeMismatch nếu không giải mã được, lỗi giải mã nếu giá trị không
+//     giải mã được bằng các khóa mã hóa đã cấu hình, hoặc lỗi từ Redis
+func (d *redisDriver) GetInto(ctx context.Context, key string, target interface{}) (bool, error) {
+	data, err := d.read(ctx, d.prefixKey(key))
+	if err != nil {
+		if err == redis.Nil {
 			d.misses++
-			return nil, false
+			return false, nil
 		}
+		return false, err
+	}
+
+	if _, err := d.unmarshal(key, data, target); err != nil {
+		return true, valueError(err)
 	}
 
 	d.hits++
-	return value, true
+	return true, nil
 }
 
 // Set đặt một giá trị vào cache với TTL tùy chọn.
@@ -182,7 +387,7 @@ func (d *redisDriver) Set(ctx context.Context, key string, value interface{}, tt
 	prefixedKey := d.prefixKey(key)
 
 	// Mã hóa dữ liệu
-	data, err := d.serializer(value)
+	data, err := d.encode(key, value, 0)
 	if err != nil {
 		return fmt.Errorf("could not serialize value: %w", err)
 	}
@@ -193,7 +398,510 @@ func (d *redisDriver) Set(ctx context.Context, key string, value interface{}, tt
 	}
 
 	// Lưu vào Redis
-	return d.client.Set(ctx, prefixedKey, data, ttl).Err()
+	if err := d.client.Set(ctx, prefixedKey, data, ttl).Err(); err != nil {
+		return err
+	}
+	return d.invalidate(ctx, InvalidationEvent{Keys: []string{key}})
+}
+
+// expiration chuyển ttl của caller thành thời gian hết hạn truyền cho lệnh SET.
+//
+// Params:
+//   - ttl: Thời gian sống (0 để sử dụng mặc định, âm để không hết hạn)
+//
+// Returns:
+//   - time.Duration: Thời gian hết hạn, 0 nếu không hết hạn
+func (d *redisDriver) expiration(ttl time.Duration) time.Duration {
+	if ttl == 0 {
+		ttl = d.default_ttl
+	}
+	if ttl < 0 {
+		return 0
+	}
+	return ttl
+}
+
+// Add lưu giá trị chỉ khi key chưa tồn tại, bằng SET NX.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được lưu, false nếu key đã tồn tại
+//   - error: Lỗi nếu có trong quá trình mã hóa hoặc lưu trữ
+func (d *redisDriver) Add(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	data, err := d.encode(key, value, 0)
+	if err != nil {
+		return false, fmt.Errorf("could not serialize value: %w", err)
+	}
+
+	added, err := d.client.SetNX(ctx, d.prefixKey(key), data, d.expiration(ttl)).Result()
+	if err != nil || !added {
+		return false, err
+	}
+	return true, d.invalidate(ctx, InvalidationEvent{Keys: []string{key}})
+}
+
+// Replace thay giá trị chỉ khi key đang tồn tại, bằng SET XX.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần thay giá trị
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được thay, false nếu key không tồn tại
+//   - error: Lỗi nếu có trong quá trình mã hóa hoặc lưu trữ
+func (d *redisDriver) Replace(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	data, err := d.encode(key, value, 0)
+	if err != nil {
+		return false, fmt.Errorf("could not serialize value: %w", err)
+	}
+
+	replaced, err := d.client.SetXX(ctx, d.prefixKey(key), data, d.expiration(ttl)).Result()
+	if err != nil || !replaced {
+		return false, err
+	}
+	return true, d.invalidate(ctx, InvalidationEvent{Keys: []string{key}})
+}
+
+// GetAndSet lưu giá trị mới và trả về giá trị cũ bằng SET ... GET.
+//
+// Khác với GETSET, SET ... GET (Redis 6.2+) đặt TTL của giá trị mới trong cùng lệnh.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - interface{}: Giá trị cũ (nil nếu không có)
+//   - bool: true nếu key tồn tại trước đó
+//   - error: Lỗi nếu có trong quá trình mã hóa, lưu trữ hoặc giải mã giá trị cũ
+func (d *redisDriver) GetAndSet(ctx context.Context, key string, value interface{}, ttl time.Duration) (interface{}, bool, error) {
+	data, err := d.encode(key, value, 0)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not serialize value: %w", err)
+	}
+
+	args := redis.SetArgs{TTL: d.expiration(ttl), Get: true}
+	old, err := d.client.SetArgs(ctx, d.prefixKey(key), data, args).Result()
+	existed := err == nil
+	if err != nil && err != redis.Nil {
+		return nil, false, err
+	}
+	if err := d.invalidate(ctx, InvalidationEvent{Keys: []string{key}}); err != nil {
+		return nil, false, err
+	}
+	if !existed {
+		return nil, false, nil
+	}
+
+	previous, _, err := d.decode(key, []byte(old))
+	if err != nil {
+		return nil, true, valueError(err)
+	}
+	return previous, true, nil
+}
+
+// GetAndDelete lấy giá trị và xóa key bằng GETDEL.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần lấy và xóa
+//
+// Returns:
+//   - interface{}: Giá trị đã xóa (nil nếu không có)
+//   - bool: true nếu key tồn tại
+//   - error: Lỗi từ Redis, lỗi giải mã nếu giá trị không giải mã được bằng các khóa mã hóa
+//     đã cấu hình, hoặc lỗi wrap ErrTypeMismatch nếu không giải mã được giá trị
+func (d *redisDriver) GetAndDelete(ctx context.Context, key string) (interface{}, bool, error) {
+	data, err := d.client.GetDel(ctx, d.prefixKey(key)).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if err := d.invalidate(ctx, InvalidationEvent{Keys: []string{key}}); err != nil {
+		return nil, false, err
+	}
+
+	value, _, err := d.decode(key, data)
+	if err != nil {
+		return nil, true, valueError(err)
+	}
+	return value, true, nil
+}
+
+// GetWithVersion lấy giá trị cùng token phiên bản của entry.
+//
+// Redis không lưu phiên bản riêng cho mỗi key; token là SHA-1 của dữ liệu đã lưu, nên
+// mọi lần ghi làm thay đổi dữ liệu đều làm thay đổi token. Ghi lại đúng dữ liệu cũ
+// không được coi là thay đổi.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm
+//
+// Returns:
+//   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
+//   - string: Token phiên bản của entry (rỗng nếu không tìm thấy)
+//   - bool: true nếu tìm thấy key
+func (d *redisDriver) GetWithVersion(ctx context.Context, key string) (interface{}, string, bool) {
+	data, err := d.client.Get(ctx, d.prefixKey(key)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			d.misses++
+		}
+		return nil, "", false
+	}
+	value, _, err := d.decode(key, data)
+	if err != nil {
+		if !isDecryptionError(err) {
+			d.misses++
+		}
+		return nil, "", false
+	}
+
+	d.hits++
+	sum := sha1.Sum(data)
+	return value, hex.EncodeToString(sum[:]), true
+}
+
+// redisCompareAndSwapScript chỉ ghi giá trị mới khi SHA-1 của dữ liệu hiện tại khớp
+// với token, trong cùng một thao tác nguyên tử trên Redis.
+var redisCompareAndSwapScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if not current or redis.sha1hex(current) ~= ARGV[1] then
+	return 0
+end
+if tonumber(ARGV[3]) > 0 then
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+else
+	redis.call("SET", KEYS[1], ARGV[2])
+end
+return 1
+`)
+
+// CompareAndSwap ghi giá trị mới chỉ khi dữ liệu của key chưa thay đổi kể từ lần đọc trả về token.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần ghi
+//   - token: Token phiên bản nhận được từ GetWithVersion
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được ghi, false nếu key không tồn tại hoặc đã bị thay đổi
+//   - error: Lỗi nếu có trong quá trình mã hóa hoặc lưu trữ
+func (d *redisDriver) CompareAndSwap(ctx context.Context, key, token string, value interface{}, ttl time.Duration) (bool, error) {
+	data, err := d.encode(key, value, 0)
+	if err != nil {
+		return false, fmt.Errorf("could not serialize value: %w", err)
+	}
+
+	keys := []string{d.prefixKey(key)}
+	swapped, err := redisCompareAndSwapScript.Run(ctx, d.client, keys, token, data, d.expiration(ttl).Milliseconds()).Bool()
+	if err != nil || !swapped {
+		return false, err
+	}
+	return true, d.invalidate(ctx, InvalidationEvent{Keys: []string{key}})
+}
+
+// TTL trả về thời gian sống còn lại của key bằng PTTL.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần kiểm tra
+//
+// Returns:
+//   - time.Duration: Thời gian sống còn lại, NoExpiration nếu key không hết hạn
+//   - bool: true nếu key tồn tại
+func (d *redisDriver) TTL(ctx context.Context, key string) (time.Duration, bool) {
+	ttl, err := d.client.PTTL(ctx, d.prefixKey(key)).Result()
+	if err != nil {
+		return 0, false
+	}
+	// PTTL trả về -2 nếu key không tồn tại và -1 nếu key không có thời hạn
+	switch ttl {
+	case -2:
+		return 0, false
+	case -1:
+		return NoExpiration, true
+	}
+	return ttl, true
+}
+
+// redisTouchScript đặt TTL mới cho key đang tồn tại: PEXPIRE khi ARGV[1] > 0,
+// ngược lại PERSIST để bỏ thời hạn.
+var redisTouchScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+if tonumber(ARGV[1]) > 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+else
+	redis.call("PERSIST", KEYS[1])
+end
+return 1
+`)
+
+// Touch đặt lại TTL của key bằng PEXPIRE (hoặc PERSIST) mà không ghi lại giá trị.
+//
+// Index tag của key không được cập nhật, nên entry được gia hạn quá thời hạn ban đầu
+// có thể bị bỏ sót bởi FlushTags sau khi index tag được dọn.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần gia hạn
+//   - ttl: Thời gian sống mới tính từ bây giờ (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu key tồn tại và đã được cập nhật
+//   - error: Lỗi từ Redis
+func (d *redisDriver) Touch(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	keys := []string{d.prefixKey(key)}
+	return redisTouchScript.Run(ctx, d.client, keys, d.expiration(ttl).Milliseconds()).Bool()
+}
+
+// redisIncrementScript tăng bộ đếm và đặt TTL nếu key vừa được tạo,
+// trong cùng một thao tác nguyên tử trên Redis.
+var redisIncrementScript = redis.NewScript(`
+local created = redis.call("EXISTS", KEYS[1]) == 0
+local value = redis.call(ARGV[1], KEYS[1], ARGV[2])
+if created and tonumber(ARGV[3]) > 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[3])
+end
+return value
+`)
+
+// Increment tăng giá trị số nguyên của key bằng INCRBY.
+//
+// Bộ đếm được lưu dưới dạng số nguyên của Redis, không qua serializer. Với serializer
+// json, giá trị này vẫn đọc được bằng Get.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (có thể âm)
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - int64: Giá trị sau khi tăng
+//   - error: ErrNotNumeric nếu giá trị hiện tại không phải số nguyên, hoặc lỗi từ Redis
+func (d *redisDriver) Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	result, err := d.increment(ctx, key, "INCRBY", delta, ttl).Int64()
+	if err != nil {
+		return 0, err
+	}
+	return result, d.invalidate(ctx, InvalidationEvent{Keys: []string{key}})
+}
+
+// Decrement giảm giá trị số nguyên của key bằng INCRBY với -delta.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần trừ đi
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - int64: Giá trị sau khi giảm
+//   - error: ErrNotNumeric nếu giá trị hiện tại không phải số nguyên, hoặc lỗi từ Redis
+func (d *redisDriver) Decrement(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	return d.Increment(ctx, key, -delta, ttl)
+}
+
+// IncrementFloat tăng giá trị số thực của key bằng INCRBYFLOAT.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (có thể âm)
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - float64: Giá trị sau khi tăng
+//   - error: ErrNotNumeric nếu giá trị hiện tại không phải số, hoặc lỗi từ Redis
+func (d *redisDriver) IncrementFloat(ctx context.Context, key string, delta float64, ttl time.Duration) (float64, error) {
+	result, err := d.increment(ctx, key, "INCRBYFLOAT", delta, ttl).Float64()
+	if err != nil {
+		return 0, err
+	}
+	return result, d.invalidate(ctx, InvalidationEvent{Keys: []string{key}})
+}
+
+// increment chạy redisIncrementScript với lệnh tăng được chỉ định.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - command: INCRBY hoặc INCRBYFLOAT
+//   - delta: Giá trị cần cộng thêm
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định, -1 để không hết hạn)
+//
+// Returns:
+//   - *redis.Cmd: Kết quả của script, lỗi "not an integer"/"not a valid float" được chuyển thành ErrNotNumeric
+func (d *redisDriver) increment(ctx context.Context, key, command string, delta interface{}, ttl time.Duration) *redis.Cmd {
+	if ttl == 0 {
+		ttl = d.default_ttl
+	}
+
+	cmd := redisIncrementScript.Run(ctx, d.client, []string{d.prefixKey(key)}, command, delta, ttl.Milliseconds())
+	if err := cmd.Err(); err != nil && (strings.Contains(err.Error(), "not an integer") || strings.Contains(err.Error(), "not a valid float")) {
+		cmd.SetErr(fmt.Errorf("%w: %v", ErrNotNumeric, err))
+	}
+	return cmd
+}
+
+// redisUnlockScript chỉ xóa khóa khi token khớp, tránh giải phóng khóa đã hết hạn
+// và được instance khác giành lại.
+var redisUnlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// redisRefreshLockScript đặt lại thời gian giữ khóa khi token khớp. ARGV[2] là TTL
+// tính bằng milliseconds, 0 để khóa không hết hạn.
+var redisRefreshLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+local ttl = tonumber(ARGV[2])
+if ttl > 0 then
+	redis.call("PEXPIRE", KEYS[1], ttl)
+else
+	redis.call("PERSIST", KEYS[1])
+end
+return 1
+`)
+
+// redisLockKeyPrefix là tiền tố (sau prefix của driver) của các key lưu khóa phân tán.
+const redisLockKeyPrefix = "__lock:"
+
+// lockKey trả về Redis key của một khóa.
+//
+// Params:
+//   - name: Tên khóa
+//
+// Returns:
+//   - string: Redis key của khóa
+func (d *redisDriver) lockKey(name string) string {
+	return d.prefix + redisLockKeyPrefix + name
+}
+
+// TryLock thử giành khóa bằng SET NX PX.
+//
+// Khóa được lưu tại key "__lock:<name>" (kèm prefix); khóa của Remember dùng tên
+// "remember:<key>" nên không trùng với khóa cùng tên. Redis tự xóa khóa khi hết hạn.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - name: Tên khóa
+//   - owner: Token định danh chủ sở hữu
+//   - ttl: Thời gian giữ khóa tối đa (<= 0 để khóa không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giành được khóa
+//   - error: Lỗi nếu không thể giao tiếp với Redis
+func (d *redisDriver) TryLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	if ttl < 0 {
+		ttl = 0
+	}
+	return d.client.SetNX(ctx, d.lockKey(name), owner, ttl).Result()
+}
+
+// RefreshLock đặt lại thời gian giữ khóa nếu owner vẫn giữ khóa.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - name: Tên khóa
+//   - owner: Token định danh chủ sở hữu
+//   - ttl: Thời gian giữ khóa mới (<= 0 để khóa không hết hạn)
+//
+// Returns:
+//   - bool: true nếu khóa được gia hạn
+//   - error: Lỗi nếu không thể giao tiếp với Redis
+func (d *redisDriver) RefreshLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	if ttl < 0 {
+		ttl = 0
+	}
+	refreshed, err := redisRefreshLockScript.Run(ctx, d.client, []string{d.lockKey(name)}, owner, ttl.Milliseconds()).Int64()
+	return refreshed == 1, err
+}
+
+// Unlock giải phóng khóa nếu owner đang giữ khóa.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - name: Tên khóa
+//   - owner: Token định danh chủ sở hữu
+//
+// Returns:
+//   - bool: true nếu khóa được giải phóng
+//   - error: Lỗi nếu không thể giao tiếp với Redis
+func (d *redisDriver) Unlock(ctx context.Context, name, owner string) (bool, error) {
+	released, err := redisUnlockScript.Run(ctx, d.client, []string{d.lockKey(name)}, owner).Int64()
+	return released == 1, err
+}
+
+// SetTagged đặt một giá trị vào cache và gắn các tag cho entry.
+//
+// Thành viên của mỗi tag được lưu trong một sorted set với score là thời điểm hết hạn
+// của entry (milliseconds, +inf nếu không hết hạn). Các thành viên đã hết hạn được
+// dọn khỏi sorted set mỗi khi tag được ghi, nên tag set không tăng trưởng vô hạn.
+// Giá trị và tag được ghi trong cùng một transaction MULTI/EXEC.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định, -1 để không hết hạn)
+//   - tags: Danh sách tag gắn với entry
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình mã hóa hoặc lưu trữ
+func (d *redisDriver) SetTagged(ctx context.Context, key string, value interface{}, ttl time.Duration, tags []string) error {
+	prefixedKey := d.prefixKey(key)
+
+	data, err := d.encode(key, value, 0)
+	if err != nil {
+		return fmt.Errorf("could not serialize value: %w", err)
+	}
+
+	if ttl == 0 {
+		ttl = d.default_ttl
+	}
+	if ttl < 0 {
+		ttl = 0
+	}
+
+	now := time.Now()
+	score := math.Inf(1)
+	if ttl > 0 {
+		score = float64(now.Add(ttl).UnixMilli())
+	}
+
+	pipe := d.client.TxPipeline()
+	pipe.Set(ctx, prefixedKey, data, ttl)
+	for _, tag := range tags {
+		tagKey := d.tagKey(tag)
+		pipe.ZAdd(ctx, tagKey, redis.Z{Score: score, Member: prefixedKey})
+		pipe.ZRemRangeByScore(ctx, tagKey, "-inf", "("+strconv.FormatInt(now.UnixMilli(), 10))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+	return d.invalidate(ctx, InvalidationEvent{Keys: []string{key}})
 }
 
 // Has kiểm tra xem một key có tồn tại trong cache không.
@@ -225,14 +933,18 @@ func (d *redisDriver) Has(ctx context.Context, key string) bool {
 //   - error: Lỗi nếu có trong quá trình xóa
 func (d *redisDriver) Delete(ctx context.Context, key string) error {
 	prefixedKey := d.prefixKey(key)
-	return d.client.Del(ctx, prefixedKey).Err()
+	if err := d.client.Del(ctx, prefixedKey).Err(); err != nil {
+		return err
+	}
+	return d.invalidate(ctx, InvalidationEvent{Keys: []string{key}})
 }
 
 // Flush xóa tất cả các key khỏi cache có prefix đã định.
 //
 // Phương thức này quét và xóa tất cả các key có tiền tố đã cấu hình
 // trong Redis database được sử dụng. Phương pháp này an toàn hơn so với
-// FLUSHDB vì nó chỉ xóa các key thuộc về cache này.
+// FLUSHDB vì nó chỉ xóa các key thuộc về cache này. Khóa phân tán không bị xóa để
+// instance đang giữ khóa không mất khóa.
 //
 // Params:
 //   - ctx: Context để kiểm soát thời gian thực thi của thao tác
@@ -247,6 +959,9 @@ func (d *redisDriver) Flush(ctx context.Context) error {
 	// Xóa từng key
 	var keys []string
 	for iter.Next(ctx) {
+		if strings.HasPrefix(strings.TrimPrefix(iter.Val(), d.prefix), redisLockKeyPrefix) {
+			continue
+		}
 		keys = append(keys, iter.Val())
 
 		// Xóa theo batch để tối ưu hiệu suất
@@ -260,12 +975,188 @@ func (d *redisDriver) Flush(ctx context.Context) error {
 
 	// Xóa batch cuối cùng
 	if len(keys) > 0 {
-		return d.client.Del(ctx, keys...).Err()
+		if err := d.client.Del(ctx, keys...).Err(); err != nil {
+			return err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	return d.invalidate(ctx, InvalidationEvent{Flush: true})
+}
+
+// FlushTags xóa tất cả các entry mang ít nhất một trong các tag được chỉ định.
+//
+// Phương thức này đọc thành viên của từng tag set, sau đó xóa các entry và gỡ
+// chúng khỏi tag set theo batch giống Flush.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - tags: Danh sách tag cần xóa
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình đọc hoặc xóa
+func (d *redisDriver) FlushTags(ctx context.Context, tags []string) error {
+	// Key của các entry bị xóa được gửi kèm sự kiện, vì bản sao ở cache cục bộ
+	// của instance khác có thể không mang tag
+	var flushed []string
+	for _, tag := range tags {
+		tagKey := d.tagKey(tag)
+		members, err := d.client.ZRange(ctx, tagKey, 0, -1).Result()
+		if err != nil {
+			return err
+		}
+
+		// Xóa theo batch để tối ưu hiệu suất. Chỉ các thành viên đã đọc được gỡ khỏi
+		// tag set, nên entry được gắn tag đồng thời trong lúc flush không bị mất dấu.
+		for start := 0; start < len(members); start += 100 {
+			end := start + 100
+			if end > len(members) {
+				end = len(members)
+			}
+			batch := members[start:end]
+			removed := make([]interface{}, len(batch))
+			for i, member := range batch {
+				removed[i] = member
+			}
+
+			pipe := d.client.Pipeline()
+			pipe.Del(ctx, batch...)
+			pipe.ZRem(ctx, tagKey, removed...)
+			if _, err := pipe.Exec(ctx); err != nil {
+				return err
+			}
+			if d.invalidation != nil {
+				for _, member := range batch {
+					flushed = append(flushed, strings.TrimPrefix(member, d.prefix))
+				}
+			}
+		}
+	}
+	return d.invalidate(ctx, InvalidationEvent{Keys: flushed, Tags: tags})
+}
+
+// redisScanCount là số key gợi ý cho mỗi lần gọi SCAN.
+const redisScanCount = 100
+
+// Keys trả về các key còn hạn khớp với pattern.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - pattern: Glob pattern của key
+//
+// Returns:
+//   - []string: Các key khớp pattern, không trùng lặp
+//   - error: Lỗi nếu pattern không hợp lệ hoặc lỗi từ Redis
+func (d *redisDriver) Keys(ctx context.Context, pattern string) ([]string, error) {
+	return scanKeys(ctx, d, pattern)
+}
+
+// Scan duyệt lần lượt các key còn hạn khớp với pattern bằng lệnh SCAN.
+//
+// Khác với KEYS, SCAN không chặn Redis trong lúc quét. Pattern được gửi tới Redis cùng
+// prefix của driver (đã được thoát các ký tự đặc biệt), nên việc lọc diễn ra phía server.
+// Theo đảm bảo của SCAN, một key có thể được trả về nhiều lần.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - pattern: Glob pattern của key
+//   - fn: Hàm được gọi cho từng key, trả về false để dừng quét
+//
+// Returns:
+//   - error: Lỗi nếu pattern không hợp lệ hoặc lỗi từ Redis
+func (d *redisDriver) Scan(ctx context.Context, pattern string, fn func(key string) bool) error {
+	if _, err := compilePattern(pattern); err != nil {
+		return err
+	}
+	if pattern == "" {
+		pattern = "*"
 	}
 
+	iter := d.client.Scan(ctx, 0, escapePattern(d.prefix)+pattern, redisScanCount).Iterator()
+	for iter.Next(ctx) {
+		key := strings.TrimPrefix(iter.Val(), d.prefix)
+		if isInternalKey(key) {
+			continue
+		}
+		if !fn(key) {
+			return nil
+		}
+	}
 	return iter.Err()
 }
 
+// DeleteMatching xóa tất cả các entry có key khớp với pattern.
+//
+// Giống Flush, các key được tìm bằng SCAN và xóa theo batch, nhưng dùng UNLINK để Redis
+// giải phóng bộ nhớ trong nền. Khi invalidation được bật, một sự kiện mang pattern được
+// phát để các instance khác xóa các key tương ứng khỏi cache cục bộ.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - pattern: Glob pattern của key
+//
+// Returns:
+//   - int: Số entry đã bị xóa
+//   - error: Lỗi nếu pattern không hợp lệ hoặc lỗi từ Redis
+func (d *redisDriver) DeleteMatching(ctx context.Context, pattern string) (int, error) {
+	if _, err := compilePattern(pattern); err != nil {
+		return 0, err
+	}
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	deleted := 0
+	keys := make([]string, 0, redisScanCount)
+	unlink := func() error {
+		if len(keys) == 0 {
+			return nil
+		}
+		n, err := d.client.Unlink(ctx, keys...).Result()
+		if err != nil {
+			return err
+		}
+		deleted += int(n)
+		keys = keys[:0]
+		return nil
+	}
+
+	iter := d.client.Scan(ctx, 0, escapePattern(d.prefix)+pattern, redisScanCount).Iterator()
+	for iter.Next(ctx) {
+		if isInternalKey(strings.TrimPrefix(iter.Val(), d.prefix)) {
+			continue
+		}
+		keys = append(keys, iter.Val())
+		if len(keys) >= redisScanCount {
+			if err := unlink(); err != nil {
+				return deleted, err
+			}
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return deleted, err
+	}
+	if err := unlink(); err != nil {
+		return deleted, err
+	}
+	return deleted, d.invalidate(ctx, InvalidationEvent{Pattern: pattern})
+}
+
+// DeleteByPrefix xóa tất cả các entry có key bắt đầu bằng prefix.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - prefix: Tiền tố của key
+//
+// Returns:
+//   - int: Số entry đã bị xóa
+//   - error: Lỗi từ Redis
+func (d *redisDriver) DeleteByPrefix(ctx context.Context, prefix string) (int, error) {
+	return d.DeleteMatching(ctx, prefixPattern(prefix))
+}
+
 // GetMultiple lấy nhiều giá trị từ cache
 func (d *redisDriver) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, []string) {
 	results := make(map[string]interface{})
@@ -291,25 +1182,16 @@ func (d *redisDriver) GetMultiple(ctx context.Context, keys []string) (map[strin
 			continue
 		}
 
-		// Giải mã dữ liệu
-		var decoded interface{}
-		data, ok := value.(string)
+		// Giải mã dữ liệu như Get
+		raw, ok := value.(string)
 		if !ok {
 			missed = append(missed, keys[i])
 			continue
 		}
-
-		// Use the same deserialization logic as Get method
-		if d.deserializer != nil {
-			if err := d.deserializer([]byte(data), &decoded); err != nil {
-				missed = append(missed, keys[i])
-				continue
-			}
-		} else {
-			if err := json.Unmarshal([]byte(data), &decoded); err != nil {
-				missed = append(missed, keys[i])
-				continue
-			}
+		decoded, _, err := d.decode(keys[i], []byte(raw))
+		if err != nil {
+			missed = append(missed, keys[i])
+			continue
 		}
 
 		results[keys[i]] = decoded
@@ -329,7 +1211,7 @@ func (d *redisDriver) SetMultiple(ctx context.Context, values map[string]interfa
 
 	for key, value := range values {
 		// Mã hóa dữ liệu
-		data, err := d.serializer(value)
+		data, err := d.encode(key, value, 0)
 		if err != nil {
 			return fmt.Errorf("could not serialize value for key '%s': %w", key, err)
 		}
@@ -339,8 +1221,15 @@ func (d *redisDriver) SetMultiple(ctx context.Context, values map[string]interfa
 	}
 
 	// Thực thi pipeline
-	_, err := pipe.Exec(ctx)
-	return err
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	return d.invalidate(ctx, InvalidationEvent{Keys: keys})
 }
 
 // DeleteMultiple xóa nhiều key khỏi cache
@@ -356,35 +1245,114 @@ func (d *redisDriver) DeleteMultiple(ctx context.Context, keys []string) error {
 	}
 
 	// Xóa tất cả các key cùng lúc
-	return d.client.Del(ctx, prefixedKeys...).Err()
+	if err := d.client.Del(ctx, prefixedKeys...).Err(); err != nil {
+		return err
+	}
+	return d.invalidate(ctx, InvalidationEvent{Keys: keys})
 }
 
-// Remember lấy một giá trị từ cache hoặc thực thi callback nếu không tìm thấy
+// Remember lấy một giá trị từ cache hoặc thực thi callback nếu không tìm thấy.
+//
+// Các lần gọi đồng thời cho cùng một key trong process được gộp lại: chỉ một
+// goroutine thực thi callback, các goroutine khác chờ và nhận cùng kết quả.
+// Khi remember_lock được bật, khóa phân tán đảm bảo chỉ một instance trong toàn hệ
+// thống thực thi callback; các instance khác chờ giá trị được ghi vào cache.
+// Giá trị không giải mã được bằng các khóa mã hóa đã cấu hình trả về lỗi giải mã và
+// callback không được gọi.
 func (d *redisDriver) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
 	// Kiểm tra cache trước
-	value, found := d.Get(ctx, key)
+	value, _, found, err := d.get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
 	if found {
 		return value, nil
 	}
 
-	// Không tìm thấy, gọi callback
-	value, err := callback()
+	// Không tìm thấy, gọi callback một lần cho mọi goroutine đang chờ key này
+	lookup := func() (interface{}, bool) { return d.Get(ctx, key) }
+	return d.flights.do(key, func() (interface{}, error) {
+		return d.rememberLock.load(ctx, key, lookup, func() (interface{}, error) {
+			value, err := callback()
+			if err != nil {
+				return nil, err
+			}
+
+			// Lưu kết quả vào cache
+			return value, d.Set(ctx, key, value, ttl)
+		})
+	})
+}
+
+// RememberStale lấy một giá trị từ cache theo cơ chế stale-while-revalidate.
+//
+// Thời điểm hết hạn mềm được lưu trong envelope của giá trị, TTL của Redis key là
+// ttl + grace. Khi remember_lock được bật, cả lần lấy giá trị lúc cache miss lẫn lần
+// làm mới trong nền đều được điều phối bằng khóa phân tán.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - ttl: Thời gian giá trị được coi là còn mới (0 để sử dụng mặc định)
+//   - grace: Thời gian giá trị cũ tiếp tục được phục vụ sau ttl
+//   - callback: Hàm được gọi để lấy dữ liệu
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi nếu có trong quá trình thực hiện hoặc từ callback
+func (d *redisDriver) RememberStale(ctx context.Context, key string, ttl, grace time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	return rememberStale(ctx, d, &d.flights, d.rememberLock, key, ttl, grace, callback)
+}
+
+// setStale lưu giá trị trong envelope mang thời điểm hết hạn mềm sau ttl,
+// với TTL của Redis key là ttl + grace.
+func (d *redisDriver) setStale(ctx context.Context, key string, value interface{}, ttl, grace time.Duration) error {
+	if ttl == 0 {
+		ttl = d.default_ttl
+	}
+	expiration := time.Duration(0)
+	var softExpiration int64
+	if ttl > 0 {
+		expiration = ttl + grace
+		if grace > 0 {
+			softExpiration = time.Now().Add(ttl).UnixNano()
+		}
+	}
+
+	data, err := d.encode(key, value, softExpiration)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("could not serialize value: %w", err)
+	}
+	if err := d.client.Set(ctx, d.prefixKey(key), data, expiration).Err(); err != nil {
+		return err
 	}
+	return d.invalidate(ctx, InvalidationEvent{Keys: []string{key}})
+}
 
-	// Lưu kết quả vào cache
-	err = d.Set(ctx, key, value, ttl)
-	return value, err
+// invalidate phát sự kiện vô hiệu hóa nếu invalidation được bật.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - event: Sự kiện cần phát
+//
+// Returns:
+//   - error: Lỗi nếu không phát được sự kiện
+func (d *redisDriver) invalidate(ctx context.Context, event InvalidationEvent) error {
+	if d.invalidation == nil {
+		return nil
+	}
+	return d.invalidation.Publish(ctx, event)
 }
 
 // Stats trả về thông tin thống kê về cache
 func (d *redisDriver) Stats(ctx context.Context) map[string]interface{} {
-	// Đếm số lượng key với prefix
-	pattern := d.prefix + "*"
-	count, err := d.client.Keys(ctx, pattern).Result()
-	countVal := len(count)
-	if err != nil {
+	// Đếm số lượng key với prefix bằng SCAN để không chặn Redis như KEYS
+	countVal := 0
+	iter := d.client.Scan(ctx, 0, escapePattern(d.prefix)+"*", redisScanCount).Iterator()
+	for iter.Next(ctx) {
+		countVal++
+	}
+	if iter.Err() != nil {
 		countVal = -1
 	}
 
@@ -394,7 +1362,7 @@ func (d *redisDriver) Stats(ctx context.Context) map[string]interface{} {
 		info = ""
 	}
 
-	return map[string]interface{}{
+	stats := map[string]interface{}{
 		"count":  countVal,
 		"hits":   d.hits,
 		"misses": d.misses,
@@ -402,59 +1370,73 @@ func (d *redisDriver) Stats(ctx context.Context) map[string]interface{} {
 		"prefix": d.prefix,
 		"info":   info,
 	}
+	d.compressor.addStats(stats)
+	d.encryptor.addStats(stats)
+	return stats
 }
 
 // Close giải phóng tài nguyên của driver
 func (d *redisDriver) Close() error {
+	if d.invalidation != nil {
+		_ = d.invalidation.Close()
+	}
 	return d.client.Close()
 }
 
-// WithSerializer thiết lập serializer theo tên
+// RunScript thực thi Lua script với các key đã được thêm prefix của driver.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - script: Lua script cần thực thi
+//   - keys: Các cache key truyền vào KEYS của script
+//   - args: Các tham số truyền vào ARGV của script
+//
+// Returns:
+//   - *redis.Cmd: Kết quả của script
+func (d *redisDriver) RunScript(ctx context.Context, script *redis.Script, keys []string, args ...interface{}) *redis.Cmd {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = d.prefixKey(key)
+	}
+	return script.Run(ctx, d.client, prefixed, args...)
+}
+
+// Invalidation trả về invalidation bus của driver, nil nếu invalidation không được bật
+func (d *redisDriver) Invalidation() InvalidationBus {
+	return d.invalidation
+}
+
+// WithSerializer trả về bản sao của driver ghi giá trị bằng codec có tên cho trước.
+//
+// Tên rỗng hoặc chưa được đăng ký sử dụng json. Giá trị đã ghi bằng codec khác vẫn
+// được đọc bằng codec đã ghi nó.
 func (d *redisDriver) WithSerializer(serializerName string) RedisDriver {
-	newDriver := &redisDriver{
-		client:      d.client,
-		prefix:      d.prefix,
-		default_ttl: d.default_ttl,
-		hits:        d.hits,
-		misses:      d.misses,
-	}
-
-	switch serializerName {
-	case "gob":
-		newDriver.serializer = func(v interface{}) ([]byte, error) {
-			var buf bytes.Buffer
-			enc := gob.NewEncoder(&buf)
-			if err := enc.Encode(v); err != nil {
-				return nil, fmt.Errorf("could not serialize value: %w", err)
-			}
-			return buf.Bytes(), nil
-		}
-		newDriver.deserializer = func(data []byte, v interface{}) error {
-			buf := bytes.NewBuffer(data)
-			dec := gob.NewDecoder(buf)
-			if err := dec.Decode(v); err != nil {
-				return fmt.Errorf("could not deserialize value: %w", err)
-			}
-			return nil
-		}
-	case "msgpack":
-		newDriver.serializer = func(v interface{}) ([]byte, error) {
-			data, err := msgpack.Marshal(v)
-			if err != nil {
-				return nil, fmt.Errorf("could not serialize value: %w", err)
-			}
-			return data, nil
-		}
-		newDriver.deserializer = func(data []byte, v interface{}) error {
-			if err := msgpack.Unmarshal(data, v); err != nil {
-				return fmt.Errorf("could not deserialize value: %w", err)
-			}
-			return nil
-		}
-	default: // json
-		newDriver.serializer = json.Marshal
-		newDriver.deserializer = json.Unmarshal
+	return &redisDriver{
+		client:       d.client,
+		prefix:       d.prefix,
+		default_ttl:  d.default_ttl,
+		codec:        redisCodec(serializerName),
+		compressor:   d.compressor,
+		encryptor:    d.encryptor,
+		hits:         d.hits,
+		misses:       d.misses,
+		rememberLock: d.rememberLock,
+		invalidation: d.invalidation,
+		slidingTTL:   d.slidingTTL,
 	}
+}
 
-	return newDriver
+// redisCodec trả về codec theo tên, json nếu tên rỗng hoặc chưa được đăng ký.
+//
+// Params:
+//   - name: Tên codec
+//
+// Returns:
+//   - codec.Codec: Codec đã đăng ký
+func redisCodec(name string) codec.Codec {
+	if c, err := codec.Get(name); err == nil {
+		return c
+	}
+	c, _ := codec.Get(codec.JSON)
+	return c
 }
@@ -0,0 +1,102 @@
+package driver
+
+import (
+	"context"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"go.fork.vn/cache/encryption"
+)
+
+// redisReencryptScript thay dữ liệu của key bằng ARGV[2] chỉ khi dữ liệu hiện tại vẫn là
+// ARGV[1], giữ nguyên TTL còn lại của key.
+var redisReencryptScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl > 0 then
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ttl)
+else
+	redis.call("SET", KEYS[1], ARGV[2])
+end
+return 1
+`)
+
+// Reencrypt mã hóa lại bằng khóa đang hoạt động mọi giá trị được mã hóa bằng khóa khác.
+//
+// Các key được duyệt bằng SCAN (chỉ key kiểu string) nên Redis không bị chặn. Mỗi giá trị
+// được thay bằng compare-and-set trong Lua script giữ nguyên TTL, nên giá trị bị ghi đè
+// trong lúc mã hóa lại không bị thay bằng giá trị cũ. Không có sự kiện invalidation nào
+// được phát vì giá trị không thay đổi.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi và dừng thao tác
+//
+// Returns:
+//   - int: Số giá trị đã được mã hóa lại
+//   - error: ErrEncryptionDisabled nếu driver không bật mã hóa, hoặc lỗi từ Redis
+func (d *redisDriver) Reencrypt(ctx context.Context) (int, error) {
+	if !d.encryptor.enabled() {
+		return 0, ErrEncryptionDisabled
+	}
+
+	count := 0
+	iter := d.client.ScanType(ctx, 0, escapePattern(d.prefix)+"*", redisScanCount, "string").Iterator()
+	for iter.Next(ctx) {
+		prefixedKey := iter.Val()
+		key := strings.TrimPrefix(prefixedKey, d.prefix)
+		if isInternalKey(key) {
+			continue
+		}
+
+		data, err := d.client.Get(ctx, prefixedKey).Bytes()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return count, err
+		}
+		sealed, ok := d.reencrypt(key, data)
+		if !ok {
+			continue
+		}
+		replaced, err := redisReencryptScript.Run(ctx, d.client, []string{prefixedKey}, data, sealed).Int()
+		if err != nil {
+			return count, err
+		}
+		count += replaced
+	}
+	return count, iter.Err()
+}
+
+// reencrypt mã hóa lại dữ liệu của key nếu dữ liệu được mã hóa bằng khóa khác khóa đang
+// hoạt động.
+//
+// Params:
+//   - key: Cache key của dữ liệu
+//   - data: Dữ liệu đọc từ Redis
+//
+// Returns:
+//   - []byte: Dữ liệu đã được mã hóa lại
+//   - bool: false nếu dữ liệu không mã hóa, đã dùng khóa đang hoạt động hoặc không giải
+//     mã được
+func (d *redisDriver) reencrypt(key string, data []byte) ([]byte, bool) {
+	payload, env := decodeRedisEnvelope(data)
+	if !env.encrypted {
+		return nil, false
+	}
+	if id, err := encryption.KeyID(payload); err != nil || !d.encryptor.stale(id) {
+		return nil, false
+	}
+
+	plaintext, err := d.encryptor.decrypt(payload, key, true)
+	if err != nil {
+		return nil, false
+	}
+	sealed, _, err := d.encryptor.encrypt(plaintext, key)
+	if err != nil {
+		return nil, false
+	}
+	return encodeRedisEnvelope(sealed, env), true
+}
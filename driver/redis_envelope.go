@@ -0,0 +1,117 @@
+package driver
+
+import (
+	"encoding/binary"
+
+	"go.fork.vn/cache/compression"
+)
+
+// Envelope cho các giá trị Redis cần lưu kèm metadata.
+//
+// Giá trị được ghi bằng codec json mà không có metadata giữ nguyên định dạng JSON, nên
+// Redis (INCRBY) và các client khác vẫn đọc được. Các giá trị khác được bọc trong envelope:
+//
+//	byte 0      magic (0xC1)
+//	byte 1      phiên bản envelope
+//	byte 2      flags
+//	8 byte      thời điểm hết hạn mềm (UnixNano, big-endian), nếu có flag redisEnvelopeSoftExpiration
+//	1 byte + n  độ dài và tên codec, nếu có flag redisEnvelopeCodec
+//	1 byte      ID thuật toán nén của dữ liệu, nếu có flag redisEnvelopeCompressed
+//	còn lại     dữ liệu của codec (đã nén nếu có flag redisEnvelopeCompressed, rồi được
+//	            mã hóa nếu có flag redisEnvelopeEncrypted)
+//
+// Dữ liệu đã mã hóa bắt đầu bằng ID của khóa đã mã hóa nó (xem package encryption).
+//
+// 0xC1 không bao giờ là byte đầu tiên của dữ liệu JSON, gob hoặc msgpack hợp lệ,
+// nên giá trị cũ và giá trị có envelope có thể cùng tồn tại.
+const (
+	redisEnvelopeMagic          byte = 0xC1
+	redisEnvelopeVersion        byte = 1
+	redisEnvelopeSoftExpiration byte = 1 << 0
+	redisEnvelopeCodec          byte = 1 << 1
+	redisEnvelopeCompressed     byte = 1 << 2
+	redisEnvelopeEncrypted      byte = 1 << 3
+)
+
+// redisEnvelope là metadata được lưu trong envelope.
+type redisEnvelope struct {
+	softExpiration int64  // Thời điểm hết hạn mềm (UnixNano), 0 nếu không có
+	codec          string // Tên codec đã mã hóa dữ liệu, rỗng với codec json
+	compression    byte   // ID thuật toán nén, compression.IDNone nếu dữ liệu không được nén
+	encrypted      bool   // true nếu dữ liệu được mã hóa
+}
+
+// encodeRedisEnvelope bọc dữ liệu đã mã hóa trong envelope.
+//
+// Params:
+//   - payload: Dữ liệu của codec (đã nén nếu env.compression khác IDNone, đã mã hóa nếu
+//     env.encrypted)
+//   - env: Metadata cần lưu
+//
+// Returns:
+//   - []byte: Dữ liệu đã được bọc
+func encodeRedisEnvelope(payload []byte, env redisEnvelope) []byte {
+	buf := make([]byte, 3, 13+len(env.codec)+len(payload))
+	buf[0] = redisEnvelopeMagic
+	buf[1] = redisEnvelopeVersion
+	if env.softExpiration > 0 {
+		buf[2] |= redisEnvelopeSoftExpiration
+		buf = binary.BigEndian.AppendUint64(buf, uint64(env.softExpiration))
+	}
+	if env.codec != "" {
+		buf[2] |= redisEnvelopeCodec
+		buf = append(buf, byte(len(env.codec)))
+		buf = append(buf, env.codec...)
+	}
+	if env.compression != compression.IDNone {
+		buf[2] |= redisEnvelopeCompressed
+		buf = append(buf, env.compression)
+	}
+	if env.encrypted {
+		buf[2] |= redisEnvelopeEncrypted
+	}
+	return append(buf, payload...)
+}
+
+// decodeRedisEnvelope tách dữ liệu và metadata khỏi envelope.
+//
+// Dữ liệu không có envelope được trả về nguyên vẹn.
+//
+// Params:
+//   - data: Dữ liệu đọc từ Redis
+//
+// Returns:
+//   - []byte: Dữ liệu của codec (đã nén nếu compression của metadata khác IDNone, đã mã
+//     hóa nếu encrypted của metadata là true)
+//   - redisEnvelope: Metadata của envelope, rỗng nếu không có envelope
+func decodeRedisEnvelope(data []byte) ([]byte, redisEnvelope) {
+	var env redisEnvelope
+	if len(data) < 3 || data[0] != redisEnvelopeMagic || data[1] != redisEnvelopeVersion {
+		return data, env
+	}
+
+	flags, payload := data[2], data[3:]
+	if flags&redisEnvelopeSoftExpiration != 0 {
+		if len(payload) < 8 {
+			return data, redisEnvelope{}
+		}
+		env.softExpiration = int64(binary.BigEndian.Uint64(payload))
+		payload = payload[8:]
+	}
+	if flags&redisEnvelopeCodec != 0 {
+		if len(payload) < 1 || len(payload) < 1+int(payload[0]) {
+			return data, redisEnvelope{}
+		}
+		env.codec = string(payload[1 : 1+payload[0]])
+		payload = payload[1+payload[0]:]
+	}
+	if flags&redisEnvelopeCompressed != 0 {
+		if len(payload) < 1 {
+			return data, redisEnvelope{}
+		}
+		env.compression = payload[0]
+		payload = payload[1:]
+	}
+	env.encrypted = flags&redisEnvelopeEncrypted != 0
+	return payload, env
+}
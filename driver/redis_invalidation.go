@@ -0,0 +1,193 @@
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+	"go.fork.vn/cache/config"
+)
+
+// InvalidationEvent mô tả các entry cần bị xóa khỏi cache cục bộ của các instance.
+type InvalidationEvent struct {
+	Origin string   `json:"origin,omitempty"` // Định danh của bus đã phát sự kiện
+	Keys   []string `json:"keys,omitempty"`   // Các cache key cần xóa
+	Tags   []string `json:"tags,omitempty"`   // Các tag cần xóa
+	Flush  bool     `json:"flush,omitempty"`  // true nếu cần xóa toàn bộ cache cục bộ
+	// Pattern là glob pattern của các key cần xóa (DeleteMatching), rỗng nếu không có
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// InvalidationBus phát và nhận sự kiện vô hiệu hóa cache giữa nhiều instance.
+//
+// Khi một instance ghi hoặc xóa dữ liệu ở cache dùng chung (ví dụ Redis), bản sao
+// trong memory driver cục bộ của các instance khác trở nên cũ. Bus phát sự kiện lên
+// một kênh pub/sub và xóa các entry tương ứng khỏi các driver cục bộ đã đăng ký ở
+// mọi instance khác.
+type InvalidationBus interface {
+	// Register đăng ký các driver cục bộ nhận sự kiện vô hiệu hóa.
+	//
+	// Params:
+	//   - drivers: Các driver cần đăng ký, thường là memory driver
+	Register(drivers ...Driver)
+
+	// Publish phát một sự kiện vô hiệu hóa tới các instance khác.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - event: Sự kiện cần phát, Origin được bus điền vào
+	//
+	// Returns:
+	//   - error: Lỗi nếu không phát được sự kiện
+	Publish(ctx context.Context, event InvalidationEvent) error
+
+	// Close hủy đăng ký kênh và dừng goroutine nhận sự kiện.
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình hủy đăng ký
+	Close() error
+}
+
+// redisInvalidationBus cài đặt InvalidationBus bằng Redis pub/sub.
+//
+// Mỗi bus có một định danh ngẫu nhiên được gửi kèm sự kiện. Sự kiện do chính bus
+// phát ra bị bỏ qua khi nhận lại, vì instance phát sự kiện đã tự cập nhật cache cục bộ
+// của mình (ví dụ qua tiered driver).
+type redisInvalidationBus struct {
+	client  *redis.Client // Redis client dùng để phát sự kiện
+	pubsub  *redis.PubSub // Đăng ký kênh sự kiện
+	channel string        // Tên kênh pub/sub
+	origin  string        // Định danh của bus
+	mu      sync.RWMutex  // Mutex bảo vệ drivers
+	drivers []Driver      // Các driver cục bộ nhận sự kiện
+	done    chan struct{} // Được đóng khi goroutine nhận sự kiện kết thúc
+	once    sync.Once     // Đảm bảo Close chỉ thực thi một lần
+}
+
+// NewInvalidationBus tạo một invalidation bus trên Redis pub/sub.
+//
+// Bus đăng ký kênh và chờ Redis xác nhận trước khi trả về, nên mọi sự kiện được phát
+// sau đó đều được nhận.
+//
+// Params:
+//   - client: Redis client
+//   - cfg: Cấu hình invalidation
+//
+// Returns:
+//   - InvalidationBus: Bus đã sẵn sàng nhận sự kiện
+//   - error: Lỗi nếu không đăng ký được kênh
+func NewInvalidationBus(client *redis.Client, cfg config.InvalidationConfig) (InvalidationBus, error) {
+	if client == nil {
+		return nil, fmt.Errorf("redis client cannot be nil")
+	}
+
+	origin, err := newLockToken()
+	if err != nil {
+		return nil, fmt.Errorf("could not generate invalidation bus id: %w", err)
+	}
+
+	ctx := context.Background()
+	channel := cfg.GetChannel()
+	pubsub := client.Subscribe(ctx, channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, fmt.Errorf("could not subscribe to invalidation channel '%s': %w", channel, err)
+	}
+
+	bus := &redisInvalidationBus{
+		client:  client,
+		pubsub:  pubsub,
+		channel: channel,
+		origin:  origin,
+		done:    make(chan struct{}),
+	}
+	go bus.listen(pubsub.Channel())
+	return bus, nil
+}
+
+// Register đăng ký các driver cục bộ nhận sự kiện vô hiệu hóa.
+//
+// Params:
+//   - drivers: Các driver cần đăng ký, thường là memory driver
+func (b *redisInvalidationBus) Register(drivers ...Driver) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.drivers = append(b.drivers, drivers...)
+}
+
+// Publish phát một sự kiện vô hiệu hóa tới các instance khác.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - event: Sự kiện cần phát, Origin được bus điền vào
+//
+// Returns:
+//   - error: Lỗi nếu không mã hóa hoặc không phát được sự kiện
+func (b *redisInvalidationBus) Publish(ctx context.Context, event InvalidationEvent) error {
+	event.Origin = b.origin
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("could not encode invalidation event: %w", err)
+	}
+	if err := b.client.Publish(ctx, b.channel, data).Err(); err != nil {
+		return fmt.Errorf("could not publish invalidation event: %w", err)
+	}
+	return nil
+}
+
+// Close hủy đăng ký kênh và chờ goroutine nhận sự kiện kết thúc.
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình hủy đăng ký
+func (b *redisInvalidationBus) Close() error {
+	var err error
+	b.once.Do(func() {
+		err = b.pubsub.Close()
+		<-b.done
+	})
+	return err
+}
+
+// listen nhận sự kiện từ kênh cho đến khi đăng ký bị đóng.
+func (b *redisInvalidationBus) listen(messages <-chan *redis.Message) {
+	defer close(b.done)
+
+	for msg := range messages {
+		var event InvalidationEvent
+		if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+			continue
+		}
+		if event.Origin == b.origin {
+			continue
+		}
+		b.apply(context.Background(), event)
+	}
+}
+
+// apply xóa các entry được mô tả bởi sự kiện khỏi mọi driver đã đăng ký.
+//
+// Lỗi từ driver cục bộ được bỏ qua vì không có caller để nhận lỗi.
+func (b *redisInvalidationBus) apply(ctx context.Context, event InvalidationEvent) {
+	b.mu.RLock()
+	drivers := b.drivers
+	b.mu.RUnlock()
+
+	for _, d := range drivers {
+		if event.Flush {
+			_ = d.Flush(ctx)
+			continue
+		}
+		if len(event.Tags) > 0 {
+			_ = d.FlushTags(ctx, event.Tags)
+		}
+		if len(event.Keys) > 0 {
+			_ = d.DeleteMultiple(ctx, event.Keys)
+		}
+		if event.Pattern != "" {
+			_, _ = d.DeleteMatching(ctx, event.Pattern)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package driver_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.fork.vn/cache/config"
+	"go.fork.vn/cache/driver"
+)
+
+// invalidationInstance mô phỏng một instance ứng dụng: L1 memory phía trước L2 redis
+type invalidationInstance struct {
+	local  driver.MemoryDriver
+	redis  driver.RedisDriver
+	tiered driver.TieredDriver
+}
+
+// newInvalidationInstance tạo một instance dùng chung miniredis server với các instance khác
+func newInvalidationInstance(t *testing.T, server *miniredis.Miniredis) *invalidationInstance {
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	redisDriver, err := driver.NewRedisDriver(config.DriverRedisConfig{
+		Enabled:      true,
+		DefaultTTL:   300,
+		Serializer:   "json",
+		Invalidation: config.InvalidationConfig{Enabled: true},
+	}, &mockRedisManager{client: client})
+	require.NoError(t, err)
+	require.NotNil(t, redisDriver.Invalidation())
+
+	local := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+	redisDriver.Invalidation().Register(local)
+	t.Cleanup(func() {
+		_ = local.Close()
+		_ = redisDriver.Close()
+	})
+
+	return &invalidationInstance{
+		local:  local,
+		redis:  redisDriver,
+		tiered: driver.NewTieredDriver(local, redisDriver),
+	}
+}
+
+func TestRedisInvalidation(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+
+	a := newInvalidationInstance(t, server)
+	b := newInvalidationInstance(t, server)
+
+	evicted := func(instance *invalidationInstance, key string) func() bool {
+		return func() bool { return !instance.local.Has(ctx, key) }
+	}
+
+	t.Run("set_evicts_other_instances", func(t *testing.T) {
+		require.NoError(t, a.tiered.Set(ctx, "user:1", "An", time.Minute))
+		value, found := b.tiered.Get(ctx, "user:1")
+		require.True(t, found)
+		assert.Equal(t, "An", value)
+		require.True(t, b.local.Has(ctx, "user:1"))
+
+		require.NoError(t, a.tiered.Set(ctx, "user:1", "Binh", time.Minute))
+
+		assert.Eventually(t, evicted(b, "user:1"), time.Second, 5*time.Millisecond)
+		value, _ = b.tiered.Get(ctx, "user:1")
+		assert.Equal(t, "Binh", value)
+
+		// Instance phát sự kiện giữ nguyên bản sao cục bộ vừa ghi
+		value, found = a.local.Get(ctx, "user:1")
+		assert.True(t, found)
+		assert.Equal(t, "Binh", value)
+	})
+
+	t.Run("delete_evicts_other_instances", func(t *testing.T) {
+		require.NoError(t, b.local.Set(ctx, "user:2", "Chi", time.Minute))
+		require.NoError(t, b.local.Set(ctx, "user:3", "Dung", time.Minute))
+
+		require.NoError(t, a.tiered.Delete(ctx, "user:2"))
+		require.NoError(t, a.tiered.DeleteMultiple(ctx, []string{"user:3"}))
+
+		assert.Eventually(t, evicted(b, "user:2"), time.Second, 5*time.Millisecond)
+		assert.Eventually(t, evicted(b, "user:3"), time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("flush_tags_evicts_untagged_copies", func(t *testing.T) {
+		require.NoError(t, a.tiered.SetTagged(ctx, "org:7:settings", "dark", time.Minute, []string{"org:7"}))
+		_, found := b.tiered.Get(ctx, "org:7:settings")
+		require.True(t, found)
+		require.NoError(t, b.local.SetTagged(ctx, "org:7:local", "x", time.Minute, []string{"org:7"}))
+
+		require.NoError(t, a.tiered.FlushTags(ctx, []string{"org:7"}))
+
+		assert.Eventually(t, evicted(b, "org:7:settings"), time.Second, 5*time.Millisecond)
+		assert.Eventually(t, evicted(b, "org:7:local"), time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("delete_matching_evicts_other_instances", func(t *testing.T) {
+		require.NoError(t, b.local.Set(ctx, "user:42:profile", "x", time.Minute))
+		require.NoError(t, b.local.Set(ctx, "user:43:profile", "y", time.Minute))
+
+		_, err := a.tiered.DeleteByPrefix(ctx, "user:42:")
+		require.NoError(t, err)
+
+		assert.Eventually(t, evicted(b, "user:42:profile"), time.Second, 5*time.Millisecond)
+		assert.True(t, b.local.Has(ctx, "user:43:profile"))
+	})
+
+	t.Run("flush_clears_other_instances", func(t *testing.T) {
+		require.NoError(t, b.local.Set(ctx, "unrelated", 1, time.Minute))
+
+		require.NoError(t, a.redis.Flush(ctx))
+
+		assert.Eventually(t, evicted(b, "unrelated"), time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("custom_events_and_channels", func(t *testing.T) {
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		defer client.Close()
+
+		publisher, err := driver.NewInvalidationBus(client, config.InvalidationConfig{Channel: "other"})
+		require.NoError(t, err)
+		defer publisher.Close()
+
+		local := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+		defer local.Close()
+		subscriber, err := driver.NewInvalidationBus(client, config.InvalidationConfig{Channel: "other"})
+		require.NoError(t, err)
+		subscriber.Register(local)
+
+		require.NoError(t, local.Set(ctx, "key", "value", time.Minute))
+		require.NoError(t, b.local.Set(ctx, "key", "value", time.Minute))
+
+		require.NoError(t, publisher.Publish(ctx, driver.InvalidationEvent{Keys: []string{"key"}}))
+		assert.Eventually(t, func() bool { return !local.Has(ctx, "key") }, time.Second, 5*time.Millisecond)
+
+		// Sự kiện trên kênh khác không ảnh hưởng tới kênh mặc định
+		assert.True(t, b.local.Has(ctx, "key"))
+
+		assert.NoError(t, subscriber.Close())
+		assert.NoError(t, subscriber.Close())
+	})
+}
+
+func TestNewInvalidationBus_Errors(t *testing.T) {
+	_, err := driver.NewInvalidationBus(nil, config.InvalidationConfig{})
+	assert.EqualError(t, err, "redis client cannot be nil")
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+	server.Close()
+
+	_, err = driver.NewRedisDriver(config.DriverRedisConfig{
+		Enabled:      true,
+		Invalidation: config.InvalidationConfig{Enabled: true, Channel: "events"},
+	}, &mockRedisManager{client: client})
+	assert.ErrorContains(t, err, "could not subscribe to invalidation channel 'events'")
+}
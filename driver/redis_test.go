@@ -3,20 +3,27 @@ package driver_test
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/gob"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/go-redis/redismock/v9"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/vmihailenco/msgpack/v5"
+	"go.fork.vn/cache/compression"
 	"go.fork.vn/cache/config"
 	"go.fork.vn/cache/driver"
+	"go.fork.vn/cache/encryption"
 	redispkg "go.fork.vn/redis"
 )
 
@@ -710,7 +717,7 @@ func TestRedisDriver_InterfaceMethods(t *testing.T) {
 		testRedisDriver, err := driver.NewRedisDriver(testConfig, testMockManager)
 		require.NoError(t, err)
 
-		mock.ExpectKeys("cache:*").SetVal([]string{"cache:key1", "cache:key2"})
+		mock.ExpectScan(0, "cache:*", 100).SetVal([]string{"cache:key1", "cache:key2"}, 0)
 		mock.ExpectInfo().SetVal("redis_version:7.0.0\nused_memory:1024\n")
 
 		stats := testRedisDriver.Stats(ctx)
@@ -1000,6 +1007,1020 @@ func TestRedisDriver_WithSerializer(t *testing.T) {
 	t.Run("Unknown_Serializer_Fallback", func(t *testing.T) {
 		jsonDriver := redisDriver.WithSerializer("unknown")
 		assert.NotNil(t, jsonDriver)
-		assert.NotEqual(t, redisDriver, jsonDriver) // Should be a new instance
+		assert.NotSame(t, redisDriver, jsonDriver) // Should be a new instance
+	})
+}
+
+// TestRedisDriver_GetInto kiểm tra việc giải mã trực tiếp vào kiểu đích
+func TestRedisDriver_GetInto(t *testing.T) {
+	ctx := context.Background()
+
+	type profile struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	newDriver := func(t *testing.T) (driver.TypedGetter, redismock.ClientMock) {
+		client, mock := redismock.NewClientMock()
+		redisDriver, err := driver.NewRedisDriver(config.DriverRedisConfig{
+			Enabled:    true,
+			DefaultTTL: 300,
+			Serializer: "json",
+		}, &mockRedisManager{client: client})
+		require.NoError(t, err)
+		getter, ok := redisDriver.(driver.TypedGetter)
+		require.True(t, ok, "redis driver should implement TypedGetter")
+		return getter, mock
+	}
+
+	t.Run("Decodes_Into_Struct", func(t *testing.T) {
+		redisDriver, mock := newDriver(t)
+		data, _ := json.Marshal(profile{Name: "Chi", Age: 25})
+		mock.ExpectGet("cache:typed:profile").SetVal(string(data))
+
+		var result profile
+		found, err := redisDriver.GetInto(ctx, "typed:profile", &result)
+
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, profile{Name: "Chi", Age: 25}, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Missing_Key", func(t *testing.T) {
+		redisDriver, mock := newDriver(t)
+		mock.ExpectGet("cache:typed:missing").RedisNil()
+
+		var result profile
+		found, err := redisDriver.GetInto(ctx, "typed:missing", &result)
+
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("Type_Mismatch", func(t *testing.T) {
+		redisDriver, mock := newDriver(t)
+		mock.ExpectGet("cache:typed:string").SetVal(`"plain string"`)
+
+		var result profile
+		found, err := redisDriver.GetInto(ctx, "typed:string", &result)
+
+		assert.True(t, found)
+		assert.ErrorIs(t, err, driver.ErrTypeMismatch)
+	})
+
+	t.Run("Redis_Error", func(t *testing.T) {
+		redisDriver, mock := newDriver(t)
+		mock.ExpectGet("cache:typed:error").SetErr(errors.New("connection refused"))
+
+		var result profile
+		found, err := redisDriver.GetInto(ctx, "typed:error", &result)
+
+		assert.False(t, found)
+		assert.Error(t, err)
+	})
+}
+
+func TestRedisDriver_Tags(t *testing.T) {
+	ctx := context.Background()
+
+	newDriver := func(t *testing.T) (driver.RedisDriver, redismock.ClientMock) {
+		client, mock := redismock.NewClientMock()
+		redisDriver, err := driver.NewRedisDriver(config.DriverRedisConfig{
+			Enabled:    true,
+			DefaultTTL: 300,
+			Serializer: "json",
+		}, &mockRedisManager{client: client})
+		require.NoError(t, err)
+		return redisDriver, mock
+	}
+
+	t.Run("SetTagged_Writes_Value_And_Tag_Sets", func(t *testing.T) {
+		redisDriver, mock := newDriver(t)
+		mock.ExpectTxPipeline()
+		mock.ExpectSet("cache:user:42", []byte(`"An"`), 0).SetVal("OK")
+		mock.ExpectZAdd("cache:__tag:org:7", redis.Z{Score: math.Inf(1), Member: "cache:user:42"}).SetVal(1)
+		mock.Regexp().ExpectZRemRangeByScore("cache:__tag:org:7", "-inf", `\(\d+`).SetVal(0)
+		mock.ExpectTxPipelineExec()
+
+		err := redisDriver.SetTagged(ctx, "user:42", "An", -1, []string{"org:7"})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("FlushTags_Deletes_Members", func(t *testing.T) {
+		redisDriver, mock := newDriver(t)
+		mock.ExpectZRange("cache:__tag:org:7", 0, -1).SetVal([]string{"cache:user:42", "cache:user:43"})
+		mock.ExpectDel("cache:user:42", "cache:user:43").SetVal(2)
+		mock.ExpectZRem("cache:__tag:org:7", "cache:user:42", "cache:user:43").SetVal(2)
+		mock.ExpectZRange("cache:__tag:org:8", 0, -1).SetVal([]string{})
+
+		err := redisDriver.FlushTags(ctx, []string{"org:7", "org:8"})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("FlushTags_Redis_Error", func(t *testing.T) {
+		redisDriver, mock := newDriver(t)
+		mock.ExpectZRange("cache:__tag:org:7", 0, -1).SetErr(errors.New("connection refused"))
+
+		err := redisDriver.FlushTags(ctx, []string{"org:7"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRedisDriver_RememberLock(t *testing.T) {
+	ctx := context.Background()
+	lockToken := `^[0-9a-f]{32}$`
+
+	newDriver := func(t *testing.T) (driver.RedisDriver, redismock.ClientMock) {
+		client, mock := redismock.NewClientMock()
+		redisDriver, err := driver.NewRedisDriver(config.DriverRedisConfig{
+			Enabled:    true,
+			DefaultTTL: 300,
+			Serializer: "json",
+			RememberLock: config.RememberLockConfig{
+				Enabled:       true,
+				TTL:           10,
+				WaitTimeout:   1,
+				RetryInterval: 1,
+			},
+		}, &mockRedisManager{client: client})
+		require.NoError(t, err)
+		return redisDriver, mock
+	}
+
+	t.Run("Lock_Holder_Runs_Callback", func(t *testing.T) {
+		redisDriver, mock := newDriver(t)
+		mock.ExpectGet("cache:hot").RedisNil()
+		mock.Regexp().ExpectSetNX("cache:__lock:remember:hot", lockToken, 10*time.Second).SetVal(true)
+		mock.ExpectGet("cache:hot").RedisNil()
+		mock.ExpectSet("cache:hot", []byte(`"fresh"`), time.Minute).SetVal("OK")
+		mock.Regexp().ExpectEvalSha(`^[0-9a-f]{40}$`, []string{"cache:__lock:remember:hot"}, lockToken).SetVal(int64(1))
+
+		value, err := redisDriver.Remember(ctx, "hot", time.Minute, func() (interface{}, error) {
+			return "fresh", nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "fresh", value)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Waiter_Reads_Value_Written_By_Holder", func(t *testing.T) {
+		redisDriver, mock := newDriver(t)
+		mock.ExpectGet("cache:hot").RedisNil()
+		mock.Regexp().ExpectSetNX("cache:__lock:remember:hot", lockToken, 10*time.Second).SetVal(false)
+		mock.ExpectGet("cache:hot").SetVal(`"from-other-instance"`)
+
+		value, err := redisDriver.Remember(ctx, "hot", time.Minute, func() (interface{}, error) {
+			t.Fatal("callback must not run while another instance holds the lock")
+			return nil, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "from-other-instance", value)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Lock_Error_Falls_Back_To_Callback", func(t *testing.T) {
+		redisDriver, mock := newDriver(t)
+		mock.ExpectGet("cache:hot").RedisNil()
+		mock.Regexp().ExpectSetNX("cache:__lock:remember:hot", lockToken, 10*time.Second).SetErr(errors.New("connection refused"))
+		mock.ExpectSet("cache:hot", []byte(`"fresh"`), time.Minute).SetVal("OK")
+
+		value, err := redisDriver.Remember(ctx, "hot", time.Minute, func() (interface{}, error) {
+			return "fresh", nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "fresh", value)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Context_Cancelled_While_Waiting", func(t *testing.T) {
+		redisDriver, mock := newDriver(t)
+		mock.ExpectGet("cache:hot").RedisNil()
+		mock.Regexp().ExpectSetNX("cache:__lock:remember:hot", lockToken, 10*time.Second).SetVal(false)
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := redisDriver.Remember(cancelled, "hot", time.Minute, func() (interface{}, error) {
+			return "fresh", nil
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("User_Lock_Does_Not_Block_Remember", func(t *testing.T) {
+		redisDriver, mock := newDriver(t)
+		mock.ExpectSetNX("cache:__lock:hot", "owner", time.Minute).SetVal(true)
+		mock.ExpectGet("cache:hot").RedisNil()
+		mock.Regexp().ExpectSetNX("cache:__lock:remember:hot", lockToken, 10*time.Second).SetVal(true)
+		mock.ExpectGet("cache:hot").RedisNil()
+		mock.ExpectSet("cache:hot", []byte(`"fresh"`), time.Minute).SetVal("OK")
+		mock.Regexp().ExpectEvalSha(`^[0-9a-f]{40}$`, []string{"cache:__lock:remember:hot"}, lockToken).SetVal(int64(1))
+
+		acquired, err := redisDriver.(driver.Locker).TryLock(ctx, "hot", "owner", time.Minute)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		value, err := redisDriver.Remember(ctx, "hot", time.Minute, func() (interface{}, error) {
+			return "fresh", nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "fresh", value)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRedisDriver_RememberStale(t *testing.T) {
+	ctx := context.Background()
+
+	newDriver := func(t *testing.T) (driver.RedisDriver, redismock.ClientMock) {
+		client, mock := redismock.NewClientMock()
+		redisDriver, err := driver.NewRedisDriver(config.DriverRedisConfig{
+			Enabled:    true,
+			DefaultTTL: 300,
+			Serializer: "json",
+		}, &mockRedisManager{client: client})
+		require.NoError(t, err)
+		return redisDriver, mock
+	}
+
+	// envelope tạo giá trị Redis có thời điểm hết hạn mềm
+	envelope := func(softExpiration time.Time, payload string) string {
+		header := []byte{0xC1, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0}
+		binary.BigEndian.PutUint64(header[3:], uint64(softExpiration.UnixNano()))
+		return string(append(header, payload...))
+	}
+
+	// matchEnvelope kiểm tra lệnh SET ghi payload trong envelope với TTL (giây) là ttl + grace
+	matchEnvelope := func(payload string, expiration time.Duration) func(expected, actual []interface{}) error {
+		return func(expected, actual []interface{}) error {
+			data, ok := actual[2].([]byte)
+			if !ok || len(data) < 11 || data[0] != 0xC1 || string(data[11:]) != payload {
+				return fmt.Errorf("unexpected value %v", actual[2])
+			}
+			if fmt.Sprint(actual[4]) != strconv.FormatInt(int64(expiration.Seconds()), 10) {
+				return fmt.Errorf("unexpected expiration %v", actual[4])
+			}
+			return nil
+		}
+	}
+
+	t.Run("Miss_Stores_Envelope", func(t *testing.T) {
+		redisDriver, mock := newDriver(t)
+		mock.ExpectGet("cache:report").RedisNil()
+		mock.CustomMatch(matchEnvelope(`"v1"`, 11*time.Minute)).ExpectSet("cache:report", nil, 11*time.Minute).SetVal("OK")
+
+		value, err := redisDriver.RememberStale(ctx, "report", time.Minute, 10*time.Minute, func() (interface{}, error) {
+			return "v1", nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "v1", value)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Fresh_Envelope_Is_Returned", func(t *testing.T) {
+		redisDriver, mock := newDriver(t)
+		mock.ExpectGet("cache:report").SetVal(envelope(time.Now().Add(time.Minute), `"v1"`))
+
+		value, err := redisDriver.RememberStale(ctx, "report", time.Minute, 10*time.Minute, func() (interface{}, error) {
+			t.Fatal("callback must not run for a fresh value")
+			return nil, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "v1", value)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Stale_Envelope_Is_Returned_And_Refreshed", func(t *testing.T) {
+		redisDriver, mock := newDriver(t)
+		mock.ExpectGet("cache:report").SetVal(envelope(time.Now().Add(-time.Second), `"v1"`))
+		mock.CustomMatch(matchEnvelope(`"v2"`, 11*time.Minute)).ExpectSet("cache:report", nil, 11*time.Minute).SetVal("OK")
+
+		value, err := redisDriver.RememberStale(ctx, "report", time.Minute, 10*time.Minute, func() (interface{}, error) {
+			return "v2", nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "v1", value)
+		assert.Eventually(t, func() bool {
+			return mock.ExpectationsWereMet() == nil
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("Get_Reads_Envelope", func(t *testing.T) {
+		redisDriver, mock := newDriver(t)
+		mock.ExpectGet("cache:report").SetVal(envelope(time.Now().Add(-time.Second), `{"name":"An"}`))
+		mock.ExpectMGet("cache:report").SetVal([]interface{}{envelope(time.Now(), `"v1"`)})
+
+		value, found := redisDriver.Get(ctx, "report")
+		assert.True(t, found)
+		assert.Equal(t, map[string]interface{}{"name": "An"}, value)
+
+		values, missed := redisDriver.GetMultiple(ctx, []string{"report"})
+		assert.Empty(t, missed)
+		assert.Equal(t, "v1", values["report"])
+	})
+}
+
+func TestRedisDriver_Increment(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	redisDriver, err := driver.NewRedisDriver(config.DriverRedisConfig{
+		Enabled:    true,
+		DefaultTTL: 300,
+		Serializer: "json",
+	}, &mockRedisManager{client: client})
+	require.NoError(t, err)
+
+	t.Run("Missing_Key_Is_Created_With_TTL", func(t *testing.T) {
+		value, err := redisDriver.Increment(ctx, "views", 5, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), value)
+		assert.Equal(t, time.Minute, server.TTL("cache:views"))
+
+		// TTL chỉ được đặt khi key được tạo
+		value, err = redisDriver.Decrement(ctx, "views", 2, time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), value)
+		assert.Equal(t, time.Minute, server.TTL("cache:views"))
+
+		stored, found := redisDriver.Get(ctx, "views")
+		assert.True(t, found)
+		assert.Equal(t, float64(3), stored)
+	})
+
+	t.Run("Default_And_No_TTL", func(t *testing.T) {
+		_, err := redisDriver.Increment(ctx, "default", 1, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 300*time.Second, server.TTL("cache:default"))
+
+		_, err = redisDriver.Increment(ctx, "forever", 1, -1)
+		assert.NoError(t, err)
+		assert.Zero(t, server.TTL("cache:forever"))
+	})
+
+	t.Run("Float", func(t *testing.T) {
+		value, err := redisDriver.IncrementFloat(ctx, "score", 1.5, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, 1.5, value)
+
+		value, err = redisDriver.IncrementFloat(ctx, "score", -0.25, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, 1.25, value)
+	})
+
+	t.Run("Value_Written_By_Set", func(t *testing.T) {
+		require.NoError(t, redisDriver.Set(ctx, "count", 41, time.Minute))
+		value, err := redisDriver.Increment(ctx, "count", 1, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), value)
+	})
+
+	t.Run("Non_Numeric_Value", func(t *testing.T) {
+		require.NoError(t, redisDriver.Set(ctx, "name", "An", time.Minute))
+
+		_, err := redisDriver.Increment(ctx, "name", 1, 0)
+		assert.ErrorIs(t, err, driver.ErrNotNumeric)
+
+		_, err = redisDriver.IncrementFloat(ctx, "name", 1, 0)
+		assert.ErrorIs(t, err, driver.ErrNotNumeric)
+	})
+}
+
+func TestRedisDriver_ConditionalWrites(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	redisDriver, err := driver.NewRedisDriver(config.DriverRedisConfig{
+		Enabled:    true,
+		DefaultTTL: 300,
+		Serializer: "json",
+	}, &mockRedisManager{client: client})
+	require.NoError(t, err)
+
+	t.Run("Add", func(t *testing.T) {
+		added, err := redisDriver.Add(ctx, "idem", "first", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, added)
+		assert.Equal(t, time.Minute, server.TTL("cache:idem"))
+
+		added, err = redisDriver.Add(ctx, "idem", "second", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, added)
+
+		value, _ := redisDriver.Get(ctx, "idem")
+		assert.Equal(t, "first", value)
+
+		added, err = redisDriver.Add(ctx, "forever", "value", -1)
+		assert.NoError(t, err)
+		assert.True(t, added)
+		assert.Zero(t, server.TTL("cache:forever"))
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		replaced, err := redisDriver.Replace(ctx, "missing", "value", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, replaced)
+		assert.False(t, server.Exists("cache:missing"))
+
+		replaced, err = redisDriver.Replace(ctx, "idem", "replaced", 0)
+		assert.NoError(t, err)
+		assert.True(t, replaced)
+		assert.Equal(t, 300*time.Second, server.TTL("cache:idem"))
+	})
+
+	t.Run("GetAndSet", func(t *testing.T) {
+		old, found, err := redisDriver.GetAndSet(ctx, "token", "t1", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, found)
+		assert.Nil(t, old)
+
+		old, found, err = redisDriver.GetAndSet(ctx, "token", "t2", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "t1", old)
+		assert.Equal(t, time.Minute, server.TTL("cache:token"))
+	})
+
+	t.Run("GetAndDelete", func(t *testing.T) {
+		value, found, err := redisDriver.GetAndDelete(ctx, "token")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "t2", value)
+		assert.False(t, server.Exists("cache:token"))
+
+		_, found, err = redisDriver.GetAndDelete(ctx, "token")
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+}
+
+func TestRedisDriver_CompareAndSwap(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	redisDriver, err := driver.NewRedisDriver(config.DriverRedisConfig{
+		Enabled:    true,
+		DefaultTTL: 300,
+		Serializer: "json",
+	}, &mockRedisManager{client: client})
+	require.NoError(t, err)
+
+	_, token, found := redisDriver.GetWithVersion(ctx, "config")
+	assert.False(t, found)
+	assert.Empty(t, token)
+
+	require.NoError(t, redisDriver.Set(ctx, "config", "v1", time.Minute))
+	value, token, found := redisDriver.GetWithVersion(ctx, "config")
+	assert.True(t, found)
+	assert.Equal(t, "v1", value)
+
+	swapped, err := redisDriver.CompareAndSwap(ctx, "config", token, "v2", 2*time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, swapped)
+	assert.Equal(t, 2*time.Minute, server.TTL("cache:config"))
+
+	swapped, err = redisDriver.CompareAndSwap(ctx, "config", token, "v3", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, swapped)
+	value, _ = redisDriver.Get(ctx, "config")
+	assert.Equal(t, "v2", value)
+
+	_, token, _ = redisDriver.GetWithVersion(ctx, "config")
+	swapped, err = redisDriver.CompareAndSwap(ctx, "config", token, "v3", -1)
+	assert.NoError(t, err)
+	assert.True(t, swapped)
+	assert.Zero(t, server.TTL("cache:config"))
+
+	swapped, err = redisDriver.CompareAndSwap(ctx, "missing", token, "v1", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, swapped)
+	assert.False(t, server.Exists("cache:missing"))
+}
+
+func TestRedisDriver_TTLAndTouch(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	redisDriver, err := driver.NewRedisDriver(config.DriverRedisConfig{
+		Enabled:    true,
+		DefaultTTL: 300,
+		Serializer: "json",
+	}, &mockRedisManager{client: client})
+	require.NoError(t, err)
+
+	_, found := redisDriver.TTL(ctx, "missing")
+	assert.False(t, found)
+	touched, err := redisDriver.Touch(ctx, "missing", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, touched)
+	assert.False(t, server.Exists("cache:missing"))
+
+	require.NoError(t, redisDriver.Set(ctx, "session", "data", time.Minute))
+	ttl, found := redisDriver.TTL(ctx, "session")
+	assert.True(t, found)
+	assert.Equal(t, time.Minute, ttl)
+
+	touched, err = redisDriver.Touch(ctx, "session", time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, touched)
+	assert.Equal(t, time.Hour, server.TTL("cache:session"))
+
+	touched, err = redisDriver.Touch(ctx, "session", -1)
+	assert.NoError(t, err)
+	assert.True(t, touched)
+	ttl, found = redisDriver.TTL(ctx, "session")
+	assert.True(t, found)
+	assert.Equal(t, driver.NoExpiration, ttl)
+
+	touched, err = redisDriver.Touch(ctx, "session", 0)
+	assert.NoError(t, err)
+	assert.True(t, touched)
+	assert.Equal(t, 300*time.Second, server.TTL("cache:session"))
+}
+
+func TestRedisDriver_SlidingExpiration(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	redisDriver, err := driver.NewRedisDriver(config.DriverRedisConfig{
+		Enabled:    true,
+		DefaultTTL: 300,
+		Serializer: "json",
+		SlidingTTL: 60,
+	}, &mockRedisManager{client: client})
+	require.NoError(t, err)
+
+	require.NoError(t, redisDriver.Set(ctx, "session", "data", time.Second))
+	require.NoError(t, redisDriver.Set(ctx, "long", "data", time.Hour))
+	require.NoError(t, redisDriver.Set(ctx, "forever", "data", -1))
+
+	value, found := redisDriver.Get(ctx, "session")
+	assert.True(t, found)
+	assert.Equal(t, "data", value)
+	var target string
+	found, err = redisDriver.(driver.TypedGetter).GetInto(ctx, "long", &target)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	_, _ = redisDriver.Get(ctx, "forever")
+	_, found = redisDriver.Get(ctx, "missing")
+	assert.False(t, found)
+
+	assert.Equal(t, time.Minute, server.TTL("cache:session"))
+	assert.Equal(t, time.Hour, server.TTL("cache:long"))
+	assert.Zero(t, server.TTL("cache:forever"))
+}
+
+func TestRedisDriver_SlidingExpirationWithSerializer(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	redisDriver, err := driver.NewRedisDriver(config.DriverRedisConfig{
+		Enabled:    true,
+		DefaultTTL: 300,
+		Serializer: "json",
+		SlidingTTL: 60,
+	}, &mockRedisManager{client: client})
+	require.NoError(t, err)
+	msgpackDriver := redisDriver.WithSerializer("msgpack")
+
+	require.NoError(t, msgpackDriver.Set(ctx, "session", "data", time.Second))
+	value, found := msgpackDriver.Get(ctx, "session")
+	assert.True(t, found)
+	assert.Equal(t, "data", value)
+
+	assert.Equal(t, time.Minute, server.TTL("cache:session"))
+}
+
+func TestRedisDriver_Lock(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	redisDriver, err := driver.NewRedisDriver(config.DriverRedisConfig{
+		Enabled:    true,
+		DefaultTTL: 300,
+		Serializer: "json",
+	}, &mockRedisManager{client: client})
+	require.NoError(t, err)
+	locker := redisDriver.(driver.Locker)
+
+	acquired, err := locker.TryLock(ctx, "job", "owner-a", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	assert.Equal(t, time.Minute, server.TTL("cache:__lock:job"))
+
+	acquired, err = locker.TryLock(ctx, "job", "owner-b", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	refreshed, err := locker.RefreshLock(ctx, "job", "owner-b", time.Hour)
+	require.NoError(t, err)
+	assert.False(t, refreshed)
+	refreshed, err = locker.RefreshLock(ctx, "job", "owner-a", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, refreshed)
+	assert.Equal(t, time.Hour, server.TTL("cache:__lock:job"))
+
+	refreshed, err = locker.RefreshLock(ctx, "job", "owner-a", -1)
+	require.NoError(t, err)
+	assert.True(t, refreshed)
+	assert.Equal(t, time.Duration(0), server.TTL("cache:__lock:job"))
+
+	released, err := locker.Unlock(ctx, "job", "owner-b")
+	require.NoError(t, err)
+	assert.False(t, released)
+	released, err = locker.Unlock(ctx, "job", "owner-a")
+	require.NoError(t, err)
+	assert.True(t, released)
+	assert.False(t, server.Exists("cache:__lock:job"))
+
+	// Flush không xóa khóa đang được giữ
+	acquired, err = locker.TryLock(ctx, "job", "owner-a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	require.NoError(t, redisDriver.Set(ctx, "job", "value", 0))
+	require.NoError(t, redisDriver.Flush(ctx))
+	assert.False(t, server.Exists("cache:job"))
+	acquired, err = locker.TryLock(ctx, "job", "owner-b", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+}
+
+func TestRedisDriver_Keys(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	redisDriver, err := driver.NewRedisDriver(config.DriverRedisConfig{
+		Enabled:    true,
+		DefaultTTL: 300,
+		Serializer: "json",
+	}, &mockRedisManager{client: client})
+	require.NoError(t, err)
+
+	require.NoError(t, redisDriver.Set(ctx, "user:1", "v", 0))
+	require.NoError(t, redisDriver.SetTagged(ctx, "user:2", "v", 0, []string{"users"}))
+	require.NoError(t, redisDriver.Set(ctx, "post:1", "v", 0))
+	_, err = redisDriver.(driver.Locker).TryLock(ctx, "user:lock", "owner", time.Minute)
+	require.NoError(t, err)
+	// Key ngoài prefix của driver không được liệt kê
+	require.NoError(t, client.Set(ctx, "other:user:3", "v", 0).Err())
+
+	keys, err := redisDriver.Keys(ctx, "user:*")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"user:1", "user:2"}, keys)
+
+	keys, err = redisDriver.Keys(ctx, "")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"user:1", "user:2", "post:1"}, keys)
+
+	visited := 0
+	err = redisDriver.Scan(ctx, "*", func(key string) bool {
+		visited++
+		return false
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, visited)
+
+	_, err = redisDriver.Keys(ctx, "user:[1")
+	assert.Error(t, err)
+}
+
+func TestRedisDriver_DeleteMatching(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	redisDriver, err := driver.NewRedisDriver(config.DriverRedisConfig{
+		Enabled:    true,
+		DefaultTTL: 300,
+		Serializer: "json",
+	}, &mockRedisManager{client: client})
+	require.NoError(t, err)
+
+	// Nhiều hơn một batch để kiểm tra việc xóa theo batch
+	for i := 0; i < 250; i++ {
+		require.NoError(t, redisDriver.Set(ctx, fmt.Sprintf("user:42:item:%d", i), i, 0))
+	}
+	require.NoError(t, redisDriver.SetTagged(ctx, "user:43:profile", "v", 0, []string{"user:42"}))
+	require.NoError(t, client.Set(ctx, "other:user:42:x", "v", 0).Err())
+
+	deleted, err := redisDriver.DeleteByPrefix(ctx, "user:42:")
+	require.NoError(t, err)
+	assert.Equal(t, 250, deleted)
+
+	keys, _ := redisDriver.Keys(ctx, "*")
+	assert.Equal(t, []string{"user:43:profile"}, keys)
+	// Key nội bộ và key ngoài prefix của driver không bị xóa
+	assert.True(t, server.Exists("cache:__tag:user:42"))
+	assert.True(t, server.Exists("other:user:42:x"))
+
+	deleted, err = redisDriver.DeleteMatching(ctx, "*")
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.True(t, server.Exists("cache:__tag:user:42"))
+}
+
+func TestRedisDriver_Codecs(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	newDriver := func(serializer string) driver.RedisDriver {
+		redisDriver, err := driver.NewRedisDriver(config.DriverRedisConfig{
+			Enabled:    true,
+			DefaultTTL: 300,
+			Serializer: serializer,
+		}, &mockRedisManager{client: client})
+		require.NoError(t, err)
+		return redisDriver
+	}
+	jsonDriver := newDriver("json")
+	msgpackDriver := newDriver("msgpack")
+
+	t.Run("Json_Values_Stay_Plain", func(t *testing.T) {
+		require.NoError(t, jsonDriver.Set(ctx, "plain", "An", time.Minute))
+
+		raw, err := server.Get("cache:plain")
+		require.NoError(t, err)
+		assert.Equal(t, `"An"`, raw)
+	})
+
+	t.Run("Values_Carry_Codec_Name", func(t *testing.T) {
+		require.NoError(t, msgpackDriver.Set(ctx, "tagged", map[string]interface{}{"name": "An"}, time.Minute))
+
+		raw, err := server.Get("cache:tagged")
+		require.NoError(t, err)
+		assert.Equal(t, byte(0xC1), raw[0])
+		assert.Equal(t, "msgpack", raw[4:4+int(raw[3])])
+	})
+
+	t.Run("Switching_Codec_Keeps_Old_Entries_Readable", func(t *testing.T) {
+		require.NoError(t, jsonDriver.Set(ctx, "written-as-json", map[string]interface{}{"name": "An"}, time.Minute))
+		require.NoError(t, msgpackDriver.Set(ctx, "written-as-msgpack", "Binh", time.Minute))
+
+		value, found := msgpackDriver.Get(ctx, "written-as-json")
+		assert.True(t, found)
+		assert.Equal(t, map[string]interface{}{"name": "An"}, value)
+
+		value, found = jsonDriver.Get(ctx, "written-as-msgpack")
+		assert.True(t, found)
+		assert.Equal(t, "Binh", value)
+
+		values, missed := jsonDriver.GetMultiple(ctx, []string{"written-as-json", "written-as-msgpack"})
+		assert.Empty(t, missed)
+		assert.Equal(t, "Binh", values["written-as-msgpack"])
+
+		var name string
+		found, err := jsonDriver.(driver.TypedGetter).GetInto(ctx, "written-as-msgpack", &name)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "Binh", name)
+	})
+
+	t.Run("Legacy_Untagged_Values_Use_Configured_Codec", func(t *testing.T) {
+		data, err := msgpack.Marshal(map[string]interface{}{"name": "Chi"})
+		require.NoError(t, err)
+		require.NoError(t, server.Set("cache:legacy", string(data)))
+
+		value, found := msgpackDriver.Get(ctx, "legacy")
+		assert.True(t, found)
+		assert.Equal(t, map[string]interface{}{"name": "Chi"}, value)
+	})
+
+	t.Run("Stale_Values_Carry_Codec_Name", func(t *testing.T) {
+		value, err := msgpackDriver.RememberStale(ctx, "report", time.Minute, time.Minute, func() (interface{}, error) {
+			return "v1", nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "v1", value)
+
+		value, found := jsonDriver.Get(ctx, "report")
+		assert.True(t, found)
+		assert.Equal(t, "v1", value)
+	})
+
+	t.Run("Unregistered_Codec_Is_A_Miss", func(t *testing.T) {
+		require.NoError(t, server.Set("cache:unknown", "\xC1\x01\x02\x04cbor\xa1"))
+
+		_, found := jsonDriver.Get(ctx, "unknown")
+		assert.False(t, found)
+	})
+}
+
+func TestRedisDriver_Compression(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	newDriver := func(algorithm string) driver.RedisDriver {
+		redisDriver, err := driver.NewRedisDriver(config.DriverRedisConfig{
+			Enabled:     true,
+			DefaultTTL:  300,
+			Serializer:  "json",
+			Compression: config.CompressionConfig{Algorithm: algorithm, MinSize: 64},
+		}, &mockRedisManager{client: client})
+		require.NoError(t, err)
+		return redisDriver
+	}
+	large := map[string]interface{}{"payload": strings.Repeat("cache compression ", 100)}
+
+	for _, algorithm := range []string{"gzip", "zstd", "snappy", "lz4"} {
+		t.Run("Round_Trip_"+algorithm, func(t *testing.T) {
+			d := newDriver(algorithm)
+			require.NoError(t, d.Set(ctx, "large:"+algorithm, large, time.Minute))
+
+			raw, err := server.Get("cache:large:" + algorithm)
+			require.NoError(t, err)
+			assert.Equal(t, byte(0xC1), raw[0])
+			assert.Less(t, len(raw), 1000)
+
+			value, found := d.Get(ctx, "large:"+algorithm)
+			assert.True(t, found)
+			assert.Equal(t, large, value)
+		})
+	}
+
+	t.Run("Small_Values_Stay_Uncompressed", func(t *testing.T) {
+		d := newDriver("zstd")
+		require.NoError(t, d.Set(ctx, "small", "An", time.Minute))
+
+		raw, err := server.Get("cache:small")
+		require.NoError(t, err)
+		assert.Equal(t, `"An"`, raw)
+	})
+
+	t.Run("Compressed_And_Uncompressed_Entries_Coexist", func(t *testing.T) {
+		plain := newDriver("")
+		compressed := newDriver("lz4")
+		require.NoError(t, plain.Set(ctx, "written-plain", large, time.Minute))
+		require.NoError(t, compressed.Set(ctx, "written-compressed", large, time.Minute))
+
+		value, found := compressed.Get(ctx, "written-plain")
+		assert.True(t, found)
+		assert.Equal(t, large, value)
+
+		value, found = plain.Get(ctx, "written-compressed")
+		assert.True(t, found)
+		assert.Equal(t, large, value)
+	})
+
+	t.Run("Stats_Report_Compression_Ratio", func(t *testing.T) {
+		d := newDriver("gzip")
+		require.NoError(t, d.Set(ctx, "stats", large, time.Minute))
+
+		stats := d.Stats(ctx)
+		assert.Equal(t, "gzip", stats["compression"])
+		assert.Equal(t, int64(1), stats["compressed_values"])
+		assert.Greater(t, stats["compression_ratio"].(float64), 1.0)
+		assert.Greater(t, stats["bytes_before_compression"].(int64), stats["bytes_after_compression"].(int64))
+	})
+
+	t.Run("Unknown_Algorithm_Is_Rejected", func(t *testing.T) {
+		_, err := driver.NewRedisDriver(config.DriverRedisConfig{
+			Enabled:     true,
+			Compression: config.CompressionConfig{Algorithm: "brotli"},
+		}, &mockRedisManager{client: client})
+		assert.ErrorIs(t, err, compression.ErrUnknownCompressor)
+	})
+}
+
+func TestRedisDriver_Encryption(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	newDriver := func(enc *config.EncryptionConfig) driver.RedisDriver {
+		redisDriver, err := driver.NewRedisDriver(config.DriverRedisConfig{
+			Enabled:    true,
+			DefaultTTL: 300,
+			Serializer: "json",
+			Encryption: enc,
+		}, &mockRedisManager{client: client})
+		require.NoError(t, err)
+		return redisDriver
+	}
+
+	t.Run("Value_Is_Not_Stored_In_Plaintext", func(t *testing.T) {
+		d := newDriver(encryptionConfig("k1", "k1"))
+		require.NoError(t, d.Set(ctx, "user:1", "secret-value", time.Minute))
+
+		raw, err := server.Get("cache:user:1")
+		require.NoError(t, err)
+		assert.Equal(t, byte(0xC1), raw[0])
+		assert.NotContains(t, raw, "secret-value")
+
+		value, found := d.Get(ctx, "user:1")
+		assert.True(t, found)
+		assert.Equal(t, "secret-value", value)
+		assert.Equal(t, "k1", d.Stats(ctx)["encryption_key"])
+	})
+
+	t.Run("Rotation_And_Reencrypt", func(t *testing.T) {
+		server.FlushAll()
+		old := newDriver(encryptionConfig("k1", "k1"))
+		require.NoError(t, old.Set(ctx, "a", "value-a", time.Minute))
+		require.NoError(t, old.Set(ctx, "forever", "value-forever", -1))
+		_, err := old.Increment(ctx, "counter", 5, time.Minute)
+		require.NoError(t, err)
+
+		d := newDriver(encryptionConfig("k2", "k1", "k2"))
+		value, found := d.Get(ctx, "a")
+		assert.True(t, found)
+		assert.Equal(t, "value-a", value)
+
+		count, err := d.(driver.Reencrypter).Reencrypt(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		// TTL được giữ nguyên
+		assert.Greater(t, server.TTL("cache:a"), time.Duration(0))
+		assert.Equal(t, time.Duration(0), server.TTL("cache:forever"))
+
+		current := newDriver(encryptionConfig("k2", "k2"))
+		for _, key := range []string{"a", "forever"} {
+			value, found := current.Get(ctx, key)
+			assert.True(t, found, key)
+			assert.Equal(t, "value-"+key, value)
+		}
+		counter, err := current.Increment(ctx, "counter", 1, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), counter)
+	})
+
+	t.Run("Tampered_Entry_Is_An_Error", func(t *testing.T) {
+		d := newDriver(encryptionConfig("k1", "k1"))
+		require.NoError(t, d.Set(ctx, "user:2", "secret-value", time.Minute))
+
+		raw, err := server.Get("cache:user:2")
+		require.NoError(t, err)
+		tampered := []byte(raw)
+		tampered[len(tampered)-1] ^= 0x01
+		require.NoError(t, server.Set("cache:user:2", string(tampered)))
+
+		_, found := d.Get(ctx, "user:2")
+		assert.False(t, found)
+
+		var target string
+		_, err = d.(driver.TypedGetter).GetInto(ctx, "user:2", &target)
+		assert.ErrorIs(t, err, encryption.ErrAuthentication)
+
+		_, err = d.Remember(ctx, "user:2", time.Minute, func() (interface{}, error) {
+			return "fresh", nil
+		})
+		assert.ErrorIs(t, err, encryption.ErrAuthentication)
+
+		_, err = d.RememberStale(ctx, "user:2", time.Minute, time.Minute, func() (interface{}, error) {
+			return "fresh", nil
+		})
+		assert.ErrorIs(t, err, encryption.ErrAuthentication)
+
+		_, _, err = d.GetAndDelete(ctx, "user:2")
+		assert.ErrorIs(t, err, encryption.ErrAuthentication)
+		assert.Equal(t, int64(5), d.Stats(ctx)["decryption_failures"])
+	})
+
+	t.Run("Entry_Copied_To_Another_Key_Is_An_Error", func(t *testing.T) {
+		d := newDriver(encryptionConfig("k1", "k1"))
+		require.NoError(t, d.Set(ctx, "source", "secret-value", time.Minute))
+
+		raw, err := server.Get("cache:source")
+		require.NoError(t, err)
+		require.NoError(t, server.Set("cache:copy", raw))
+
+		var target string
+		_, err = d.(driver.TypedGetter).GetInto(ctx, "copy", &target)
+		assert.ErrorIs(t, err, encryption.ErrAuthentication)
 	})
 }
@@ -0,0 +1,314 @@
+package driver
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"go.fork.vn/cache/config"
+)
+
+// errRememberAborted được trả về cho các goroutine đang chờ khi callback của
+// goroutine dẫn đầu kết thúc bằng panic.
+var errRememberAborted = errors.New("remember callback aborted")
+
+// flightCall là một lần thực thi đang diễn ra của flightGroup.
+type flightCall struct {
+	wg    sync.WaitGroup // Được giải phóng khi lần thực thi kết thúc
+	value interface{}    // Kết quả của lần thực thi
+	err   error          // Lỗi của lần thực thi
+}
+
+// flightGroup gộp các lần gọi đồng thời cho cùng một key thành một lần thực thi.
+//
+// Giá trị zero của flightGroup sẵn sàng để sử dụng.
+type flightGroup struct {
+	mu    sync.Mutex             // Mutex bảo vệ calls
+	calls map[string]*flightCall // Các lần thực thi đang diễn ra theo key
+}
+
+// do thực thi fn cho key, đảm bảo tại một thời điểm chỉ có một lần thực thi cho mỗi key.
+//
+// Các goroutine gọi do trong lúc fn đang chạy sẽ chờ và nhận cùng kết quả.
+//
+// Params:
+//   - key: Key dùng để gộp các lần gọi
+//   - fn: Hàm cần thực thi
+//
+// Returns:
+//   - interface{}: Kết quả của fn
+//   - error: Lỗi của fn
+func (g *flightGroup) do(key string, fn func() (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		call.wg.Wait()
+		return call.value, call.err
+	}
+	call := &flightCall{err: errRememberAborted}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		call.wg.Done()
+	}()
+
+	call.value, call.err = fn()
+	return call.value, call.err
+}
+
+// start thực thi fn cho key trong một goroutine riêng nếu key chưa có lần thực thi
+// nào đang diễn ra.
+//
+// Params:
+//   - key: Key dùng để gộp các lần gọi
+//   - fn: Hàm cần thực thi
+//
+// Returns:
+//   - bool: true nếu một lần thực thi mới được khởi chạy
+func (g *flightGroup) start(key string, fn func() (interface{}, error)) bool {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	if _, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		return false
+	}
+	call := &flightCall{err: errRememberAborted}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	go func() {
+		defer func() {
+			// Lần làm mới chạy nền không có caller để nhận panic
+			_ = recover()
+			g.mu.Lock()
+			delete(g.calls, key)
+			g.mu.Unlock()
+			call.wg.Done()
+		}()
+		call.value, call.err = fn()
+	}()
+	return true
+}
+
+// rememberLockPrefix là tiền tố tên khóa của Remember, tách khóa của Remember khỏi khóa
+// mà người dùng giành bằng Manager.Lock với cùng tên.
+const rememberLockPrefix = "remember:"
+
+// rememberLock điều phối Remember giữa nhiều instance bằng khóa phân tán.
+//
+// Con trỏ nil hợp lệ và có nghĩa là không sử dụng khóa phân tán. Khóa của key được
+// giành với tên "remember:<key>".
+type rememberLock struct {
+	backend       Locker        // Khóa phân tán
+	ttl           time.Duration // Thời gian giữ khóa tối đa
+	waitTimeout   time.Duration // Thời gian chờ tối đa của instance không giữ khóa
+	retryInterval time.Duration // Khoảng thời gian giữa các lần kiểm tra lại
+}
+
+// newRememberLock tạo rememberLock từ cấu hình.
+//
+// Params:
+//   - backend: Khóa phân tán của driver
+//   - cfg: Cấu hình khóa
+//
+// Returns:
+//   - *rememberLock: nil nếu khóa phân tán không được bật
+func newRememberLock(backend Locker, cfg config.RememberLockConfig) *rememberLock {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &rememberLock{
+		backend:       backend,
+		ttl:           cfg.GetTTL(),
+		waitTimeout:   cfg.GetWaitTimeout(),
+		retryInterval: cfg.GetRetryInterval(),
+	}
+}
+
+// load lấy giá trị cho key, chỉ để một instance thực thi load tại một thời điểm.
+//
+// Instance giành được khóa kiểm tra lại cache rồi thực thi load. Các instance khác
+// kiểm tra cache định kỳ và thử giành lại khóa cho đến khi có giá trị, khóa được
+// giải phóng hoặc hết thời gian chờ. Khi khóa phân tán gặp lỗi hoặc hết thời gian
+// chờ, load được thực thi trực tiếp để cache không trở thành điểm lỗi duy nhất.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key
+//   - lookup: Hàm đọc giá trị có thể dùng được từ cache
+//   - load: Hàm thực thi callback và ghi kết quả vào cache
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ load
+//   - error: Lỗi từ load hoặc lỗi context khi đang chờ
+func (l *rememberLock) load(ctx context.Context, key string, lookup func() (interface{}, bool), load func() (interface{}, error)) (interface{}, error) {
+	if l == nil {
+		return load()
+	}
+
+	token, err := newLockToken()
+	if err != nil {
+		return load()
+	}
+
+	name := rememberLockPrefix + key
+	deadline := time.Now().Add(l.waitTimeout)
+	for {
+		acquired, err := l.backend.TryLock(ctx, name, token, l.ttl)
+		if err != nil {
+			return load()
+		}
+		if acquired {
+			defer l.backend.Unlock(context.WithoutCancel(ctx), name, token)
+
+			// Instance giữ khóa trước đó có thể vừa ghi giá trị
+			if value, found := lookup(); found {
+				return value, nil
+			}
+			return load()
+		}
+
+		if !time.Now().Before(deadline) {
+			return load()
+		}
+
+		timer := time.NewTimer(l.retryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		if value, found := lookup(); found {
+			return value, nil
+		}
+	}
+}
+
+// newLockToken tạo token ngẫu nhiên định danh chủ sở hữu khóa.
+//
+// Returns:
+//   - string: Token dạng hex
+//   - error: Lỗi nếu không đọc được dữ liệu ngẫu nhiên
+func newLockToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// staleStore là driver lưu được thời điểm hết hạn mềm của entry, dùng cho RememberStale.
+type staleStore interface {
+	// getStale lấy giá trị cùng thời điểm hết hạn mềm (UnixNano, 0 nếu không có); lỗi
+	// được trả về khi entry tồn tại nhưng không giải mã được
+	getStale(ctx context.Context, key string) (interface{}, int64, bool, error)
+	// setStale lưu giá trị còn mới trong ttl và được phục vụ thêm trong grace
+	setStale(ctx context.Context, key string, value interface{}, ttl, grace time.Duration) error
+}
+
+// rememberStale cài đặt stale-while-revalidate cho RememberStale.
+//
+// Entry còn mới được trả về ngay. Entry đã qua hạn mềm nhưng còn trong grace được
+// trả về ngay, đồng thời callback được thực thi trong nền (tối đa một lần cho mỗi key)
+// để làm mới giá trị. Khi không có entry, callback được thực thi như Remember. Entry
+// không giải mã được là lỗi: callback không được thực thi và entry không bị ghi đè.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - store: Driver lưu giá trị
+//   - flights: Nhóm gộp các lần gọi đồng thời của driver
+//   - lock: Khóa phân tán của driver, nil nếu không bật
+//   - key: Cache key
+//   - ttl: Thời gian giá trị được coi là còn mới
+//   - grace: Thời gian giá trị cũ tiếp tục được phục vụ sau ttl
+//   - callback: Hàm được gọi để lấy dữ liệu
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi từ callback, lỗi giải mã entry hoặc lỗi từ driver
+func rememberStale(ctx context.Context, store staleStore, flights *flightGroup, lock *rememberLock, key string, ttl, grace time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	refresh := func(ctx context.Context) func() (interface{}, error) {
+		// Chỉ giá trị còn mới mới được coi là kết quả của một lần làm mới
+		lookup := func() (interface{}, bool) {
+			value, softExpiration, found, err := store.getStale(ctx, key)
+			if err != nil || !found || isStale(softExpiration) {
+				return nil, false
+			}
+			return value, true
+		}
+		return func() (interface{}, error) {
+			return lock.load(ctx, key, lookup, func() (interface{}, error) {
+				value, err := callback()
+				if err != nil {
+					return nil, err
+				}
+				return value, store.setStale(ctx, key, value, ttl, grace)
+			})
+		}
+	}
+
+	value, softExpiration, found, err := store.getStale(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		if isStale(softExpiration) {
+			// Làm mới trong nền, không phụ thuộc vào việc request hiện tại kết thúc
+			flights.start(key, refresh(context.WithoutCancel(ctx)))
+		}
+		return value, nil
+	}
+
+	return flights.do(key, refresh(ctx))
+}
+
+// isStale kiểm tra thời điểm hết hạn mềm đã qua hay chưa.
+//
+// Params:
+//   - softExpiration: Thời điểm hết hạn mềm (UnixNano), 0 nếu không có
+//
+// Returns:
+//   - bool: true nếu giá trị đã cũ
+func isStale(softExpiration int64) bool {
+	return softExpiration > 0 && time.Now().UnixNano() > softExpiration
+}
+
+// staleExpirations tính thời điểm hết hạn cứng và mềm của một entry.
+//
+// Params:
+//   - ttl: Thời gian giá trị được coi là còn mới (0 để sử dụng defaultTTL, âm để không hết hạn)
+//   - grace: Thời gian giá trị cũ tiếp tục được phục vụ sau ttl
+//   - defaultTTL: TTL mặc định của driver
+//
+// Returns:
+//   - int64: Thời điểm hết hạn cứng (UnixNano), 0 nếu không hết hạn
+//   - int64: Thời điểm hết hạn mềm (UnixNano), 0 nếu không có
+func staleExpirations(ttl, grace, defaultTTL time.Duration) (int64, int64) {
+	if ttl == 0 {
+		ttl = defaultTTL
+	}
+	if ttl <= 0 {
+		return 0, 0
+	}
+	now := time.Now()
+	if grace <= 0 {
+		return now.Add(ttl).UnixNano(), 0
+	}
+	return now.Add(ttl + grace).UnixNano(), now.Add(ttl).UnixNano()
+}
@@ -0,0 +1,141 @@
+package driver
+
+import (
+	"reflect"
+)
+
+// Sizer là interface tùy chọn cho các giá trị tự báo kích thước của chúng.
+//
+// Memory driver sử dụng CacheSize để tính dung lượng của entry khi cấu hình
+// max_bytes. Các giá trị không cài đặt Sizer sẽ được ước lượng bằng reflection.
+type Sizer interface {
+	// CacheSize trả về kích thước ước lượng của giá trị tính theo byte.
+	CacheSize() int64
+}
+
+// Kích thước header ước lượng của các kiểu có phần dữ liệu nằm ngoài giá trị.
+const (
+	stringHeaderSize    = 16
+	sliceHeaderSize     = 24
+	mapHeaderSize       = 48
+	interfaceHeaderSize = 16
+	pointerSize         = 8
+)
+
+// estimateSize ước lượng dung lượng bộ nhớ của một entry.
+//
+// Kết quả bao gồm độ dài của key và kích thước của giá trị. Giá trị cài đặt
+// Sizer được tin tưởng tuyệt đối; các giá trị khác được duyệt bằng reflection,
+// mỗi vùng nhớ được trỏ tới chỉ được tính một lần.
+//
+// Params:
+//   - key: Cache key
+//   - value: Giá trị cần ước lượng
+//
+// Returns:
+//   - int64: Kích thước ước lượng tính theo byte
+func estimateSize(key string, value interface{}) int64 {
+	size := int64(len(key)) + stringHeaderSize
+	if sizer, ok := value.(Sizer); ok {
+		return size + sizer.CacheSize()
+	}
+	if value == nil {
+		return size
+	}
+	return size + sizeOfValue(reflect.ValueOf(value), make(map[uintptr]struct{}))
+}
+
+// sizeOfValue ước lượng kích thước của một reflect.Value.
+//
+// Params:
+//   - v: Giá trị cần ước lượng
+//   - seen: Các con trỏ đã được tính, tránh tính trùng và vòng lặp vô hạn
+//
+// Returns:
+//   - int64: Kích thước ước lượng tính theo byte
+func sizeOfValue(v reflect.Value, seen map[uintptr]struct{}) int64 {
+	switch v.Kind() {
+	case reflect.String:
+		return stringHeaderSize + int64(v.Len())
+	case reflect.Ptr:
+		if v.IsNil() || visited(v.Pointer(), seen) {
+			return pointerSize
+		}
+		return pointerSize + sizeOfValue(v.Elem(), seen)
+	case reflect.Interface:
+		if v.IsNil() {
+			return interfaceHeaderSize
+		}
+		return interfaceHeaderSize + sizeOfValue(v.Elem(), seen)
+	case reflect.Slice:
+		if v.IsNil() || visited(v.Pointer(), seen) {
+			return sliceHeaderSize
+		}
+		return sliceHeaderSize + sizeOfElements(v, seen)
+	case reflect.Array:
+		return sizeOfElements(v, seen)
+	case reflect.Map:
+		if v.IsNil() || visited(v.Pointer(), seen) {
+			return pointerSize
+		}
+		size := int64(mapHeaderSize)
+		iter := v.MapRange()
+		for iter.Next() {
+			size += sizeOfValue(iter.Key(), seen) + sizeOfValue(iter.Value(), seen)
+		}
+		return size
+	case reflect.Struct:
+		var size int64
+		for i := 0; i < v.NumField(); i++ {
+			size += sizeOfValue(v.Field(i), seen)
+		}
+		return size
+	default:
+		return int64(v.Type().Size())
+	}
+}
+
+// sizeOfElements ước lượng tổng kích thước các phần tử của slice hoặc array.
+//
+// Với phần tử có kích thước cố định (không chứa con trỏ), kết quả được tính
+// trực tiếp mà không cần duyệt từng phần tử.
+func sizeOfElements(v reflect.Value, seen map[uintptr]struct{}) int64 {
+	if isFixedSize(v.Type().Elem()) {
+		return int64(v.Len()) * int64(v.Type().Elem().Size())
+	}
+	var size int64
+	for i := 0; i < v.Len(); i++ {
+		size += sizeOfValue(v.Index(i), seen)
+	}
+	return size
+}
+
+// isFixedSize kiểm tra một kiểu có kích thước cố định hay không.
+func isFixedSize(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array:
+		return isFixedSize(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !isFixedSize(t.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// visited đánh dấu con trỏ đã được tính và trả về true nếu nó đã có trước đó.
+func visited(ptr uintptr, seen map[uintptr]struct{}) bool {
+	if _, ok := seen[ptr]; ok {
+		return true
+	}
+	seen[ptr] = struct{}{}
+	return false
+}
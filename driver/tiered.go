@@ -0,0 +1,779 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"go.fork.vn/cache/config"
+)
+
+// TieredDriver là driver xếp chồng nhiều driver thành các tầng cache.
+type TieredDriver interface {
+	Driver
+
+	// Tiers trả về danh sách các tầng theo thứ tự từ L1 xuống tầng cuối.
+	//
+	// Returns:
+	//   - []Driver: Bản sao danh sách các tầng
+	Tiers() []Driver
+}
+
+// tieredDriver cài đặt cache nhiều tầng, ví dụ L1 memory phía trước L2 redis.
+//
+// Thao tác đọc kiểm tra lần lượt từ tầng trên xuống. Khi tìm thấy giá trị ở một tầng
+// dưới, giá trị được chép ngược lên các tầng phía trên với backfillTTL, thường ngắn hơn
+// TTL ở tầng dưới để các tầng trên không giữ dữ liệu cũ quá lâu.
+//
+// Thao tác ghi và xóa được áp dụng cho mọi tầng, bắt đầu từ tầng cuối để một lần đọc
+// đồng thời không chép giá trị cũ từ tầng dưới lên tầng trên vừa được cập nhật.
+//
+// tieredDriver không sở hữu các tầng: Close không đóng các driver bên dưới, chúng được
+// đóng bởi nơi đã tạo ra chúng (thường là cache.Manager).
+type tieredDriver struct {
+	tiers       []Driver       // Các tầng theo thứ tự từ L1 xuống tầng cuối
+	backfillTTL time.Duration  // Thời gian sống của giá trị được chép lên tầng trên
+	hits        []atomic.Int64 // Số lần cache hit theo từng tầng
+	misses      atomic.Int64   // Số lần không tìm thấy ở tầng nào
+}
+
+// NewTieredDriver tạo một tiered driver với backfill TTL mặc định.
+//
+// Params:
+//   - l1: Tầng đầu tiên, thường là memory driver
+//   - l2: Các tầng tiếp theo theo thứ tự từ nhanh đến chậm
+//
+// Returns:
+//   - TieredDriver: Driver đã được khởi tạo
+func NewTieredDriver(l1 Driver, l2 ...Driver) TieredDriver {
+	cfg := config.DriverTieredConfig{}
+	return newTieredDriver(append([]Driver{l1}, l2...), cfg.GetBackfillTTL())
+}
+
+// NewTieredDriverWithConfig tạo một tiered driver từ cấu hình.
+//
+// Params:
+//   - cfg: Cấu hình tiered driver
+//   - tiers: Các tầng theo thứ tự từ L1 xuống tầng cuối
+//
+// Returns:
+//   - TieredDriver: Driver đã được khởi tạo
+//   - error: Lỗi nếu không có tầng nào hoặc có tầng nil
+func NewTieredDriverWithConfig(cfg config.DriverTieredConfig, tiers ...Driver) (TieredDriver, error) {
+	if len(tiers) == 0 {
+		return nil, errors.New("tiered driver requires at least one tier")
+	}
+	for i, tier := range tiers {
+		if tier == nil {
+			return nil, fmt.Errorf("tiered driver tier %d is nil", i)
+		}
+	}
+	return newTieredDriver(append([]Driver(nil), tiers...), cfg.GetBackfillTTL()), nil
+}
+
+// newTieredDriver khởi tạo tieredDriver.
+func newTieredDriver(tiers []Driver, backfillTTL time.Duration) *tieredDriver {
+	return &tieredDriver{
+		tiers:       tiers,
+		backfillTTL: backfillTTL,
+		hits:        make([]atomic.Int64, len(tiers)),
+	}
+}
+
+// Tiers trả về danh sách các tầng theo thứ tự từ L1 xuống tầng cuối.
+//
+// Returns:
+//   - []Driver: Bản sao danh sách các tầng
+func (d *tieredDriver) Tiers() []Driver {
+	return append([]Driver(nil), d.tiers...)
+}
+
+// Get lấy một giá trị từ tầng đầu tiên có key và chép giá trị lên các tầng phía trên.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm
+//
+// Returns:
+//   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
+//   - bool: true nếu tìm thấy key ở một tầng bất kỳ
+func (d *tieredDriver) Get(ctx context.Context, key string) (interface{}, bool) {
+	value, found := d.lookup(ctx, key, len(d.tiers))
+	if !found {
+		d.misses.Add(1)
+	}
+	return value, found
+}
+
+// GetInto lấy một giá trị từ tầng đầu tiên có key và giải mã vào target.
+//
+// Tầng cài đặt TypedGetter giải mã trực tiếp; các tầng khác dùng Get kèm kiểm tra kiểu.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm
+//   - target: Con trỏ (khác nil) tới biến nhận giá trị
+//
+// Returns:
+//   - bool: true nếu tìm thấy key ở một tầng bất kỳ
+//   - error: Lỗi wrap ErrTypeMismatch nếu giá trị không phù hợp với kiểu đích
+func (d *tieredDriver) GetInto(ctx context.Context, key string, target interface{}) (bool, error) {
+	for i, tier := range d.tiers {
+		var found bool
+		var err error
+		if getter, ok := tier.(TypedGetter); ok {
+			found, err = getter.GetInto(ctx, key, target)
+		} else {
+			var value interface{}
+			if value, found = tier.Get(ctx, key); found {
+				err = assignValue(target, value)
+			}
+		}
+		if err != nil {
+			return found, err
+		}
+		if found {
+			d.hits[i].Add(1)
+			d.backfill(ctx, key, reflect.ValueOf(target).Elem().Interface(), i)
+			return true, nil
+		}
+	}
+
+	d.misses.Add(1)
+	return false, nil
+}
+
+// Set đặt một giá trị vào mọi tầng.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định của từng tầng, -1 để không hết hạn)
+//
+// Returns:
+//   - error: Lỗi đầu tiên gặp phải, các tầng còn lại vẫn được ghi
+func (d *tieredDriver) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return d.each(func(tier Driver) error {
+		return tier.Set(ctx, key, value, ttl)
+	})
+}
+
+// Has kiểm tra xem key có tồn tại ở một tầng bất kỳ không.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần kiểm tra
+//
+// Returns:
+//   - bool: true nếu key tồn tại ở ít nhất một tầng
+func (d *tieredDriver) Has(ctx context.Context, key string) bool {
+	for _, tier := range d.tiers {
+		if tier.Has(ctx, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Delete xóa một key khỏi mọi tầng.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần xóa
+//
+// Returns:
+//   - error: Lỗi đầu tiên gặp phải, các tầng còn lại vẫn được xóa
+func (d *tieredDriver) Delete(ctx context.Context, key string) error {
+	return d.each(func(tier Driver) error {
+		return tier.Delete(ctx, key)
+	})
+}
+
+// Flush xóa tất cả các key khỏi mọi tầng.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//
+// Returns:
+//   - error: Lỗi đầu tiên gặp phải, các tầng còn lại vẫn được xóa
+func (d *tieredDriver) Flush(ctx context.Context) error {
+	return d.each(func(tier Driver) error {
+		return tier.Flush(ctx)
+	})
+}
+
+// GetMultiple lấy nhiều giá trị, mỗi tầng chỉ được hỏi các key chưa tìm thấy ở tầng trên.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - keys: Danh sách các key cần lấy
+//
+// Returns:
+//   - map[string]interface{}: Map chứa các key tìm thấy và giá trị tương ứng
+//   - []string: Danh sách các key không tìm thấy ở tầng nào
+func (d *tieredDriver) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, []string) {
+	result := make(map[string]interface{}, len(keys))
+	missed := keys
+
+	for i, tier := range d.tiers {
+		if len(missed) == 0 {
+			break
+		}
+		found, rest := tier.GetMultiple(ctx, missed)
+		for key, value := range found {
+			result[key] = value
+		}
+		if len(found) > 0 {
+			d.hits[i].Add(int64(len(found)))
+			for _, upper := range d.tiers[:i] {
+				_ = upper.SetMultiple(ctx, found, d.backfillTTL)
+			}
+		}
+		missed = rest
+	}
+
+	d.misses.Add(int64(len(missed)))
+	return result, missed
+}
+
+// SetMultiple đặt nhiều giá trị vào mọi tầng.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - values: Map chứa các key và giá trị tương ứng cần lưu trữ
+//   - ttl: Thời gian sống chung cho tất cả các giá trị
+//
+// Returns:
+//   - error: Lỗi đầu tiên gặp phải, các tầng còn lại vẫn được ghi
+func (d *tieredDriver) SetMultiple(ctx context.Context, values map[string]interface{}, ttl time.Duration) error {
+	return d.each(func(tier Driver) error {
+		return tier.SetMultiple(ctx, values, ttl)
+	})
+}
+
+// DeleteMultiple xóa nhiều key khỏi mọi tầng.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - keys: Danh sách các key cần xóa
+//
+// Returns:
+//   - error: Lỗi đầu tiên gặp phải, các tầng còn lại vẫn được xóa
+func (d *tieredDriver) DeleteMultiple(ctx context.Context, keys []string) error {
+	return d.each(func(tier Driver) error {
+		return tier.DeleteMultiple(ctx, keys)
+	})
+}
+
+// Remember lấy một giá trị từ cache hoặc thực thi callback nếu không tìm thấy.
+//
+// Các tầng trên được kiểm tra trước. Nếu không tìm thấy, Remember của tầng cuối được
+// sử dụng để callback được hưởng cơ chế gộp lời gọi và khóa phân tán của tầng đó,
+// sau đó giá trị được chép lên các tầng trên với backfillTTL.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - ttl: Thời gian sống của giá trị ở tầng cuối nếu phải lấy từ callback
+//   - callback: Hàm được gọi để lấy dữ liệu khi key không có trong cache
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi từ callback hoặc từ tầng cuối
+func (d *tieredDriver) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	last := len(d.tiers) - 1
+	if value, found := d.lookup(ctx, key, last); found {
+		return value, nil
+	}
+
+	value, err := d.tiers[last].Remember(ctx, key, ttl, callback)
+	if err != nil {
+		return nil, err
+	}
+	d.backfill(ctx, key, value, last)
+	return value, nil
+}
+
+// RememberStale lấy một giá trị theo cơ chế stale-while-revalidate của tầng cuối.
+//
+// Các tầng trên được kiểm tra trước; giá trị ở các tầng trên sống theo backfillTTL
+// nên không có khoảng grace riêng.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - ttl: Thời gian giá trị được coi là còn mới ở tầng cuối
+//   - grace: Thời gian giá trị cũ tiếp tục được phục vụ sau ttl
+//   - callback: Hàm được gọi để lấy dữ liệu
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi từ callback hoặc từ tầng cuối
+func (d *tieredDriver) RememberStale(ctx context.Context, key string, ttl, grace time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	last := len(d.tiers) - 1
+	if value, found := d.lookup(ctx, key, last); found {
+		return value, nil
+	}
+
+	value, err := d.tiers[last].RememberStale(ctx, key, ttl, grace, callback)
+	if err != nil {
+		return nil, err
+	}
+	d.backfill(ctx, key, value, last)
+	return value, nil
+}
+
+// Add lưu giá trị ở tầng cuối nếu key chưa tồn tại ở tầng đó.
+//
+// Tầng cuối quyết định sự tồn tại của key; khi giá trị được lưu, bản sao cũ ở các
+// tầng trên bị xóa.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị ở tầng cuối
+//
+// Returns:
+//   - bool: true nếu giá trị được lưu
+//   - error: Lỗi từ tầng cuối
+func (d *tieredDriver) Add(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	added, err := d.tiers[len(d.tiers)-1].Add(ctx, key, value, ttl)
+	if err != nil || !added {
+		return false, err
+	}
+	d.evictUpper(ctx, key)
+	return true, nil
+}
+
+// Replace thay giá trị ở tầng cuối nếu key tồn tại ở tầng đó và xóa bản sao ở các tầng trên.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần thay giá trị
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới ở tầng cuối
+//
+// Returns:
+//   - bool: true nếu giá trị được thay
+//   - error: Lỗi từ tầng cuối
+func (d *tieredDriver) Replace(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	replaced, err := d.tiers[len(d.tiers)-1].Replace(ctx, key, value, ttl)
+	if err != nil || !replaced {
+		return false, err
+	}
+	d.evictUpper(ctx, key)
+	return true, nil
+}
+
+// GetAndSet lưu giá trị mới ở tầng cuối, trả về giá trị cũ và xóa bản sao ở các tầng trên.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới ở tầng cuối
+//
+// Returns:
+//   - interface{}: Giá trị cũ ở tầng cuối (nil nếu không có)
+//   - bool: true nếu key tồn tại ở tầng cuối trước đó
+//   - error: Lỗi từ tầng cuối
+func (d *tieredDriver) GetAndSet(ctx context.Context, key string, value interface{}, ttl time.Duration) (interface{}, bool, error) {
+	old, found, err := d.tiers[len(d.tiers)-1].GetAndSet(ctx, key, value, ttl)
+	if err != nil {
+		return nil, false, err
+	}
+	d.evictUpper(ctx, key)
+	return old, found, nil
+}
+
+// GetAndDelete lấy và xóa key ở tầng cuối, đồng thời xóa bản sao ở các tầng trên.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần lấy và xóa
+//
+// Returns:
+//   - interface{}: Giá trị đã xóa ở tầng cuối (nil nếu không có)
+//   - bool: true nếu key tồn tại ở tầng cuối
+//   - error: Lỗi từ tầng cuối
+func (d *tieredDriver) GetAndDelete(ctx context.Context, key string) (interface{}, bool, error) {
+	value, found, err := d.tiers[len(d.tiers)-1].GetAndDelete(ctx, key)
+	d.evictUpper(ctx, key)
+	return value, found, err
+}
+
+// GetWithVersion lấy giá trị và token phiên bản trực tiếp từ tầng cuối.
+//
+// Các tầng trên không được dùng vì chỉ tầng cuối giữ phiên bản chuẩn của entry.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm
+//
+// Returns:
+//   - interface{}: Giá trị ở tầng cuối (nil nếu không tìm thấy)
+//   - string: Token phiên bản của tầng cuối (rỗng nếu không tìm thấy)
+//   - bool: true nếu tìm thấy key ở tầng cuối
+func (d *tieredDriver) GetWithVersion(ctx context.Context, key string) (interface{}, string, bool) {
+	return d.tiers[len(d.tiers)-1].GetWithVersion(ctx, key)
+}
+
+// CompareAndSwap ghi giá trị mới ở tầng cuối nếu token khớp và xóa bản sao ở các tầng trên.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần ghi
+//   - token: Token phiên bản nhận được từ GetWithVersion
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới ở tầng cuối
+//
+// Returns:
+//   - bool: true nếu giá trị được ghi
+//   - error: Lỗi từ tầng cuối
+func (d *tieredDriver) CompareAndSwap(ctx context.Context, key, token string, value interface{}, ttl time.Duration) (bool, error) {
+	swapped, err := d.tiers[len(d.tiers)-1].CompareAndSwap(ctx, key, token, value, ttl)
+	if err != nil || !swapped {
+		return false, err
+	}
+	d.evictUpper(ctx, key)
+	return true, nil
+}
+
+// TTL trả về thời gian sống còn lại của key ở tầng cuối.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần kiểm tra
+//
+// Returns:
+//   - time.Duration: Thời gian sống còn lại, NoExpiration nếu entry không hết hạn
+//   - bool: true nếu tìm thấy key ở tầng cuối
+func (d *tieredDriver) TTL(ctx context.Context, key string) (time.Duration, bool) {
+	return d.tiers[len(d.tiers)-1].TTL(ctx, key)
+}
+
+// Touch đặt lại thời gian sống của key ở tầng cuối và xóa bản sao ở các tầng trên.
+//
+// Bản sao ở tầng trên có thời hạn riêng (backfill_ttl) nên được xóa thay vì gia hạn,
+// tránh trường hợp bản sao sống lâu hơn entry ở tầng cuối khi ttl bị rút ngắn.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần gia hạn
+//   - ttl: Thời gian sống mới ở tầng cuối
+//
+// Returns:
+//   - bool: true nếu key tồn tại ở tầng cuối và đã được cập nhật
+//   - error: Lỗi từ tầng cuối
+func (d *tieredDriver) Touch(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	touched, err := d.tiers[len(d.tiers)-1].Touch(ctx, key, ttl)
+	if err != nil || !touched {
+		return false, err
+	}
+	d.evictUpper(ctx, key)
+	return true, nil
+}
+
+// TryLock thử giành khóa ở tầng cuối.
+//
+// Tầng cuối là nguồn dữ liệu chung giữa các instance nên giữ toàn bộ khóa.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - name: Tên khóa
+//   - owner: Token định danh chủ sở hữu
+//   - ttl: Thời gian giữ khóa tối đa (<= 0 để khóa không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giành được khóa
+//   - error: ErrLockNotSupported nếu tầng cuối không cài đặt Locker, hoặc lỗi từ tầng cuối
+func (d *tieredDriver) TryLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	locker, ok := d.tiers[len(d.tiers)-1].(Locker)
+	if !ok {
+		return false, ErrLockNotSupported
+	}
+	return locker.TryLock(ctx, name, owner, ttl)
+}
+
+// RefreshLock đặt lại thời gian giữ khóa ở tầng cuối nếu owner vẫn giữ khóa.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - name: Tên khóa
+//   - owner: Token định danh chủ sở hữu
+//   - ttl: Thời gian giữ khóa mới (<= 0 để khóa không hết hạn)
+//
+// Returns:
+//   - bool: true nếu khóa được gia hạn
+//   - error: ErrLockNotSupported nếu tầng cuối không cài đặt Locker, hoặc lỗi từ tầng cuối
+func (d *tieredDriver) RefreshLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	locker, ok := d.tiers[len(d.tiers)-1].(Locker)
+	if !ok {
+		return false, ErrLockNotSupported
+	}
+	return locker.RefreshLock(ctx, name, owner, ttl)
+}
+
+// Unlock giải phóng khóa ở tầng cuối nếu owner đang giữ khóa.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - name: Tên khóa
+//   - owner: Token định danh chủ sở hữu
+//
+// Returns:
+//   - bool: true nếu khóa được giải phóng
+//   - error: ErrLockNotSupported nếu tầng cuối không cài đặt Locker, hoặc lỗi từ tầng cuối
+func (d *tieredDriver) Unlock(ctx context.Context, name, owner string) (bool, error) {
+	locker, ok := d.tiers[len(d.tiers)-1].(Locker)
+	if !ok {
+		return false, ErrLockNotSupported
+	}
+	return locker.Unlock(ctx, name, owner)
+}
+
+// Increment tăng bộ đếm ở tầng cuối và xóa bản sao của key khỏi các tầng trên.
+//
+// Tầng cuối là nguồn dữ liệu chung nên giữ giá trị chuẩn của bộ đếm; bản sao cũ ở
+// các tầng trên bị xóa để lần đọc tiếp theo lấy giá trị mới.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (có thể âm)
+//   - ttl: Thời gian sống khi entry được tạo mới ở tầng cuối
+//
+// Returns:
+//   - int64: Giá trị sau khi tăng
+//   - error: Lỗi từ tầng cuối
+func (d *tieredDriver) Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	value, err := d.tiers[len(d.tiers)-1].Increment(ctx, key, delta, ttl)
+	if err != nil {
+		return 0, err
+	}
+	d.evictUpper(ctx, key)
+	return value, nil
+}
+
+// Decrement giảm bộ đếm ở tầng cuối và xóa bản sao của key khỏi các tầng trên.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần trừ đi
+//   - ttl: Thời gian sống khi entry được tạo mới ở tầng cuối
+//
+// Returns:
+//   - int64: Giá trị sau khi giảm
+//   - error: Lỗi từ tầng cuối
+func (d *tieredDriver) Decrement(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	return d.Increment(ctx, key, -delta, ttl)
+}
+
+// IncrementFloat tăng bộ đếm số thực ở tầng cuối và xóa bản sao của key khỏi các tầng trên.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (có thể âm)
+//   - ttl: Thời gian sống khi entry được tạo mới ở tầng cuối
+//
+// Returns:
+//   - float64: Giá trị sau khi tăng
+//   - error: Lỗi từ tầng cuối
+func (d *tieredDriver) IncrementFloat(ctx context.Context, key string, delta float64, ttl time.Duration) (float64, error) {
+	value, err := d.tiers[len(d.tiers)-1].IncrementFloat(ctx, key, delta, ttl)
+	if err != nil {
+		return 0, err
+	}
+	d.evictUpper(ctx, key)
+	return value, nil
+}
+
+// SetTagged đặt một giá trị có tag vào mọi tầng.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị
+//   - tags: Danh sách tag gắn với entry
+//
+// Returns:
+//   - error: Lỗi đầu tiên gặp phải, các tầng còn lại vẫn được ghi
+func (d *tieredDriver) SetTagged(ctx context.Context, key string, value interface{}, ttl time.Duration, tags []string) error {
+	return d.each(func(tier Driver) error {
+		return tier.SetTagged(ctx, key, value, ttl, tags)
+	})
+}
+
+// FlushTags xóa các entry mang ít nhất một trong các tag khỏi mọi tầng.
+//
+// Giá trị được chép lên tầng trên khi đọc không mang tag, nên chúng không bị xóa
+// bởi FlushTags và tồn tại tối đa backfillTTL.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - tags: Danh sách tag cần xóa
+//
+// Returns:
+//   - error: Lỗi đầu tiên gặp phải, các tầng còn lại vẫn được xóa
+func (d *tieredDriver) FlushTags(ctx context.Context, tags []string) error {
+	return d.each(func(tier Driver) error {
+		return tier.FlushTags(ctx, tags)
+	})
+}
+
+// Keys trả về các key còn hạn khớp với pattern ở tầng cuối.
+//
+// Thao tác ghi được áp dụng cho mọi tầng nên tầng cuối chứa mọi key; các tầng trên chỉ
+// giữ bản sao.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - pattern: Glob pattern của key
+//
+// Returns:
+//   - []string: Các key khớp pattern
+//   - error: Lỗi từ tầng cuối
+func (d *tieredDriver) Keys(ctx context.Context, pattern string) ([]string, error) {
+	return d.tiers[len(d.tiers)-1].Keys(ctx, pattern)
+}
+
+// Scan duyệt lần lượt các key còn hạn khớp với pattern ở tầng cuối.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - pattern: Glob pattern của key
+//   - fn: Hàm được gọi cho từng key, trả về false để dừng quét
+//
+// Returns:
+//   - error: Lỗi từ tầng cuối
+func (d *tieredDriver) Scan(ctx context.Context, pattern string, fn func(key string) bool) error {
+	return d.tiers[len(d.tiers)-1].Scan(ctx, pattern, fn)
+}
+
+// DeleteMatching xóa các entry có key khớp với pattern khỏi mọi tầng.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - pattern: Glob pattern của key
+//
+// Returns:
+//   - int: Số entry đã bị xóa ở tầng cuối
+//   - error: Lỗi đầu tiên gặp phải, các tầng còn lại vẫn được xóa
+func (d *tieredDriver) DeleteMatching(ctx context.Context, pattern string) (int, error) {
+	deleted, counted := 0, false
+	err := d.each(func(tier Driver) error {
+		n, err := tier.DeleteMatching(ctx, pattern)
+		// each bắt đầu từ tầng cuối
+		if !counted {
+			deleted, counted = n, true
+		}
+		return err
+	})
+	return deleted, err
+}
+
+// DeleteByPrefix xóa các entry có key bắt đầu bằng prefix khỏi mọi tầng.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - prefix: Tiền tố của key
+//
+// Returns:
+//   - int: Số entry đã bị xóa ở tầng cuối
+//   - error: Lỗi đầu tiên gặp phải, các tầng còn lại vẫn được xóa
+func (d *tieredDriver) DeleteByPrefix(ctx context.Context, prefix string) (int, error) {
+	return d.DeleteMatching(ctx, prefixPattern(prefix))
+}
+
+// Stats trả về thông tin thống kê của tiered driver và của từng tầng.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//
+// Returns:
+//   - map[string]interface{}: Map chứa các thông tin thống kê
+func (d *tieredDriver) Stats(ctx context.Context) map[string]interface{} {
+	tiers := make([]map[string]interface{}, len(d.tiers))
+	hits := make([]int64, len(d.tiers))
+	for i, tier := range d.tiers {
+		tiers[i] = tier.Stats(ctx)
+		hits[i] = d.hits[i].Load()
+	}
+
+	return map[string]interface{}{
+		"type":         "tiered",
+		"tiers":        tiers,
+		"tier_hits":    hits,
+		"misses":       d.misses.Load(),
+		"backfill_ttl": d.backfillTTL,
+	}
+}
+
+// Close không làm gì vì tieredDriver không sở hữu các tầng.
+//
+// Returns:
+//   - error: Luôn trả về nil
+func (d *tieredDriver) Close() error {
+	return nil
+}
+
+// lookup tìm key trong n tầng đầu tiên và chép giá trị tìm thấy lên các tầng phía trên.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm
+//   - n: Số tầng cần kiểm tra
+//
+// Returns:
+//   - interface{}: Giá trị tìm thấy
+//   - bool: true nếu tìm thấy key
+func (d *tieredDriver) lookup(ctx context.Context, key string, n int) (interface{}, bool) {
+	for i, tier := range d.tiers[:n] {
+		if value, found := tier.Get(ctx, key); found {
+			d.hits[i].Add(1)
+			d.backfill(ctx, key, value, i)
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// backfill chép giá trị lên các tầng phía trên tầng level với backfillTTL.
+//
+// Lỗi khi chép được bỏ qua vì giá trị vẫn có ở tầng dưới.
+func (d *tieredDriver) backfill(ctx context.Context, key string, value interface{}, level int) {
+	for _, upper := range d.tiers[:level] {
+		_ = upper.Set(ctx, key, value, d.backfillTTL)
+	}
+}
+
+// evictUpper xóa key khỏi các tầng phía trên tầng cuối.
+//
+// Lỗi khi xóa được bỏ qua vì bản sao ở tầng trên sẽ hết hạn sau tối đa backfillTTL.
+func (d *tieredDriver) evictUpper(ctx context.Context, key string) {
+	for _, upper := range d.tiers[:len(d.tiers)-1] {
+		_ = upper.Delete(ctx, key)
+	}
+}
+
+// each thực thi fn trên mọi tầng, bắt đầu từ tầng cuối.
+//
+// Returns:
+//   - error: Lỗi đầu tiên gặp phải kèm chỉ số tầng
+func (d *tieredDriver) each(fn func(tier Driver) error) error {
+	var firstErr error
+	for i := len(d.tiers) - 1; i >= 0; i-- {
+		if err := fn(d.tiers[i]); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("cache tier %d: %w", i, err)
+		}
+	}
+	return firstErr
+}
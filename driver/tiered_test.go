@@ -0,0 +1,369 @@
+package driver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.fork.vn/cache/config"
+	"go.fork.vn/cache/driver"
+	cacheMocks "go.fork.vn/cache/mocks"
+)
+
+// newTestTiers tạo hai memory driver làm L1 và L2 cho tiered driver
+func newTestTiers(t *testing.T) (driver.MemoryDriver, driver.MemoryDriver) {
+	l1 := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+	l2 := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+	t.Cleanup(func() {
+		_ = l1.Close()
+		_ = l2.Close()
+	})
+	return l1, l2
+}
+
+func TestNewTieredDriverWithConfig(t *testing.T) {
+	l1, l2 := newTestTiers(t)
+
+	tiered, err := driver.NewTieredDriverWithConfig(config.DriverTieredConfig{BackfillTTL: 5}, l1, l2)
+	require.NoError(t, err)
+	assert.Equal(t, []driver.Driver{l1, l2}, tiered.Tiers())
+	assert.Equal(t, 5*time.Second, tiered.Stats(context.Background())["backfill_ttl"])
+
+	_, err = driver.NewTieredDriverWithConfig(config.DriverTieredConfig{})
+	assert.EqualError(t, err, "tiered driver requires at least one tier")
+
+	_, err = driver.NewTieredDriverWithConfig(config.DriverTieredConfig{}, l1, nil)
+	assert.EqualError(t, err, "tiered driver tier 1 is nil")
+}
+
+func TestTieredDriver(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("get_backfills_upper_tier", func(t *testing.T) {
+		l1, l2 := newTestTiers(t)
+		tiered, err := driver.NewTieredDriverWithConfig(config.DriverTieredConfig{BackfillTTL: 1}, l1, l2)
+		require.NoError(t, err)
+
+		require.NoError(t, l2.Set(ctx, "key", "value", time.Hour))
+
+		value, found := tiered.Get(ctx, "key")
+		assert.True(t, found)
+		assert.Equal(t, "value", value)
+
+		value, found = l1.Get(ctx, "key")
+		assert.True(t, found)
+		assert.Equal(t, "value", value)
+
+		// Bản sao ở L1 sống theo backfill TTL, ngắn hơn TTL ở L2
+		time.Sleep(1100 * time.Millisecond)
+		assert.False(t, l1.Has(ctx, "key"))
+		assert.True(t, l2.Has(ctx, "key"))
+
+		stats := tiered.Stats(ctx)
+		assert.Equal(t, "tiered", stats["type"])
+		assert.Equal(t, []int64{0, 1}, stats["tier_hits"])
+		assert.Len(t, stats["tiers"], 2)
+	})
+
+	t.Run("writes_and_deletes_reach_every_tier", func(t *testing.T) {
+		l1, l2 := newTestTiers(t)
+		tiered := driver.NewTieredDriver(l1, l2)
+
+		require.NoError(t, tiered.Set(ctx, "a", 1, time.Minute))
+		require.NoError(t, tiered.SetMultiple(ctx, map[string]interface{}{"b": 2, "c": 3}, time.Minute))
+		require.NoError(t, tiered.SetTagged(ctx, "d", 4, time.Minute, []string{"group"}))
+		for _, tier := range []driver.Driver{l1, l2} {
+			for _, key := range []string{"a", "b", "c", "d"} {
+				assert.True(t, tier.Has(ctx, key), key)
+			}
+		}
+
+		require.NoError(t, tiered.Delete(ctx, "a"))
+		require.NoError(t, tiered.DeleteMultiple(ctx, []string{"b"}))
+		require.NoError(t, tiered.FlushTags(ctx, []string{"group"}))
+		for _, tier := range []driver.Driver{l1, l2} {
+			assert.False(t, tier.Has(ctx, "a"))
+			assert.False(t, tier.Has(ctx, "b"))
+			assert.True(t, tier.Has(ctx, "c"))
+			assert.False(t, tier.Has(ctx, "d"))
+		}
+
+		require.NoError(t, tiered.Flush(ctx))
+		assert.False(t, l1.Has(ctx, "c"))
+		assert.False(t, l2.Has(ctx, "c"))
+	})
+
+	t.Run("get_multiple_falls_through", func(t *testing.T) {
+		l1, l2 := newTestTiers(t)
+		tiered := driver.NewTieredDriver(l1, l2)
+
+		require.NoError(t, l1.Set(ctx, "a", 1, time.Minute))
+		require.NoError(t, l2.Set(ctx, "b", 2, time.Minute))
+
+		values, missed := tiered.GetMultiple(ctx, []string{"a", "b", "c"})
+		assert.Equal(t, map[string]interface{}{"a": 1, "b": 2}, values)
+		assert.Equal(t, []string{"c"}, missed)
+		assert.True(t, l1.Has(ctx, "b"))
+		assert.True(t, tiered.Has(ctx, "a"))
+		assert.False(t, tiered.Has(ctx, "c"))
+	})
+
+	t.Run("get_into_backfills_upper_tier", func(t *testing.T) {
+		l1, l2 := newTestTiers(t)
+		tiered := driver.NewTieredDriver(l1, l2)
+		require.NoError(t, l2.Set(ctx, "count", 42, time.Minute))
+
+		var count int
+		found, err := tiered.(driver.TypedGetter).GetInto(ctx, "count", &count)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, 42, count)
+		assert.True(t, l1.Has(ctx, "count"))
+
+		var name string
+		_, err = tiered.(driver.TypedGetter).GetInto(ctx, "count", &name)
+		assert.ErrorIs(t, err, driver.ErrTypeMismatch)
+	})
+
+	t.Run("remember_uses_last_tier", func(t *testing.T) {
+		l1, l2 := newTestTiers(t)
+		tiered := driver.NewTieredDriver(l1, l2)
+		calls := 0
+		callback := func() (interface{}, error) {
+			calls++
+			return "computed", nil
+		}
+
+		value, err := tiered.Remember(ctx, "key", time.Minute, callback)
+		assert.NoError(t, err)
+		assert.Equal(t, "computed", value)
+		assert.True(t, l1.Has(ctx, "key"))
+		assert.True(t, l2.Has(ctx, "key"))
+
+		value, err = tiered.RememberStale(ctx, "key", time.Minute, time.Minute, callback)
+		assert.NoError(t, err)
+		assert.Equal(t, "computed", value)
+		assert.Equal(t, 1, calls)
+
+		_, err = tiered.Remember(ctx, "broken", time.Minute, func() (interface{}, error) {
+			return nil, errors.New("boom")
+		})
+		assert.EqualError(t, err, "boom")
+		assert.False(t, tiered.Has(ctx, "broken"))
+	})
+
+	t.Run("write_errors_do_not_stop_other_tiers", func(t *testing.T) {
+		l1, _ := newTestTiers(t)
+		l2 := cacheMocks.NewMockDriver(t)
+		tiered := driver.NewTieredDriver(l1, l2)
+
+		l2.EXPECT().Set(ctx, "key", "value", time.Minute).Return(errors.New("unavailable")).Once()
+		l2.EXPECT().Delete(ctx, "key").Return(nil).Once()
+		l2.EXPECT().Get(mock.Anything, "missing").Return(nil, false).Once()
+		l2.EXPECT().Stats(ctx).Return(map[string]interface{}{"type": "mock"}).Once()
+
+		err := tiered.Set(ctx, "key", "value", time.Minute)
+		assert.EqualError(t, err, "cache tier 1: unavailable")
+		assert.True(t, l1.Has(ctx, "key"))
+
+		assert.NoError(t, tiered.Delete(ctx, "key"))
+		assert.False(t, l1.Has(ctx, "key"))
+
+		_, found := tiered.Get(ctx, "missing")
+		assert.False(t, found)
+		assert.Equal(t, int64(1), tiered.Stats(ctx)["misses"])
+	})
+}
+
+func TestTieredDriver_Increment(t *testing.T) {
+	ctx := context.Background()
+	l1, l2 := newTestTiers(t)
+	tiered := driver.NewTieredDriver(l1, l2)
+
+	value, err := tiered.Increment(ctx, "views", 1, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), value)
+
+	// Bản sao ở L1 được chép khi đọc và bị xóa khi bộ đếm thay đổi
+	_, found := tiered.Get(ctx, "views")
+	require.True(t, found)
+	require.True(t, l1.Has(ctx, "views"))
+
+	value, err = tiered.Increment(ctx, "views", 2, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), value)
+	assert.False(t, l1.Has(ctx, "views"))
+
+	value, err = tiered.Decrement(ctx, "views", 1, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), value)
+
+	score, err := tiered.IncrementFloat(ctx, "score", 0.5, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, score)
+
+	require.NoError(t, tiered.Set(ctx, "name", "An", time.Minute))
+	_, err = tiered.Increment(ctx, "name", 1, time.Minute)
+	assert.ErrorIs(t, err, driver.ErrNotNumeric)
+	assert.True(t, l1.Has(ctx, "name"))
+}
+
+func TestTieredDriver_ConditionalWrites(t *testing.T) {
+	ctx := context.Background()
+	l1, l2 := newTestTiers(t)
+	tiered := driver.NewTieredDriver(l1, l2)
+
+	// Bản sao cũ ở L1 không ảnh hưởng tới Add, vì tầng cuối quyết định sự tồn tại của key
+	require.NoError(t, l1.Set(ctx, "idem", "stale", time.Minute))
+	added, err := tiered.Add(ctx, "idem", "first", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.False(t, l1.Has(ctx, "idem"))
+
+	added, err = tiered.Add(ctx, "idem", "second", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	_, _ = tiered.Get(ctx, "idem")
+	replaced, err := tiered.Replace(ctx, "idem", "replaced", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, replaced)
+	assert.False(t, l1.Has(ctx, "idem"))
+
+	old, found, err := tiered.GetAndSet(ctx, "idem", "swapped", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "replaced", old)
+
+	_, _ = tiered.Get(ctx, "idem")
+	value, found, err := tiered.GetAndDelete(ctx, "idem")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "swapped", value)
+	assert.False(t, l1.Has(ctx, "idem"))
+	assert.False(t, l2.Has(ctx, "idem"))
+}
+
+func TestTieredDriver_CompareAndSwap(t *testing.T) {
+	ctx := context.Background()
+	l1, l2 := newTestTiers(t)
+	tiered := driver.NewTieredDriver(l1, l2)
+
+	require.NoError(t, tiered.Set(ctx, "config", "v1", time.Minute))
+	_, token, found := tiered.GetWithVersion(ctx, "config")
+	require.True(t, found)
+	_, l2Token, _ := l2.GetWithVersion(ctx, "config")
+	assert.Equal(t, l2Token, token)
+
+	_, _ = tiered.Get(ctx, "config")
+	swapped, err := tiered.CompareAndSwap(ctx, "config", token, "v2", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, swapped)
+	assert.False(t, l1.Has(ctx, "config"))
+
+	// Token cũ bị từ chối và bản sao ở L1 được giữ nguyên
+	_, _ = tiered.Get(ctx, "config")
+	swapped, err = tiered.CompareAndSwap(ctx, "config", token, "v3", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, swapped)
+	value, _ := l1.Get(ctx, "config")
+	assert.Equal(t, "v2", value)
+}
+
+func TestTieredDriver_TTLAndTouch(t *testing.T) {
+	ctx := context.Background()
+	l1, l2 := newTestTiers(t)
+	tiered := driver.NewTieredDriver(l1, l2)
+
+	require.NoError(t, l1.Set(ctx, "session", "data", time.Hour))
+	require.NoError(t, l2.Set(ctx, "session", "data", time.Minute))
+
+	// TTL được đọc từ tầng cuối
+	ttl, found := tiered.TTL(ctx, "session")
+	require.True(t, found)
+	assert.InDelta(t, time.Minute, ttl, float64(time.Second))
+
+	touched, err := tiered.Touch(ctx, "session", 2*time.Minute)
+	require.NoError(t, err)
+	assert.True(t, touched)
+	assert.False(t, l1.Has(ctx, "session"))
+	ttl, _ = l2.TTL(ctx, "session")
+	assert.InDelta(t, 2*time.Minute, ttl, float64(time.Second))
+
+	touched, err = tiered.Touch(ctx, "missing", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, touched)
+}
+
+func TestTieredDriver_Lock(t *testing.T) {
+	ctx := context.Background()
+	l1, l2 := newTestTiers(t)
+	tiered := driver.NewTieredDriver(l1, l2).(driver.Locker)
+
+	// Khóa được giữ ở tầng cuối
+	acquired, err := tiered.TryLock(ctx, "job", "owner-a", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	acquired, _ = l2.(driver.Locker).TryLock(ctx, "job", "owner-b", time.Minute)
+	assert.False(t, acquired)
+	acquired, _ = l1.(driver.Locker).TryLock(ctx, "job", "owner-b", time.Minute)
+	assert.True(t, acquired)
+
+	refreshed, err := tiered.RefreshLock(ctx, "job", "owner-a", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, refreshed)
+	released, err := tiered.Unlock(ctx, "job", "owner-a")
+	require.NoError(t, err)
+	assert.True(t, released)
+
+	// Tầng cuối không cài đặt Locker
+	mockDriver := cacheMocks.NewMockDriver(t)
+	_, err = driver.NewTieredDriver(l1, mockDriver).(driver.Locker).TryLock(ctx, "job", "owner-a", time.Minute)
+	assert.ErrorIs(t, err, driver.ErrLockNotSupported)
+}
+
+func TestTieredDriver_Keys(t *testing.T) {
+	ctx := context.Background()
+	l1, l2 := newTestTiers(t)
+	tiered := driver.NewTieredDriver(l1, l2)
+
+	require.NoError(t, tiered.Set(ctx, "user:1", "v", 0))
+	require.NoError(t, l2.Set(ctx, "user:2", "v", 0))
+	require.NoError(t, l1.Set(ctx, "user:3", "v", 0))
+
+	// Key được liệt kê từ tầng cuối
+	keys, err := tiered.Keys(ctx, "user:*")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"user:1", "user:2"}, keys)
+
+	var scanned []string
+	err = tiered.Scan(ctx, "user:*", func(key string) bool {
+		scanned = append(scanned, key)
+		return true
+	})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"user:1", "user:2"}, scanned)
+}
+
+func TestTieredDriver_DeleteMatching(t *testing.T) {
+	ctx := context.Background()
+	l1, l2 := newTestTiers(t)
+	tiered := driver.NewTieredDriver(l1, l2)
+
+	require.NoError(t, tiered.Set(ctx, "user:42:profile", "v", 0))
+	require.NoError(t, tiered.Set(ctx, "user:42:posts", "v", 0))
+	require.NoError(t, l1.Set(ctx, "user:42:local", "v", 0))
+	require.NoError(t, tiered.Set(ctx, "user:43:profile", "v", 0))
+
+	deleted, err := tiered.DeleteByPrefix(ctx, "user:42:")
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	assert.False(t, l1.Has(ctx, "user:42:local"))
+	assert.False(t, l2.Has(ctx, "user:42:profile"))
+	assert.True(t, l1.Has(ctx, "user:43:profile"))
+	assert.True(t, l2.Has(ctx, "user:43:profile"))
+}
@@ -0,0 +1,48 @@
+package driver
+
+import "time"
+
+// NoExpiration là giá trị TTL trả về cho entry không có thời điểm hết hạn.
+const NoExpiration time.Duration = -1
+
+// remainingTTL tính thời gian sống còn lại từ thời điểm hết hạn của entry.
+//
+// Params:
+//   - expiration: Thời điểm hết hạn (UnixNano), 0 nếu không hết hạn
+//
+// Returns:
+//   - time.Duration: Thời gian sống còn lại, NoExpiration nếu entry không hết hạn
+func remainingTTL(expiration int64) time.Duration {
+	if expiration == 0 {
+		return NoExpiration
+	}
+	remaining := time.Until(time.Unix(0, expiration))
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// slidingExpiration tính thời điểm hết hạn mới của entry khi được đọc trúng với
+// sliding expiration.
+//
+// Thời điểm hết hạn chỉ được kéo dài, không bao giờ bị rút ngắn, và entry không hết hạn
+// được giữ nguyên.
+//
+// Params:
+//   - expiration: Thời điểm hết hạn hiện tại (UnixNano), 0 nếu không hết hạn
+//   - sliding: Thời gian sống được gia hạn sau mỗi lần đọc
+//
+// Returns:
+//   - int64: Thời điểm hết hạn mới (UnixNano)
+//   - bool: true nếu thời điểm hết hạn cần được cập nhật
+func slidingExpiration(expiration int64, sliding time.Duration) (int64, bool) {
+	if expiration == 0 || sliding <= 0 {
+		return expiration, false
+	}
+	extended := time.Now().Add(sliding).UnixNano()
+	if extended <= expiration {
+		return expiration, false
+	}
+	return extended, true
+}
@@ -0,0 +1,207 @@
+// Package encryption mã hóa giá trị cache khi lưu trữ (at-rest) bằng AEAD.
+//
+// Keyring giữ một khóa đang hoạt động dùng để mã hóa entry mới và các khóa cũ chỉ dùng để
+// giải mã, nên khóa có thể được xoay vòng mà không làm mất các entry đã ghi. Mỗi dữ liệu
+// đã mã hóa bắt đầu bằng ID của khóa đã mã hóa nó:
+//
+//	byte 0      phiên bản định dạng
+//	byte 1      độ dài ID khóa
+//	n byte      ID khóa
+//	còn lại     nonce và dữ liệu đã mã hóa kèm tag xác thực
+//
+// Phần header (phiên bản và ID khóa) cùng associated data do driver truyền vào (cache key
+// của entry) được xác thực, nên dữ liệu bị sửa ở bất kỳ vị trí nào hoặc bị chép sang key
+// khác đều bị phát hiện khi giải mã.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Tên các thuật toán mã hóa.
+const (
+	// AESGCM mã hóa bằng AES-GCM với khóa 16, 24 hoặc 32 byte (AES-128/192/256)
+	AESGCM = "aes-gcm"
+
+	// XChaCha20Poly1305 mã hóa bằng XChaCha20-Poly1305 với khóa 32 byte và nonce 24 byte
+	XChaCha20Poly1305 = "xchacha20-poly1305"
+)
+
+// MaxKeyIDLength là độ dài tối đa của ID khóa tính theo byte.
+const MaxKeyIDLength = 255
+
+// formatVersion là phiên bản hiện tại của định dạng dữ liệu đã mã hóa.
+const formatVersion byte = 1
+
+var (
+	// ErrAuthentication được trả về khi dữ liệu đã mã hóa bị hỏng, bị sửa hoặc không được
+	// mã hóa bằng khóa mang ID trong header.
+	ErrAuthentication = errors.New("cache value authentication failed")
+
+	// ErrUnknownKey được trả về khi dữ liệu được mã hóa bằng khóa không có trong keyring.
+	ErrUnknownKey = errors.New("unknown cache encryption key")
+
+	// ErrInvalidKey được trả về khi cấu hình khóa không hợp lệ.
+	ErrInvalidKey = errors.New("invalid cache encryption key")
+)
+
+// Key là một khóa mã hóa.
+type Key struct {
+	// ID là định danh của khóa được lưu cùng mỗi entry, tối đa MaxKeyIDLength byte
+	ID string
+
+	// Algorithm là thuật toán của khóa: AESGCM (mặc định khi để trống) hoặc XChaCha20Poly1305
+	Algorithm string
+
+	// Secret là giá trị khóa
+	Secret []byte
+}
+
+// Keyring mã hóa bằng khóa đang hoạt động và giải mã bằng khóa mang ID trong header.
+//
+// Keyring an toàn khi được dùng đồng thời.
+type Keyring struct {
+	active string                 // ID của khóa dùng để mã hóa
+	aeads  map[string]cipher.AEAD // AEAD theo ID khóa
+}
+
+// NewKeyring tạo keyring từ danh sách khóa.
+//
+// Params:
+//   - activeID: ID của khóa dùng để mã hóa entry mới
+//   - keys: Các khóa, gồm khóa đang hoạt động và các khóa chỉ dùng để giải mã
+//
+// Returns:
+//   - *Keyring: Keyring đã được khởi tạo
+//   - error: Lỗi wrap ErrInvalidKey nếu khóa không hợp lệ, trùng ID hoặc không có khóa
+//     mang activeID
+func NewKeyring(activeID string, keys ...Key) (*Keyring, error) {
+	k := &Keyring{active: activeID, aeads: make(map[string]cipher.AEAD, len(keys))}
+	for _, key := range keys {
+		if key.ID == "" || len(key.ID) > MaxKeyIDLength {
+			return nil, fmt.Errorf("%w: key ID '%s' must be 1 to %d bytes", ErrInvalidKey, key.ID, MaxKeyIDLength)
+		}
+		if _, ok := k.aeads[key.ID]; ok {
+			return nil, fmt.Errorf("%w: duplicate key ID '%s'", ErrInvalidKey, key.ID)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("%w '%s': %v", ErrInvalidKey, key.ID, err)
+		}
+		k.aeads[key.ID] = aead
+	}
+	if _, ok := k.aeads[activeID]; !ok {
+		return nil, fmt.Errorf("%w: active key '%s' is not configured", ErrInvalidKey, activeID)
+	}
+	return k, nil
+}
+
+// newAEAD tạo AEAD theo thuật toán của khóa.
+func newAEAD(key Key) (cipher.AEAD, error) {
+	switch key.Algorithm {
+	case "", AESGCM:
+		block, err := aes.NewCipher(key.Secret)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCM(block)
+	case XChaCha20Poly1305:
+		return chacha20poly1305.NewX(key.Secret)
+	}
+	return nil, fmt.Errorf("unsupported algorithm '%s'", key.Algorithm)
+}
+
+// ActiveKeyID trả về ID của khóa dùng để mã hóa entry mới.
+//
+// Returns:
+//   - string: ID khóa
+func (k *Keyring) ActiveKeyID() string {
+	return k.active
+}
+
+// Encrypt mã hóa plaintext bằng khóa đang hoạt động với nonce ngẫu nhiên.
+//
+// Params:
+//   - plaintext: Dữ liệu cần mã hóa
+//   - associatedData: Dữ liệu được xác thực nhưng không được mã hóa, phải được truyền lại
+//     nguyên vẹn khi giải mã
+//
+// Returns:
+//   - []byte: Dữ liệu đã mã hóa, bắt đầu bằng header chứa ID khóa
+//   - error: Lỗi nếu không tạo được nonce
+func (k *Keyring) Encrypt(plaintext, associatedData []byte) ([]byte, error) {
+	aead := k.aeads[k.active]
+	headerSize := 2 + len(k.active)
+
+	out := make([]byte, headerSize+aead.NonceSize(), headerSize+aead.NonceSize()+len(plaintext)+aead.Overhead())
+	out[0] = formatVersion
+	out[1] = byte(len(k.active))
+	copy(out[2:], k.active)
+
+	nonce := out[headerSize:]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("could not generate nonce: %w", err)
+	}
+	return aead.Seal(out, nonce, plaintext, additionalData(out[:headerSize], associatedData)), nil
+}
+
+// Decrypt giải mã dữ liệu bằng khóa mang ID trong header.
+//
+// Params:
+//   - data: Dữ liệu đã mã hóa
+//   - associatedData: Associated data đã truyền cho Encrypt
+//
+// Returns:
+//   - []byte: Dữ liệu gốc
+//   - error: Lỗi wrap ErrUnknownKey nếu khóa không có trong keyring, hoặc wrap
+//     ErrAuthentication nếu dữ liệu hỏng, bị sửa hoặc associatedData không khớp
+func (k *Keyring) Decrypt(data, associatedData []byte) ([]byte, error) {
+	id, err := KeyID(data)
+	if err != nil {
+		return nil, err
+	}
+	aead, ok := k.aeads[id]
+	if !ok {
+		return nil, fmt.Errorf("%w '%s'", ErrUnknownKey, id)
+	}
+
+	headerSize := 2 + len(id)
+	if len(data) < headerSize+aead.NonceSize()+aead.Overhead() {
+		return nil, fmt.Errorf("%w: ciphertext too short", ErrAuthentication)
+	}
+	nonce := data[headerSize : headerSize+aead.NonceSize()]
+	plaintext, err := aead.Open(nil, nonce, data[headerSize+aead.NonceSize():], additionalData(data[:headerSize], associatedData))
+	if err != nil {
+		return nil, fmt.Errorf("%w with key '%s'", ErrAuthentication, id)
+	}
+	return plaintext, nil
+}
+
+// KeyID đọc ID của khóa đã mã hóa dữ liệu mà không giải mã.
+//
+// Params:
+//   - data: Dữ liệu đã mã hóa
+//
+// Returns:
+//   - string: ID khóa
+//   - error: Lỗi wrap ErrAuthentication nếu header không hợp lệ
+func KeyID(data []byte) (string, error) {
+	if len(data) < 2 || data[0] != formatVersion || len(data) < 2+int(data[1]) {
+		return "", fmt.Errorf("%w: invalid header", ErrAuthentication)
+	}
+	return string(data[2 : 2+data[1]]), nil
+}
+
+// additionalData ghép header với associated data của driver thành dữ liệu được xác thực.
+func additionalData(header, associatedData []byte) []byte {
+	if len(associatedData) == 0 {
+		return header
+	}
+	return append(append(make([]byte, 0, len(header)+len(associatedData)), header...), associatedData...)
+}
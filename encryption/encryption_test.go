@@ -0,0 +1,119 @@
+package encryption_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.fork.vn/cache/encryption"
+)
+
+func testKey(id, algorithm string, size int) encryption.Key {
+	return encryption.Key{ID: id, Algorithm: algorithm, Secret: bytes.Repeat([]byte(id[:1]), size)}
+}
+
+func TestKeyringRoundTrip(t *testing.T) {
+	for _, key := range []encryption.Key{
+		testKey("a128", encryption.AESGCM, 16),
+		testKey("b256", encryption.AESGCM, 32),
+		testKey("c256", "", 32),
+		testKey("x", encryption.XChaCha20Poly1305, 32),
+	} {
+		t.Run(key.ID, func(t *testing.T) {
+			k, err := encryption.NewKeyring(key.ID, key)
+			require.NoError(t, err)
+
+			sealed, err := k.Encrypt([]byte("secret value"), []byte("user:1"))
+			require.NoError(t, err)
+			assert.NotContains(t, string(sealed), "secret value")
+
+			id, err := encryption.KeyID(sealed)
+			require.NoError(t, err)
+			assert.Equal(t, key.ID, id)
+
+			plaintext, err := k.Decrypt(sealed, []byte("user:1"))
+			require.NoError(t, err)
+			assert.Equal(t, []byte("secret value"), plaintext)
+
+			// Nonce ngẫu nhiên cho kết quả khác nhau với cùng dữ liệu
+			again, err := k.Encrypt([]byte("secret value"), []byte("user:1"))
+			require.NoError(t, err)
+			assert.NotEqual(t, sealed, again)
+		})
+	}
+}
+
+func TestKeyringRotation(t *testing.T) {
+	oldKey := testKey("old", encryption.AESGCM, 32)
+	newKey := testKey("new", encryption.XChaCha20Poly1305, 32)
+
+	before, err := encryption.NewKeyring("old", oldKey)
+	require.NoError(t, err)
+	sealed, err := before.Encrypt([]byte("value"), nil)
+	require.NoError(t, err)
+
+	after, err := encryption.NewKeyring("new", oldKey, newKey)
+	require.NoError(t, err)
+	assert.Equal(t, "new", after.ActiveKeyID())
+
+	plaintext, err := after.Decrypt(sealed, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), plaintext)
+
+	// Gỡ khóa cũ khỏi keyring
+	retired, err := encryption.NewKeyring("new", newKey)
+	require.NoError(t, err)
+	_, err = retired.Decrypt(sealed, nil)
+	assert.ErrorIs(t, err, encryption.ErrUnknownKey)
+}
+
+func TestKeyringRejectsTamperedData(t *testing.T) {
+	k, err := encryption.NewKeyring("k1", testKey("k1", encryption.AESGCM, 32), testKey("k2", encryption.AESGCM, 32))
+	require.NoError(t, err)
+	sealed, err := k.Encrypt([]byte("value"), []byte("a"))
+	require.NoError(t, err)
+
+	tampered := append([]byte(nil), sealed...)
+	tampered[len(tampered)-1] ^= 0x01
+	_, err = k.Decrypt(tampered, []byte("a"))
+	assert.ErrorIs(t, err, encryption.ErrAuthentication)
+
+	// Associated data khác (entry bị chép sang key khác)
+	_, err = k.Decrypt(sealed, []byte("b"))
+	assert.ErrorIs(t, err, encryption.ErrAuthentication)
+
+	// Header bị sửa để trỏ tới khóa khác
+	relabeled := append([]byte(nil), sealed...)
+	relabeled[3] = '2'
+	_, err = k.Decrypt(relabeled, []byte("a"))
+	assert.ErrorIs(t, err, encryption.ErrAuthentication)
+
+	_, err = k.Decrypt(sealed[:10], []byte("a"))
+	assert.ErrorIs(t, err, encryption.ErrAuthentication)
+
+	_, err = encryption.KeyID([]byte{0x09, 0x01})
+	assert.ErrorIs(t, err, encryption.ErrAuthentication)
+}
+
+func TestNewKeyringInvalid(t *testing.T) {
+	valid := testKey("k", encryption.AESGCM, 32)
+
+	tests := map[string]struct {
+		active string
+		keys   []encryption.Key
+	}{
+		"missing active key": {active: "other", keys: []encryption.Key{valid}},
+		"empty ID":           {active: "", keys: []encryption.Key{{Secret: valid.Secret}}},
+		"duplicate ID":       {active: "k", keys: []encryption.Key{valid, valid}},
+		"short AES key":      {active: "k", keys: []encryption.Key{testKey("k", encryption.AESGCM, 10)}},
+		"short XChaCha key":  {active: "k", keys: []encryption.Key{testKey("k", encryption.XChaCha20Poly1305, 16)}},
+		"unknown algorithm":  {active: "k", keys: []encryption.Key{testKey("k", "des", 32)}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := encryption.NewKeyring(tt.active, tt.keys...)
+			assert.ErrorIs(t, err, encryption.ErrInvalidKey)
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.fork.vn/cache/driver"
+)
+
+// ErrLockTimeout được trả về bởi Lock.Block khi không giành được khóa trong thời gian chờ.
+var ErrLockTimeout = errors.New("cache lock wait timed out")
+
+// lockRetryInterval là khoảng thời gian giữa các lần thử lại của Acquire và Block.
+const lockRetryInterval = 50 * time.Millisecond
+
+// Lock là một khóa phân tán có tên trên một cache driver.
+//
+// Mỗi Lock mang một owner token; chỉ Lock có cùng owner mới gia hạn hoặc giải phóng
+// được khóa. Lock an toàn khi được dùng đồng thời từ nhiều goroutine, và owner có thể
+// được truyền sang goroutine hoặc process khác để giải phóng khóa qua Manager.RestoreLock.
+type Lock struct {
+	locker driver.Locker // Driver lưu khóa
+	name   string        // Tên khóa
+	owner  string        // Token định danh chủ sở hữu
+	ttl    time.Duration // Thời gian giữ khóa tối đa (<= 0 để khóa không hết hạn)
+}
+
+// NewLock tạo một Lock với owner token mới trên driver được chỉ định.
+//
+// Params:
+//   - locker: Driver lưu khóa
+//   - name: Tên khóa
+//   - ttl: Thời gian giữ khóa tối đa (<= 0 để khóa không hết hạn)
+//
+// Returns:
+//   - *Lock: Khóa chưa được giành
+//   - error: Lỗi nếu không tạo được owner token
+func NewLock(locker driver.Locker, name string, ttl time.Duration) (*Lock, error) {
+	owner, err := driver.NewLockOwner()
+	if err != nil {
+		return nil, err
+	}
+	return RestoreLock(locker, name, owner, ttl), nil
+}
+
+// RestoreLock tạo lại Lock từ owner token của một khóa đã được giành.
+//
+// Params:
+//   - locker: Driver lưu khóa
+//   - name: Tên khóa
+//   - owner: Owner token lấy từ Lock.Owner
+//   - ttl: Thời gian giữ khóa dùng khi gia hạn (<= 0 để khóa không hết hạn)
+//
+// Returns:
+//   - *Lock: Khóa với owner được chỉ định
+func RestoreLock(locker driver.Locker, name, owner string, ttl time.Duration) *Lock {
+	return &Lock{locker: locker, name: name, owner: owner, ttl: ttl}
+}
+
+// Name trả về tên khóa.
+func (l *Lock) Name() string {
+	return l.name
+}
+
+// Owner trả về owner token của khóa.
+func (l *Lock) Owner() string {
+	return l.owner
+}
+
+// TryAcquire thử giành khóa một lần, không chờ.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//
+// Returns:
+//   - bool: true nếu giành được khóa
+//   - error: Lỗi từ driver
+func (l *Lock) TryAcquire(ctx context.Context) (bool, error) {
+	return l.locker.TryLock(ctx, l.name, l.owner, l.ttl)
+}
+
+// Acquire chờ cho đến khi giành được khóa hoặc ctx kết thúc.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian chờ
+//
+// Returns:
+//   - error: Lỗi của ctx nếu ctx kết thúc trước khi giành được khóa, hoặc lỗi từ driver
+func (l *Lock) Acquire(ctx context.Context) error {
+	for {
+		acquired, err := l.TryAcquire(ctx)
+		if err != nil {
+			return err
+		}
+		if acquired {
+			return nil
+		}
+
+		timer := time.NewTimer(lockRetryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Block chờ tối đa timeout để giành khóa.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian chờ
+//   - timeout: Thời gian chờ tối đa
+//
+// Returns:
+//   - error: ErrLockTimeout nếu hết thời gian chờ, lỗi của ctx nếu ctx kết thúc trước,
+//     hoặc lỗi từ driver
+func (l *Lock) Block(ctx context.Context, timeout time.Duration) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := l.Acquire(waitCtx)
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return ErrLockTimeout
+	}
+	return err
+}
+
+// Release giải phóng khóa nếu owner của Lock đang giữ khóa.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//
+// Returns:
+//   - bool: true nếu khóa được giải phóng, false nếu khóa đã hết hạn hoặc thuộc owner khác
+//   - error: Lỗi từ driver
+func (l *Lock) Release(ctx context.Context) (bool, error) {
+	return l.locker.Unlock(ctx, l.name, l.owner)
+}
+
+// Refresh đặt lại thời gian giữ khóa về ttl của Lock, tính từ bây giờ.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//
+// Returns:
+//   - bool: true nếu owner vẫn giữ khóa và khóa đã được gia hạn
+//   - error: Lỗi từ driver
+func (l *Lock) Refresh(ctx context.Context) (bool, error) {
+	return l.locker.RefreshLock(ctx, l.name, l.owner, l.ttl)
+}
+
+// KeepAlive gia hạn khóa định kỳ trong nền cho các tác vụ chạy lâu.
+//
+// Khóa được gia hạn mỗi interval cho đến khi stop được gọi, ctx kết thúc hoặc khóa bị
+// mất. Channel lost được đóng khi một lần gia hạn cho biết owner không còn giữ khóa;
+// lỗi tạm thời từ driver được bỏ qua và thử lại ở lần sau. Với Lock không hết hạn,
+// không có lần gia hạn nào được thực hiện.
+//
+// Params:
+//   - ctx: Context giới hạn thời gian gia hạn
+//   - interval: Khoảng thời gian giữa các lần gia hạn (<= 0 để dùng một phần ba ttl)
+//
+// Returns:
+//   - <-chan struct{}: Channel được đóng khi khóa bị mất
+//   - func(): Hàm dừng việc gia hạn, chờ goroutine gia hạn kết thúc
+func (l *Lock) KeepAlive(ctx context.Context, interval time.Duration) (<-chan struct{}, func()) {
+	lost := make(chan struct{})
+	if l.ttl <= 0 {
+		return lost, func() {}
+	}
+	if interval <= 0 {
+		interval = max(l.ttl/3, time.Millisecond)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			refreshed, err := l.Refresh(ctx)
+			if err == nil && !refreshed {
+				close(lost)
+				return
+			}
+		}
+	}()
+
+	return lost, func() {
+		cancel()
+		<-done
+	}
+}
@@ -0,0 +1,159 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.fork.vn/cache"
+	"go.fork.vn/cache/config"
+	"go.fork.vn/cache/driver"
+)
+
+// TestLock kiểm tra Lock với memory driver thật
+func TestLock(t *testing.T) {
+	ctx := context.Background()
+
+	newLocker := func(t *testing.T) driver.Locker {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+		t.Cleanup(func() { _ = memoryDriver.Close() })
+		return memoryDriver.(driver.Locker)
+	}
+
+	t.Run("try_acquire_is_exclusive", func(t *testing.T) {
+		locker := newLocker(t)
+		first, err := cache.NewLock(locker, "report", time.Minute)
+		require.NoError(t, err)
+		second, err := cache.NewLock(locker, "report", time.Minute)
+		require.NoError(t, err)
+		assert.NotEqual(t, first.Owner(), second.Owner())
+
+		acquired, err := first.TryAcquire(ctx)
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+
+		acquired, err = second.TryAcquire(ctx)
+		assert.NoError(t, err)
+		assert.False(t, acquired)
+
+		// Chỉ chủ sở hữu giải phóng được khóa
+		released, err := second.Release(ctx)
+		assert.NoError(t, err)
+		assert.False(t, released)
+
+		released, err = first.Release(ctx)
+		assert.NoError(t, err)
+		assert.True(t, released)
+
+		acquired, err = second.TryAcquire(ctx)
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+	})
+
+	t.Run("block_times_out", func(t *testing.T) {
+		locker := newLocker(t)
+		holder, _ := cache.NewLock(locker, "report", time.Minute)
+		waiter, _ := cache.NewLock(locker, "report", time.Minute)
+		require.NoError(t, holder.Acquire(ctx))
+
+		err := waiter.Block(ctx, 120*time.Millisecond)
+		assert.ErrorIs(t, err, cache.ErrLockTimeout)
+	})
+
+	t.Run("block_acquires_after_release", func(t *testing.T) {
+		locker := newLocker(t)
+		holder, _ := cache.NewLock(locker, "report", time.Minute)
+		waiter, _ := cache.NewLock(locker, "report", time.Minute)
+		require.NoError(t, holder.Acquire(ctx))
+
+		go func() {
+			time.Sleep(80 * time.Millisecond)
+			_, _ = holder.Release(ctx)
+		}()
+
+		assert.NoError(t, waiter.Block(ctx, time.Second))
+	})
+
+	t.Run("acquire_stops_when_context_is_cancelled", func(t *testing.T) {
+		locker := newLocker(t)
+		holder, _ := cache.NewLock(locker, "report", time.Minute)
+		waiter, _ := cache.NewLock(locker, "report", time.Minute)
+		require.NoError(t, holder.Acquire(ctx))
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		assert.ErrorIs(t, waiter.Acquire(cancelled), context.Canceled)
+	})
+
+	t.Run("expired_lock_can_be_taken_over", func(t *testing.T) {
+		locker := newLocker(t)
+		holder, _ := cache.NewLock(locker, "report", 50*time.Millisecond)
+		waiter, _ := cache.NewLock(locker, "report", time.Minute)
+		require.NoError(t, holder.Acquire(ctx))
+
+		time.Sleep(80 * time.Millisecond)
+		acquired, err := waiter.TryAcquire(ctx)
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+
+		refreshed, err := holder.Refresh(ctx)
+		assert.NoError(t, err)
+		assert.False(t, refreshed)
+	})
+
+	t.Run("restored_lock_releases_across_goroutines", func(t *testing.T) {
+		locker := newLocker(t)
+		holder, _ := cache.NewLock(locker, "report", time.Minute)
+		require.NoError(t, holder.Acquire(ctx))
+
+		released := make(chan bool)
+		go func(owner string) {
+			ok, _ := cache.RestoreLock(locker, "report", owner, time.Minute).Release(ctx)
+			released <- ok
+		}(holder.Owner())
+
+		assert.True(t, <-released)
+		acquired, _ := holder.TryAcquire(ctx)
+		assert.True(t, acquired)
+	})
+
+	t.Run("keep_alive_renews_lease", func(t *testing.T) {
+		locker := newLocker(t)
+		holder, _ := cache.NewLock(locker, "report", 90*time.Millisecond)
+		waiter, _ := cache.NewLock(locker, "report", time.Minute)
+		require.NoError(t, holder.Acquire(ctx))
+
+		lost, stop := holder.KeepAlive(ctx, 20*time.Millisecond)
+		time.Sleep(200 * time.Millisecond)
+
+		acquired, _ := waiter.TryAcquire(ctx)
+		assert.False(t, acquired, "lease must outlive its original ttl while kept alive")
+		select {
+		case <-lost:
+			t.Fatal("lease must not be reported lost")
+		default:
+		}
+		stop()
+
+		released, _ := holder.Release(ctx)
+		assert.True(t, released)
+	})
+
+	t.Run("keep_alive_reports_lost_lease", func(t *testing.T) {
+		locker := newLocker(t)
+		holder, _ := cache.NewLock(locker, "report", time.Minute)
+		require.NoError(t, holder.Acquire(ctx))
+
+		lost, stop := holder.KeepAlive(ctx, 10*time.Millisecond)
+		defer stop()
+		_, _ = cache.RestoreLock(locker, "report", holder.Owner(), time.Minute).Release(ctx)
+
+		select {
+		case <-lost:
+		case <-time.After(time.Second):
+			t.Fatal("lost lease was not reported")
+		}
+	})
+}
@@ -15,6 +15,10 @@ import (
 // Nó cho phép đăng ký nhiều driver dựa trên tên và đặt một driver mặc định
 // để sử dụng cho các thao tác cache. Nó cũng cung cấp các phương thức tiện ích
 // cho tất cả các thao tác cơ bản trên cache mà không cần trực tiếp tương tác với driver.
+//
+// Mỗi phương thức X có biến thể XContext truyền context của caller xuống driver,
+// cho phép deadline, cancellation và các giá trị request-scoped đến được driver;
+// X tương đương với XContext(context.Background(), ...).
 type Manager interface {
 	// Get lấy một giá trị từ cache.
 	//
@@ -28,6 +32,17 @@ type Manager interface {
 	//   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
 	Get(key string) (interface{}, bool)
 
+	// GetContext giống Get nhưng nhận context của caller.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Cache key cần tìm
+	//
+	// Returns:
+	//   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
+	//   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
+	GetContext(ctx context.Context, key string) (interface{}, bool)
+
 	// Set đặt một giá trị vào cache với TTL tùy chọn.
 	//
 	// Phương thức này lưu trữ một cặp key-value vào cache mặc định với thời gian sống
@@ -42,6 +57,20 @@ type Manager interface {
 	//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
 	Set(key string, value interface{}, ttl time.Duration) error
 
+	// SetContext đặt một giá trị vào cache với context được chỉ định.
+	//
+	// Phương thức này giống Set nhưng truyền context của caller xuống driver mặc định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Cache key để lưu giá trị
+	//   - value: Giá trị cần lưu trữ
+	//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
+	SetContext(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+
 	// Has kiểm tra xem một key có tồn tại trong cache không.
 	//
 	// Phương thức này xác định liệu một key có tồn tại trong cache mặc định và chưa hết hạn hay không.
@@ -53,6 +82,16 @@ type Manager interface {
 	//   - bool: true nếu key tồn tại và chưa hết hạn, false nếu ngược lại
 	Has(key string) bool
 
+	// HasContext kiểm tra xem một key có tồn tại trong cache không với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Cache key cần kiểm tra
+	//
+	// Returns:
+	//   - bool: true nếu key tồn tại và chưa hết hạn, false nếu ngược lại
+	HasContext(ctx context.Context, key string) bool
+
 	// Delete xóa một key khỏi cache.
 	//
 	// Phương thức này xóa key và giá trị tương ứng khỏi cache mặc định nếu tồn tại.
@@ -64,6 +103,16 @@ type Manager interface {
 	//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
 	Delete(key string) error
 
+	// DeleteContext xóa một key khỏi cache với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Cache key cần xóa
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
+	DeleteContext(ctx context.Context, key string) error
+
 	// Flush xóa tất cả các key khỏi cache.
 	//
 	// Phương thức này xóa tất cả dữ liệu trong cache mặc định, làm trống hoàn toàn bộ nhớ cache.
@@ -72,6 +121,15 @@ type Manager interface {
 	//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
 	Flush() error
 
+	// FlushContext xóa tất cả các key khỏi cache với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
+	FlushContext(ctx context.Context) error
+
 	// GetMultiple lấy nhiều giá trị từ cache.
 	//
 	// Phương thức này lấy các giá trị tương ứng với nhiều key từ cache mặc định trong một lần gọi.
@@ -84,6 +142,17 @@ type Manager interface {
 	//   - []string: Danh sách các key không tìm thấy hoặc đã hết hạn
 	GetMultiple(keys []string) (map[string]interface{}, []string)
 
+	// GetMultipleContext lấy nhiều giá trị từ cache với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - keys: Danh sách các khóa cần lấy
+	//
+	// Returns:
+	//   - map[string]interface{}: Map chứa các key tìm thấy và giá trị tương ứng
+	//   - []string: Danh sách các key không tìm thấy hoặc đã hết hạn
+	GetMultipleContext(ctx context.Context, keys []string) (map[string]interface{}, []string)
+
 	// SetMultiple đặt nhiều giá trị vào cache.
 	//
 	// Phương thức này lưu trữ nhiều cặp key-value vào cache mặc định trong một lần gọi
@@ -97,6 +166,17 @@ type Manager interface {
 	//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
 	SetMultiple(values map[string]interface{}, ttl time.Duration) error
 
+	// SetMultipleContext đặt nhiều giá trị vào cache với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - values: Map chứa các key và giá trị tương ứng cần lưu trữ
+	//   - ttl: Thời gian sống chung cho tất cả các giá trị
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
+	SetMultipleContext(ctx context.Context, values map[string]interface{}, ttl time.Duration) error
+
 	// DeleteMultiple xóa nhiều key khỏi cache.
 	//
 	// Phương thức này xóa nhiều key và giá trị tương ứng khỏi cache mặc định trong một lần gọi.
@@ -108,6 +188,16 @@ type Manager interface {
 	//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
 	DeleteMultiple(keys []string) error
 
+	// DeleteMultipleContext xóa nhiều key khỏi cache với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - keys: Danh sách các khóa cần xóa
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
+	DeleteMultipleContext(ctx context.Context, keys []string) error
+
 	// Remember lấy một giá trị từ cache hoặc thực thi callback nếu không tìm thấy.
 	//
 	// Phương thức này kiểm tra xem một key có tồn tại trong cache mặc định không, nếu có thì
@@ -124,237 +214,1477 @@ type Manager interface {
 	//   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc driver mặc định không được cấu hình
 	Remember(key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error)
 
-	// AddDriver thêm một driver vào manager.
+	// RememberContext lấy một giá trị từ cache hoặc thực thi callback nếu không tìm thấy,
+	// với context được chỉ định.
 	//
-	// Phương thức này đăng ký một driver mới với manager theo tên xác định.
-	// Nếu chưa có driver mặc định được đặt, driver đầu tiên được thêm vào sẽ trở thành mặc định.
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Cache key cần tìm hoặc lưu vào cache
+	//   - ttl: Thời gian sống của giá trị nếu phải lấy từ callback
+	//   - callback: Hàm được gọi để lấy dữ liệu khi key không có trong cache
+	//
+	// Returns:
+	//   - interface{}: Giá trị từ cache hoặc từ callback
+	//   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc driver mặc định không được cấu hình
+	RememberContext(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error)
+
+	// RememberStale lấy một giá trị từ cache theo cơ chế stale-while-revalidate.
+	//
+	// Giá trị được coi là còn mới trong ttl và tiếp tục được phục vụ trong grace sau đó.
+	// Trong khoảng grace, giá trị cũ được trả về ngay và callback được thực thi trong nền
+	// để làm mới. Khi key không có trong cache, phương thức hoạt động giống Remember.
 	//
 	// Params:
-	//   - name: Tên định danh cho driver
-	//   - driver: Đối tượng driver cần thêm vào
-	AddDriver(name string, driver driver.Driver)
+	//   - key: Cache key cần tìm hoặc lưu vào cache
+	//   - ttl: Thời gian giá trị được coi là còn mới
+	//   - grace: Thời gian giá trị cũ tiếp tục được phục vụ sau ttl
+	//   - callback: Hàm được gọi để lấy dữ liệu
+	//
+	// Returns:
+	//   - interface{}: Giá trị từ cache hoặc từ callback
+	//   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc driver mặc định không được cấu hình
+	RememberStale(key string, ttl, grace time.Duration, callback func() (interface{}, error)) (interface{}, error)
 
-	// SetDefaultDriver đặt driver mặc định.
+	// RememberStaleContext lấy một giá trị từ cache theo cơ chế stale-while-revalidate
+	// với context được chỉ định.
 	//
-	// Phương thức này thiết lập driver mặc định được sử dụng cho các thao tác cache.
-	// Driver phải đã được đăng ký với manager trước đó.
+	// Lần làm mới trong nền không bị hủy khi ctx kết thúc, nhưng vẫn nhận các giá trị
+	// request-scoped của ctx.
 	//
 	// Params:
-	//   - name: Tên của driver cần đặt làm mặc định
-	SetDefaultDriver(name string)
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Cache key cần tìm hoặc lưu vào cache
+	//   - ttl: Thời gian giá trị được coi là còn mới
+	//   - grace: Thời gian giá trị cũ tiếp tục được phục vụ sau ttl
+	//   - callback: Hàm được gọi để lấy dữ liệu
+	//
+	// Returns:
+	//   - interface{}: Giá trị từ cache hoặc từ callback
+	//   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc driver mặc định không được cấu hình
+	RememberStaleContext(ctx context.Context, key string, ttl, grace time.Duration, callback func() (interface{}, error)) (interface{}, error)
 
-	// Driver trả về một driver cụ thể theo tên.
+	// Add lưu giá trị vào cache mặc định chỉ khi key chưa tồn tại.
 	//
-	// Phương thức này lấy một driver đã đăng ký dựa theo tên của nó.
+	// Việc kiểm tra và ghi là nguyên tử trên từng driver, nên Add phù hợp cho idempotency key
+	// và chống xử lý trùng lặp.
 	//
 	// Params:
-	//   - name: Tên của driver cần lấy
+	//   - key: Cache key để lưu giá trị
+	//   - value: Giá trị cần lưu trữ
+	//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định của driver, -1 để không hết hạn)
 	//
 	// Returns:
-	//   - driver.Driver: Đối tượng driver được yêu cầu
-	//   - error: Lỗi nếu driver không tồn tại
-	Driver(name string) (driver.Driver, error)
+	//   - bool: true nếu giá trị được lưu, false nếu key đã tồn tại
+	//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+	Add(key string, value interface{}, ttl time.Duration) (bool, error)
+
+	// AddContext lưu giá trị chỉ khi key chưa tồn tại với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Cache key để lưu giá trị
+	//   - value: Giá trị cần lưu trữ
+	//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - bool: true nếu giá trị được lưu, false nếu key đã tồn tại
+	//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+	AddContext(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
+
+	// Replace thay giá trị trong cache mặc định chỉ khi key đang tồn tại.
+	//
+	// Params:
+	//   - key: Cache key cần thay giá trị
+	//   - value: Giá trị mới
+	//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - bool: true nếu giá trị được thay, false nếu key không tồn tại
+	//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+	Replace(key string, value interface{}, ttl time.Duration) (bool, error)
+
+	// ReplaceContext thay giá trị chỉ khi key đang tồn tại với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Cache key cần thay giá trị
+	//   - value: Giá trị mới
+	//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - bool: true nếu giá trị được thay, false nếu key không tồn tại
+	//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+	ReplaceContext(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
+
+	// GetAndSet lưu giá trị mới vào cache mặc định và trả về giá trị cũ một cách nguyên tử.
+	//
+	// Params:
+	//   - key: Cache key để lưu giá trị
+	//   - value: Giá trị mới
+	//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - interface{}: Giá trị cũ (nil nếu không có)
+	//   - bool: true nếu key tồn tại trước đó
+	//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+	GetAndSet(key string, value interface{}, ttl time.Duration) (interface{}, bool, error)
+
+	// GetAndSetContext lưu giá trị mới và trả về giá trị cũ với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Cache key để lưu giá trị
+	//   - value: Giá trị mới
+	//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - interface{}: Giá trị cũ (nil nếu không có)
+	//   - bool: true nếu key tồn tại trước đó
+	//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+	GetAndSetContext(ctx context.Context, key string, value interface{}, ttl time.Duration) (interface{}, bool, error)
+
+	// GetAndDelete lấy giá trị và xóa key khỏi cache mặc định một cách nguyên tử (pull).
+	//
+	// Khi nhiều caller cùng gọi trên một key, chỉ một caller nhận được giá trị.
+	//
+	// Params:
+	//   - key: Cache key cần lấy và xóa
+	//
+	// Returns:
+	//   - interface{}: Giá trị đã xóa (nil nếu không có)
+	//   - bool: true nếu key tồn tại và chưa hết hạn
+	//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+	GetAndDelete(key string) (interface{}, bool, error)
+
+	// GetAndDeleteContext lấy giá trị và xóa key với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Cache key cần lấy và xóa
+	//
+	// Returns:
+	//   - interface{}: Giá trị đã xóa (nil nếu không có)
+	//   - bool: true nếu key tồn tại và chưa hết hạn
+	//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+	GetAndDeleteContext(ctx context.Context, key string) (interface{}, bool, error)
+
+	// GetWithVersion lấy giá trị cùng token phiên bản của entry từ cache mặc định.
+	//
+	// Token được dùng với CompareAndSwap để ghi đè entry chỉ khi nó chưa bị thay đổi.
+	//
+	// Params:
+	//   - key: Cache key cần tìm
+	//
+	// Returns:
+	//   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
+	//   - string: Token phiên bản của entry (rỗng nếu không tìm thấy)
+	//   - bool: true nếu tìm thấy key, false nếu không tìm thấy hoặc driver mặc định không được cấu hình
+	GetWithVersion(key string) (interface{}, string, bool)
+
+	// GetWithVersionContext lấy giá trị cùng token phiên bản với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Cache key cần tìm
+	//
+	// Returns:
+	//   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
+	//   - string: Token phiên bản của entry (rỗng nếu không tìm thấy)
+	//   - bool: true nếu tìm thấy key, false nếu không tìm thấy hoặc driver mặc định không được cấu hình
+	GetWithVersionContext(ctx context.Context, key string) (interface{}, string, bool)
+
+	// CompareAndSwap ghi giá trị mới vào cache mặc định chỉ khi entry chưa thay đổi
+	// kể từ lần đọc trả về token.
+	//
+	// Params:
+	//   - key: Cache key cần ghi
+	//   - token: Token phiên bản nhận được từ GetWithVersion
+	//   - value: Giá trị mới
+	//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - bool: true nếu giá trị được ghi, false nếu key không tồn tại hoặc đã bị thay đổi
+	//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+	CompareAndSwap(key, token string, value interface{}, ttl time.Duration) (bool, error)
+
+	// CompareAndSwapContext ghi giá trị mới theo token phiên bản với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Cache key cần ghi
+	//   - token: Token phiên bản nhận được từ GetWithVersion
+	//   - value: Giá trị mới
+	//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - bool: true nếu giá trị được ghi, false nếu key không tồn tại hoặc đã bị thay đổi
+	//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+	CompareAndSwapContext(ctx context.Context, key, token string, value interface{}, ttl time.Duration) (bool, error)
+
+	// TTL trả về thời gian sống còn lại của key trong cache mặc định.
+	//
+	// Params:
+	//   - key: Cache key cần kiểm tra
+	//
+	// Returns:
+	//   - time.Duration: Thời gian sống còn lại, driver.NoExpiration nếu entry không hết hạn
+	//   - bool: true nếu tìm thấy key, false nếu không tìm thấy hoặc driver mặc định không được cấu hình
+	TTL(key string) (time.Duration, bool)
+
+	// TTLContext trả về thời gian sống còn lại của key với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Cache key cần kiểm tra
+	//
+	// Returns:
+	//   - time.Duration: Thời gian sống còn lại, driver.NoExpiration nếu entry không hết hạn
+	//   - bool: true nếu tìm thấy key, false nếu không tìm thấy hoặc driver mặc định không được cấu hình
+	TTLContext(ctx context.Context, key string) (time.Duration, bool)
+
+	// Touch đặt lại thời gian sống của key trong cache mặc định mà không ghi lại giá trị.
+	//
+	// Params:
+	//   - key: Cache key cần gia hạn
+	//   - ttl: Thời gian sống mới tính từ bây giờ (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - bool: true nếu key tồn tại và đã được cập nhật
+	//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+	Touch(key string, ttl time.Duration) (bool, error)
+
+	// TouchContext đặt lại thời gian sống của key với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Cache key cần gia hạn
+	//   - ttl: Thời gian sống mới tính từ bây giờ (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - bool: true nếu key tồn tại và đã được cập nhật
+	//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+	TouchContext(ctx context.Context, key string, ttl time.Duration) (bool, error)
+
+	// Increment tăng giá trị số nguyên của key trong cache mặc định một cách nguyên tử.
+	//
+	// Nếu key chưa tồn tại, giá trị bắt đầu từ 0 và entry mới nhận ttl. Thời điểm hết hạn
+	// của entry đã tồn tại được giữ nguyên.
+	//
+	// Params:
+	//   - key: Khóa của bộ đếm
+	//   - delta: Giá trị cần cộng thêm (có thể âm)
+	//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - int64: Giá trị sau khi tăng
+	//   - error: driver.ErrNotNumeric nếu giá trị hiện tại không phải số nguyên, lỗi từ driver,
+	//     hoặc driver mặc định không được cấu hình
+	Increment(key string, delta int64, ttl time.Duration) (int64, error)
+
+	// IncrementContext tăng giá trị số nguyên của key với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa của bộ đếm
+	//   - delta: Giá trị cần cộng thêm (có thể âm)
+	//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - int64: Giá trị sau khi tăng
+	//   - error: driver.ErrNotNumeric nếu giá trị hiện tại không phải số nguyên, lỗi từ driver,
+	//     hoặc driver mặc định không được cấu hình
+	IncrementContext(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+
+	// Decrement giảm giá trị số nguyên của key trong cache mặc định một cách nguyên tử.
+	//
+	// Params:
+	//   - key: Khóa của bộ đếm
+	//   - delta: Giá trị cần trừ đi
+	//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - int64: Giá trị sau khi giảm
+	//   - error: driver.ErrNotNumeric nếu giá trị hiện tại không phải số nguyên, lỗi từ driver,
+	//     hoặc driver mặc định không được cấu hình
+	Decrement(key string, delta int64, ttl time.Duration) (int64, error)
+
+	// DecrementContext giảm giá trị số nguyên của key với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa của bộ đếm
+	//   - delta: Giá trị cần trừ đi
+	//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - int64: Giá trị sau khi giảm
+	//   - error: driver.ErrNotNumeric nếu giá trị hiện tại không phải số nguyên, lỗi từ driver,
+	//     hoặc driver mặc định không được cấu hình
+	DecrementContext(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+
+	// IncrementFloat tăng giá trị số thực của key trong cache mặc định một cách nguyên tử.
+	//
+	// Params:
+	//   - key: Khóa của bộ đếm
+	//   - delta: Giá trị cần cộng thêm (có thể âm)
+	//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - float64: Giá trị sau khi tăng
+	//   - error: driver.ErrNotNumeric nếu giá trị hiện tại không phải số, lỗi từ driver,
+	//     hoặc driver mặc định không được cấu hình
+	IncrementFloat(key string, delta float64, ttl time.Duration) (float64, error)
+
+	// IncrementFloatContext tăng giá trị số thực của key với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Khóa của bộ đếm
+	//   - delta: Giá trị cần cộng thêm (có thể âm)
+	//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - float64: Giá trị sau khi tăng
+	//   - error: driver.ErrNotNumeric nếu giá trị hiện tại không phải số, lỗi từ driver,
+	//     hoặc driver mặc định không được cấu hình
+	IncrementFloatContext(ctx context.Context, key string, delta float64, ttl time.Duration) (float64, error)
+
+	// Tags trả về một view của cache gắn với các tag được chỉ định.
+	//
+	// Các entry được ghi qua view mang các tag này, và Flush của view xóa mọi entry
+	// mang ít nhất một trong các tag trên driver mặc định.
+	//
+	// Params:
+	//   - names: Danh sách tag
+	//
+	// Returns:
+	//   - TaggedCache: View của cache gắn với các tag
+	Tags(names ...string) TaggedCache
+
+	// FlushTags xóa tất cả các entry mang ít nhất một trong các tag khỏi cache mặc định.
+	//
+	// Params:
+	//   - tags: Danh sách tag cần xóa
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
+	FlushTags(tags ...string) error
+
+	// FlushTagsContext xóa tất cả các entry mang ít nhất một trong các tag khỏi cache mặc định
+	// với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - tags: Danh sách tag cần xóa
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
+	FlushTagsContext(ctx context.Context, tags []string) error
+
+	// Keys trả về các key còn hạn khớp với glob pattern trong cache mặc định.
+	//
+	// Pattern theo cú pháp glob của Redis ("*", "?", "[abc]", "\" để thoát); pattern rỗng
+	// khớp mọi key. Các key nội bộ bắt đầu bằng "__" không được trả về.
+	//
+	// Params:
+	//   - pattern: Glob pattern của key
+	//
+	// Returns:
+	//   - []string: Các key khớp pattern
+	//   - error: Lỗi nếu pattern không hợp lệ, lỗi từ driver hoặc driver mặc định không được cấu hình
+	Keys(pattern string) ([]string, error)
+
+	// KeysContext trả về các key còn hạn khớp với glob pattern trong cache mặc định
+	// với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - pattern: Glob pattern của key
+	//
+	// Returns:
+	//   - []string: Các key khớp pattern
+	//   - error: Lỗi nếu pattern không hợp lệ, lỗi từ driver hoặc driver mặc định không được cấu hình
+	KeysContext(ctx context.Context, pattern string) ([]string, error)
+
+	// Scan duyệt lần lượt các key còn hạn khớp với glob pattern trong cache mặc định.
+	//
+	// Khác với Keys, các key không được tải toàn bộ vào bộ nhớ. fn trả về false để dừng quét.
+	//
+	// Params:
+	//   - pattern: Glob pattern của key
+	//   - fn: Hàm được gọi cho từng key
+	//
+	// Returns:
+	//   - error: Lỗi nếu pattern không hợp lệ, lỗi từ driver hoặc driver mặc định không được cấu hình
+	Scan(pattern string, fn func(key string) bool) error
+
+	// ScanContext duyệt lần lượt các key còn hạn khớp với glob pattern trong cache mặc định
+	// với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - pattern: Glob pattern của key
+	//   - fn: Hàm được gọi cho từng key
+	//
+	// Returns:
+	//   - error: Lỗi nếu pattern không hợp lệ, ctx kết thúc, lỗi từ driver hoặc driver mặc
+	//     định không được cấu hình
+	ScanContext(ctx context.Context, pattern string, fn func(key string) bool) error
+
+	// DeleteMatching xóa tất cả các entry có key khớp với glob pattern khỏi cache mặc định.
+	//
+	// Pattern có cùng cú pháp với Keys; các key nội bộ bắt đầu bằng "__" không bị xóa.
+	//
+	// Params:
+	//   - pattern: Glob pattern của key (ví dụ "user:42:*")
+	//
+	// Returns:
+	//   - int: Số entry đã bị xóa
+	//   - error: Lỗi nếu pattern không hợp lệ, lỗi từ driver hoặc driver mặc định không được cấu hình
+	DeleteMatching(pattern string) (int, error)
+
+	// DeleteMatchingContext xóa tất cả các entry có key khớp với glob pattern khỏi cache mặc
+	// định với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - pattern: Glob pattern của key
+	//
+	// Returns:
+	//   - int: Số entry đã bị xóa
+	//   - error: Lỗi nếu pattern không hợp lệ, lỗi từ driver hoặc driver mặc định không được cấu hình
+	DeleteMatchingContext(ctx context.Context, pattern string) (int, error)
+
+	// DeleteByPrefix xóa tất cả các entry có key bắt đầu bằng prefix khỏi cache mặc định.
+	//
+	// Params:
+	//   - prefix: Tiền tố của key (ví dụ "user:42:"), không được hiểu là pattern
+	//
+	// Returns:
+	//   - int: Số entry đã bị xóa
+	//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+	DeleteByPrefix(prefix string) (int, error)
+
+	// DeleteByPrefixContext xóa tất cả các entry có key bắt đầu bằng prefix khỏi cache mặc
+	// định với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - prefix: Tiền tố của key
+	//
+	// Returns:
+	//   - int: Số entry đã bị xóa
+	//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+	DeleteByPrefixContext(ctx context.Context, prefix string) (int, error)
+
+	// Lock tạo một khóa phân tán có tên trên driver mặc định.
+	//
+	// Khóa chưa được giành khi trả về; dùng Acquire, TryAcquire hoặc Block để giành khóa.
+	// Mỗi Lock mang một owner token mới.
+	//
+	// Params:
+	//   - name: Tên khóa
+	//   - ttl: Thời gian giữ khóa tối đa (<= 0 để khóa không hết hạn)
+	//
+	// Returns:
+	//   - *Lock: Khóa trên driver mặc định
+	//   - error: driver.ErrLockNotSupported nếu driver mặc định không hỗ trợ khóa,
+	//     hoặc driver mặc định không được cấu hình
+	Lock(name string, ttl time.Duration) (*Lock, error)
+
+	// RestoreLock tạo lại một khóa trên driver mặc định từ owner token của nó.
+	//
+	// Cho phép một goroutine hoặc process khác gia hạn hoặc giải phóng khóa đã được giành.
+	//
+	// Params:
+	//   - name: Tên khóa
+	//   - owner: Owner token lấy từ Lock.Owner
+	//   - ttl: Thời gian giữ khóa dùng khi gia hạn (<= 0 để khóa không hết hạn)
+	//
+	// Returns:
+	//   - *Lock: Khóa với owner được chỉ định
+	//   - error: driver.ErrLockNotSupported nếu driver mặc định không hỗ trợ khóa,
+	//     hoặc driver mặc định không được cấu hình
+	RestoreLock(name, owner string, ttl time.Duration) (*Lock, error)
+
+	// AddDriver thêm một driver vào manager.
+	//
+	// Phương thức này đăng ký một driver mới với manager theo tên xác định.
+	// Nếu chưa có driver mặc định được đặt, driver đầu tiên được thêm vào sẽ trở thành mặc định.
+	//
+	// Params:
+	//   - name: Tên định danh cho driver
+	//   - driver: Đối tượng driver cần thêm vào
+	AddDriver(name string, driver driver.Driver)
+
+	// SetDefaultDriver đặt driver mặc định.
+	//
+	// Phương thức này thiết lập driver mặc định được sử dụng cho các thao tác cache.
+	// Driver phải đã được đăng ký với manager trước đó.
+	//
+	// Params:
+	//   - name: Tên của driver cần đặt làm mặc định
+	SetDefaultDriver(name string)
+
+	// Driver trả về một driver cụ thể theo tên.
+	//
+	// Phương thức này lấy một driver đã đăng ký dựa theo tên của nó.
+	//
+	// Params:
+	//   - name: Tên của driver cần lấy
+	//
+	// Returns:
+	//   - driver.Driver: Đối tượng driver được yêu cầu
+	//   - error: Lỗi nếu driver không tồn tại
+	Driver(name string) (driver.Driver, error)
+
+	// DefaultDriver trả về driver mặc định.
+	//
+	// Phương thức này lấy driver mặc định hiện tại của manager, được dùng bởi các
+	// thao tác cache và bởi các lớp facade như Typed.
+	//
+	// Returns:
+	//   - driver.Driver: Đối tượng driver mặc định
+	//   - error: Lỗi nếu không có driver mặc định hoặc driver mặc định không tồn tại
+	DefaultDriver() (driver.Driver, error)
+
+	// Stats trả về thông tin thống kê về tất cả các driver.
+	//
+	// Phương thức này thu thập và trả về các thông tin thống kê về trạng thái hiện tại
+	// của tất cả các driver đã đăng ký.
+	//
+	// Returns:
+	//   - map[string]map[string]interface{}: Map chứa thông tin thống kê của từng driver, với key là tên driver
+	Stats() map[string]map[string]interface{}
+
+	// StatsContext trả về thông tin thống kê về tất cả các driver với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//
+	// Returns:
+	//   - map[string]map[string]interface{}: Map chứa thông tin thống kê của từng driver, với key là tên driver
+	StatsContext(ctx context.Context) map[string]map[string]interface{}
+
+	// Close đóng tất cả các driver.
+	//
+	// Phương thức này giải phóng tài nguyên của tất cả các driver đã đăng ký.
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình đóng bất kỳ driver nào
+	Close() error
+}
+
+// manager là implementation mặc định của Manager.
+//
+// manager quản lý nhiều driver cache thông qua một map driver và cung cấp
+// cơ chế để thực hiện các thao tác cache qua driver mặc định. Nó đảm bảo thread-safety
+// thông qua RWMutex và cung cấp các phương thức tiện ích để tương tác với nhiều driver.
+type manager struct {
+	drivers       map[string]driver.Driver // Map chứa tất cả các driver đã đăng ký
+	defaultDriver string                   // Tên của driver mặc định
+	mu            sync.RWMutex             // Mutex cho các thao tác thread-safe
+}
+
+// NewManager tạo một manager mới.
+//
+// Phương thức này khởi tạo một DefaultManager mới, sẵn sàng để đăng ký các driver.
+//
+// Returns:
+//   - Manager: Đối tượng Manager mới được khởi tạo
+func NewManager() Manager {
+	return &manager{
+		drivers: make(map[string]driver.Driver),
+	}
+}
+
+// Get lấy một giá trị từ cache.
+//
+// Phương thức này tìm kiếm và trả về giá trị từ cache mặc định dựa trên key được cung cấp.
+//
+// Params:
+//   - key: Cache key cần tìm
+//
+// Returns:
+//   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
+//   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
+func (m *manager) Get(key string) (interface{}, bool) {
+	return m.GetContext(context.Background(), key)
+}
+
+// GetContext giống Get nhưng nhận context của caller.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm
+//
+// Returns:
+//   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
+//   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
+func (m *manager) GetContext(ctx context.Context, key string) (interface{}, bool) {
+	driver, err := m.DefaultDriver()
+	if err != nil {
+		return nil, false
+	}
+	return driver.Get(ctx, key)
+}
+
+// Set đặt một giá trị vào cache với TTL tùy chọn.
+//
+// Phương thức này lưu trữ một cặp key-value vào cache mặc định với thời gian sống được chỉ định.
+//
+// Params:
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
+func (m *manager) Set(key string, value interface{}, ttl time.Duration) error {
+	return m.SetContext(context.Background(), key, value, ttl)
+}
+
+// SetContext giống Set nhưng nhận context của caller.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
+func (m *manager) SetContext(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	driver, err := m.DefaultDriver()
+	if err != nil {
+		return err
+	}
+	return driver.Set(ctx, key, value, ttl)
+}
+
+// Has kiểm tra xem một key có tồn tại trong cache không.
+//
+// Phương thức này xác định liệu một key có tồn tại trong cache mặc định và chưa hết hạn hay không.
+//
+// Params:
+//   - key: Cache key cần kiểm tra
+//
+// Returns:
+//   - bool: true nếu key tồn tại và chưa hết hạn, false nếu ngược lại
+func (m *manager) Has(key string) bool {
+	return m.HasContext(context.Background(), key)
+}
+
+// HasContext giống Has nhưng nhận context của caller.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần kiểm tra
+//
+// Returns:
+//   - bool: true nếu key tồn tại và chưa hết hạn, false nếu ngược lại
+func (m *manager) HasContext(ctx context.Context, key string) bool {
+	driver, err := m.DefaultDriver()
+	if err != nil {
+		return false
+	}
+	return driver.Has(ctx, key)
+}
+
+// Delete xóa một key khỏi cache.
+//
+// Phương thức này xóa key và giá trị tương ứng khỏi cache mặc định nếu tồn tại.
+//
+// Params:
+//   - key: Cache key cần xóa
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
+func (m *manager) Delete(key string) error {
+	return m.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext giống Delete nhưng nhận context của caller.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần xóa
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
+func (m *manager) DeleteContext(ctx context.Context, key string) error {
+	driver, err := m.DefaultDriver()
+	if err != nil {
+		return err
+	}
+	return driver.Delete(ctx, key)
+}
+
+// Flush xóa tất cả các key khỏi cache.
+//
+// Phương thức này xóa tất cả dữ liệu trong cache mặc định, làm trống hoàn toàn bộ nhớ cache.
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
+func (m *manager) Flush() error {
+	return m.FlushContext(context.Background())
+}
+
+// FlushContext giống Flush nhưng nhận context của caller.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
+func (m *manager) FlushContext(ctx context.Context) error {
+	driver, err := m.DefaultDriver()
+	if err != nil {
+		return err
+	}
+	return driver.Flush(ctx)
+}
+
+// GetMultiple lấy nhiều giá trị từ cache.
+//
+// Phương thức này lấy các giá trị tương ứng với nhiều key từ cache mặc định trong một lần gọi.
+//
+// Params:
+//   - keys: Danh sách các khóa cần lấy
+//
+// Returns:
+//   - map[string]interface{}: Map chứa các key tìm thấy và giá trị tương ứng
+//   - []string: Danh sách các key không tìm thấy hoặc đã hết hạn
+func (m *manager) GetMultiple(keys []string) (map[string]interface{}, []string) {
+	return m.GetMultipleContext(context.Background(), keys)
+}
+
+// GetMultipleContext giống GetMultiple nhưng nhận context của caller.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - keys: Danh sách các khóa cần lấy
+//
+// Returns:
+//   - map[string]interface{}: Map chứa các key tìm thấy và giá trị tương ứng
+//   - []string: Danh sách các key không tìm thấy hoặc đã hết hạn
+func (m *manager) GetMultipleContext(ctx context.Context, keys []string) (map[string]interface{}, []string) {
+	driver, err := m.DefaultDriver()
+	if err != nil {
+		return make(map[string]interface{}), keys
+	}
+	return driver.GetMultiple(ctx, keys)
+}
+
+// SetMultiple đặt nhiều giá trị vào cache.
+//
+// Phương thức này lưu trữ nhiều cặp key-value vào cache mặc định trong một lần gọi
+// với cùng một thời gian sống.
+//
+// Params:
+//   - values: Map chứa các key và giá trị tương ứng cần lưu trữ
+//   - ttl: Thời gian sống chung cho tất cả các giá trị
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
+func (m *manager) SetMultiple(values map[string]interface{}, ttl time.Duration) error {
+	return m.SetMultipleContext(context.Background(), values, ttl)
+}
+
+// SetMultipleContext giống SetMultiple nhưng nhận context của caller.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - values: Map chứa các key và giá trị tương ứng cần lưu trữ
+//   - ttl: Thời gian sống chung cho tất cả các giá trị
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
+func (m *manager) SetMultipleContext(ctx context.Context, values map[string]interface{}, ttl time.Duration) error {
+	driver, err := m.DefaultDriver()
+	if err != nil {
+		return err
+	}
+	return driver.SetMultiple(ctx, values, ttl)
+}
+
+// DeleteMultiple xóa nhiều key khỏi cache.
+//
+// Phương thức này xóa nhiều key và giá trị tương ứng khỏi cache mặc định trong một lần gọi.
+//
+// Params:
+//   - keys: Danh sách các khóa cần xóa
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
+func (m *manager) DeleteMultiple(keys []string) error {
+	return m.DeleteMultipleContext(context.Background(), keys)
+}
+
+// DeleteMultipleContext giống DeleteMultiple nhưng nhận context của caller.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - keys: Danh sách các khóa cần xóa
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
+func (m *manager) DeleteMultipleContext(ctx context.Context, keys []string) error {
+	driver, err := m.DefaultDriver()
+	if err != nil {
+		return err
+	}
+	return driver.DeleteMultiple(ctx, keys)
+}
+
+// Remember lấy một giá trị từ cache hoặc thực thi callback nếu không tìm thấy.
+//
+// Phương thức này kiểm tra xem một key có tồn tại trong cache mặc định không, nếu có thì
+// trả về giá trị tương ứng. Nếu key không tồn tại hoặc đã hết hạn, phương thức
+// sẽ gọi hàm callback để lấy dữ liệu, lưu kết quả vào cache và trả về giá trị đó.
+//
+// Params:
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - ttl: Thời gian sống của giá trị nếu phải lấy từ callback
+//   - callback: Hàm được gọi để lấy dữ liệu khi key không có trong cache
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc driver mặc định không được cấu hình
+func (m *manager) Remember(key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	return m.RememberContext(context.Background(), key, ttl, callback)
+}
+
+// RememberContext giống Remember nhưng nhận context của caller.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - ttl: Thời gian sống của giá trị nếu phải lấy từ callback
+//   - callback: Hàm được gọi để lấy dữ liệu khi key không có trong cache
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc driver mặc định không được cấu hình
+func (m *manager) RememberContext(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	driver, err := m.DefaultDriver()
+	if err != nil {
+		return nil, err
+	}
+	return driver.Remember(ctx, key, ttl, callback)
+}
+
+// RememberStale lấy một giá trị từ cache theo cơ chế stale-while-revalidate.
+//
+// Params:
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - ttl: Thời gian giá trị được coi là còn mới
+//   - grace: Thời gian giá trị cũ tiếp tục được phục vụ sau ttl
+//   - callback: Hàm được gọi để lấy dữ liệu
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc driver mặc định không được cấu hình
+func (m *manager) RememberStale(key string, ttl, grace time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	return m.RememberStaleContext(context.Background(), key, ttl, grace, callback)
+}
+
+// RememberStaleContext lấy một giá trị từ cache theo cơ chế stale-while-revalidate
+// với context được chỉ định.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - ttl: Thời gian giá trị được coi là còn mới
+//   - grace: Thời gian giá trị cũ tiếp tục được phục vụ sau ttl
+//   - callback: Hàm được gọi để lấy dữ liệu
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc driver mặc định không được cấu hình
+func (m *manager) RememberStaleContext(ctx context.Context, key string, ttl, grace time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	driver, err := m.DefaultDriver()
+	if err != nil {
+		return nil, err
+	}
+	return driver.RememberStale(ctx, key, ttl, grace, callback)
+}
+
+// Add lưu giá trị vào cache mặc định chỉ khi key chưa tồn tại.
+//
+// Params:
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được lưu, false nếu key đã tồn tại
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) Add(key string, value interface{}, ttl time.Duration) (bool, error) {
+	return m.AddContext(context.Background(), key, value, ttl)
+}
+
+// AddContext lưu giá trị chỉ khi key chưa tồn tại với context được chỉ định.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được lưu, false nếu key đã tồn tại
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) AddContext(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	driver, err := m.DefaultDriver()
+	if err != nil {
+		return false, err
+	}
+	return driver.Add(ctx, key, value, ttl)
+}
+
+// Replace thay giá trị trong cache mặc định chỉ khi key đang tồn tại.
+//
+// Params:
+//   - key: Cache key cần thay giá trị
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được thay, false nếu key không tồn tại
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) Replace(key string, value interface{}, ttl time.Duration) (bool, error) {
+	return m.ReplaceContext(context.Background(), key, value, ttl)
+}
 
-	// Stats trả về thông tin thống kê về tất cả các driver.
-	//
-	// Phương thức này thu thập và trả về các thông tin thống kê về trạng thái hiện tại
-	// của tất cả các driver đã đăng ký.
-	//
-	// Returns:
-	//   - map[string]map[string]interface{}: Map chứa thông tin thống kê của từng driver, với key là tên driver
-	Stats() map[string]map[string]interface{}
+// ReplaceContext thay giá trị chỉ khi key đang tồn tại với context được chỉ định.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần thay giá trị
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được thay, false nếu key không tồn tại
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) ReplaceContext(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	driver, err := m.DefaultDriver()
+	if err != nil {
+		return false, err
+	}
+	return driver.Replace(ctx, key, value, ttl)
+}
 
-	// Close đóng tất cả các driver.
-	//
-	// Phương thức này giải phóng tài nguyên của tất cả các driver đã đăng ký.
-	//
-	// Returns:
-	//   - error: Lỗi nếu có trong quá trình đóng bất kỳ driver nào
-	Close() error
+// GetAndSet lưu giá trị mới vào cache mặc định và trả về giá trị cũ một cách nguyên tử.
+//
+// Params:
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+//
+// Returns:
+//   - interface{}: Giá trị cũ (nil nếu không có)
+//   - bool: true nếu key tồn tại trước đó
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) GetAndSet(key string, value interface{}, ttl time.Duration) (interface{}, bool, error) {
+	return m.GetAndSetContext(context.Background(), key, value, ttl)
 }
 
-// manager là implementation mặc định của Manager.
+// GetAndSetContext lưu giá trị mới và trả về giá trị cũ với context được chỉ định.
 //
-// manager quản lý nhiều driver cache thông qua một map driver và cung cấp
-// cơ chế để thực hiện các thao tác cache qua driver mặc định. Nó đảm bảo thread-safety
-// thông qua RWMutex và cung cấp các phương thức tiện ích để tương tác với nhiều driver.
-type manager struct {
-	drivers       map[string]driver.Driver // Map chứa tất cả các driver đã đăng ký
-	defaultDriver string                   // Tên của driver mặc định
-	mu            sync.RWMutex             // Mutex cho các thao tác thread-safe
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+//
+// Returns:
+//   - interface{}: Giá trị cũ (nil nếu không có)
+//   - bool: true nếu key tồn tại trước đó
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) GetAndSetContext(ctx context.Context, key string, value interface{}, ttl time.Duration) (interface{}, bool, error) {
+	driver, err := m.DefaultDriver()
+	if err != nil {
+		return nil, false, err
+	}
+	return driver.GetAndSet(ctx, key, value, ttl)
 }
 
-// NewManager tạo một manager mới.
+// GetAndDelete lấy giá trị và xóa key khỏi cache mặc định một cách nguyên tử (pull).
 //
-// Phương thức này khởi tạo một DefaultManager mới, sẵn sàng để đăng ký các driver.
+// Params:
+//   - key: Cache key cần lấy và xóa
 //
 // Returns:
-//   - Manager: Đối tượng Manager mới được khởi tạo
-func NewManager() Manager {
-	return &manager{
-		drivers: make(map[string]driver.Driver),
+//   - interface{}: Giá trị đã xóa (nil nếu không có)
+//   - bool: true nếu key tồn tại và chưa hết hạn
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) GetAndDelete(key string) (interface{}, bool, error) {
+	return m.GetAndDeleteContext(context.Background(), key)
+}
+
+// GetAndDeleteContext lấy giá trị và xóa key với context được chỉ định.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần lấy và xóa
+//
+// Returns:
+//   - interface{}: Giá trị đã xóa (nil nếu không có)
+//   - bool: true nếu key tồn tại và chưa hết hạn
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) GetAndDeleteContext(ctx context.Context, key string) (interface{}, bool, error) {
+	driver, err := m.DefaultDriver()
+	if err != nil {
+		return nil, false, err
 	}
+	return driver.GetAndDelete(ctx, key)
 }
 
-// Get lấy một giá trị từ cache.
+// GetWithVersion lấy giá trị cùng token phiên bản của entry từ cache mặc định.
 //
-// Phương thức này tìm kiếm và trả về giá trị từ cache mặc định dựa trên key được cung cấp.
+// Params:
+//   - key: Cache key cần tìm
+//
+// Returns:
+//   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
+//   - string: Token phiên bản của entry (rỗng nếu không tìm thấy)
+//   - bool: true nếu tìm thấy key, false nếu không tìm thấy hoặc driver mặc định không được cấu hình
+func (m *manager) GetWithVersion(key string) (interface{}, string, bool) {
+	return m.GetWithVersionContext(context.Background(), key)
+}
+
+// GetWithVersionContext lấy giá trị cùng token phiên bản với context được chỉ định.
 //
 // Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
 //   - key: Cache key cần tìm
 //
 // Returns:
 //   - interface{}: Giá trị được lưu trong cache (nil nếu không tìm thấy)
-//   - bool: true nếu tìm thấy key và chưa hết hạn, false nếu ngược lại
-func (m *manager) Get(key string) (interface{}, bool) {
+//   - string: Token phiên bản của entry (rỗng nếu không tìm thấy)
+//   - bool: true nếu tìm thấy key, false nếu không tìm thấy hoặc driver mặc định không được cấu hình
+func (m *manager) GetWithVersionContext(ctx context.Context, key string) (interface{}, string, bool) {
 	driver, err := m.DefaultDriver()
 	if err != nil {
-		return nil, false
+		return nil, "", false
 	}
-	return driver.Get(context.Background(), key)
+	return driver.GetWithVersion(ctx, key)
 }
 
-// Set đặt một giá trị vào cache với TTL tùy chọn.
+// CompareAndSwap ghi giá trị mới vào cache mặc định chỉ khi entry chưa thay đổi
+// kể từ lần đọc trả về token.
 //
-// Phương thức này lưu trữ một cặp key-value vào cache mặc định với thời gian sống được chỉ định.
+// Params:
+//   - key: Cache key cần ghi
+//   - token: Token phiên bản nhận được từ GetWithVersion
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu giá trị được ghi, false nếu key không tồn tại hoặc đã bị thay đổi
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) CompareAndSwap(key, token string, value interface{}, ttl time.Duration) (bool, error) {
+	return m.CompareAndSwapContext(context.Background(), key, token, value, ttl)
+}
+
+// CompareAndSwapContext ghi giá trị mới theo token phiên bản với context được chỉ định.
 //
 // Params:
-//   - key: Cache key để lưu giá trị
-//   - value: Giá trị cần lưu trữ
-//   - ttl: Thời gian sống của giá trị
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần ghi
+//   - token: Token phiên bản nhận được từ GetWithVersion
+//   - value: Giá trị mới
+//   - ttl: Thời gian sống của giá trị mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
 //
 // Returns:
-//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
-func (m *manager) Set(key string, value interface{}, ttl time.Duration) error {
+//   - bool: true nếu giá trị được ghi, false nếu key không tồn tại hoặc đã bị thay đổi
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) CompareAndSwapContext(ctx context.Context, key, token string, value interface{}, ttl time.Duration) (bool, error) {
 	driver, err := m.DefaultDriver()
 	if err != nil {
-		return err
+		return false, err
 	}
-	return driver.Set(context.Background(), key, value, ttl)
+	return driver.CompareAndSwap(ctx, key, token, value, ttl)
 }
 
-// Has kiểm tra xem một key có tồn tại trong cache không.
+// TTL trả về thời gian sống còn lại của key trong cache mặc định.
 //
-// Phương thức này xác định liệu một key có tồn tại trong cache mặc định và chưa hết hạn hay không.
+// Params:
+//   - key: Cache key cần kiểm tra
+//
+// Returns:
+//   - time.Duration: Thời gian sống còn lại, driver.NoExpiration nếu entry không hết hạn
+//   - bool: true nếu tìm thấy key, false nếu không tìm thấy hoặc driver mặc định không được cấu hình
+func (m *manager) TTL(key string) (time.Duration, bool) {
+	return m.TTLContext(context.Background(), key)
+}
+
+// TTLContext trả về thời gian sống còn lại của key với context được chỉ định.
 //
 // Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
 //   - key: Cache key cần kiểm tra
 //
 // Returns:
-//   - bool: true nếu key tồn tại và chưa hết hạn, false nếu ngược lại
-func (m *manager) Has(key string) bool {
+//   - time.Duration: Thời gian sống còn lại, driver.NoExpiration nếu entry không hết hạn
+//   - bool: true nếu tìm thấy key, false nếu không tìm thấy hoặc driver mặc định không được cấu hình
+func (m *manager) TTLContext(ctx context.Context, key string) (time.Duration, bool) {
 	driver, err := m.DefaultDriver()
 	if err != nil {
-		return false
+		return 0, false
 	}
-	return driver.Has(context.Background(), key)
+	return driver.TTL(ctx, key)
 }
 
-// Delete xóa một key khỏi cache.
+// Touch đặt lại thời gian sống của key trong cache mặc định mà không ghi lại giá trị.
 //
-// Phương thức này xóa key và giá trị tương ứng khỏi cache mặc định nếu tồn tại.
+// Params:
+//   - key: Cache key cần gia hạn
+//   - ttl: Thời gian sống mới tính từ bây giờ (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+//
+// Returns:
+//   - bool: true nếu key tồn tại và đã được cập nhật
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) Touch(key string, ttl time.Duration) (bool, error) {
+	return m.TouchContext(context.Background(), key, ttl)
+}
+
+// TouchContext đặt lại thời gian sống của key với context được chỉ định.
 //
 // Params:
-//   - key: Cache key cần xóa
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần gia hạn
+//   - ttl: Thời gian sống mới tính từ bây giờ (0 để sử dụng mặc định của driver, -1 để không hết hạn)
 //
 // Returns:
-//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
-func (m *manager) Delete(key string) error {
+//   - bool: true nếu key tồn tại và đã được cập nhật
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) TouchContext(ctx context.Context, key string, ttl time.Duration) (bool, error) {
 	driver, err := m.DefaultDriver()
 	if err != nil {
-		return err
+		return false, err
 	}
-	return driver.Delete(context.Background(), key)
+	return driver.Touch(ctx, key, ttl)
 }
 
-// Flush xóa tất cả các key khỏi cache.
+// Increment tăng giá trị số nguyên của key trong cache mặc định một cách nguyên tử.
 //
-// Phương thức này xóa tất cả dữ liệu trong cache mặc định, làm trống hoàn toàn bộ nhớ cache.
+// Params:
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (có thể âm)
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+//
+// Returns:
+//   - int64: Giá trị sau khi tăng
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) Increment(key string, delta int64, ttl time.Duration) (int64, error) {
+	return m.IncrementContext(context.Background(), key, delta, ttl)
+}
+
+// IncrementContext tăng giá trị số nguyên của key với context được chỉ định.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (có thể âm)
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+//
+// Returns:
+//   - int64: Giá trị sau khi tăng
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) IncrementContext(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	driver, err := m.DefaultDriver()
+	if err != nil {
+		return 0, err
+	}
+	return driver.Increment(ctx, key, delta, ttl)
+}
+
+// Decrement giảm giá trị số nguyên của key trong cache mặc định một cách nguyên tử.
+//
+// Params:
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần trừ đi
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+//
+// Returns:
+//   - int64: Giá trị sau khi giảm
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) Decrement(key string, delta int64, ttl time.Duration) (int64, error) {
+	return m.DecrementContext(context.Background(), key, delta, ttl)
+}
+
+// DecrementContext giảm giá trị số nguyên của key với context được chỉ định.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần trừ đi
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+//
+// Returns:
+//   - int64: Giá trị sau khi giảm
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) DecrementContext(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	driver, err := m.DefaultDriver()
+	if err != nil {
+		return 0, err
+	}
+	return driver.Decrement(ctx, key, delta, ttl)
+}
+
+// IncrementFloat tăng giá trị số thực của key trong cache mặc định một cách nguyên tử.
+//
+// Params:
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (có thể âm)
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+//
+// Returns:
+//   - float64: Giá trị sau khi tăng
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) IncrementFloat(key string, delta float64, ttl time.Duration) (float64, error) {
+	return m.IncrementFloatContext(context.Background(), key, delta, ttl)
+}
+
+// IncrementFloatContext tăng giá trị số thực của key với context được chỉ định.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Khóa của bộ đếm
+//   - delta: Giá trị cần cộng thêm (có thể âm)
+//   - ttl: Thời gian sống khi entry được tạo mới (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+//
+// Returns:
+//   - float64: Giá trị sau khi tăng
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) IncrementFloatContext(ctx context.Context, key string, delta float64, ttl time.Duration) (float64, error) {
+	driver, err := m.DefaultDriver()
+	if err != nil {
+		return 0, err
+	}
+	return driver.IncrementFloat(ctx, key, delta, ttl)
+}
+
+// Tags trả về một view của cache gắn với các tag được chỉ định.
+//
+// Driver mặc định được xác định tại thời điểm thực hiện từng thao tác của view,
+// không phải tại thời điểm gọi Tags.
+//
+// Params:
+//   - names: Danh sách tag
+//
+// Returns:
+//   - TaggedCache: View của cache gắn với các tag
+func (m *manager) Tags(names ...string) TaggedCache {
+	return &taggedCache{
+		manager: m,
+		tags:    append([]string(nil), names...),
+	}
+}
+
+// FlushTags xóa tất cả các entry mang ít nhất một trong các tag khỏi cache mặc định.
+//
+// Params:
+//   - tags: Danh sách tag cần xóa
 //
 // Returns:
 //   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
-func (m *manager) Flush() error {
+func (m *manager) FlushTags(tags ...string) error {
+	return m.FlushTagsContext(context.Background(), tags)
+}
+
+// FlushTagsContext xóa tất cả các entry mang ít nhất một trong các tag khỏi cache mặc định
+// với context được chỉ định.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - tags: Danh sách tag cần xóa
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
+func (m *manager) FlushTagsContext(ctx context.Context, tags []string) error {
 	driver, err := m.DefaultDriver()
 	if err != nil {
 		return err
 	}
-	return driver.Flush(context.Background())
+	return driver.FlushTags(ctx, tags)
 }
 
-// GetMultiple lấy nhiều giá trị từ cache.
+// Keys trả về các key còn hạn khớp với glob pattern trong cache mặc định.
 //
-// Phương thức này lấy các giá trị tương ứng với nhiều key từ cache mặc định trong một lần gọi.
+// Params:
+//   - pattern: Glob pattern của key
+//
+// Returns:
+//   - []string: Các key khớp pattern
+//   - error: Lỗi nếu pattern không hợp lệ, lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) Keys(pattern string) ([]string, error) {
+	return m.KeysContext(context.Background(), pattern)
+}
+
+// KeysContext trả về các key còn hạn khớp với glob pattern trong cache mặc định
+// với context được chỉ định.
 //
 // Params:
-//   - keys: Danh sách các khóa cần lấy
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - pattern: Glob pattern của key
 //
 // Returns:
-//   - map[string]interface{}: Map chứa các key tìm thấy và giá trị tương ứng
-//   - []string: Danh sách các key không tìm thấy hoặc đã hết hạn
-func (m *manager) GetMultiple(keys []string) (map[string]interface{}, []string) {
+//   - []string: Các key khớp pattern
+//   - error: Lỗi nếu pattern không hợp lệ, lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) KeysContext(ctx context.Context, pattern string) ([]string, error) {
 	driver, err := m.DefaultDriver()
 	if err != nil {
-		return make(map[string]interface{}), keys
+		return nil, err
 	}
-	return driver.GetMultiple(context.Background(), keys)
+	return driver.Keys(ctx, pattern)
 }
 
-// SetMultiple đặt nhiều giá trị vào cache.
+// Scan duyệt lần lượt các key còn hạn khớp với glob pattern trong cache mặc định.
 //
-// Phương thức này lưu trữ nhiều cặp key-value vào cache mặc định trong một lần gọi
-// với cùng một thời gian sống.
+// Params:
+//   - pattern: Glob pattern của key
+//   - fn: Hàm được gọi cho từng key, trả về false để dừng quét
+//
+// Returns:
+//   - error: Lỗi nếu pattern không hợp lệ, lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) Scan(pattern string, fn func(key string) bool) error {
+	return m.ScanContext(context.Background(), pattern, fn)
+}
+
+// ScanContext duyệt lần lượt các key còn hạn khớp với glob pattern trong cache mặc định
+// với context được chỉ định.
 //
 // Params:
-//   - values: Map chứa các key và giá trị tương ứng cần lưu trữ
-//   - ttl: Thời gian sống chung cho tất cả các giá trị
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - pattern: Glob pattern của key
+//   - fn: Hàm được gọi cho từng key, trả về false để dừng quét
 //
 // Returns:
-//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
-func (m *manager) SetMultiple(values map[string]interface{}, ttl time.Duration) error {
+//   - error: Lỗi nếu pattern không hợp lệ, ctx kết thúc, lỗi từ driver hoặc driver mặc
+//     định không được cấu hình
+func (m *manager) ScanContext(ctx context.Context, pattern string, fn func(key string) bool) error {
 	driver, err := m.DefaultDriver()
 	if err != nil {
 		return err
 	}
-	return driver.SetMultiple(context.Background(), values, ttl)
+	return driver.Scan(ctx, pattern, fn)
 }
 
-// DeleteMultiple xóa nhiều key khỏi cache.
+// DeleteMatching xóa tất cả các entry có key khớp với glob pattern khỏi cache mặc định.
 //
-// Phương thức này xóa nhiều key và giá trị tương ứng khỏi cache mặc định trong một lần gọi.
+// Params:
+//   - pattern: Glob pattern của key
+//
+// Returns:
+//   - int: Số entry đã bị xóa
+//   - error: Lỗi nếu pattern không hợp lệ, lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) DeleteMatching(pattern string) (int, error) {
+	return m.DeleteMatchingContext(context.Background(), pattern)
+}
+
+// DeleteMatchingContext xóa tất cả các entry có key khớp với glob pattern khỏi cache mặc
+// định với context được chỉ định.
 //
 // Params:
-//   - keys: Danh sách các khóa cần xóa
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - pattern: Glob pattern của key
 //
 // Returns:
-//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
-func (m *manager) DeleteMultiple(keys []string) error {
+//   - int: Số entry đã bị xóa
+//   - error: Lỗi nếu pattern không hợp lệ, lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) DeleteMatchingContext(ctx context.Context, pattern string) (int, error) {
 	driver, err := m.DefaultDriver()
 	if err != nil {
-		return err
+		return 0, err
 	}
-	return driver.DeleteMultiple(context.Background(), keys)
+	return driver.DeleteMatching(ctx, pattern)
 }
 
-// Remember lấy một giá trị từ cache hoặc thực thi callback nếu không tìm thấy.
+// DeleteByPrefix xóa tất cả các entry có key bắt đầu bằng prefix khỏi cache mặc định.
 //
-// Phương thức này kiểm tra xem một key có tồn tại trong cache mặc định không, nếu có thì
-// trả về giá trị tương ứng. Nếu key không tồn tại hoặc đã hết hạn, phương thức
-// sẽ gọi hàm callback để lấy dữ liệu, lưu kết quả vào cache và trả về giá trị đó.
+// Params:
+//   - prefix: Tiền tố của key
+//
+// Returns:
+//   - int: Số entry đã bị xóa
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) DeleteByPrefix(prefix string) (int, error) {
+	return m.DeleteByPrefixContext(context.Background(), prefix)
+}
+
+// DeleteByPrefixContext xóa tất cả các entry có key bắt đầu bằng prefix khỏi cache mặc
+// định với context được chỉ định.
 //
 // Params:
-//   - key: Cache key cần tìm hoặc lưu vào cache
-//   - ttl: Thời gian sống của giá trị nếu phải lấy từ callback
-//   - callback: Hàm được gọi để lấy dữ liệu khi key không có trong cache
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - prefix: Tiền tố của key
 //
 // Returns:
-//   - interface{}: Giá trị từ cache hoặc từ callback
-//   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc driver mặc định không được cấu hình
-func (m *manager) Remember(key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+//   - int: Số entry đã bị xóa
+//   - error: Lỗi từ driver hoặc driver mặc định không được cấu hình
+func (m *manager) DeleteByPrefixContext(ctx context.Context, prefix string) (int, error) {
 	driver, err := m.DefaultDriver()
+	if err != nil {
+		return 0, err
+	}
+	return driver.DeleteByPrefix(ctx, prefix)
+}
+
+// Lock tạo một khóa phân tán có tên trên driver mặc định.
+//
+// Driver mặc định được xác định tại thời điểm gọi Lock, nên khóa luôn nằm trên cùng
+// một driver kể cả khi driver mặc định thay đổi sau đó.
+//
+// Params:
+//   - name: Tên khóa
+//   - ttl: Thời gian giữ khóa tối đa (<= 0 để khóa không hết hạn)
+//
+// Returns:
+//   - *Lock: Khóa trên driver mặc định
+//   - error: driver.ErrLockNotSupported nếu driver mặc định không hỗ trợ khóa,
+//     hoặc driver mặc định không được cấu hình
+func (m *manager) Lock(name string, ttl time.Duration) (*Lock, error) {
+	locker, err := m.defaultLocker()
+	if err != nil {
+		return nil, err
+	}
+	return NewLock(locker, name, ttl)
+}
+
+// RestoreLock tạo lại một khóa trên driver mặc định từ owner token của nó.
+//
+// Params:
+//   - name: Tên khóa
+//   - owner: Owner token lấy từ Lock.Owner
+//   - ttl: Thời gian giữ khóa dùng khi gia hạn (<= 0 để khóa không hết hạn)
+//
+// Returns:
+//   - *Lock: Khóa với owner được chỉ định
+//   - error: driver.ErrLockNotSupported nếu driver mặc định không hỗ trợ khóa,
+//     hoặc driver mặc định không được cấu hình
+func (m *manager) RestoreLock(name, owner string, ttl time.Duration) (*Lock, error) {
+	locker, err := m.defaultLocker()
+	if err != nil {
+		return nil, err
+	}
+	return RestoreLock(locker, name, owner, ttl), nil
+}
+
+// defaultLocker trả về driver mặc định dưới dạng driver.Locker.
+func (m *manager) defaultLocker() (driver.Locker, error) {
+	d, err := m.DefaultDriver()
 	if err != nil {
 		return nil, err
 	}
-	return driver.Remember(context.Background(), key, ttl, callback)
+	locker, ok := d.(driver.Locker)
+	if !ok {
+		return nil, driver.ErrLockNotSupported
+	}
+	return locker, nil
 }
 
 // AddDriver thêm một driver vào manager.
@@ -422,12 +1752,23 @@ func (m *manager) Driver(name string) (driver.Driver, error) {
 // Returns:
 //   - map[string]map[string]interface{}: Map chứa thông tin thống kê của từng driver, với key là tên driver
 func (m *manager) Stats() map[string]map[string]interface{} {
+	return m.StatsContext(context.Background())
+}
+
+// StatsContext giống Stats nhưng nhận context của caller.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//
+// Returns:
+//   - map[string]map[string]interface{}: Map chứa thông tin thống kê của từng driver, với key là tên driver
+func (m *manager) StatsContext(ctx context.Context) map[string]map[string]interface{} {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	stats := make(map[string]map[string]interface{})
 	for name, driver := range m.drivers {
-		stats[name] = driver.Stats(context.Background())
+		stats[name] = driver.Stats(ctx)
 	}
 	return stats
 }
@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.fork.vn/cache"
+	"go.fork.vn/cache/config"
+	"go.fork.vn/cache/driver"
 	cache_mocks "go.fork.vn/cache/mocks"
 )
 
@@ -563,6 +565,594 @@ func TestManager_Remember(t *testing.T) {
 	})
 }
 
+// TestManager_RememberStale kiểm tra RememberStale chuyển tiếp ttl và grace xuống driver
+func TestManager_RememberStale(t *testing.T) {
+	t.Run("forwards_ttl_and_grace_to_default_driver", func(t *testing.T) {
+		// Arrange
+		mockDriver := cache_mocks.NewMockDriver(t)
+		mockDriver.EXPECT().RememberStale(context.Background(), "key", time.Minute, 10*time.Minute, mock.AnythingOfType("func() (interface {}, error)")).Return("stale-value", nil)
+
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act
+		value, err := manager.RememberStale("key", time.Minute, 10*time.Minute, func() (interface{}, error) {
+			return "new-value", nil
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "stale-value", value)
+	})
+
+	t.Run("returns_error_when_no_default_driver_is_set", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+
+		// Act
+		value, err := manager.RememberStale("key", time.Minute, time.Minute, func() (interface{}, error) {
+			return "value", nil
+		})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, value)
+	})
+}
+
+// ctxKey là kiểu key dùng cho context.WithValue trong các test
+type ctxKey string
+
+// TestManager_Increment kiểm tra các thao tác bộ đếm được chuyển tiếp xuống driver mặc định
+func TestManager_Increment(t *testing.T) {
+	t.Run("forwards_counters_to_default_driver", func(t *testing.T) {
+		// Arrange
+		mockDriver := cache_mocks.NewMockDriver(t)
+		mockDriver.EXPECT().Increment(context.Background(), "views", int64(2), time.Minute).Return(int64(5), nil)
+		mockDriver.EXPECT().Decrement(context.Background(), "views", int64(1), time.Duration(0)).Return(int64(4), nil)
+		mockDriver.EXPECT().IncrementFloat(context.Background(), "score", 0.5, time.Duration(-1)).Return(1.5, nil)
+
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act
+		incremented, incErr := manager.Increment("views", 2, time.Minute)
+		decremented, decErr := manager.Decrement("views", 1, 0)
+		score, floatErr := manager.IncrementFloat("score", 0.5, -1)
+
+		// Assert
+		assert.NoError(t, incErr)
+		assert.Equal(t, int64(5), incremented)
+		assert.NoError(t, decErr)
+		assert.Equal(t, int64(4), decremented)
+		assert.NoError(t, floatErr)
+		assert.Equal(t, 1.5, score)
+	})
+
+	t.Run("context_variants_pass_caller_context_to_driver", func(t *testing.T) {
+		// Arrange
+		ctx := context.WithValue(context.Background(), ctxKey("request-id"), "req-42")
+		mockDriver := cache_mocks.NewMockDriver(t)
+		mockDriver.EXPECT().Increment(ctx, "views", int64(1), time.Duration(0)).Return(int64(1), nil)
+		mockDriver.EXPECT().Decrement(ctx, "views", int64(1), time.Duration(0)).Return(int64(0), nil)
+		mockDriver.EXPECT().IncrementFloat(ctx, "score", 1.0, time.Duration(0)).Return(1.0, nil)
+
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act & Assert
+		_, err := manager.IncrementContext(ctx, "views", 1, 0)
+		assert.NoError(t, err)
+		_, err = manager.DecrementContext(ctx, "views", 1, 0)
+		assert.NoError(t, err)
+		_, err = manager.IncrementFloatContext(ctx, "score", 1, 0)
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns_error_when_no_default_driver_is_set", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+
+		// Act
+		value, err := manager.Increment("views", 1, 0)
+		_, decErr := manager.Decrement("views", 1, 0)
+		_, floatErr := manager.IncrementFloat("score", 1, 0)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Zero(t, value)
+		assert.Error(t, decErr)
+		assert.Error(t, floatErr)
+	})
+
+	t.Run("propagates_not_numeric_error", func(t *testing.T) {
+		// Arrange
+		mockDriver := cache_mocks.NewMockDriver(t)
+		mockDriver.EXPECT().Increment(context.Background(), "name", int64(1), time.Duration(0)).Return(int64(0), driver.ErrNotNumeric)
+
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act
+		_, err := manager.Increment("name", 1, 0)
+
+		// Assert
+		assert.ErrorIs(t, err, driver.ErrNotNumeric)
+	})
+}
+
+// TestManager_ConditionalWrites kiểm tra Add, Replace, GetAndSet và GetAndDelete được chuyển tiếp xuống driver mặc định
+func TestManager_ConditionalWrites(t *testing.T) {
+	t.Run("forwards_to_default_driver", func(t *testing.T) {
+		// Arrange
+		mockDriver := cache_mocks.NewMockDriver(t)
+		mockDriver.EXPECT().Add(context.Background(), "idem", "value", time.Minute).Return(true, nil)
+		mockDriver.EXPECT().Replace(context.Background(), "idem", "new", time.Duration(0)).Return(false, nil)
+		mockDriver.EXPECT().GetAndSet(context.Background(), "token", "t2", time.Minute).Return("t1", true, nil)
+		mockDriver.EXPECT().GetAndDelete(context.Background(), "job").Return("payload", true, nil)
+
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act & Assert
+		added, err := manager.Add("idem", "value", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, added)
+
+		replaced, err := manager.Replace("idem", "new", 0)
+		assert.NoError(t, err)
+		assert.False(t, replaced)
+
+		old, found, err := manager.GetAndSet("token", "t2", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "t1", old)
+
+		value, found, err := manager.GetAndDelete("job")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "payload", value)
+	})
+
+	t.Run("context_variants_pass_caller_context_to_driver", func(t *testing.T) {
+		// Arrange
+		ctx := context.WithValue(context.Background(), ctxKey("request-id"), "req-42")
+		mockDriver := cache_mocks.NewMockDriver(t)
+		mockDriver.EXPECT().Add(ctx, "idem", 1, time.Minute).Return(true, nil)
+		mockDriver.EXPECT().Replace(ctx, "idem", 2, time.Minute).Return(true, nil)
+		mockDriver.EXPECT().GetAndSet(ctx, "idem", 3, time.Minute).Return(2, true, nil)
+		mockDriver.EXPECT().GetAndDelete(ctx, "idem").Return(3, true, nil)
+
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act & Assert
+		_, err := manager.AddContext(ctx, "idem", 1, time.Minute)
+		assert.NoError(t, err)
+		_, err = manager.ReplaceContext(ctx, "idem", 2, time.Minute)
+		assert.NoError(t, err)
+		_, _, err = manager.GetAndSetContext(ctx, "idem", 3, time.Minute)
+		assert.NoError(t, err)
+		_, _, err = manager.GetAndDeleteContext(ctx, "idem")
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns_error_when_no_default_driver_is_set", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+
+		// Act
+		added, addErr := manager.Add("idem", "value", time.Minute)
+		replaced, replaceErr := manager.Replace("idem", "value", time.Minute)
+		_, found, swapErr := manager.GetAndSet("idem", "value", time.Minute)
+		_, pulled, pullErr := manager.GetAndDelete("idem")
+
+		// Assert
+		assert.Error(t, addErr)
+		assert.False(t, added)
+		assert.Error(t, replaceErr)
+		assert.False(t, replaced)
+		assert.Error(t, swapErr)
+		assert.False(t, found)
+		assert.Error(t, pullErr)
+		assert.False(t, pulled)
+	})
+}
+
+func TestManager_CompareAndSwap(t *testing.T) {
+	t.Run("forwards_to_default_driver", func(t *testing.T) {
+		// Arrange
+		mockDriver := cache_mocks.NewMockDriver(t)
+		mockDriver.EXPECT().GetWithVersion(context.Background(), "config").Return("v1", "7", true)
+		mockDriver.EXPECT().CompareAndSwap(context.Background(), "config", "7", "v2", time.Minute).Return(true, nil)
+
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act
+		value, token, found := manager.GetWithVersion("config")
+		swapped, err := manager.CompareAndSwap("config", token, "v2", time.Minute)
+
+		// Assert
+		assert.True(t, found)
+		assert.Equal(t, "v1", value)
+		assert.Equal(t, "7", token)
+		assert.NoError(t, err)
+		assert.True(t, swapped)
+	})
+
+	t.Run("context_variants_pass_caller_context_to_driver", func(t *testing.T) {
+		// Arrange
+		ctx := context.WithValue(context.Background(), ctxKey("request-id"), "req-42")
+		mockDriver := cache_mocks.NewMockDriver(t)
+		mockDriver.EXPECT().GetWithVersion(ctx, "config").Return("v1", "7", true)
+		mockDriver.EXPECT().CompareAndSwap(ctx, "config", "7", "v2", time.Minute).Return(false, nil)
+
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act
+		_, token, _ := manager.GetWithVersionContext(ctx, "config")
+		swapped, err := manager.CompareAndSwapContext(ctx, "config", token, "v2", time.Minute)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, swapped)
+	})
+
+	t.Run("returns_error_when_no_default_driver_is_set", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+
+		// Act
+		_, token, found := manager.GetWithVersion("config")
+		swapped, err := manager.CompareAndSwap("config", "7", "v2", time.Minute)
+
+		// Assert
+		assert.False(t, found)
+		assert.Empty(t, token)
+		assert.Error(t, err)
+		assert.False(t, swapped)
+	})
+}
+
+func TestManager_TTLAndTouch(t *testing.T) {
+	t.Run("forwards_to_default_driver", func(t *testing.T) {
+		// Arrange
+		mockDriver := cache_mocks.NewMockDriver(t)
+		mockDriver.EXPECT().TTL(context.Background(), "session").Return(time.Minute, true)
+		mockDriver.EXPECT().Touch(context.Background(), "session", time.Hour).Return(true, nil)
+
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act
+		ttl, found := manager.TTL("session")
+		touched, err := manager.Touch("session", time.Hour)
+
+		// Assert
+		assert.True(t, found)
+		assert.Equal(t, time.Minute, ttl)
+		assert.NoError(t, err)
+		assert.True(t, touched)
+	})
+
+	t.Run("context_variants_pass_caller_context_to_driver", func(t *testing.T) {
+		// Arrange
+		ctx := context.WithValue(context.Background(), ctxKey("request-id"), "req-42")
+		mockDriver := cache_mocks.NewMockDriver(t)
+		mockDriver.EXPECT().TTL(ctx, "session").Return(driver.NoExpiration, true)
+		mockDriver.EXPECT().Touch(ctx, "session", time.Duration(0)).Return(false, nil)
+
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+
+		// Act
+		ttl, _ := manager.TTLContext(ctx, "session")
+		touched, err := manager.TouchContext(ctx, "session", 0)
+
+		// Assert
+		assert.Equal(t, driver.NoExpiration, ttl)
+		assert.NoError(t, err)
+		assert.False(t, touched)
+	})
+
+	t.Run("returns_error_when_no_default_driver_is_set", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+
+		// Act
+		_, found := manager.TTL("session")
+		touched, err := manager.Touch("session", time.Hour)
+
+		// Assert
+		assert.False(t, found)
+		assert.Error(t, err)
+		assert.False(t, touched)
+	})
+}
+
+// TestManager_Lock kiểm tra Lock và RestoreLock trên driver mặc định
+func TestManager_Lock(t *testing.T) {
+	t.Run("creates_lock_on_default_driver", func(t *testing.T) {
+		// Arrange
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+		defer memoryDriver.Close()
+		manager := cache.NewManager()
+		manager.AddDriver("memory", memoryDriver)
+
+		// Act
+		lock, err := manager.Lock("invoices", time.Minute)
+		assert.NoError(t, err)
+		acquired, _ := lock.TryAcquire(context.Background())
+		restored, restoreErr := manager.RestoreLock("invoices", lock.Owner(), time.Minute)
+		released, _ := restored.Release(context.Background())
+
+		// Assert
+		assert.True(t, acquired)
+		assert.NoError(t, restoreErr)
+		assert.Equal(t, "invoices", restored.Name())
+		assert.True(t, released)
+	})
+
+	t.Run("returns_error_when_driver_does_not_support_locks", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+		manager.AddDriver("mock", cache_mocks.NewMockDriver(t))
+
+		// Act
+		lock, err := manager.Lock("invoices", time.Minute)
+
+		// Assert
+		assert.Nil(t, lock)
+		assert.ErrorIs(t, err, driver.ErrLockNotSupported)
+	})
+
+	t.Run("returns_error_when_no_default_driver_is_set", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+
+		// Act
+		lock, err := manager.RestoreLock("invoices", "owner", time.Minute)
+
+		// Assert
+		assert.Nil(t, lock)
+		assert.Error(t, err)
+	})
+}
+
+// TestManager_Keys kiểm tra việc liệt kê và quét key trên driver mặc định
+func TestManager_Keys(t *testing.T) {
+	t.Run("lists_keys_of_default_driver", func(t *testing.T) {
+		// Arrange
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+		defer memoryDriver.Close()
+		manager := cache.NewManager()
+		manager.AddDriver("memory", memoryDriver)
+		_ = manager.Set("user:1", "a", time.Minute)
+		_ = manager.Set("user:2", "b", time.Minute)
+		_ = manager.Set("post:1", "c", time.Minute)
+
+		// Act
+		keys, err := manager.Keys("user:*")
+		var scanned []string
+		scanErr := manager.Scan("post:*", func(key string) bool {
+			scanned = append(scanned, key)
+			return true
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"user:1", "user:2"}, keys)
+		assert.NoError(t, scanErr)
+		assert.Equal(t, []string{"post:1"}, scanned)
+	})
+
+	t.Run("passes_context_to_driver", func(t *testing.T) {
+		// Arrange
+		ctx := context.WithValue(context.Background(), ctxKey("request-id"), "req-42")
+		mockDriver := cache_mocks.NewMockDriver(t)
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+		mockDriver.EXPECT().Keys(ctx, "user:*").Return([]string{"user:1"}, nil)
+
+		// Act
+		keys, err := manager.KeysContext(ctx, "user:*")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"user:1"}, keys)
+	})
+
+	t.Run("returns_error_when_no_default_driver_is_set", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+
+		// Act
+		keys, err := manager.Keys("*")
+		scanErr := manager.Scan("*", func(string) bool { return true })
+
+		// Assert
+		assert.Nil(t, keys)
+		assert.Error(t, err)
+		assert.Error(t, scanErr)
+	})
+}
+
+// TestManager_DeleteMatching kiểm tra việc xóa theo pattern và prefix trên driver mặc định
+func TestManager_DeleteMatching(t *testing.T) {
+	t.Run("deletes_matching_keys_of_default_driver", func(t *testing.T) {
+		// Arrange
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+		defer memoryDriver.Close()
+		manager := cache.NewManager()
+		manager.AddDriver("memory", memoryDriver)
+		_ = manager.Set("user:42:profile", "a", time.Minute)
+		_ = manager.Set("user:42:posts", "b", time.Minute)
+		_ = manager.Set("user:43:profile", "c", time.Minute)
+		_ = manager.Set("post:1", "d", time.Minute)
+
+		// Act
+		byPrefix, prefixErr := manager.DeleteByPrefix("user:42:")
+		byPattern, patternErr := manager.DeleteMatching("user:*:profile")
+
+		// Assert
+		assert.NoError(t, prefixErr)
+		assert.Equal(t, 2, byPrefix)
+		assert.NoError(t, patternErr)
+		assert.Equal(t, 1, byPattern)
+		assert.True(t, manager.Has("post:1"))
+	})
+
+	t.Run("passes_context_to_driver", func(t *testing.T) {
+		// Arrange
+		ctx := context.WithValue(context.Background(), ctxKey("request-id"), "req-42")
+		mockDriver := cache_mocks.NewMockDriver(t)
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+		mockDriver.EXPECT().DeleteByPrefix(ctx, "user:42:").Return(3, nil)
+
+		// Act
+		deleted, err := manager.DeleteByPrefixContext(ctx, "user:42:")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, deleted)
+	})
+
+	t.Run("returns_error_when_no_default_driver_is_set", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+
+		// Act
+		deleted, err := manager.DeleteMatching("*")
+		_, prefixErr := manager.DeleteByPrefix("user:")
+
+		// Assert
+		assert.Equal(t, 0, deleted)
+		assert.Error(t, err)
+		assert.Error(t, prefixErr)
+	})
+}
+
+// TestManager_ContextVariants kiểm tra các phương thức *Context truyền context xuống driver
+func TestManager_ContextVariants(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey("request-id"), "req-42")
+
+	newManager := func(t *testing.T) (cache.Manager, *cache_mocks.MockDriver) {
+		mockDriver := cache_mocks.NewMockDriver(t)
+		manager := cache.NewManager()
+		manager.AddDriver("mock", mockDriver)
+		return manager, mockDriver
+	}
+
+	t.Run("get_context_passes_caller_context_to_driver", func(t *testing.T) {
+		// Arrange
+		manager, mockDriver := newManager(t)
+		mockDriver.EXPECT().Get(ctx, "key").Return("value", true)
+
+		// Act
+		value, found := manager.GetContext(ctx, "key")
+
+		// Assert
+		assert.True(t, found)
+		assert.Equal(t, "value", value)
+	})
+
+	t.Run("set_has_delete_context_pass_caller_context_to_driver", func(t *testing.T) {
+		// Arrange
+		manager, mockDriver := newManager(t)
+		mockDriver.EXPECT().Set(ctx, "key", "value", time.Minute).Return(nil)
+		mockDriver.EXPECT().Has(ctx, "key").Return(true)
+		mockDriver.EXPECT().Delete(ctx, "key").Return(nil)
+
+		// Act & Assert
+		assert.NoError(t, manager.SetContext(ctx, "key", "value", time.Minute))
+		assert.True(t, manager.HasContext(ctx, "key"))
+		assert.NoError(t, manager.DeleteContext(ctx, "key"))
+	})
+
+	t.Run("batch_context_methods_pass_caller_context_to_driver", func(t *testing.T) {
+		// Arrange
+		manager, mockDriver := newManager(t)
+		values := map[string]interface{}{"a": 1}
+		mockDriver.EXPECT().GetMultiple(ctx, []string{"a", "b"}).Return(map[string]interface{}{"a": 1}, []string{"b"})
+		mockDriver.EXPECT().SetMultiple(ctx, values, time.Minute).Return(nil)
+		mockDriver.EXPECT().DeleteMultiple(ctx, []string{"a"}).Return(nil)
+		mockDriver.EXPECT().Flush(ctx).Return(nil)
+
+		// Act
+		found, missed := manager.GetMultipleContext(ctx, []string{"a", "b"})
+
+		// Assert
+		assert.Equal(t, map[string]interface{}{"a": 1}, found)
+		assert.Equal(t, []string{"b"}, missed)
+		assert.NoError(t, manager.SetMultipleContext(ctx, values, time.Minute))
+		assert.NoError(t, manager.DeleteMultipleContext(ctx, []string{"a"}))
+		assert.NoError(t, manager.FlushContext(ctx))
+	})
+
+	t.Run("remember_context_passes_caller_context_to_driver", func(t *testing.T) {
+		// Arrange
+		manager, mockDriver := newManager(t)
+		mockDriver.EXPECT().Remember(ctx, "key", time.Minute, mock.AnythingOfType("func() (interface {}, error)")).Return("value", nil)
+
+		// Act
+		value, err := manager.RememberContext(ctx, "key", time.Minute, func() (interface{}, error) {
+			return "value", nil
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "value", value)
+	})
+
+	t.Run("stats_context_passes_caller_context_to_driver", func(t *testing.T) {
+		// Arrange
+		manager, mockDriver := newManager(t)
+		mockDriver.EXPECT().Stats(ctx).Return(map[string]interface{}{"type": "mock"})
+
+		// Act
+		stats := manager.StatsContext(ctx)
+
+		// Assert
+		assert.Equal(t, "mock", stats["mock"]["type"])
+	})
+
+	t.Run("deadline_reaches_driver", func(t *testing.T) {
+		// Arrange
+		manager, mockDriver := newManager(t)
+		deadlineCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		mockDriver.EXPECT().Get(mock.Anything, "key").RunAndReturn(func(c context.Context, key string) (interface{}, bool) {
+			_, hasDeadline := c.Deadline()
+			return hasDeadline, true
+		})
+
+		// Act
+		value, found := manager.GetContext(deadlineCtx, "key")
+
+		// Assert
+		assert.True(t, found)
+		assert.Equal(t, true, value)
+	})
+
+	t.Run("returns_error_when_no_default_driver_is_set", func(t *testing.T) {
+		// Arrange
+		manager := cache.NewManager()
+
+		// Act
+		value, found := manager.GetContext(ctx, "key")
+		err := manager.SetContext(ctx, "key", "value", time.Minute)
+
+		// Assert
+		assert.False(t, found)
+		assert.Nil(t, value)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no default cache driver set")
+	})
+}
+
 // TestManager_AddDriver kiểm tra phương thức AddDriver với các kịch bản khác nhau
 func TestManager_AddDriver(t *testing.T) {
 	t.Run("adds_driver_successfully", func(t *testing.T) {
@@ -23,6 +23,65 @@ func (_m *MockFileDriver) EXPECT() *MockFileDriver_Expecter {
 	return &MockFileDriver_Expecter{mock: &_m.Mock}
 }
 
+// Add provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockFileDriver) Add(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFileDriver_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type MockFileDriver_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockFileDriver_Expecter) Add(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockFileDriver_Add_Call {
+	return &MockFileDriver_Add_Call{Call: _e.mock.On("Add", ctx, key, value, ttl)}
+}
+
+func (_c *MockFileDriver_Add_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *MockFileDriver_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockFileDriver_Add_Call) Return(_a0 bool, _a1 error) *MockFileDriver_Add_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileDriver_Add_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) (bool, error)) *MockFileDriver_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Close provides a mock function with no fields
 func (_m *MockFileDriver) Close() error {
 	ret := _m.Called()
@@ -68,6 +127,125 @@ func (_c *MockFileDriver_Close_Call) RunAndReturn(run func() error) *MockFileDri
 	return _c
 }
 
+// CompareAndSwap provides a mock function with given fields: ctx, key, token, value, ttl
+func (_m *MockFileDriver) CompareAndSwap(ctx context.Context, key string, token string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, token, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompareAndSwap")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, token, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, token, value, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, token, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFileDriver_CompareAndSwap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareAndSwap'
+type MockFileDriver_CompareAndSwap_Call struct {
+	*mock.Call
+}
+
+// CompareAndSwap is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - token string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockFileDriver_Expecter) CompareAndSwap(ctx interface{}, key interface{}, token interface{}, value interface{}, ttl interface{}) *MockFileDriver_CompareAndSwap_Call {
+	return &MockFileDriver_CompareAndSwap_Call{Call: _e.mock.On("CompareAndSwap", ctx, key, token, value, ttl)}
+}
+
+func (_c *MockFileDriver_CompareAndSwap_Call) Run(run func(ctx context.Context, key string, token string, value interface{}, ttl time.Duration)) *MockFileDriver_CompareAndSwap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(interface{}), args[4].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockFileDriver_CompareAndSwap_Call) Return(_a0 bool, _a1 error) *MockFileDriver_CompareAndSwap_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileDriver_CompareAndSwap_Call) RunAndReturn(run func(context.Context, string, string, interface{}, time.Duration) (bool, error)) *MockFileDriver_CompareAndSwap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Decrement provides a mock function with given fields: ctx, key, delta, ttl
+func (_m *MockFileDriver) Decrement(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	ret := _m.Called(ctx, key, delta, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Decrement")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) (int64, error)); ok {
+		return rf(ctx, key, delta, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) int64); ok {
+		r0 = rf(ctx, key, delta, ttl)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, time.Duration) error); ok {
+		r1 = rf(ctx, key, delta, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFileDriver_Decrement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Decrement'
+type MockFileDriver_Decrement_Call struct {
+	*mock.Call
+}
+
+// Decrement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - delta int64
+//   - ttl time.Duration
+func (_e *MockFileDriver_Expecter) Decrement(ctx interface{}, key interface{}, delta interface{}, ttl interface{}) *MockFileDriver_Decrement_Call {
+	return &MockFileDriver_Decrement_Call{Call: _e.mock.On("Decrement", ctx, key, delta, ttl)}
+}
+
+func (_c *MockFileDriver_Decrement_Call) Run(run func(ctx context.Context, key string, delta int64, ttl time.Duration)) *MockFileDriver_Decrement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockFileDriver_Decrement_Call) Return(_a0 int64, _a1 error) *MockFileDriver_Decrement_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileDriver_Decrement_Call) RunAndReturn(run func(context.Context, string, int64, time.Duration) (int64, error)) *MockFileDriver_Decrement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function with given fields: ctx, key
 func (_m *MockFileDriver) Delete(ctx context.Context, key string) error {
 	ret := _m.Called(ctx, key)
@@ -115,6 +293,120 @@ func (_c *MockFileDriver_Delete_Call) RunAndReturn(run func(context.Context, str
 	return _c
 }
 
+// DeleteByPrefix provides a mock function with given fields: ctx, prefix
+func (_m *MockFileDriver) DeleteByPrefix(ctx context.Context, prefix string) (int, error) {
+	ret := _m.Called(ctx, prefix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByPrefix")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, prefix)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, prefix)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFileDriver_DeleteByPrefix_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByPrefix'
+type MockFileDriver_DeleteByPrefix_Call struct {
+	*mock.Call
+}
+
+// DeleteByPrefix is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prefix string
+func (_e *MockFileDriver_Expecter) DeleteByPrefix(ctx interface{}, prefix interface{}) *MockFileDriver_DeleteByPrefix_Call {
+	return &MockFileDriver_DeleteByPrefix_Call{Call: _e.mock.On("DeleteByPrefix", ctx, prefix)}
+}
+
+func (_c *MockFileDriver_DeleteByPrefix_Call) Run(run func(ctx context.Context, prefix string)) *MockFileDriver_DeleteByPrefix_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockFileDriver_DeleteByPrefix_Call) Return(_a0 int, _a1 error) *MockFileDriver_DeleteByPrefix_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileDriver_DeleteByPrefix_Call) RunAndReturn(run func(context.Context, string) (int, error)) *MockFileDriver_DeleteByPrefix_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMatching provides a mock function with given fields: ctx, pattern
+func (_m *MockFileDriver) DeleteMatching(ctx context.Context, pattern string) (int, error) {
+	ret := _m.Called(ctx, pattern)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMatching")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, pattern)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, pattern)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pattern)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFileDriver_DeleteMatching_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMatching'
+type MockFileDriver_DeleteMatching_Call struct {
+	*mock.Call
+}
+
+// DeleteMatching is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pattern string
+func (_e *MockFileDriver_Expecter) DeleteMatching(ctx interface{}, pattern interface{}) *MockFileDriver_DeleteMatching_Call {
+	return &MockFileDriver_DeleteMatching_Call{Call: _e.mock.On("DeleteMatching", ctx, pattern)}
+}
+
+func (_c *MockFileDriver_DeleteMatching_Call) Run(run func(ctx context.Context, pattern string)) *MockFileDriver_DeleteMatching_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockFileDriver_DeleteMatching_Call) Return(_a0 int, _a1 error) *MockFileDriver_DeleteMatching_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileDriver_DeleteMatching_Call) RunAndReturn(run func(context.Context, string) (int, error)) *MockFileDriver_DeleteMatching_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteMultiple provides a mock function with given fields: ctx, keys
 func (_m *MockFileDriver) DeleteMultiple(ctx context.Context, keys []string) error {
 	ret := _m.Called(ctx, keys)
@@ -208,230 +500,823 @@ func (_c *MockFileDriver_Flush_Call) RunAndReturn(run func(context.Context) erro
 	return _c
 }
 
+// FlushTags provides a mock function with given fields: ctx, tags
+func (_m *MockFileDriver) FlushTags(ctx context.Context, tags []string) error {
+	ret := _m.Called(ctx, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlushTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockFileDriver_FlushTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlushTags'
+type MockFileDriver_FlushTags_Call struct {
+	*mock.Call
+}
+
+// FlushTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tags []string
+func (_e *MockFileDriver_Expecter) FlushTags(ctx interface{}, tags interface{}) *MockFileDriver_FlushTags_Call {
+	return &MockFileDriver_FlushTags_Call{Call: _e.mock.On("FlushTags", ctx, tags)}
+}
+
+func (_c *MockFileDriver_FlushTags_Call) Run(run func(ctx context.Context, tags []string)) *MockFileDriver_FlushTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockFileDriver_FlushTags_Call) Return(_a0 error) *MockFileDriver_FlushTags_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFileDriver_FlushTags_Call) RunAndReturn(run func(context.Context, []string) error) *MockFileDriver_FlushTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Get provides a mock function with given fields: ctx, key
 func (_m *MockFileDriver) Get(ctx context.Context, key string) (interface{}, bool) {
 	ret := _m.Called(ctx, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Get")
+		panic("no return value specified for Get")
+	}
+
+	var r0 interface{}
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) (interface{}, bool)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) interface{}); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockFileDriver_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockFileDriver_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockFileDriver_Expecter) Get(ctx interface{}, key interface{}) *MockFileDriver_Get_Call {
+	return &MockFileDriver_Get_Call{Call: _e.mock.On("Get", ctx, key)}
+}
+
+func (_c *MockFileDriver_Get_Call) Run(run func(ctx context.Context, key string)) *MockFileDriver_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockFileDriver_Get_Call) Return(_a0 interface{}, _a1 bool) *MockFileDriver_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileDriver_Get_Call) RunAndReturn(run func(context.Context, string) (interface{}, bool)) *MockFileDriver_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAndDelete provides a mock function with given fields: ctx, key
+func (_m *MockFileDriver) GetAndDelete(ctx context.Context, key string) (interface{}, bool, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAndDelete")
+	}
+
+	var r0 interface{}
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (interface{}, bool, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) interface{}); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, key)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockFileDriver_GetAndDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAndDelete'
+type MockFileDriver_GetAndDelete_Call struct {
+	*mock.Call
+}
+
+// GetAndDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockFileDriver_Expecter) GetAndDelete(ctx interface{}, key interface{}) *MockFileDriver_GetAndDelete_Call {
+	return &MockFileDriver_GetAndDelete_Call{Call: _e.mock.On("GetAndDelete", ctx, key)}
+}
+
+func (_c *MockFileDriver_GetAndDelete_Call) Run(run func(ctx context.Context, key string)) *MockFileDriver_GetAndDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockFileDriver_GetAndDelete_Call) Return(_a0 interface{}, _a1 bool, _a2 error) *MockFileDriver_GetAndDelete_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockFileDriver_GetAndDelete_Call) RunAndReturn(run func(context.Context, string) (interface{}, bool, error)) *MockFileDriver_GetAndDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAndSet provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockFileDriver) GetAndSet(ctx context.Context, key string, value interface{}, ttl time.Duration) (interface{}, bool, error) {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAndSet")
+	}
+
+	var r0 interface{}
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (interface{}, bool, error)); ok {
+		return rf(ctx, key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) interface{}); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r1 = rf(ctx, key, value, ttl)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r2 = rf(ctx, key, value, ttl)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockFileDriver_GetAndSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAndSet'
+type MockFileDriver_GetAndSet_Call struct {
+	*mock.Call
+}
+
+// GetAndSet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockFileDriver_Expecter) GetAndSet(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockFileDriver_GetAndSet_Call {
+	return &MockFileDriver_GetAndSet_Call{Call: _e.mock.On("GetAndSet", ctx, key, value, ttl)}
+}
+
+func (_c *MockFileDriver_GetAndSet_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *MockFileDriver_GetAndSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockFileDriver_GetAndSet_Call) Return(_a0 interface{}, _a1 bool, _a2 error) *MockFileDriver_GetAndSet_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockFileDriver_GetAndSet_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) (interface{}, bool, error)) *MockFileDriver_GetAndSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMultiple provides a mock function with given fields: ctx, keys
+func (_m *MockFileDriver) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, []string) {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMultiple")
+	}
+
+	var r0 map[string]interface{}
+	var r1 []string
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]interface{}, []string)); ok {
+		return rf(ctx, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]interface{}); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) []string); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	return r0, r1
+}
+
+// MockFileDriver_GetMultiple_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMultiple'
+type MockFileDriver_GetMultiple_Call struct {
+	*mock.Call
+}
+
+// GetMultiple is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []string
+func (_e *MockFileDriver_Expecter) GetMultiple(ctx interface{}, keys interface{}) *MockFileDriver_GetMultiple_Call {
+	return &MockFileDriver_GetMultiple_Call{Call: _e.mock.On("GetMultiple", ctx, keys)}
+}
+
+func (_c *MockFileDriver_GetMultiple_Call) Run(run func(ctx context.Context, keys []string)) *MockFileDriver_GetMultiple_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockFileDriver_GetMultiple_Call) Return(_a0 map[string]interface{}, _a1 []string) *MockFileDriver_GetMultiple_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileDriver_GetMultiple_Call) RunAndReturn(run func(context.Context, []string) (map[string]interface{}, []string)) *MockFileDriver_GetMultiple_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWithVersion provides a mock function with given fields: ctx, key
+func (_m *MockFileDriver) GetWithVersion(ctx context.Context, key string) (interface{}, string, bool) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWithVersion")
+	}
+
+	var r0 interface{}
+	var r1 string
+	var r2 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) (interface{}, string, bool)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) interface{}); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) bool); ok {
+		r2 = rf(ctx, key)
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+
+	return r0, r1, r2
+}
+
+// MockFileDriver_GetWithVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWithVersion'
+type MockFileDriver_GetWithVersion_Call struct {
+	*mock.Call
+}
+
+// GetWithVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockFileDriver_Expecter) GetWithVersion(ctx interface{}, key interface{}) *MockFileDriver_GetWithVersion_Call {
+	return &MockFileDriver_GetWithVersion_Call{Call: _e.mock.On("GetWithVersion", ctx, key)}
+}
+
+func (_c *MockFileDriver_GetWithVersion_Call) Run(run func(ctx context.Context, key string)) *MockFileDriver_GetWithVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockFileDriver_GetWithVersion_Call) Return(_a0 interface{}, _a1 string, _a2 bool) *MockFileDriver_GetWithVersion_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockFileDriver_GetWithVersion_Call) RunAndReturn(run func(context.Context, string) (interface{}, string, bool)) *MockFileDriver_GetWithVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Has provides a mock function with given fields: ctx, key
+func (_m *MockFileDriver) Has(ctx context.Context, key string) bool {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Has")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockFileDriver_Has_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Has'
+type MockFileDriver_Has_Call struct {
+	*mock.Call
+}
+
+// Has is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockFileDriver_Expecter) Has(ctx interface{}, key interface{}) *MockFileDriver_Has_Call {
+	return &MockFileDriver_Has_Call{Call: _e.mock.On("Has", ctx, key)}
+}
+
+func (_c *MockFileDriver_Has_Call) Run(run func(ctx context.Context, key string)) *MockFileDriver_Has_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockFileDriver_Has_Call) Return(_a0 bool) *MockFileDriver_Has_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFileDriver_Has_Call) RunAndReturn(run func(context.Context, string) bool) *MockFileDriver_Has_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Increment provides a mock function with given fields: ctx, key, delta, ttl
+func (_m *MockFileDriver) Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	ret := _m.Called(ctx, key, delta, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Increment")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) (int64, error)); ok {
+		return rf(ctx, key, delta, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) int64); ok {
+		r0 = rf(ctx, key, delta, ttl)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, time.Duration) error); ok {
+		r1 = rf(ctx, key, delta, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFileDriver_Increment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Increment'
+type MockFileDriver_Increment_Call struct {
+	*mock.Call
+}
+
+// Increment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - delta int64
+//   - ttl time.Duration
+func (_e *MockFileDriver_Expecter) Increment(ctx interface{}, key interface{}, delta interface{}, ttl interface{}) *MockFileDriver_Increment_Call {
+	return &MockFileDriver_Increment_Call{Call: _e.mock.On("Increment", ctx, key, delta, ttl)}
+}
+
+func (_c *MockFileDriver_Increment_Call) Run(run func(ctx context.Context, key string, delta int64, ttl time.Duration)) *MockFileDriver_Increment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockFileDriver_Increment_Call) Return(_a0 int64, _a1 error) *MockFileDriver_Increment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileDriver_Increment_Call) RunAndReturn(run func(context.Context, string, int64, time.Duration) (int64, error)) *MockFileDriver_Increment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementFloat provides a mock function with given fields: ctx, key, delta, ttl
+func (_m *MockFileDriver) IncrementFloat(ctx context.Context, key string, delta float64, ttl time.Duration) (float64, error) {
+	ret := _m.Called(ctx, key, delta, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementFloat")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, time.Duration) (float64, error)); ok {
+		return rf(ctx, key, delta, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, time.Duration) float64); ok {
+		r0 = rf(ctx, key, delta, ttl)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, float64, time.Duration) error); ok {
+		r1 = rf(ctx, key, delta, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFileDriver_IncrementFloat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementFloat'
+type MockFileDriver_IncrementFloat_Call struct {
+	*mock.Call
+}
+
+// IncrementFloat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - delta float64
+//   - ttl time.Duration
+func (_e *MockFileDriver_Expecter) IncrementFloat(ctx interface{}, key interface{}, delta interface{}, ttl interface{}) *MockFileDriver_IncrementFloat_Call {
+	return &MockFileDriver_IncrementFloat_Call{Call: _e.mock.On("IncrementFloat", ctx, key, delta, ttl)}
+}
+
+func (_c *MockFileDriver_IncrementFloat_Call) Run(run func(ctx context.Context, key string, delta float64, ttl time.Duration)) *MockFileDriver_IncrementFloat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(float64), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockFileDriver_IncrementFloat_Call) Return(_a0 float64, _a1 error) *MockFileDriver_IncrementFloat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileDriver_IncrementFloat_Call) RunAndReturn(run func(context.Context, string, float64, time.Duration) (float64, error)) *MockFileDriver_IncrementFloat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Keys provides a mock function with given fields: ctx, pattern
+func (_m *MockFileDriver) Keys(ctx context.Context, pattern string) ([]string, error) {
+	ret := _m.Called(ctx, pattern)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Keys")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, pattern)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, pattern)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pattern)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFileDriver_Keys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Keys'
+type MockFileDriver_Keys_Call struct {
+	*mock.Call
+}
+
+// Keys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pattern string
+func (_e *MockFileDriver_Expecter) Keys(ctx interface{}, pattern interface{}) *MockFileDriver_Keys_Call {
+	return &MockFileDriver_Keys_Call{Call: _e.mock.On("Keys", ctx, pattern)}
+}
+
+func (_c *MockFileDriver_Keys_Call) Run(run func(ctx context.Context, pattern string)) *MockFileDriver_Keys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockFileDriver_Keys_Call) Return(_a0 []string, _a1 error) *MockFileDriver_Keys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileDriver_Keys_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *MockFileDriver_Keys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remember provides a mock function with given fields: ctx, key, ttl, callback
+func (_m *MockFileDriver) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	ret := _m.Called(ctx, key, ttl, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remember")
 	}
 
 	var r0 interface{}
-	var r1 bool
-	if rf, ok := ret.Get(0).(func(context.Context, string) (interface{}, bool)); ok {
-		return rf(ctx, key)
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
+		return rf(ctx, key, ttl, callback)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) interface{}); ok {
-		r0 = rf(ctx, key)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, func() (interface{}, error)) interface{}); ok {
+		r0 = rf(ctx, key, ttl, callback)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(interface{})
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
-		r1 = rf(ctx, key)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, func() (interface{}, error)) error); ok {
+		r1 = rf(ctx, key, ttl, callback)
 	} else {
-		r1 = ret.Get(1).(bool)
+		r1 = ret.Error(1)
 	}
 
 	return r0, r1
 }
 
-// MockFileDriver_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockFileDriver_Get_Call struct {
+// MockFileDriver_Remember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remember'
+type MockFileDriver_Remember_Call struct {
 	*mock.Call
 }
 
-// Get is a helper method to define mock.On call
+// Remember is a helper method to define mock.On call
 //   - ctx context.Context
 //   - key string
-func (_e *MockFileDriver_Expecter) Get(ctx interface{}, key interface{}) *MockFileDriver_Get_Call {
-	return &MockFileDriver_Get_Call{Call: _e.mock.On("Get", ctx, key)}
+//   - ttl time.Duration
+//   - callback func()(interface{} , error)
+func (_e *MockFileDriver_Expecter) Remember(ctx interface{}, key interface{}, ttl interface{}, callback interface{}) *MockFileDriver_Remember_Call {
+	return &MockFileDriver_Remember_Call{Call: _e.mock.On("Remember", ctx, key, ttl, callback)}
 }
 
-func (_c *MockFileDriver_Get_Call) Run(run func(ctx context.Context, key string)) *MockFileDriver_Get_Call {
+func (_c *MockFileDriver_Remember_Call) Run(run func(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error))) *MockFileDriver_Remember_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(func() (interface{}, error)))
 	})
 	return _c
 }
 
-func (_c *MockFileDriver_Get_Call) Return(_a0 interface{}, _a1 bool) *MockFileDriver_Get_Call {
+func (_c *MockFileDriver_Remember_Call) Return(_a0 interface{}, _a1 error) *MockFileDriver_Remember_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockFileDriver_Get_Call) RunAndReturn(run func(context.Context, string) (interface{}, bool)) *MockFileDriver_Get_Call {
+func (_c *MockFileDriver_Remember_Call) RunAndReturn(run func(context.Context, string, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockFileDriver_Remember_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMultiple provides a mock function with given fields: ctx, keys
-func (_m *MockFileDriver) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, []string) {
-	ret := _m.Called(ctx, keys)
+// RememberStale provides a mock function with given fields: ctx, key, ttl, grace, callback
+func (_m *MockFileDriver) RememberStale(ctx context.Context, key string, ttl time.Duration, grace time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	ret := _m.Called(ctx, key, ttl, grace, callback)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMultiple")
+		panic("no return value specified for RememberStale")
 	}
 
-	var r0 map[string]interface{}
-	var r1 []string
-	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]interface{}, []string)); ok {
-		return rf(ctx, keys)
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
+		return rf(ctx, key, ttl, grace, callback)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]interface{}); ok {
-		r0 = rf(ctx, keys)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, time.Duration, func() (interface{}, error)) interface{}); ok {
+		r0 = rf(ctx, key, ttl, grace, callback)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]interface{})
+			r0 = ret.Get(0).(interface{})
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []string) []string); ok {
-		r1 = rf(ctx, keys)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, time.Duration, func() (interface{}, error)) error); ok {
+		r1 = rf(ctx, key, ttl, grace, callback)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).([]string)
-		}
+		r1 = ret.Error(1)
 	}
 
 	return r0, r1
 }
 
-// MockFileDriver_GetMultiple_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMultiple'
-type MockFileDriver_GetMultiple_Call struct {
+// MockFileDriver_RememberStale_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RememberStale'
+type MockFileDriver_RememberStale_Call struct {
 	*mock.Call
 }
 
-// GetMultiple is a helper method to define mock.On call
+// RememberStale is a helper method to define mock.On call
 //   - ctx context.Context
-//   - keys []string
-func (_e *MockFileDriver_Expecter) GetMultiple(ctx interface{}, keys interface{}) *MockFileDriver_GetMultiple_Call {
-	return &MockFileDriver_GetMultiple_Call{Call: _e.mock.On("GetMultiple", ctx, keys)}
+//   - key string
+//   - ttl time.Duration
+//   - grace time.Duration
+//   - callback func()(interface{} , error)
+func (_e *MockFileDriver_Expecter) RememberStale(ctx interface{}, key interface{}, ttl interface{}, grace interface{}, callback interface{}) *MockFileDriver_RememberStale_Call {
+	return &MockFileDriver_RememberStale_Call{Call: _e.mock.On("RememberStale", ctx, key, ttl, grace, callback)}
 }
 
-func (_c *MockFileDriver_GetMultiple_Call) Run(run func(ctx context.Context, keys []string)) *MockFileDriver_GetMultiple_Call {
+func (_c *MockFileDriver_RememberStale_Call) Run(run func(ctx context.Context, key string, ttl time.Duration, grace time.Duration, callback func() (interface{}, error))) *MockFileDriver_RememberStale_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].([]string))
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(time.Duration), args[4].(func() (interface{}, error)))
 	})
 	return _c
 }
 
-func (_c *MockFileDriver_GetMultiple_Call) Return(_a0 map[string]interface{}, _a1 []string) *MockFileDriver_GetMultiple_Call {
+func (_c *MockFileDriver_RememberStale_Call) Return(_a0 interface{}, _a1 error) *MockFileDriver_RememberStale_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockFileDriver_GetMultiple_Call) RunAndReturn(run func(context.Context, []string) (map[string]interface{}, []string)) *MockFileDriver_GetMultiple_Call {
+func (_c *MockFileDriver_RememberStale_Call) RunAndReturn(run func(context.Context, string, time.Duration, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockFileDriver_RememberStale_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Has provides a mock function with given fields: ctx, key
-func (_m *MockFileDriver) Has(ctx context.Context, key string) bool {
-	ret := _m.Called(ctx, key)
+// Replace provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockFileDriver) Replace(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, value, ttl)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Has")
+		panic("no return value specified for Replace")
 	}
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-		r0 = rf(ctx, key)
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, ttl)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockFileDriver_Has_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Has'
-type MockFileDriver_Has_Call struct {
+// MockFileDriver_Replace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Replace'
+type MockFileDriver_Replace_Call struct {
 	*mock.Call
 }
 
-// Has is a helper method to define mock.On call
+// Replace is a helper method to define mock.On call
 //   - ctx context.Context
 //   - key string
-func (_e *MockFileDriver_Expecter) Has(ctx interface{}, key interface{}) *MockFileDriver_Has_Call {
-	return &MockFileDriver_Has_Call{Call: _e.mock.On("Has", ctx, key)}
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockFileDriver_Expecter) Replace(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockFileDriver_Replace_Call {
+	return &MockFileDriver_Replace_Call{Call: _e.mock.On("Replace", ctx, key, value, ttl)}
 }
 
-func (_c *MockFileDriver_Has_Call) Run(run func(ctx context.Context, key string)) *MockFileDriver_Has_Call {
+func (_c *MockFileDriver_Replace_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *MockFileDriver_Replace_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
 	})
 	return _c
 }
 
-func (_c *MockFileDriver_Has_Call) Return(_a0 bool) *MockFileDriver_Has_Call {
-	_c.Call.Return(_a0)
+func (_c *MockFileDriver_Replace_Call) Return(_a0 bool, _a1 error) *MockFileDriver_Replace_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockFileDriver_Has_Call) RunAndReturn(run func(context.Context, string) bool) *MockFileDriver_Has_Call {
+func (_c *MockFileDriver_Replace_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) (bool, error)) *MockFileDriver_Replace_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Remember provides a mock function with given fields: ctx, key, ttl, callback
-func (_m *MockFileDriver) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
-	ret := _m.Called(ctx, key, ttl, callback)
+// Scan provides a mock function with given fields: ctx, pattern, fn
+func (_m *MockFileDriver) Scan(ctx context.Context, pattern string, fn func(string) bool) error {
+	ret := _m.Called(ctx, pattern, fn)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Remember")
-	}
-
-	var r0 interface{}
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
-		return rf(ctx, key, ttl, callback)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, func() (interface{}, error)) interface{}); ok {
-		r0 = rf(ctx, key, ttl, callback)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(interface{})
-		}
+		panic("no return value specified for Scan")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, func() (interface{}, error)) error); ok {
-		r1 = rf(ctx, key, ttl, callback)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(string) bool) error); ok {
+		r0 = rf(ctx, pattern, fn)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockFileDriver_Remember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remember'
-type MockFileDriver_Remember_Call struct {
+// MockFileDriver_Scan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Scan'
+type MockFileDriver_Scan_Call struct {
 	*mock.Call
 }
 
-// Remember is a helper method to define mock.On call
+// Scan is a helper method to define mock.On call
 //   - ctx context.Context
-//   - key string
-//   - ttl time.Duration
-//   - callback func()(interface{} , error)
-func (_e *MockFileDriver_Expecter) Remember(ctx interface{}, key interface{}, ttl interface{}, callback interface{}) *MockFileDriver_Remember_Call {
-	return &MockFileDriver_Remember_Call{Call: _e.mock.On("Remember", ctx, key, ttl, callback)}
+//   - pattern string
+//   - fn func(string) bool
+func (_e *MockFileDriver_Expecter) Scan(ctx interface{}, pattern interface{}, fn interface{}) *MockFileDriver_Scan_Call {
+	return &MockFileDriver_Scan_Call{Call: _e.mock.On("Scan", ctx, pattern, fn)}
 }
 
-func (_c *MockFileDriver_Remember_Call) Run(run func(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error))) *MockFileDriver_Remember_Call {
+func (_c *MockFileDriver_Scan_Call) Run(run func(ctx context.Context, pattern string, fn func(string) bool)) *MockFileDriver_Scan_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(func() (interface{}, error)))
+		run(args[0].(context.Context), args[1].(string), args[2].(func(string) bool))
 	})
 	return _c
 }
 
-func (_c *MockFileDriver_Remember_Call) Return(_a0 interface{}, _a1 error) *MockFileDriver_Remember_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockFileDriver_Scan_Call) Return(_a0 error) *MockFileDriver_Scan_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockFileDriver_Remember_Call) RunAndReturn(run func(context.Context, string, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockFileDriver_Remember_Call {
+func (_c *MockFileDriver_Scan_Call) RunAndReturn(run func(context.Context, string, func(string) bool) error) *MockFileDriver_Scan_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -533,6 +1418,56 @@ func (_c *MockFileDriver_SetMultiple_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// SetTagged provides a mock function with given fields: ctx, key, value, ttl, tags
+func (_m *MockFileDriver) SetTagged(ctx context.Context, key string, value interface{}, ttl time.Duration, tags []string) error {
+	ret := _m.Called(ctx, key, value, ttl, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTagged")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration, []string) error); ok {
+		r0 = rf(ctx, key, value, ttl, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockFileDriver_SetTagged_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTagged'
+type MockFileDriver_SetTagged_Call struct {
+	*mock.Call
+}
+
+// SetTagged is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+//   - tags []string
+func (_e *MockFileDriver_Expecter) SetTagged(ctx interface{}, key interface{}, value interface{}, ttl interface{}, tags interface{}) *MockFileDriver_SetTagged_Call {
+	return &MockFileDriver_SetTagged_Call{Call: _e.mock.On("SetTagged", ctx, key, value, ttl, tags)}
+}
+
+func (_c *MockFileDriver_SetTagged_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration, tags []string)) *MockFileDriver_SetTagged_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration), args[4].([]string))
+	})
+	return _c
+}
+
+func (_c *MockFileDriver_SetTagged_Call) Return(_a0 error) *MockFileDriver_SetTagged_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFileDriver_SetTagged_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration, []string) error) *MockFileDriver_SetTagged_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Stats provides a mock function with given fields: ctx
 func (_m *MockFileDriver) Stats(ctx context.Context) map[string]interface{} {
 	ret := _m.Called(ctx)
@@ -581,6 +1516,121 @@ func (_c *MockFileDriver_Stats_Call) RunAndReturn(run func(context.Context) map[
 	return _c
 }
 
+// TTL provides a mock function with given fields: ctx, key
+func (_m *MockFileDriver) TTL(ctx context.Context, key string) (time.Duration, bool) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TTL")
+	}
+
+	var r0 time.Duration
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) (time.Duration, bool)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) time.Duration); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockFileDriver_TTL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TTL'
+type MockFileDriver_TTL_Call struct {
+	*mock.Call
+}
+
+// TTL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockFileDriver_Expecter) TTL(ctx interface{}, key interface{}) *MockFileDriver_TTL_Call {
+	return &MockFileDriver_TTL_Call{Call: _e.mock.On("TTL", ctx, key)}
+}
+
+func (_c *MockFileDriver_TTL_Call) Run(run func(ctx context.Context, key string)) *MockFileDriver_TTL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockFileDriver_TTL_Call) Return(_a0 time.Duration, _a1 bool) *MockFileDriver_TTL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileDriver_TTL_Call) RunAndReturn(run func(context.Context, string) (time.Duration, bool)) *MockFileDriver_TTL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Touch provides a mock function with given fields: ctx, key, ttl
+func (_m *MockFileDriver) Touch(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Touch")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) bool); ok {
+		r0 = rf(ctx, key, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFileDriver_Touch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Touch'
+type MockFileDriver_Touch_Call struct {
+	*mock.Call
+}
+
+// Touch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - ttl time.Duration
+func (_e *MockFileDriver_Expecter) Touch(ctx interface{}, key interface{}, ttl interface{}) *MockFileDriver_Touch_Call {
+	return &MockFileDriver_Touch_Call{Call: _e.mock.On("Touch", ctx, key, ttl)}
+}
+
+func (_c *MockFileDriver_Touch_Call) Run(run func(ctx context.Context, key string, ttl time.Duration)) *MockFileDriver_Touch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockFileDriver_Touch_Call) Return(_a0 bool, _a1 error) *MockFileDriver_Touch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFileDriver_Touch_Call) RunAndReturn(run func(context.Context, string, time.Duration) (bool, error)) *MockFileDriver_Touch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockFileDriver creates a new instance of MockFileDriver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockFileDriver(t interface {
@@ -3,9 +3,14 @@
 package cache_mocks
 
 import (
-	mock "github.com/stretchr/testify/mock"
+	context "context"
+
+	cache "go.fork.vn/cache"
+
 	driver "go.fork.vn/cache/driver"
 
+	mock "github.com/stretchr/testify/mock"
+
 	time "time"
 )
 
@@ -22,6 +27,123 @@ func (_m *MockManager) EXPECT() *MockManager_Expecter {
 	return &MockManager_Expecter{mock: &_m.Mock}
 }
 
+// Add provides a mock function with given fields: key, value, ttl
+func (_m *MockManager) Add(key string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(string, interface{}, time.Duration) bool); ok {
+		r0 = rf(key, value, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, interface{}, time.Duration) error); ok {
+		r1 = rf(key, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type MockManager_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) Add(key interface{}, value interface{}, ttl interface{}) *MockManager_Add_Call {
+	return &MockManager_Add_Call{Call: _e.mock.On("Add", key, value, ttl)}
+}
+
+func (_c *MockManager_Add_Call) Run(run func(key string, value interface{}, ttl time.Duration)) *MockManager_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(interface{}), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_Add_Call) Return(_a0 bool, _a1 error) *MockManager_Add_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_Add_Call) RunAndReturn(run func(string, interface{}, time.Duration) (bool, error)) *MockManager_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddContext provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockManager) AddContext(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddContext")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_AddContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddContext'
+type MockManager_AddContext_Call struct {
+	*mock.Call
+}
+
+// AddContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) AddContext(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockManager_AddContext_Call {
+	return &MockManager_AddContext_Call{Call: _e.mock.On("AddContext", ctx, key, value, ttl)}
+}
+
+func (_c *MockManager_AddContext_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *MockManager_AddContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_AddContext_Call) Return(_a0 bool, _a1 error) *MockManager_AddContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_AddContext_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) (bool, error)) *MockManager_AddContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddDriver provides a mock function with given fields: name, _a1
 func (_m *MockManager) AddDriver(name string, _a1 driver.Driver) {
 	_m.Called(name, _a1)
@@ -101,121 +223,146 @@ func (_c *MockManager_Close_Call) RunAndReturn(run func() error) *MockManager_Cl
 	return _c
 }
 
-// Delete provides a mock function with given fields: key
-func (_m *MockManager) Delete(key string) error {
-	ret := _m.Called(key)
+// CompareAndSwap provides a mock function with given fields: key, token, value, ttl
+func (_m *MockManager) CompareAndSwap(key string, token string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(key, token, value, ttl)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for CompareAndSwap")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(key)
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(key, token, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(key, token, value, ttl)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string, interface{}, time.Duration) error); ok {
+		r1 = rf(key, token, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockManager_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type MockManager_Delete_Call struct {
+// MockManager_CompareAndSwap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareAndSwap'
+type MockManager_CompareAndSwap_Call struct {
 	*mock.Call
 }
 
-// Delete is a helper method to define mock.On call
+// CompareAndSwap is a helper method to define mock.On call
 //   - key string
-func (_e *MockManager_Expecter) Delete(key interface{}) *MockManager_Delete_Call {
-	return &MockManager_Delete_Call{Call: _e.mock.On("Delete", key)}
+//   - token string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) CompareAndSwap(key interface{}, token interface{}, value interface{}, ttl interface{}) *MockManager_CompareAndSwap_Call {
+	return &MockManager_CompareAndSwap_Call{Call: _e.mock.On("CompareAndSwap", key, token, value, ttl)}
 }
 
-func (_c *MockManager_Delete_Call) Run(run func(key string)) *MockManager_Delete_Call {
+func (_c *MockManager_CompareAndSwap_Call) Run(run func(key string, token string, value interface{}, ttl time.Duration)) *MockManager_CompareAndSwap_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
 	})
 	return _c
 }
 
-func (_c *MockManager_Delete_Call) Return(_a0 error) *MockManager_Delete_Call {
-	_c.Call.Return(_a0)
+func (_c *MockManager_CompareAndSwap_Call) Return(_a0 bool, _a1 error) *MockManager_CompareAndSwap_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockManager_Delete_Call) RunAndReturn(run func(string) error) *MockManager_Delete_Call {
+func (_c *MockManager_CompareAndSwap_Call) RunAndReturn(run func(string, string, interface{}, time.Duration) (bool, error)) *MockManager_CompareAndSwap_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteMultiple provides a mock function with given fields: keys
-func (_m *MockManager) DeleteMultiple(keys []string) error {
-	ret := _m.Called(keys)
+// CompareAndSwapContext provides a mock function with given fields: ctx, key, token, value, ttl
+func (_m *MockManager) CompareAndSwapContext(ctx context.Context, key string, token string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, token, value, ttl)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteMultiple")
+		panic("no return value specified for CompareAndSwapContext")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func([]string) error); ok {
-		r0 = rf(keys)
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, token, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, token, value, ttl)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, token, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockManager_DeleteMultiple_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMultiple'
-type MockManager_DeleteMultiple_Call struct {
+// MockManager_CompareAndSwapContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareAndSwapContext'
+type MockManager_CompareAndSwapContext_Call struct {
 	*mock.Call
 }
 
-// DeleteMultiple is a helper method to define mock.On call
-//   - keys []string
-func (_e *MockManager_Expecter) DeleteMultiple(keys interface{}) *MockManager_DeleteMultiple_Call {
-	return &MockManager_DeleteMultiple_Call{Call: _e.mock.On("DeleteMultiple", keys)}
+// CompareAndSwapContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - token string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) CompareAndSwapContext(ctx interface{}, key interface{}, token interface{}, value interface{}, ttl interface{}) *MockManager_CompareAndSwapContext_Call {
+	return &MockManager_CompareAndSwapContext_Call{Call: _e.mock.On("CompareAndSwapContext", ctx, key, token, value, ttl)}
 }
 
-func (_c *MockManager_DeleteMultiple_Call) Run(run func(keys []string)) *MockManager_DeleteMultiple_Call {
+func (_c *MockManager_CompareAndSwapContext_Call) Run(run func(ctx context.Context, key string, token string, value interface{}, ttl time.Duration)) *MockManager_CompareAndSwapContext_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(interface{}), args[4].(time.Duration))
 	})
 	return _c
 }
 
-func (_c *MockManager_DeleteMultiple_Call) Return(_a0 error) *MockManager_DeleteMultiple_Call {
-	_c.Call.Return(_a0)
+func (_c *MockManager_CompareAndSwapContext_Call) Return(_a0 bool, _a1 error) *MockManager_CompareAndSwapContext_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockManager_DeleteMultiple_Call) RunAndReturn(run func([]string) error) *MockManager_DeleteMultiple_Call {
+func (_c *MockManager_CompareAndSwapContext_Call) RunAndReturn(run func(context.Context, string, string, interface{}, time.Duration) (bool, error)) *MockManager_CompareAndSwapContext_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Driver provides a mock function with given fields: name
-func (_m *MockManager) Driver(name string) (driver.Driver, error) {
-	ret := _m.Called(name)
+// Decrement provides a mock function with given fields: key, delta, ttl
+func (_m *MockManager) Decrement(key string, delta int64, ttl time.Duration) (int64, error) {
+	ret := _m.Called(key, delta, ttl)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Driver")
+		panic("no return value specified for Decrement")
 	}
 
-	var r0 driver.Driver
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (driver.Driver, error)); ok {
-		return rf(name)
+	if rf, ok := ret.Get(0).(func(string, int64, time.Duration) (int64, error)); ok {
+		return rf(key, delta, ttl)
 	}
-	if rf, ok := ret.Get(0).(func(string) driver.Driver); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(string, int64, time.Duration) int64); ok {
+		r0 = rf(key, delta, ttl)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(driver.Driver)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(string, int64, time.Duration) error); ok {
+		r1 = rf(key, delta, ttl)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -223,266 +370,275 @@ func (_m *MockManager) Driver(name string) (driver.Driver, error) {
 	return r0, r1
 }
 
-// MockManager_Driver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Driver'
-type MockManager_Driver_Call struct {
+// MockManager_Decrement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Decrement'
+type MockManager_Decrement_Call struct {
 	*mock.Call
 }
 
-// Driver is a helper method to define mock.On call
-//   - name string
-func (_e *MockManager_Expecter) Driver(name interface{}) *MockManager_Driver_Call {
-	return &MockManager_Driver_Call{Call: _e.mock.On("Driver", name)}
+// Decrement is a helper method to define mock.On call
+//   - key string
+//   - delta int64
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) Decrement(key interface{}, delta interface{}, ttl interface{}) *MockManager_Decrement_Call {
+	return &MockManager_Decrement_Call{Call: _e.mock.On("Decrement", key, delta, ttl)}
 }
 
-func (_c *MockManager_Driver_Call) Run(run func(name string)) *MockManager_Driver_Call {
+func (_c *MockManager_Decrement_Call) Run(run func(key string, delta int64, ttl time.Duration)) *MockManager_Decrement_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(int64), args[2].(time.Duration))
 	})
 	return _c
 }
 
-func (_c *MockManager_Driver_Call) Return(_a0 driver.Driver, _a1 error) *MockManager_Driver_Call {
+func (_c *MockManager_Decrement_Call) Return(_a0 int64, _a1 error) *MockManager_Decrement_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockManager_Driver_Call) RunAndReturn(run func(string) (driver.Driver, error)) *MockManager_Driver_Call {
+func (_c *MockManager_Decrement_Call) RunAndReturn(run func(string, int64, time.Duration) (int64, error)) *MockManager_Decrement_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Flush provides a mock function with no fields
-func (_m *MockManager) Flush() error {
-	ret := _m.Called()
+// DecrementContext provides a mock function with given fields: ctx, key, delta, ttl
+func (_m *MockManager) DecrementContext(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	ret := _m.Called(ctx, key, delta, ttl)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Flush")
+		panic("no return value specified for DecrementContext")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
-		r0 = rf()
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) (int64, error)); ok {
+		return rf(ctx, key, delta, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) int64); ok {
+		r0 = rf(ctx, key, delta, ttl)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, time.Duration) error); ok {
+		r1 = rf(ctx, key, delta, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockManager_Flush_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Flush'
-type MockManager_Flush_Call struct {
+// MockManager_DecrementContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DecrementContext'
+type MockManager_DecrementContext_Call struct {
 	*mock.Call
 }
 
-// Flush is a helper method to define mock.On call
-func (_e *MockManager_Expecter) Flush() *MockManager_Flush_Call {
-	return &MockManager_Flush_Call{Call: _e.mock.On("Flush")}
+// DecrementContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - delta int64
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) DecrementContext(ctx interface{}, key interface{}, delta interface{}, ttl interface{}) *MockManager_DecrementContext_Call {
+	return &MockManager_DecrementContext_Call{Call: _e.mock.On("DecrementContext", ctx, key, delta, ttl)}
 }
 
-func (_c *MockManager_Flush_Call) Run(run func()) *MockManager_Flush_Call {
+func (_c *MockManager_DecrementContext_Call) Run(run func(ctx context.Context, key string, delta int64, ttl time.Duration)) *MockManager_DecrementContext_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(time.Duration))
 	})
 	return _c
 }
 
-func (_c *MockManager_Flush_Call) Return(_a0 error) *MockManager_Flush_Call {
-	_c.Call.Return(_a0)
+func (_c *MockManager_DecrementContext_Call) Return(_a0 int64, _a1 error) *MockManager_DecrementContext_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockManager_Flush_Call) RunAndReturn(run func() error) *MockManager_Flush_Call {
+func (_c *MockManager_DecrementContext_Call) RunAndReturn(run func(context.Context, string, int64, time.Duration) (int64, error)) *MockManager_DecrementContext_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Get provides a mock function with given fields: key
-func (_m *MockManager) Get(key string) (interface{}, bool) {
-	ret := _m.Called(key)
+// DefaultDriver provides a mock function with no fields
+func (_m *MockManager) DefaultDriver() (driver.Driver, error) {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for Get")
+		panic("no return value specified for DefaultDriver")
 	}
 
-	var r0 interface{}
-	var r1 bool
-	if rf, ok := ret.Get(0).(func(string) (interface{}, bool)); ok {
-		return rf(key)
+	var r0 driver.Driver
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (driver.Driver, error)); ok {
+		return rf()
 	}
-	if rf, ok := ret.Get(0).(func(string) interface{}); ok {
-		r0 = rf(key)
+	if rf, ok := ret.Get(0).(func() driver.Driver); ok {
+		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(interface{})
+			r0 = ret.Get(0).(driver.Driver)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) bool); ok {
-		r1 = rf(key)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
-		r1 = ret.Get(1).(bool)
+		r1 = ret.Error(1)
 	}
 
 	return r0, r1
 }
 
-// MockManager_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockManager_Get_Call struct {
+// MockManager_DefaultDriver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DefaultDriver'
+type MockManager_DefaultDriver_Call struct {
 	*mock.Call
 }
 
-// Get is a helper method to define mock.On call
-//   - key string
-func (_e *MockManager_Expecter) Get(key interface{}) *MockManager_Get_Call {
-	return &MockManager_Get_Call{Call: _e.mock.On("Get", key)}
+// DefaultDriver is a helper method to define mock.On call
+func (_e *MockManager_Expecter) DefaultDriver() *MockManager_DefaultDriver_Call {
+	return &MockManager_DefaultDriver_Call{Call: _e.mock.On("DefaultDriver")}
 }
 
-func (_c *MockManager_Get_Call) Run(run func(key string)) *MockManager_Get_Call {
+func (_c *MockManager_DefaultDriver_Call) Run(run func()) *MockManager_DefaultDriver_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run()
 	})
 	return _c
 }
 
-func (_c *MockManager_Get_Call) Return(_a0 interface{}, _a1 bool) *MockManager_Get_Call {
+func (_c *MockManager_DefaultDriver_Call) Return(_a0 driver.Driver, _a1 error) *MockManager_DefaultDriver_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockManager_Get_Call) RunAndReturn(run func(string) (interface{}, bool)) *MockManager_Get_Call {
+func (_c *MockManager_DefaultDriver_Call) RunAndReturn(run func() (driver.Driver, error)) *MockManager_DefaultDriver_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMultiple provides a mock function with given fields: keys
-func (_m *MockManager) GetMultiple(keys []string) (map[string]interface{}, []string) {
-	ret := _m.Called(keys)
+// Delete provides a mock function with given fields: key
+func (_m *MockManager) Delete(key string) error {
+	ret := _m.Called(key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMultiple")
+		panic("no return value specified for Delete")
 	}
 
-	var r0 map[string]interface{}
-	var r1 []string
-	if rf, ok := ret.Get(0).(func([]string) (map[string]interface{}, []string)); ok {
-		return rf(keys)
-	}
-	if rf, ok := ret.Get(0).(func([]string) map[string]interface{}); ok {
-		r0 = rf(keys)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(key)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]interface{})
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func([]string) []string); ok {
-		r1 = rf(keys)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).([]string)
-		}
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockManager_GetMultiple_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMultiple'
-type MockManager_GetMultiple_Call struct {
+// MockManager_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockManager_Delete_Call struct {
 	*mock.Call
 }
 
-// GetMultiple is a helper method to define mock.On call
-//   - keys []string
-func (_e *MockManager_Expecter) GetMultiple(keys interface{}) *MockManager_GetMultiple_Call {
-	return &MockManager_GetMultiple_Call{Call: _e.mock.On("GetMultiple", keys)}
+// Delete is a helper method to define mock.On call
+//   - key string
+func (_e *MockManager_Expecter) Delete(key interface{}) *MockManager_Delete_Call {
+	return &MockManager_Delete_Call{Call: _e.mock.On("Delete", key)}
 }
 
-func (_c *MockManager_GetMultiple_Call) Run(run func(keys []string)) *MockManager_GetMultiple_Call {
+func (_c *MockManager_Delete_Call) Run(run func(key string)) *MockManager_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]string))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *MockManager_GetMultiple_Call) Return(_a0 map[string]interface{}, _a1 []string) *MockManager_GetMultiple_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockManager_Delete_Call) Return(_a0 error) *MockManager_Delete_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockManager_GetMultiple_Call) RunAndReturn(run func([]string) (map[string]interface{}, []string)) *MockManager_GetMultiple_Call {
+func (_c *MockManager_Delete_Call) RunAndReturn(run func(string) error) *MockManager_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Has provides a mock function with given fields: key
-func (_m *MockManager) Has(key string) bool {
-	ret := _m.Called(key)
+// DeleteByPrefix provides a mock function with given fields: prefix
+func (_m *MockManager) DeleteByPrefix(prefix string) (int, error) {
+	ret := _m.Called(prefix)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Has")
+		panic("no return value specified for DeleteByPrefix")
 	}
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(key)
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(prefix)
+	}
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(prefix)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(int)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockManager_Has_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Has'
-type MockManager_Has_Call struct {
+// MockManager_DeleteByPrefix_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByPrefix'
+type MockManager_DeleteByPrefix_Call struct {
 	*mock.Call
 }
 
-// Has is a helper method to define mock.On call
-//   - key string
-func (_e *MockManager_Expecter) Has(key interface{}) *MockManager_Has_Call {
-	return &MockManager_Has_Call{Call: _e.mock.On("Has", key)}
+// DeleteByPrefix is a helper method to define mock.On call
+//   - prefix string
+func (_e *MockManager_Expecter) DeleteByPrefix(prefix interface{}) *MockManager_DeleteByPrefix_Call {
+	return &MockManager_DeleteByPrefix_Call{Call: _e.mock.On("DeleteByPrefix", prefix)}
 }
 
-func (_c *MockManager_Has_Call) Run(run func(key string)) *MockManager_Has_Call {
+func (_c *MockManager_DeleteByPrefix_Call) Run(run func(prefix string)) *MockManager_DeleteByPrefix_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *MockManager_Has_Call) Return(_a0 bool) *MockManager_Has_Call {
-	_c.Call.Return(_a0)
+func (_c *MockManager_DeleteByPrefix_Call) Return(_a0 int, _a1 error) *MockManager_DeleteByPrefix_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockManager_Has_Call) RunAndReturn(run func(string) bool) *MockManager_Has_Call {
+func (_c *MockManager_DeleteByPrefix_Call) RunAndReturn(run func(string) (int, error)) *MockManager_DeleteByPrefix_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Remember provides a mock function with given fields: key, ttl, callback
-func (_m *MockManager) Remember(key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
-	ret := _m.Called(key, ttl, callback)
+// DeleteByPrefixContext provides a mock function with given fields: ctx, prefix
+func (_m *MockManager) DeleteByPrefixContext(ctx context.Context, prefix string) (int, error) {
+	ret := _m.Called(ctx, prefix)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Remember")
+		panic("no return value specified for DeleteByPrefixContext")
 	}
 
-	var r0 interface{}
+	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
-		return rf(key, ttl, callback)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, prefix)
 	}
-	if rf, ok := ret.Get(0).(func(string, time.Duration, func() (interface{}, error)) interface{}); ok {
-		r0 = rf(key, ttl, callback)
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, prefix)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(interface{})
-		}
+		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, time.Duration, func() (interface{}, error)) error); ok {
-		r1 = rf(key, ttl, callback)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prefix)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -490,47 +646,46 @@ func (_m *MockManager) Remember(key string, ttl time.Duration, callback func() (
 	return r0, r1
 }
 
-// MockManager_Remember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remember'
-type MockManager_Remember_Call struct {
+// MockManager_DeleteByPrefixContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByPrefixContext'
+type MockManager_DeleteByPrefixContext_Call struct {
 	*mock.Call
 }
 
-// Remember is a helper method to define mock.On call
-//   - key string
-//   - ttl time.Duration
-//   - callback func()(interface{} , error)
-func (_e *MockManager_Expecter) Remember(key interface{}, ttl interface{}, callback interface{}) *MockManager_Remember_Call {
-	return &MockManager_Remember_Call{Call: _e.mock.On("Remember", key, ttl, callback)}
+// DeleteByPrefixContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prefix string
+func (_e *MockManager_Expecter) DeleteByPrefixContext(ctx interface{}, prefix interface{}) *MockManager_DeleteByPrefixContext_Call {
+	return &MockManager_DeleteByPrefixContext_Call{Call: _e.mock.On("DeleteByPrefixContext", ctx, prefix)}
 }
 
-func (_c *MockManager_Remember_Call) Run(run func(key string, ttl time.Duration, callback func() (interface{}, error))) *MockManager_Remember_Call {
+func (_c *MockManager_DeleteByPrefixContext_Call) Run(run func(ctx context.Context, prefix string)) *MockManager_DeleteByPrefixContext_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(time.Duration), args[2].(func() (interface{}, error)))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockManager_Remember_Call) Return(_a0 interface{}, _a1 error) *MockManager_Remember_Call {
+func (_c *MockManager_DeleteByPrefixContext_Call) Return(_a0 int, _a1 error) *MockManager_DeleteByPrefixContext_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockManager_Remember_Call) RunAndReturn(run func(string, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockManager_Remember_Call {
+func (_c *MockManager_DeleteByPrefixContext_Call) RunAndReturn(run func(context.Context, string) (int, error)) *MockManager_DeleteByPrefixContext_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Set provides a mock function with given fields: key, value, ttl
-func (_m *MockManager) Set(key string, value interface{}, ttl time.Duration) error {
-	ret := _m.Called(key, value, ttl)
+// DeleteContext provides a mock function with given fields: ctx, key
+func (_m *MockManager) DeleteContext(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Set")
+		panic("no return value specified for DeleteContext")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, interface{}, time.Duration) error); ok {
-		r0 = rf(key, value, ttl)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -538,80 +693,159 @@ func (_m *MockManager) Set(key string, value interface{}, ttl time.Duration) err
 	return r0
 }
 
-// MockManager_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
-type MockManager_Set_Call struct {
+// MockManager_DeleteContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteContext'
+type MockManager_DeleteContext_Call struct {
 	*mock.Call
 }
 
-// Set is a helper method to define mock.On call
+// DeleteContext is a helper method to define mock.On call
+//   - ctx context.Context
 //   - key string
-//   - value interface{}
-//   - ttl time.Duration
-func (_e *MockManager_Expecter) Set(key interface{}, value interface{}, ttl interface{}) *MockManager_Set_Call {
-	return &MockManager_Set_Call{Call: _e.mock.On("Set", key, value, ttl)}
+func (_e *MockManager_Expecter) DeleteContext(ctx interface{}, key interface{}) *MockManager_DeleteContext_Call {
+	return &MockManager_DeleteContext_Call{Call: _e.mock.On("DeleteContext", ctx, key)}
 }
 
-func (_c *MockManager_Set_Call) Run(run func(key string, value interface{}, ttl time.Duration)) *MockManager_Set_Call {
+func (_c *MockManager_DeleteContext_Call) Run(run func(ctx context.Context, key string)) *MockManager_DeleteContext_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(interface{}), args[2].(time.Duration))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockManager_Set_Call) Return(_a0 error) *MockManager_Set_Call {
+func (_c *MockManager_DeleteContext_Call) Return(_a0 error) *MockManager_DeleteContext_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockManager_Set_Call) RunAndReturn(run func(string, interface{}, time.Duration) error) *MockManager_Set_Call {
+func (_c *MockManager_DeleteContext_Call) RunAndReturn(run func(context.Context, string) error) *MockManager_DeleteContext_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetDefaultDriver provides a mock function with given fields: name
-func (_m *MockManager) SetDefaultDriver(name string) {
-	_m.Called(name)
+// DeleteMatching provides a mock function with given fields: pattern
+func (_m *MockManager) DeleteMatching(pattern string) (int, error) {
+	ret := _m.Called(pattern)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMatching")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(pattern)
+	}
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(pattern)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pattern)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockManager_SetDefaultDriver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDefaultDriver'
-type MockManager_SetDefaultDriver_Call struct {
+// MockManager_DeleteMatching_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMatching'
+type MockManager_DeleteMatching_Call struct {
 	*mock.Call
 }
 
-// SetDefaultDriver is a helper method to define mock.On call
-//   - name string
-func (_e *MockManager_Expecter) SetDefaultDriver(name interface{}) *MockManager_SetDefaultDriver_Call {
-	return &MockManager_SetDefaultDriver_Call{Call: _e.mock.On("SetDefaultDriver", name)}
+// DeleteMatching is a helper method to define mock.On call
+//   - pattern string
+func (_e *MockManager_Expecter) DeleteMatching(pattern interface{}) *MockManager_DeleteMatching_Call {
+	return &MockManager_DeleteMatching_Call{Call: _e.mock.On("DeleteMatching", pattern)}
 }
 
-func (_c *MockManager_SetDefaultDriver_Call) Run(run func(name string)) *MockManager_SetDefaultDriver_Call {
+func (_c *MockManager_DeleteMatching_Call) Run(run func(pattern string)) *MockManager_DeleteMatching_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *MockManager_SetDefaultDriver_Call) Return() *MockManager_SetDefaultDriver_Call {
-	_c.Call.Return()
+func (_c *MockManager_DeleteMatching_Call) Return(_a0 int, _a1 error) *MockManager_DeleteMatching_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockManager_SetDefaultDriver_Call) RunAndReturn(run func(string)) *MockManager_SetDefaultDriver_Call {
-	_c.Run(run)
+func (_c *MockManager_DeleteMatching_Call) RunAndReturn(run func(string) (int, error)) *MockManager_DeleteMatching_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// SetMultiple provides a mock function with given fields: values, ttl
-func (_m *MockManager) SetMultiple(values map[string]interface{}, ttl time.Duration) error {
-	ret := _m.Called(values, ttl)
+// DeleteMatchingContext provides a mock function with given fields: ctx, pattern
+func (_m *MockManager) DeleteMatchingContext(ctx context.Context, pattern string) (int, error) {
+	ret := _m.Called(ctx, pattern)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetMultiple")
+		panic("no return value specified for DeleteMatchingContext")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, pattern)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, pattern)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pattern)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_DeleteMatchingContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMatchingContext'
+type MockManager_DeleteMatchingContext_Call struct {
+	*mock.Call
+}
+
+// DeleteMatchingContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pattern string
+func (_e *MockManager_Expecter) DeleteMatchingContext(ctx interface{}, pattern interface{}) *MockManager_DeleteMatchingContext_Call {
+	return &MockManager_DeleteMatchingContext_Call{Call: _e.mock.On("DeleteMatchingContext", ctx, pattern)}
+}
+
+func (_c *MockManager_DeleteMatchingContext_Call) Run(run func(ctx context.Context, pattern string)) *MockManager_DeleteMatchingContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_DeleteMatchingContext_Call) Return(_a0 int, _a1 error) *MockManager_DeleteMatchingContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_DeleteMatchingContext_Call) RunAndReturn(run func(context.Context, string) (int, error)) *MockManager_DeleteMatchingContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMultiple provides a mock function with given fields: keys
+func (_m *MockManager) DeleteMultiple(keys []string) error {
+	ret := _m.Called(keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMultiple")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(map[string]interface{}, time.Duration) error); ok {
-		r0 = rf(values, ttl)
+	if rf, ok := ret.Get(0).(func([]string) error); ok {
+		r0 = rf(keys)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -619,78 +853,2595 @@ func (_m *MockManager) SetMultiple(values map[string]interface{}, ttl time.Durat
 	return r0
 }
 
-// MockManager_SetMultiple_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMultiple'
-type MockManager_SetMultiple_Call struct {
+// MockManager_DeleteMultiple_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMultiple'
+type MockManager_DeleteMultiple_Call struct {
 	*mock.Call
 }
 
-// SetMultiple is a helper method to define mock.On call
-//   - values map[string]interface{}
-//   - ttl time.Duration
-func (_e *MockManager_Expecter) SetMultiple(values interface{}, ttl interface{}) *MockManager_SetMultiple_Call {
-	return &MockManager_SetMultiple_Call{Call: _e.mock.On("SetMultiple", values, ttl)}
+// DeleteMultiple is a helper method to define mock.On call
+//   - keys []string
+func (_e *MockManager_Expecter) DeleteMultiple(keys interface{}) *MockManager_DeleteMultiple_Call {
+	return &MockManager_DeleteMultiple_Call{Call: _e.mock.On("DeleteMultiple", keys)}
 }
 
-func (_c *MockManager_SetMultiple_Call) Run(run func(values map[string]interface{}, ttl time.Duration)) *MockManager_SetMultiple_Call {
+func (_c *MockManager_DeleteMultiple_Call) Run(run func(keys []string)) *MockManager_DeleteMultiple_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(map[string]interface{}), args[1].(time.Duration))
+		run(args[0].([]string))
 	})
 	return _c
 }
 
-func (_c *MockManager_SetMultiple_Call) Return(_a0 error) *MockManager_SetMultiple_Call {
+func (_c *MockManager_DeleteMultiple_Call) Return(_a0 error) *MockManager_DeleteMultiple_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockManager_SetMultiple_Call) RunAndReturn(run func(map[string]interface{}, time.Duration) error) *MockManager_SetMultiple_Call {
+func (_c *MockManager_DeleteMultiple_Call) RunAndReturn(run func([]string) error) *MockManager_DeleteMultiple_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Stats provides a mock function with no fields
-func (_m *MockManager) Stats() map[string]map[string]interface{} {
-	ret := _m.Called()
+// DeleteMultipleContext provides a mock function with given fields: ctx, keys
+func (_m *MockManager) DeleteMultipleContext(ctx context.Context, keys []string) error {
+	ret := _m.Called(ctx, keys)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Stats")
+		panic("no return value specified for DeleteMultipleContext")
 	}
 
-	var r0 map[string]map[string]interface{}
-	if rf, ok := ret.Get(0).(func() map[string]map[string]interface{}); ok {
-		r0 = rf()
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, keys)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]map[string]interface{})
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// MockManager_Stats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stats'
-type MockManager_Stats_Call struct {
+// MockManager_DeleteMultipleContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMultipleContext'
+type MockManager_DeleteMultipleContext_Call struct {
 	*mock.Call
 }
 
-// Stats is a helper method to define mock.On call
-func (_e *MockManager_Expecter) Stats() *MockManager_Stats_Call {
-	return &MockManager_Stats_Call{Call: _e.mock.On("Stats")}
+// DeleteMultipleContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []string
+func (_e *MockManager_Expecter) DeleteMultipleContext(ctx interface{}, keys interface{}) *MockManager_DeleteMultipleContext_Call {
+	return &MockManager_DeleteMultipleContext_Call{Call: _e.mock.On("DeleteMultipleContext", ctx, keys)}
 }
 
-func (_c *MockManager_Stats_Call) Run(run func()) *MockManager_Stats_Call {
+func (_c *MockManager_DeleteMultipleContext_Call) Run(run func(ctx context.Context, keys []string)) *MockManager_DeleteMultipleContext_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context), args[1].([]string))
 	})
 	return _c
 }
 
-func (_c *MockManager_Stats_Call) Return(_a0 map[string]map[string]interface{}) *MockManager_Stats_Call {
+func (_c *MockManager_DeleteMultipleContext_Call) Return(_a0 error) *MockManager_DeleteMultipleContext_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockManager_Stats_Call) RunAndReturn(run func() map[string]map[string]interface{}) *MockManager_Stats_Call {
+func (_c *MockManager_DeleteMultipleContext_Call) RunAndReturn(run func(context.Context, []string) error) *MockManager_DeleteMultipleContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Driver provides a mock function with given fields: name
+func (_m *MockManager) Driver(name string) (driver.Driver, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Driver")
+	}
+
+	var r0 driver.Driver
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (driver.Driver, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) driver.Driver); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(driver.Driver)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_Driver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Driver'
+type MockManager_Driver_Call struct {
+	*mock.Call
+}
+
+// Driver is a helper method to define mock.On call
+//   - name string
+func (_e *MockManager_Expecter) Driver(name interface{}) *MockManager_Driver_Call {
+	return &MockManager_Driver_Call{Call: _e.mock.On("Driver", name)}
+}
+
+func (_c *MockManager_Driver_Call) Run(run func(name string)) *MockManager_Driver_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_Driver_Call) Return(_a0 driver.Driver, _a1 error) *MockManager_Driver_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_Driver_Call) RunAndReturn(run func(string) (driver.Driver, error)) *MockManager_Driver_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Flush provides a mock function with no fields
+func (_m *MockManager) Flush() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Flush")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_Flush_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Flush'
+type MockManager_Flush_Call struct {
+	*mock.Call
+}
+
+// Flush is a helper method to define mock.On call
+func (_e *MockManager_Expecter) Flush() *MockManager_Flush_Call {
+	return &MockManager_Flush_Call{Call: _e.mock.On("Flush")}
+}
+
+func (_c *MockManager_Flush_Call) Run(run func()) *MockManager_Flush_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockManager_Flush_Call) Return(_a0 error) *MockManager_Flush_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Flush_Call) RunAndReturn(run func() error) *MockManager_Flush_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FlushContext provides a mock function with given fields: ctx
+func (_m *MockManager) FlushContext(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlushContext")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_FlushContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlushContext'
+type MockManager_FlushContext_Call struct {
+	*mock.Call
+}
+
+// FlushContext is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockManager_Expecter) FlushContext(ctx interface{}) *MockManager_FlushContext_Call {
+	return &MockManager_FlushContext_Call{Call: _e.mock.On("FlushContext", ctx)}
+}
+
+func (_c *MockManager_FlushContext_Call) Run(run func(ctx context.Context)) *MockManager_FlushContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockManager_FlushContext_Call) Return(_a0 error) *MockManager_FlushContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_FlushContext_Call) RunAndReturn(run func(context.Context) error) *MockManager_FlushContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FlushTags provides a mock function with given fields: tags
+func (_m *MockManager) FlushTags(tags ...string) error {
+	_va := make([]interface{}, len(tags))
+	for _i := range tags {
+		_va[_i] = tags[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlushTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(...string) error); ok {
+		r0 = rf(tags...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_FlushTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlushTags'
+type MockManager_FlushTags_Call struct {
+	*mock.Call
+}
+
+// FlushTags is a helper method to define mock.On call
+//   - tags ...string
+func (_e *MockManager_Expecter) FlushTags(tags ...interface{}) *MockManager_FlushTags_Call {
+	return &MockManager_FlushTags_Call{Call: _e.mock.On("FlushTags",
+		append([]interface{}{}, tags...)...)}
+}
+
+func (_c *MockManager_FlushTags_Call) Run(run func(tags ...string)) *MockManager_FlushTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockManager_FlushTags_Call) Return(_a0 error) *MockManager_FlushTags_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_FlushTags_Call) RunAndReturn(run func(...string) error) *MockManager_FlushTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FlushTagsContext provides a mock function with given fields: ctx, tags
+func (_m *MockManager) FlushTagsContext(ctx context.Context, tags []string) error {
+	ret := _m.Called(ctx, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlushTagsContext")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_FlushTagsContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlushTagsContext'
+type MockManager_FlushTagsContext_Call struct {
+	*mock.Call
+}
+
+// FlushTagsContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tags []string
+func (_e *MockManager_Expecter) FlushTagsContext(ctx interface{}, tags interface{}) *MockManager_FlushTagsContext_Call {
+	return &MockManager_FlushTagsContext_Call{Call: _e.mock.On("FlushTagsContext", ctx, tags)}
+}
+
+func (_c *MockManager_FlushTagsContext_Call) Run(run func(ctx context.Context, tags []string)) *MockManager_FlushTagsContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockManager_FlushTagsContext_Call) Return(_a0 error) *MockManager_FlushTagsContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_FlushTagsContext_Call) RunAndReturn(run func(context.Context, []string) error) *MockManager_FlushTagsContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: key
+func (_m *MockManager) Get(key string) (interface{}, bool) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 interface{}
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(string) (interface{}, bool)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) interface{}); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockManager_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockManager_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - key string
+func (_e *MockManager_Expecter) Get(key interface{}) *MockManager_Get_Call {
+	return &MockManager_Get_Call{Call: _e.mock.On("Get", key)}
+}
+
+func (_c *MockManager_Get_Call) Run(run func(key string)) *MockManager_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_Get_Call) Return(_a0 interface{}, _a1 bool) *MockManager_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_Get_Call) RunAndReturn(run func(string) (interface{}, bool)) *MockManager_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAndDelete provides a mock function with given fields: key
+func (_m *MockManager) GetAndDelete(key string) (interface{}, bool, error) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAndDelete")
+	}
+
+	var r0 interface{}
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string) (interface{}, bool, error)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) interface{}); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(key)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockManager_GetAndDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAndDelete'
+type MockManager_GetAndDelete_Call struct {
+	*mock.Call
+}
+
+// GetAndDelete is a helper method to define mock.On call
+//   - key string
+func (_e *MockManager_Expecter) GetAndDelete(key interface{}) *MockManager_GetAndDelete_Call {
+	return &MockManager_GetAndDelete_Call{Call: _e.mock.On("GetAndDelete", key)}
+}
+
+func (_c *MockManager_GetAndDelete_Call) Run(run func(key string)) *MockManager_GetAndDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_GetAndDelete_Call) Return(_a0 interface{}, _a1 bool, _a2 error) *MockManager_GetAndDelete_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockManager_GetAndDelete_Call) RunAndReturn(run func(string) (interface{}, bool, error)) *MockManager_GetAndDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAndDeleteContext provides a mock function with given fields: ctx, key
+func (_m *MockManager) GetAndDeleteContext(ctx context.Context, key string) (interface{}, bool, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAndDeleteContext")
+	}
+
+	var r0 interface{}
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (interface{}, bool, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) interface{}); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, key)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockManager_GetAndDeleteContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAndDeleteContext'
+type MockManager_GetAndDeleteContext_Call struct {
+	*mock.Call
+}
+
+// GetAndDeleteContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockManager_Expecter) GetAndDeleteContext(ctx interface{}, key interface{}) *MockManager_GetAndDeleteContext_Call {
+	return &MockManager_GetAndDeleteContext_Call{Call: _e.mock.On("GetAndDeleteContext", ctx, key)}
+}
+
+func (_c *MockManager_GetAndDeleteContext_Call) Run(run func(ctx context.Context, key string)) *MockManager_GetAndDeleteContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_GetAndDeleteContext_Call) Return(_a0 interface{}, _a1 bool, _a2 error) *MockManager_GetAndDeleteContext_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockManager_GetAndDeleteContext_Call) RunAndReturn(run func(context.Context, string) (interface{}, bool, error)) *MockManager_GetAndDeleteContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAndSet provides a mock function with given fields: key, value, ttl
+func (_m *MockManager) GetAndSet(key string, value interface{}, ttl time.Duration) (interface{}, bool, error) {
+	ret := _m.Called(key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAndSet")
+	}
+
+	var r0 interface{}
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, interface{}, time.Duration) (interface{}, bool, error)); ok {
+		return rf(key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(string, interface{}, time.Duration) interface{}); ok {
+		r0 = rf(key, value, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, interface{}, time.Duration) bool); ok {
+		r1 = rf(key, value, ttl)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, interface{}, time.Duration) error); ok {
+		r2 = rf(key, value, ttl)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockManager_GetAndSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAndSet'
+type MockManager_GetAndSet_Call struct {
+	*mock.Call
+}
+
+// GetAndSet is a helper method to define mock.On call
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) GetAndSet(key interface{}, value interface{}, ttl interface{}) *MockManager_GetAndSet_Call {
+	return &MockManager_GetAndSet_Call{Call: _e.mock.On("GetAndSet", key, value, ttl)}
+}
+
+func (_c *MockManager_GetAndSet_Call) Run(run func(key string, value interface{}, ttl time.Duration)) *MockManager_GetAndSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(interface{}), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_GetAndSet_Call) Return(_a0 interface{}, _a1 bool, _a2 error) *MockManager_GetAndSet_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockManager_GetAndSet_Call) RunAndReturn(run func(string, interface{}, time.Duration) (interface{}, bool, error)) *MockManager_GetAndSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAndSetContext provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockManager) GetAndSetContext(ctx context.Context, key string, value interface{}, ttl time.Duration) (interface{}, bool, error) {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAndSetContext")
+	}
+
+	var r0 interface{}
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (interface{}, bool, error)); ok {
+		return rf(ctx, key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) interface{}); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r1 = rf(ctx, key, value, ttl)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r2 = rf(ctx, key, value, ttl)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockManager_GetAndSetContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAndSetContext'
+type MockManager_GetAndSetContext_Call struct {
+	*mock.Call
+}
+
+// GetAndSetContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) GetAndSetContext(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockManager_GetAndSetContext_Call {
+	return &MockManager_GetAndSetContext_Call{Call: _e.mock.On("GetAndSetContext", ctx, key, value, ttl)}
+}
+
+func (_c *MockManager_GetAndSetContext_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *MockManager_GetAndSetContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_GetAndSetContext_Call) Return(_a0 interface{}, _a1 bool, _a2 error) *MockManager_GetAndSetContext_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockManager_GetAndSetContext_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) (interface{}, bool, error)) *MockManager_GetAndSetContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetContext provides a mock function with given fields: ctx, key
+func (_m *MockManager) GetContext(ctx context.Context, key string) (interface{}, bool) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContext")
+	}
+
+	var r0 interface{}
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) (interface{}, bool)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) interface{}); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockManager_GetContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContext'
+type MockManager_GetContext_Call struct {
+	*mock.Call
+}
+
+// GetContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockManager_Expecter) GetContext(ctx interface{}, key interface{}) *MockManager_GetContext_Call {
+	return &MockManager_GetContext_Call{Call: _e.mock.On("GetContext", ctx, key)}
+}
+
+func (_c *MockManager_GetContext_Call) Run(run func(ctx context.Context, key string)) *MockManager_GetContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_GetContext_Call) Return(_a0 interface{}, _a1 bool) *MockManager_GetContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_GetContext_Call) RunAndReturn(run func(context.Context, string) (interface{}, bool)) *MockManager_GetContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMultiple provides a mock function with given fields: keys
+func (_m *MockManager) GetMultiple(keys []string) (map[string]interface{}, []string) {
+	ret := _m.Called(keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMultiple")
+	}
+
+	var r0 map[string]interface{}
+	var r1 []string
+	if rf, ok := ret.Get(0).(func([]string) (map[string]interface{}, []string)); ok {
+		return rf(keys)
+	}
+	if rf, ok := ret.Get(0).(func([]string) map[string]interface{}); ok {
+		r0 = rf(keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) []string); ok {
+		r1 = rf(keys)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	return r0, r1
+}
+
+// MockManager_GetMultiple_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMultiple'
+type MockManager_GetMultiple_Call struct {
+	*mock.Call
+}
+
+// GetMultiple is a helper method to define mock.On call
+//   - keys []string
+func (_e *MockManager_Expecter) GetMultiple(keys interface{}) *MockManager_GetMultiple_Call {
+	return &MockManager_GetMultiple_Call{Call: _e.mock.On("GetMultiple", keys)}
+}
+
+func (_c *MockManager_GetMultiple_Call) Run(run func(keys []string)) *MockManager_GetMultiple_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *MockManager_GetMultiple_Call) Return(_a0 map[string]interface{}, _a1 []string) *MockManager_GetMultiple_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_GetMultiple_Call) RunAndReturn(run func([]string) (map[string]interface{}, []string)) *MockManager_GetMultiple_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMultipleContext provides a mock function with given fields: ctx, keys
+func (_m *MockManager) GetMultipleContext(ctx context.Context, keys []string) (map[string]interface{}, []string) {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMultipleContext")
+	}
+
+	var r0 map[string]interface{}
+	var r1 []string
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]interface{}, []string)); ok {
+		return rf(ctx, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]interface{}); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) []string); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	return r0, r1
+}
+
+// MockManager_GetMultipleContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMultipleContext'
+type MockManager_GetMultipleContext_Call struct {
+	*mock.Call
+}
+
+// GetMultipleContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []string
+func (_e *MockManager_Expecter) GetMultipleContext(ctx interface{}, keys interface{}) *MockManager_GetMultipleContext_Call {
+	return &MockManager_GetMultipleContext_Call{Call: _e.mock.On("GetMultipleContext", ctx, keys)}
+}
+
+func (_c *MockManager_GetMultipleContext_Call) Run(run func(ctx context.Context, keys []string)) *MockManager_GetMultipleContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockManager_GetMultipleContext_Call) Return(_a0 map[string]interface{}, _a1 []string) *MockManager_GetMultipleContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_GetMultipleContext_Call) RunAndReturn(run func(context.Context, []string) (map[string]interface{}, []string)) *MockManager_GetMultipleContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWithVersion provides a mock function with given fields: key
+func (_m *MockManager) GetWithVersion(key string) (interface{}, string, bool) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWithVersion")
+	}
+
+	var r0 interface{}
+	var r1 string
+	var r2 bool
+	if rf, ok := ret.Get(0).(func(string) (interface{}, string, bool)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) interface{}); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) string); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(string) bool); ok {
+		r2 = rf(key)
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+
+	return r0, r1, r2
+}
+
+// MockManager_GetWithVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWithVersion'
+type MockManager_GetWithVersion_Call struct {
+	*mock.Call
+}
+
+// GetWithVersion is a helper method to define mock.On call
+//   - key string
+func (_e *MockManager_Expecter) GetWithVersion(key interface{}) *MockManager_GetWithVersion_Call {
+	return &MockManager_GetWithVersion_Call{Call: _e.mock.On("GetWithVersion", key)}
+}
+
+func (_c *MockManager_GetWithVersion_Call) Run(run func(key string)) *MockManager_GetWithVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_GetWithVersion_Call) Return(_a0 interface{}, _a1 string, _a2 bool) *MockManager_GetWithVersion_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockManager_GetWithVersion_Call) RunAndReturn(run func(string) (interface{}, string, bool)) *MockManager_GetWithVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWithVersionContext provides a mock function with given fields: ctx, key
+func (_m *MockManager) GetWithVersionContext(ctx context.Context, key string) (interface{}, string, bool) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWithVersionContext")
+	}
+
+	var r0 interface{}
+	var r1 string
+	var r2 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) (interface{}, string, bool)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) interface{}); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) bool); ok {
+		r2 = rf(ctx, key)
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+
+	return r0, r1, r2
+}
+
+// MockManager_GetWithVersionContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWithVersionContext'
+type MockManager_GetWithVersionContext_Call struct {
+	*mock.Call
+}
+
+// GetWithVersionContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockManager_Expecter) GetWithVersionContext(ctx interface{}, key interface{}) *MockManager_GetWithVersionContext_Call {
+	return &MockManager_GetWithVersionContext_Call{Call: _e.mock.On("GetWithVersionContext", ctx, key)}
+}
+
+func (_c *MockManager_GetWithVersionContext_Call) Run(run func(ctx context.Context, key string)) *MockManager_GetWithVersionContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_GetWithVersionContext_Call) Return(_a0 interface{}, _a1 string, _a2 bool) *MockManager_GetWithVersionContext_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockManager_GetWithVersionContext_Call) RunAndReturn(run func(context.Context, string) (interface{}, string, bool)) *MockManager_GetWithVersionContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Has provides a mock function with given fields: key
+func (_m *MockManager) Has(key string) bool {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Has")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockManager_Has_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Has'
+type MockManager_Has_Call struct {
+	*mock.Call
+}
+
+// Has is a helper method to define mock.On call
+//   - key string
+func (_e *MockManager_Expecter) Has(key interface{}) *MockManager_Has_Call {
+	return &MockManager_Has_Call{Call: _e.mock.On("Has", key)}
+}
+
+func (_c *MockManager_Has_Call) Run(run func(key string)) *MockManager_Has_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_Has_Call) Return(_a0 bool) *MockManager_Has_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Has_Call) RunAndReturn(run func(string) bool) *MockManager_Has_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasContext provides a mock function with given fields: ctx, key
+func (_m *MockManager) HasContext(ctx context.Context, key string) bool {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasContext")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockManager_HasContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasContext'
+type MockManager_HasContext_Call struct {
+	*mock.Call
+}
+
+// HasContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockManager_Expecter) HasContext(ctx interface{}, key interface{}) *MockManager_HasContext_Call {
+	return &MockManager_HasContext_Call{Call: _e.mock.On("HasContext", ctx, key)}
+}
+
+func (_c *MockManager_HasContext_Call) Run(run func(ctx context.Context, key string)) *MockManager_HasContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_HasContext_Call) Return(_a0 bool) *MockManager_HasContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_HasContext_Call) RunAndReturn(run func(context.Context, string) bool) *MockManager_HasContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Increment provides a mock function with given fields: key, delta, ttl
+func (_m *MockManager) Increment(key string, delta int64, ttl time.Duration) (int64, error) {
+	ret := _m.Called(key, delta, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Increment")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64, time.Duration) (int64, error)); ok {
+		return rf(key, delta, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64, time.Duration) int64); ok {
+		r0 = rf(key, delta, ttl)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64, time.Duration) error); ok {
+		r1 = rf(key, delta, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_Increment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Increment'
+type MockManager_Increment_Call struct {
+	*mock.Call
+}
+
+// Increment is a helper method to define mock.On call
+//   - key string
+//   - delta int64
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) Increment(key interface{}, delta interface{}, ttl interface{}) *MockManager_Increment_Call {
+	return &MockManager_Increment_Call{Call: _e.mock.On("Increment", key, delta, ttl)}
+}
+
+func (_c *MockManager_Increment_Call) Run(run func(key string, delta int64, ttl time.Duration)) *MockManager_Increment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int64), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_Increment_Call) Return(_a0 int64, _a1 error) *MockManager_Increment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_Increment_Call) RunAndReturn(run func(string, int64, time.Duration) (int64, error)) *MockManager_Increment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementContext provides a mock function with given fields: ctx, key, delta, ttl
+func (_m *MockManager) IncrementContext(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	ret := _m.Called(ctx, key, delta, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementContext")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) (int64, error)); ok {
+		return rf(ctx, key, delta, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) int64); ok {
+		r0 = rf(ctx, key, delta, ttl)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, time.Duration) error); ok {
+		r1 = rf(ctx, key, delta, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_IncrementContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementContext'
+type MockManager_IncrementContext_Call struct {
+	*mock.Call
+}
+
+// IncrementContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - delta int64
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) IncrementContext(ctx interface{}, key interface{}, delta interface{}, ttl interface{}) *MockManager_IncrementContext_Call {
+	return &MockManager_IncrementContext_Call{Call: _e.mock.On("IncrementContext", ctx, key, delta, ttl)}
+}
+
+func (_c *MockManager_IncrementContext_Call) Run(run func(ctx context.Context, key string, delta int64, ttl time.Duration)) *MockManager_IncrementContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_IncrementContext_Call) Return(_a0 int64, _a1 error) *MockManager_IncrementContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_IncrementContext_Call) RunAndReturn(run func(context.Context, string, int64, time.Duration) (int64, error)) *MockManager_IncrementContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementFloat provides a mock function with given fields: key, delta, ttl
+func (_m *MockManager) IncrementFloat(key string, delta float64, ttl time.Duration) (float64, error) {
+	ret := _m.Called(key, delta, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementFloat")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, float64, time.Duration) (float64, error)); ok {
+		return rf(key, delta, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(string, float64, time.Duration) float64); ok {
+		r0 = rf(key, delta, ttl)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, float64, time.Duration) error); ok {
+		r1 = rf(key, delta, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_IncrementFloat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementFloat'
+type MockManager_IncrementFloat_Call struct {
+	*mock.Call
+}
+
+// IncrementFloat is a helper method to define mock.On call
+//   - key string
+//   - delta float64
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) IncrementFloat(key interface{}, delta interface{}, ttl interface{}) *MockManager_IncrementFloat_Call {
+	return &MockManager_IncrementFloat_Call{Call: _e.mock.On("IncrementFloat", key, delta, ttl)}
+}
+
+func (_c *MockManager_IncrementFloat_Call) Run(run func(key string, delta float64, ttl time.Duration)) *MockManager_IncrementFloat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(float64), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_IncrementFloat_Call) Return(_a0 float64, _a1 error) *MockManager_IncrementFloat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_IncrementFloat_Call) RunAndReturn(run func(string, float64, time.Duration) (float64, error)) *MockManager_IncrementFloat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementFloatContext provides a mock function with given fields: ctx, key, delta, ttl
+func (_m *MockManager) IncrementFloatContext(ctx context.Context, key string, delta float64, ttl time.Duration) (float64, error) {
+	ret := _m.Called(ctx, key, delta, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementFloatContext")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, time.Duration) (float64, error)); ok {
+		return rf(ctx, key, delta, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, time.Duration) float64); ok {
+		r0 = rf(ctx, key, delta, ttl)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, float64, time.Duration) error); ok {
+		r1 = rf(ctx, key, delta, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_IncrementFloatContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementFloatContext'
+type MockManager_IncrementFloatContext_Call struct {
+	*mock.Call
+}
+
+// IncrementFloatContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - delta float64
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) IncrementFloatContext(ctx interface{}, key interface{}, delta interface{}, ttl interface{}) *MockManager_IncrementFloatContext_Call {
+	return &MockManager_IncrementFloatContext_Call{Call: _e.mock.On("IncrementFloatContext", ctx, key, delta, ttl)}
+}
+
+func (_c *MockManager_IncrementFloatContext_Call) Run(run func(ctx context.Context, key string, delta float64, ttl time.Duration)) *MockManager_IncrementFloatContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(float64), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_IncrementFloatContext_Call) Return(_a0 float64, _a1 error) *MockManager_IncrementFloatContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_IncrementFloatContext_Call) RunAndReturn(run func(context.Context, string, float64, time.Duration) (float64, error)) *MockManager_IncrementFloatContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Keys provides a mock function with given fields: pattern
+func (_m *MockManager) Keys(pattern string) ([]string, error) {
+	ret := _m.Called(pattern)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Keys")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return rf(pattern)
+	}
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(pattern)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pattern)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_Keys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Keys'
+type MockManager_Keys_Call struct {
+	*mock.Call
+}
+
+// Keys is a helper method to define mock.On call
+//   - pattern string
+func (_e *MockManager_Expecter) Keys(pattern interface{}) *MockManager_Keys_Call {
+	return &MockManager_Keys_Call{Call: _e.mock.On("Keys", pattern)}
+}
+
+func (_c *MockManager_Keys_Call) Run(run func(pattern string)) *MockManager_Keys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_Keys_Call) Return(_a0 []string, _a1 error) *MockManager_Keys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_Keys_Call) RunAndReturn(run func(string) ([]string, error)) *MockManager_Keys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// KeysContext provides a mock function with given fields: ctx, pattern
+func (_m *MockManager) KeysContext(ctx context.Context, pattern string) ([]string, error) {
+	ret := _m.Called(ctx, pattern)
+
+	if len(ret) == 0 {
+		panic("no return value specified for KeysContext")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, pattern)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, pattern)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pattern)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_KeysContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'KeysContext'
+type MockManager_KeysContext_Call struct {
+	*mock.Call
+}
+
+// KeysContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pattern string
+func (_e *MockManager_Expecter) KeysContext(ctx interface{}, pattern interface{}) *MockManager_KeysContext_Call {
+	return &MockManager_KeysContext_Call{Call: _e.mock.On("KeysContext", ctx, pattern)}
+}
+
+func (_c *MockManager_KeysContext_Call) Run(run func(ctx context.Context, pattern string)) *MockManager_KeysContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_KeysContext_Call) Return(_a0 []string, _a1 error) *MockManager_KeysContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_KeysContext_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *MockManager_KeysContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Lock provides a mock function with given fields: name, ttl
+func (_m *MockManager) Lock(name string, ttl time.Duration) (*cache.Lock, error) {
+	ret := _m.Called(name, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lock")
+	}
+
+	var r0 *cache.Lock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Duration) (*cache.Lock, error)); ok {
+		return rf(name, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Duration) *cache.Lock); ok {
+		r0 = rf(name, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*cache.Lock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Duration) error); ok {
+		r1 = rf(name, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_Lock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lock'
+type MockManager_Lock_Call struct {
+	*mock.Call
+}
+
+// Lock is a helper method to define mock.On call
+//   - name string
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) Lock(name interface{}, ttl interface{}) *MockManager_Lock_Call {
+	return &MockManager_Lock_Call{Call: _e.mock.On("Lock", name, ttl)}
+}
+
+func (_c *MockManager_Lock_Call) Run(run func(name string, ttl time.Duration)) *MockManager_Lock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_Lock_Call) Return(_a0 *cache.Lock, _a1 error) *MockManager_Lock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_Lock_Call) RunAndReturn(run func(string, time.Duration) (*cache.Lock, error)) *MockManager_Lock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remember provides a mock function with given fields: key, ttl, callback
+func (_m *MockManager) Remember(key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	ret := _m.Called(key, ttl, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remember")
+	}
+
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
+		return rf(key, ttl, callback)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Duration, func() (interface{}, error)) interface{}); ok {
+		r0 = rf(key, ttl, callback)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Duration, func() (interface{}, error)) error); ok {
+		r1 = rf(key, ttl, callback)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_Remember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remember'
+type MockManager_Remember_Call struct {
+	*mock.Call
+}
+
+// Remember is a helper method to define mock.On call
+//   - key string
+//   - ttl time.Duration
+//   - callback func()(interface{} , error)
+func (_e *MockManager_Expecter) Remember(key interface{}, ttl interface{}, callback interface{}) *MockManager_Remember_Call {
+	return &MockManager_Remember_Call{Call: _e.mock.On("Remember", key, ttl, callback)}
+}
+
+func (_c *MockManager_Remember_Call) Run(run func(key string, ttl time.Duration, callback func() (interface{}, error))) *MockManager_Remember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Duration), args[2].(func() (interface{}, error)))
+	})
+	return _c
+}
+
+func (_c *MockManager_Remember_Call) Return(_a0 interface{}, _a1 error) *MockManager_Remember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_Remember_Call) RunAndReturn(run func(string, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockManager_Remember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RememberContext provides a mock function with given fields: ctx, key, ttl, callback
+func (_m *MockManager) RememberContext(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	ret := _m.Called(ctx, key, ttl, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RememberContext")
+	}
+
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
+		return rf(ctx, key, ttl, callback)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, func() (interface{}, error)) interface{}); ok {
+		r0 = rf(ctx, key, ttl, callback)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, func() (interface{}, error)) error); ok {
+		r1 = rf(ctx, key, ttl, callback)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_RememberContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RememberContext'
+type MockManager_RememberContext_Call struct {
+	*mock.Call
+}
+
+// RememberContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - ttl time.Duration
+//   - callback func()(interface{} , error)
+func (_e *MockManager_Expecter) RememberContext(ctx interface{}, key interface{}, ttl interface{}, callback interface{}) *MockManager_RememberContext_Call {
+	return &MockManager_RememberContext_Call{Call: _e.mock.On("RememberContext", ctx, key, ttl, callback)}
+}
+
+func (_c *MockManager_RememberContext_Call) Run(run func(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error))) *MockManager_RememberContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(func() (interface{}, error)))
+	})
+	return _c
+}
+
+func (_c *MockManager_RememberContext_Call) Return(_a0 interface{}, _a1 error) *MockManager_RememberContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_RememberContext_Call) RunAndReturn(run func(context.Context, string, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockManager_RememberContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RememberStale provides a mock function with given fields: key, ttl, grace, callback
+func (_m *MockManager) RememberStale(key string, ttl time.Duration, grace time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	ret := _m.Called(key, ttl, grace, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RememberStale")
+	}
+
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Duration, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
+		return rf(key, ttl, grace, callback)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Duration, time.Duration, func() (interface{}, error)) interface{}); ok {
+		r0 = rf(key, ttl, grace, callback)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Duration, time.Duration, func() (interface{}, error)) error); ok {
+		r1 = rf(key, ttl, grace, callback)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_RememberStale_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RememberStale'
+type MockManager_RememberStale_Call struct {
+	*mock.Call
+}
+
+// RememberStale is a helper method to define mock.On call
+//   - key string
+//   - ttl time.Duration
+//   - grace time.Duration
+//   - callback func()(interface{} , error)
+func (_e *MockManager_Expecter) RememberStale(key interface{}, ttl interface{}, grace interface{}, callback interface{}) *MockManager_RememberStale_Call {
+	return &MockManager_RememberStale_Call{Call: _e.mock.On("RememberStale", key, ttl, grace, callback)}
+}
+
+func (_c *MockManager_RememberStale_Call) Run(run func(key string, ttl time.Duration, grace time.Duration, callback func() (interface{}, error))) *MockManager_RememberStale_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Duration), args[2].(time.Duration), args[3].(func() (interface{}, error)))
+	})
+	return _c
+}
+
+func (_c *MockManager_RememberStale_Call) Return(_a0 interface{}, _a1 error) *MockManager_RememberStale_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_RememberStale_Call) RunAndReturn(run func(string, time.Duration, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockManager_RememberStale_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RememberStaleContext provides a mock function with given fields: ctx, key, ttl, grace, callback
+func (_m *MockManager) RememberStaleContext(ctx context.Context, key string, ttl time.Duration, grace time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	ret := _m.Called(ctx, key, ttl, grace, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RememberStaleContext")
+	}
+
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
+		return rf(ctx, key, ttl, grace, callback)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, time.Duration, func() (interface{}, error)) interface{}); ok {
+		r0 = rf(ctx, key, ttl, grace, callback)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, time.Duration, func() (interface{}, error)) error); ok {
+		r1 = rf(ctx, key, ttl, grace, callback)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_RememberStaleContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RememberStaleContext'
+type MockManager_RememberStaleContext_Call struct {
+	*mock.Call
+}
+
+// RememberStaleContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - ttl time.Duration
+//   - grace time.Duration
+//   - callback func()(interface{} , error)
+func (_e *MockManager_Expecter) RememberStaleContext(ctx interface{}, key interface{}, ttl interface{}, grace interface{}, callback interface{}) *MockManager_RememberStaleContext_Call {
+	return &MockManager_RememberStaleContext_Call{Call: _e.mock.On("RememberStaleContext", ctx, key, ttl, grace, callback)}
+}
+
+func (_c *MockManager_RememberStaleContext_Call) Run(run func(ctx context.Context, key string, ttl time.Duration, grace time.Duration, callback func() (interface{}, error))) *MockManager_RememberStaleContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(time.Duration), args[4].(func() (interface{}, error)))
+	})
+	return _c
+}
+
+func (_c *MockManager_RememberStaleContext_Call) Return(_a0 interface{}, _a1 error) *MockManager_RememberStaleContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_RememberStaleContext_Call) RunAndReturn(run func(context.Context, string, time.Duration, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockManager_RememberStaleContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Replace provides a mock function with given fields: key, value, ttl
+func (_m *MockManager) Replace(key string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Replace")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(string, interface{}, time.Duration) bool); ok {
+		r0 = rf(key, value, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, interface{}, time.Duration) error); ok {
+		r1 = rf(key, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_Replace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Replace'
+type MockManager_Replace_Call struct {
+	*mock.Call
+}
+
+// Replace is a helper method to define mock.On call
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) Replace(key interface{}, value interface{}, ttl interface{}) *MockManager_Replace_Call {
+	return &MockManager_Replace_Call{Call: _e.mock.On("Replace", key, value, ttl)}
+}
+
+func (_c *MockManager_Replace_Call) Run(run func(key string, value interface{}, ttl time.Duration)) *MockManager_Replace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(interface{}), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_Replace_Call) Return(_a0 bool, _a1 error) *MockManager_Replace_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_Replace_Call) RunAndReturn(run func(string, interface{}, time.Duration) (bool, error)) *MockManager_Replace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceContext provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockManager) ReplaceContext(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceContext")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_ReplaceContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceContext'
+type MockManager_ReplaceContext_Call struct {
+	*mock.Call
+}
+
+// ReplaceContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) ReplaceContext(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockManager_ReplaceContext_Call {
+	return &MockManager_ReplaceContext_Call{Call: _e.mock.On("ReplaceContext", ctx, key, value, ttl)}
+}
+
+func (_c *MockManager_ReplaceContext_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *MockManager_ReplaceContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_ReplaceContext_Call) Return(_a0 bool, _a1 error) *MockManager_ReplaceContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_ReplaceContext_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) (bool, error)) *MockManager_ReplaceContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreLock provides a mock function with given fields: name, owner, ttl
+func (_m *MockManager) RestoreLock(name string, owner string, ttl time.Duration) (*cache.Lock, error) {
+	ret := _m.Called(name, owner, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreLock")
+	}
+
+	var r0 *cache.Lock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, time.Duration) (*cache.Lock, error)); ok {
+		return rf(name, owner, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, time.Duration) *cache.Lock); ok {
+		r0 = rf(name, owner, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*cache.Lock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, time.Duration) error); ok {
+		r1 = rf(name, owner, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_RestoreLock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreLock'
+type MockManager_RestoreLock_Call struct {
+	*mock.Call
+}
+
+// RestoreLock is a helper method to define mock.On call
+//   - name string
+//   - owner string
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) RestoreLock(name interface{}, owner interface{}, ttl interface{}) *MockManager_RestoreLock_Call {
+	return &MockManager_RestoreLock_Call{Call: _e.mock.On("RestoreLock", name, owner, ttl)}
+}
+
+func (_c *MockManager_RestoreLock_Call) Run(run func(name string, owner string, ttl time.Duration)) *MockManager_RestoreLock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_RestoreLock_Call) Return(_a0 *cache.Lock, _a1 error) *MockManager_RestoreLock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_RestoreLock_Call) RunAndReturn(run func(string, string, time.Duration) (*cache.Lock, error)) *MockManager_RestoreLock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Scan provides a mock function with given fields: pattern, fn
+func (_m *MockManager) Scan(pattern string, fn func(string) bool) error {
+	ret := _m.Called(pattern, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Scan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, func(string) bool) error); ok {
+		r0 = rf(pattern, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_Scan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Scan'
+type MockManager_Scan_Call struct {
+	*mock.Call
+}
+
+// Scan is a helper method to define mock.On call
+//   - pattern string
+//   - fn func(string) bool
+func (_e *MockManager_Expecter) Scan(pattern interface{}, fn interface{}) *MockManager_Scan_Call {
+	return &MockManager_Scan_Call{Call: _e.mock.On("Scan", pattern, fn)}
+}
+
+func (_c *MockManager_Scan_Call) Run(run func(pattern string, fn func(string) bool)) *MockManager_Scan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(func(string) bool))
+	})
+	return _c
+}
+
+func (_c *MockManager_Scan_Call) Return(_a0 error) *MockManager_Scan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Scan_Call) RunAndReturn(run func(string, func(string) bool) error) *MockManager_Scan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScanContext provides a mock function with given fields: ctx, pattern, fn
+func (_m *MockManager) ScanContext(ctx context.Context, pattern string, fn func(string) bool) error {
+	ret := _m.Called(ctx, pattern, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScanContext")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(string) bool) error); ok {
+		r0 = rf(ctx, pattern, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_ScanContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScanContext'
+type MockManager_ScanContext_Call struct {
+	*mock.Call
+}
+
+// ScanContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pattern string
+//   - fn func(string) bool
+func (_e *MockManager_Expecter) ScanContext(ctx interface{}, pattern interface{}, fn interface{}) *MockManager_ScanContext_Call {
+	return &MockManager_ScanContext_Call{Call: _e.mock.On("ScanContext", ctx, pattern, fn)}
+}
+
+func (_c *MockManager_ScanContext_Call) Run(run func(ctx context.Context, pattern string, fn func(string) bool)) *MockManager_ScanContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(func(string) bool))
+	})
+	return _c
+}
+
+func (_c *MockManager_ScanContext_Call) Return(_a0 error) *MockManager_ScanContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_ScanContext_Call) RunAndReturn(run func(context.Context, string, func(string) bool) error) *MockManager_ScanContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function with given fields: key, value, ttl
+func (_m *MockManager) Set(key string, value interface{}, ttl time.Duration) error {
+	ret := _m.Called(key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Set")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, interface{}, time.Duration) error); ok {
+		r0 = rf(key, value, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type MockManager_Set_Call struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) Set(key interface{}, value interface{}, ttl interface{}) *MockManager_Set_Call {
+	return &MockManager_Set_Call{Call: _e.mock.On("Set", key, value, ttl)}
+}
+
+func (_c *MockManager_Set_Call) Run(run func(key string, value interface{}, ttl time.Duration)) *MockManager_Set_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(interface{}), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_Set_Call) Return(_a0 error) *MockManager_Set_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Set_Call) RunAndReturn(run func(string, interface{}, time.Duration) error) *MockManager_Set_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetContext provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockManager) SetContext(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetContext")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_SetContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetContext'
+type MockManager_SetContext_Call struct {
+	*mock.Call
+}
+
+// SetContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) SetContext(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockManager_SetContext_Call {
+	return &MockManager_SetContext_Call{Call: _e.mock.On("SetContext", ctx, key, value, ttl)}
+}
+
+func (_c *MockManager_SetContext_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *MockManager_SetContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_SetContext_Call) Return(_a0 error) *MockManager_SetContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_SetContext_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) error) *MockManager_SetContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDefaultDriver provides a mock function with given fields: name
+func (_m *MockManager) SetDefaultDriver(name string) {
+	_m.Called(name)
+}
+
+// MockManager_SetDefaultDriver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDefaultDriver'
+type MockManager_SetDefaultDriver_Call struct {
+	*mock.Call
+}
+
+// SetDefaultDriver is a helper method to define mock.On call
+//   - name string
+func (_e *MockManager_Expecter) SetDefaultDriver(name interface{}) *MockManager_SetDefaultDriver_Call {
+	return &MockManager_SetDefaultDriver_Call{Call: _e.mock.On("SetDefaultDriver", name)}
+}
+
+func (_c *MockManager_SetDefaultDriver_Call) Run(run func(name string)) *MockManager_SetDefaultDriver_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_SetDefaultDriver_Call) Return() *MockManager_SetDefaultDriver_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockManager_SetDefaultDriver_Call) RunAndReturn(run func(string)) *MockManager_SetDefaultDriver_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetMultiple provides a mock function with given fields: values, ttl
+func (_m *MockManager) SetMultiple(values map[string]interface{}, ttl time.Duration) error {
+	ret := _m.Called(values, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMultiple")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, time.Duration) error); ok {
+		r0 = rf(values, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_SetMultiple_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMultiple'
+type MockManager_SetMultiple_Call struct {
+	*mock.Call
+}
+
+// SetMultiple is a helper method to define mock.On call
+//   - values map[string]interface{}
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) SetMultiple(values interface{}, ttl interface{}) *MockManager_SetMultiple_Call {
+	return &MockManager_SetMultiple_Call{Call: _e.mock.On("SetMultiple", values, ttl)}
+}
+
+func (_c *MockManager_SetMultiple_Call) Run(run func(values map[string]interface{}, ttl time.Duration)) *MockManager_SetMultiple_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(map[string]interface{}), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_SetMultiple_Call) Return(_a0 error) *MockManager_SetMultiple_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_SetMultiple_Call) RunAndReturn(run func(map[string]interface{}, time.Duration) error) *MockManager_SetMultiple_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMultipleContext provides a mock function with given fields: ctx, values, ttl
+func (_m *MockManager) SetMultipleContext(ctx context.Context, values map[string]interface{}, ttl time.Duration) error {
+	ret := _m.Called(ctx, values, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMultipleContext")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}, time.Duration) error); ok {
+		r0 = rf(ctx, values, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_SetMultipleContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMultipleContext'
+type MockManager_SetMultipleContext_Call struct {
+	*mock.Call
+}
+
+// SetMultipleContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - values map[string]interface{}
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) SetMultipleContext(ctx interface{}, values interface{}, ttl interface{}) *MockManager_SetMultipleContext_Call {
+	return &MockManager_SetMultipleContext_Call{Call: _e.mock.On("SetMultipleContext", ctx, values, ttl)}
+}
+
+func (_c *MockManager_SetMultipleContext_Call) Run(run func(ctx context.Context, values map[string]interface{}, ttl time.Duration)) *MockManager_SetMultipleContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(map[string]interface{}), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_SetMultipleContext_Call) Return(_a0 error) *MockManager_SetMultipleContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_SetMultipleContext_Call) RunAndReturn(run func(context.Context, map[string]interface{}, time.Duration) error) *MockManager_SetMultipleContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stats provides a mock function with no fields
+func (_m *MockManager) Stats() map[string]map[string]interface{} {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stats")
+	}
+
+	var r0 map[string]map[string]interface{}
+	if rf, ok := ret.Get(0).(func() map[string]map[string]interface{}); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]map[string]interface{})
+		}
+	}
+
+	return r0
+}
+
+// MockManager_Stats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stats'
+type MockManager_Stats_Call struct {
+	*mock.Call
+}
+
+// Stats is a helper method to define mock.On call
+func (_e *MockManager_Expecter) Stats() *MockManager_Stats_Call {
+	return &MockManager_Stats_Call{Call: _e.mock.On("Stats")}
+}
+
+func (_c *MockManager_Stats_Call) Run(run func()) *MockManager_Stats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockManager_Stats_Call) Return(_a0 map[string]map[string]interface{}) *MockManager_Stats_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Stats_Call) RunAndReturn(run func() map[string]map[string]interface{}) *MockManager_Stats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StatsContext provides a mock function with given fields: ctx
+func (_m *MockManager) StatsContext(ctx context.Context) map[string]map[string]interface{} {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StatsContext")
+	}
+
+	var r0 map[string]map[string]interface{}
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]map[string]interface{}); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]map[string]interface{})
+		}
+	}
+
+	return r0
+}
+
+// MockManager_StatsContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StatsContext'
+type MockManager_StatsContext_Call struct {
+	*mock.Call
+}
+
+// StatsContext is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockManager_Expecter) StatsContext(ctx interface{}) *MockManager_StatsContext_Call {
+	return &MockManager_StatsContext_Call{Call: _e.mock.On("StatsContext", ctx)}
+}
+
+func (_c *MockManager_StatsContext_Call) Run(run func(ctx context.Context)) *MockManager_StatsContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockManager_StatsContext_Call) Return(_a0 map[string]map[string]interface{}) *MockManager_StatsContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_StatsContext_Call) RunAndReturn(run func(context.Context) map[string]map[string]interface{}) *MockManager_StatsContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TTL provides a mock function with given fields: key
+func (_m *MockManager) TTL(key string) (time.Duration, bool) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TTL")
+	}
+
+	var r0 time.Duration
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(string) (time.Duration, bool)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) time.Duration); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockManager_TTL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TTL'
+type MockManager_TTL_Call struct {
+	*mock.Call
+}
+
+// TTL is a helper method to define mock.On call
+//   - key string
+func (_e *MockManager_Expecter) TTL(key interface{}) *MockManager_TTL_Call {
+	return &MockManager_TTL_Call{Call: _e.mock.On("TTL", key)}
+}
+
+func (_c *MockManager_TTL_Call) Run(run func(key string)) *MockManager_TTL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_TTL_Call) Return(_a0 time.Duration, _a1 bool) *MockManager_TTL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_TTL_Call) RunAndReturn(run func(string) (time.Duration, bool)) *MockManager_TTL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TTLContext provides a mock function with given fields: ctx, key
+func (_m *MockManager) TTLContext(ctx context.Context, key string) (time.Duration, bool) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TTLContext")
+	}
+
+	var r0 time.Duration
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) (time.Duration, bool)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) time.Duration); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockManager_TTLContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TTLContext'
+type MockManager_TTLContext_Call struct {
+	*mock.Call
+}
+
+// TTLContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockManager_Expecter) TTLContext(ctx interface{}, key interface{}) *MockManager_TTLContext_Call {
+	return &MockManager_TTLContext_Call{Call: _e.mock.On("TTLContext", ctx, key)}
+}
+
+func (_c *MockManager_TTLContext_Call) Run(run func(ctx context.Context, key string)) *MockManager_TTLContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_TTLContext_Call) Return(_a0 time.Duration, _a1 bool) *MockManager_TTLContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_TTLContext_Call) RunAndReturn(run func(context.Context, string) (time.Duration, bool)) *MockManager_TTLContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Tags provides a mock function with given fields: names
+func (_m *MockManager) Tags(names ...string) cache.TaggedCache {
+	_va := make([]interface{}, len(names))
+	for _i := range names {
+		_va[_i] = names[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Tags")
+	}
+
+	var r0 cache.TaggedCache
+	if rf, ok := ret.Get(0).(func(...string) cache.TaggedCache); ok {
+		r0 = rf(names...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cache.TaggedCache)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_Tags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Tags'
+type MockManager_Tags_Call struct {
+	*mock.Call
+}
+
+// Tags is a helper method to define mock.On call
+//   - names ...string
+func (_e *MockManager_Expecter) Tags(names ...interface{}) *MockManager_Tags_Call {
+	return &MockManager_Tags_Call{Call: _e.mock.On("Tags",
+		append([]interface{}{}, names...)...)}
+}
+
+func (_c *MockManager_Tags_Call) Run(run func(names ...string)) *MockManager_Tags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockManager_Tags_Call) Return(_a0 cache.TaggedCache) *MockManager_Tags_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Tags_Call) RunAndReturn(run func(...string) cache.TaggedCache) *MockManager_Tags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Touch provides a mock function with given fields: key, ttl
+func (_m *MockManager) Touch(key string, ttl time.Duration) (bool, error) {
+	ret := _m.Called(key, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Touch")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Duration) (bool, error)); ok {
+		return rf(key, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Duration) bool); ok {
+		r0 = rf(key, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Duration) error); ok {
+		r1 = rf(key, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_Touch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Touch'
+type MockManager_Touch_Call struct {
+	*mock.Call
+}
+
+// Touch is a helper method to define mock.On call
+//   - key string
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) Touch(key interface{}, ttl interface{}) *MockManager_Touch_Call {
+	return &MockManager_Touch_Call{Call: _e.mock.On("Touch", key, ttl)}
+}
+
+func (_c *MockManager_Touch_Call) Run(run func(key string, ttl time.Duration)) *MockManager_Touch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_Touch_Call) Return(_a0 bool, _a1 error) *MockManager_Touch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_Touch_Call) RunAndReturn(run func(string, time.Duration) (bool, error)) *MockManager_Touch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchContext provides a mock function with given fields: ctx, key, ttl
+func (_m *MockManager) TouchContext(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchContext")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) bool); ok {
+		r0 = rf(ctx, key, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_TouchContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchContext'
+type MockManager_TouchContext_Call struct {
+	*mock.Call
+}
+
+// TouchContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - ttl time.Duration
+func (_e *MockManager_Expecter) TouchContext(ctx interface{}, key interface{}, ttl interface{}) *MockManager_TouchContext_Call {
+	return &MockManager_TouchContext_Call{Call: _e.mock.On("TouchContext", ctx, key, ttl)}
+}
+
+func (_c *MockManager_TouchContext_Call) Run(run func(ctx context.Context, key string, ttl time.Duration)) *MockManager_TouchContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockManager_TouchContext_Call) Return(_a0 bool, _a1 error) *MockManager_TouchContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_TouchContext_Call) RunAndReturn(run func(context.Context, string, time.Duration) (bool, error)) *MockManager_TouchContext_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -23,6 +23,65 @@ func (_m *MockMemoryDriver) EXPECT() *MockMemoryDriver_Expecter {
 	return &MockMemoryDriver_Expecter{mock: &_m.Mock}
 }
 
+// Add provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockMemoryDriver) Add(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMemoryDriver_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type MockMemoryDriver_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockMemoryDriver_Expecter) Add(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockMemoryDriver_Add_Call {
+	return &MockMemoryDriver_Add_Call{Call: _e.mock.On("Add", ctx, key, value, ttl)}
+}
+
+func (_c *MockMemoryDriver_Add_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *MockMemoryDriver_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockMemoryDriver_Add_Call) Return(_a0 bool, _a1 error) *MockMemoryDriver_Add_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMemoryDriver_Add_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) (bool, error)) *MockMemoryDriver_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Close provides a mock function with no fields
 func (_m *MockMemoryDriver) Close() error {
 	ret := _m.Called()
@@ -68,6 +127,125 @@ func (_c *MockMemoryDriver_Close_Call) RunAndReturn(run func() error) *MockMemor
 	return _c
 }
 
+// CompareAndSwap provides a mock function with given fields: ctx, key, token, value, ttl
+func (_m *MockMemoryDriver) CompareAndSwap(ctx context.Context, key string, token string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, token, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompareAndSwap")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, token, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, token, value, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, token, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMemoryDriver_CompareAndSwap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareAndSwap'
+type MockMemoryDriver_CompareAndSwap_Call struct {
+	*mock.Call
+}
+
+// CompareAndSwap is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - token string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockMemoryDriver_Expecter) CompareAndSwap(ctx interface{}, key interface{}, token interface{}, value interface{}, ttl interface{}) *MockMemoryDriver_CompareAndSwap_Call {
+	return &MockMemoryDriver_CompareAndSwap_Call{Call: _e.mock.On("CompareAndSwap", ctx, key, token, value, ttl)}
+}
+
+func (_c *MockMemoryDriver_CompareAndSwap_Call) Run(run func(ctx context.Context, key string, token string, value interface{}, ttl time.Duration)) *MockMemoryDriver_CompareAndSwap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(interface{}), args[4].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockMemoryDriver_CompareAndSwap_Call) Return(_a0 bool, _a1 error) *MockMemoryDriver_CompareAndSwap_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMemoryDriver_CompareAndSwap_Call) RunAndReturn(run func(context.Context, string, string, interface{}, time.Duration) (bool, error)) *MockMemoryDriver_CompareAndSwap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Decrement provides a mock function with given fields: ctx, key, delta, ttl
+func (_m *MockMemoryDriver) Decrement(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	ret := _m.Called(ctx, key, delta, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Decrement")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) (int64, error)); ok {
+		return rf(ctx, key, delta, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) int64); ok {
+		r0 = rf(ctx, key, delta, ttl)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, time.Duration) error); ok {
+		r1 = rf(ctx, key, delta, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMemoryDriver_Decrement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Decrement'
+type MockMemoryDriver_Decrement_Call struct {
+	*mock.Call
+}
+
+// Decrement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - delta int64
+//   - ttl time.Duration
+func (_e *MockMemoryDriver_Expecter) Decrement(ctx interface{}, key interface{}, delta interface{}, ttl interface{}) *MockMemoryDriver_Decrement_Call {
+	return &MockMemoryDriver_Decrement_Call{Call: _e.mock.On("Decrement", ctx, key, delta, ttl)}
+}
+
+func (_c *MockMemoryDriver_Decrement_Call) Run(run func(ctx context.Context, key string, delta int64, ttl time.Duration)) *MockMemoryDriver_Decrement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockMemoryDriver_Decrement_Call) Return(_a0 int64, _a1 error) *MockMemoryDriver_Decrement_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMemoryDriver_Decrement_Call) RunAndReturn(run func(context.Context, string, int64, time.Duration) (int64, error)) *MockMemoryDriver_Decrement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function with given fields: ctx, key
 func (_m *MockMemoryDriver) Delete(ctx context.Context, key string) error {
 	ret := _m.Called(ctx, key)
@@ -115,6 +293,120 @@ func (_c *MockMemoryDriver_Delete_Call) RunAndReturn(run func(context.Context, s
 	return _c
 }
 
+// DeleteByPrefix provides a mock function with given fields: ctx, prefix
+func (_m *MockMemoryDriver) DeleteByPrefix(ctx context.Context, prefix string) (int, error) {
+	ret := _m.Called(ctx, prefix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByPrefix")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, prefix)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, prefix)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMemoryDriver_DeleteByPrefix_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByPrefix'
+type MockMemoryDriver_DeleteByPrefix_Call struct {
+	*mock.Call
+}
+
+// DeleteByPrefix is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prefix string
+func (_e *MockMemoryDriver_Expecter) DeleteByPrefix(ctx interface{}, prefix interface{}) *MockMemoryDriver_DeleteByPrefix_Call {
+	return &MockMemoryDriver_DeleteByPrefix_Call{Call: _e.mock.On("DeleteByPrefix", ctx, prefix)}
+}
+
+func (_c *MockMemoryDriver_DeleteByPrefix_Call) Run(run func(ctx context.Context, prefix string)) *MockMemoryDriver_DeleteByPrefix_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockMemoryDriver_DeleteByPrefix_Call) Return(_a0 int, _a1 error) *MockMemoryDriver_DeleteByPrefix_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMemoryDriver_DeleteByPrefix_Call) RunAndReturn(run func(context.Context, string) (int, error)) *MockMemoryDriver_DeleteByPrefix_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMatching provides a mock function with given fields: ctx, pattern
+func (_m *MockMemoryDriver) DeleteMatching(ctx context.Context, pattern string) (int, error) {
+	ret := _m.Called(ctx, pattern)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMatching")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, pattern)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, pattern)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pattern)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMemoryDriver_DeleteMatching_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMatching'
+type MockMemoryDriver_DeleteMatching_Call struct {
+	*mock.Call
+}
+
+// DeleteMatching is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pattern string
+func (_e *MockMemoryDriver_Expecter) DeleteMatching(ctx interface{}, pattern interface{}) *MockMemoryDriver_DeleteMatching_Call {
+	return &MockMemoryDriver_DeleteMatching_Call{Call: _e.mock.On("DeleteMatching", ctx, pattern)}
+}
+
+func (_c *MockMemoryDriver_DeleteMatching_Call) Run(run func(ctx context.Context, pattern string)) *MockMemoryDriver_DeleteMatching_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockMemoryDriver_DeleteMatching_Call) Return(_a0 int, _a1 error) *MockMemoryDriver_DeleteMatching_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMemoryDriver_DeleteMatching_Call) RunAndReturn(run func(context.Context, string) (int, error)) *MockMemoryDriver_DeleteMatching_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteMultiple provides a mock function with given fields: ctx, keys
 func (_m *MockMemoryDriver) DeleteMultiple(ctx context.Context, keys []string) error {
 	ret := _m.Called(ctx, keys)
@@ -208,230 +500,823 @@ func (_c *MockMemoryDriver_Flush_Call) RunAndReturn(run func(context.Context) er
 	return _c
 }
 
+// FlushTags provides a mock function with given fields: ctx, tags
+func (_m *MockMemoryDriver) FlushTags(ctx context.Context, tags []string) error {
+	ret := _m.Called(ctx, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlushTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMemoryDriver_FlushTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlushTags'
+type MockMemoryDriver_FlushTags_Call struct {
+	*mock.Call
+}
+
+// FlushTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tags []string
+func (_e *MockMemoryDriver_Expecter) FlushTags(ctx interface{}, tags interface{}) *MockMemoryDriver_FlushTags_Call {
+	return &MockMemoryDriver_FlushTags_Call{Call: _e.mock.On("FlushTags", ctx, tags)}
+}
+
+func (_c *MockMemoryDriver_FlushTags_Call) Run(run func(ctx context.Context, tags []string)) *MockMemoryDriver_FlushTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockMemoryDriver_FlushTags_Call) Return(_a0 error) *MockMemoryDriver_FlushTags_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMemoryDriver_FlushTags_Call) RunAndReturn(run func(context.Context, []string) error) *MockMemoryDriver_FlushTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Get provides a mock function with given fields: ctx, key
 func (_m *MockMemoryDriver) Get(ctx context.Context, key string) (interface{}, bool) {
 	ret := _m.Called(ctx, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Get")
+		panic("no return value specified for Get")
+	}
+
+	var r0 interface{}
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) (interface{}, bool)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) interface{}); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockMemoryDriver_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockMemoryDriver_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockMemoryDriver_Expecter) Get(ctx interface{}, key interface{}) *MockMemoryDriver_Get_Call {
+	return &MockMemoryDriver_Get_Call{Call: _e.mock.On("Get", ctx, key)}
+}
+
+func (_c *MockMemoryDriver_Get_Call) Run(run func(ctx context.Context, key string)) *MockMemoryDriver_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockMemoryDriver_Get_Call) Return(_a0 interface{}, _a1 bool) *MockMemoryDriver_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMemoryDriver_Get_Call) RunAndReturn(run func(context.Context, string) (interface{}, bool)) *MockMemoryDriver_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAndDelete provides a mock function with given fields: ctx, key
+func (_m *MockMemoryDriver) GetAndDelete(ctx context.Context, key string) (interface{}, bool, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAndDelete")
+	}
+
+	var r0 interface{}
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (interface{}, bool, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) interface{}); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, key)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockMemoryDriver_GetAndDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAndDelete'
+type MockMemoryDriver_GetAndDelete_Call struct {
+	*mock.Call
+}
+
+// GetAndDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockMemoryDriver_Expecter) GetAndDelete(ctx interface{}, key interface{}) *MockMemoryDriver_GetAndDelete_Call {
+	return &MockMemoryDriver_GetAndDelete_Call{Call: _e.mock.On("GetAndDelete", ctx, key)}
+}
+
+func (_c *MockMemoryDriver_GetAndDelete_Call) Run(run func(ctx context.Context, key string)) *MockMemoryDriver_GetAndDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockMemoryDriver_GetAndDelete_Call) Return(_a0 interface{}, _a1 bool, _a2 error) *MockMemoryDriver_GetAndDelete_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockMemoryDriver_GetAndDelete_Call) RunAndReturn(run func(context.Context, string) (interface{}, bool, error)) *MockMemoryDriver_GetAndDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAndSet provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockMemoryDriver) GetAndSet(ctx context.Context, key string, value interface{}, ttl time.Duration) (interface{}, bool, error) {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAndSet")
+	}
+
+	var r0 interface{}
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (interface{}, bool, error)); ok {
+		return rf(ctx, key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) interface{}); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r1 = rf(ctx, key, value, ttl)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r2 = rf(ctx, key, value, ttl)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockMemoryDriver_GetAndSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAndSet'
+type MockMemoryDriver_GetAndSet_Call struct {
+	*mock.Call
+}
+
+// GetAndSet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockMemoryDriver_Expecter) GetAndSet(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockMemoryDriver_GetAndSet_Call {
+	return &MockMemoryDriver_GetAndSet_Call{Call: _e.mock.On("GetAndSet", ctx, key, value, ttl)}
+}
+
+func (_c *MockMemoryDriver_GetAndSet_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *MockMemoryDriver_GetAndSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockMemoryDriver_GetAndSet_Call) Return(_a0 interface{}, _a1 bool, _a2 error) *MockMemoryDriver_GetAndSet_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockMemoryDriver_GetAndSet_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) (interface{}, bool, error)) *MockMemoryDriver_GetAndSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMultiple provides a mock function with given fields: ctx, keys
+func (_m *MockMemoryDriver) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, []string) {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMultiple")
+	}
+
+	var r0 map[string]interface{}
+	var r1 []string
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]interface{}, []string)); ok {
+		return rf(ctx, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]interface{}); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) []string); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	return r0, r1
+}
+
+// MockMemoryDriver_GetMultiple_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMultiple'
+type MockMemoryDriver_GetMultiple_Call struct {
+	*mock.Call
+}
+
+// GetMultiple is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []string
+func (_e *MockMemoryDriver_Expecter) GetMultiple(ctx interface{}, keys interface{}) *MockMemoryDriver_GetMultiple_Call {
+	return &MockMemoryDriver_GetMultiple_Call{Call: _e.mock.On("GetMultiple", ctx, keys)}
+}
+
+func (_c *MockMemoryDriver_GetMultiple_Call) Run(run func(ctx context.Context, keys []string)) *MockMemoryDriver_GetMultiple_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockMemoryDriver_GetMultiple_Call) Return(_a0 map[string]interface{}, _a1 []string) *MockMemoryDriver_GetMultiple_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMemoryDriver_GetMultiple_Call) RunAndReturn(run func(context.Context, []string) (map[string]interface{}, []string)) *MockMemoryDriver_GetMultiple_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWithVersion provides a mock function with given fields: ctx, key
+func (_m *MockMemoryDriver) GetWithVersion(ctx context.Context, key string) (interface{}, string, bool) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWithVersion")
+	}
+
+	var r0 interface{}
+	var r1 string
+	var r2 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) (interface{}, string, bool)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) interface{}); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) bool); ok {
+		r2 = rf(ctx, key)
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+
+	return r0, r1, r2
+}
+
+// MockMemoryDriver_GetWithVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWithVersion'
+type MockMemoryDriver_GetWithVersion_Call struct {
+	*mock.Call
+}
+
+// GetWithVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockMemoryDriver_Expecter) GetWithVersion(ctx interface{}, key interface{}) *MockMemoryDriver_GetWithVersion_Call {
+	return &MockMemoryDriver_GetWithVersion_Call{Call: _e.mock.On("GetWithVersion", ctx, key)}
+}
+
+func (_c *MockMemoryDriver_GetWithVersion_Call) Run(run func(ctx context.Context, key string)) *MockMemoryDriver_GetWithVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockMemoryDriver_GetWithVersion_Call) Return(_a0 interface{}, _a1 string, _a2 bool) *MockMemoryDriver_GetWithVersion_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockMemoryDriver_GetWithVersion_Call) RunAndReturn(run func(context.Context, string) (interface{}, string, bool)) *MockMemoryDriver_GetWithVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Has provides a mock function with given fields: ctx, key
+func (_m *MockMemoryDriver) Has(ctx context.Context, key string) bool {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Has")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockMemoryDriver_Has_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Has'
+type MockMemoryDriver_Has_Call struct {
+	*mock.Call
+}
+
+// Has is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockMemoryDriver_Expecter) Has(ctx interface{}, key interface{}) *MockMemoryDriver_Has_Call {
+	return &MockMemoryDriver_Has_Call{Call: _e.mock.On("Has", ctx, key)}
+}
+
+func (_c *MockMemoryDriver_Has_Call) Run(run func(ctx context.Context, key string)) *MockMemoryDriver_Has_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockMemoryDriver_Has_Call) Return(_a0 bool) *MockMemoryDriver_Has_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMemoryDriver_Has_Call) RunAndReturn(run func(context.Context, string) bool) *MockMemoryDriver_Has_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Increment provides a mock function with given fields: ctx, key, delta, ttl
+func (_m *MockMemoryDriver) Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	ret := _m.Called(ctx, key, delta, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Increment")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) (int64, error)); ok {
+		return rf(ctx, key, delta, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) int64); ok {
+		r0 = rf(ctx, key, delta, ttl)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, time.Duration) error); ok {
+		r1 = rf(ctx, key, delta, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMemoryDriver_Increment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Increment'
+type MockMemoryDriver_Increment_Call struct {
+	*mock.Call
+}
+
+// Increment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - delta int64
+//   - ttl time.Duration
+func (_e *MockMemoryDriver_Expecter) Increment(ctx interface{}, key interface{}, delta interface{}, ttl interface{}) *MockMemoryDriver_Increment_Call {
+	return &MockMemoryDriver_Increment_Call{Call: _e.mock.On("Increment", ctx, key, delta, ttl)}
+}
+
+func (_c *MockMemoryDriver_Increment_Call) Run(run func(ctx context.Context, key string, delta int64, ttl time.Duration)) *MockMemoryDriver_Increment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockMemoryDriver_Increment_Call) Return(_a0 int64, _a1 error) *MockMemoryDriver_Increment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMemoryDriver_Increment_Call) RunAndReturn(run func(context.Context, string, int64, time.Duration) (int64, error)) *MockMemoryDriver_Increment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementFloat provides a mock function with given fields: ctx, key, delta, ttl
+func (_m *MockMemoryDriver) IncrementFloat(ctx context.Context, key string, delta float64, ttl time.Duration) (float64, error) {
+	ret := _m.Called(ctx, key, delta, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementFloat")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, time.Duration) (float64, error)); ok {
+		return rf(ctx, key, delta, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, time.Duration) float64); ok {
+		r0 = rf(ctx, key, delta, ttl)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, float64, time.Duration) error); ok {
+		r1 = rf(ctx, key, delta, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMemoryDriver_IncrementFloat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementFloat'
+type MockMemoryDriver_IncrementFloat_Call struct {
+	*mock.Call
+}
+
+// IncrementFloat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - delta float64
+//   - ttl time.Duration
+func (_e *MockMemoryDriver_Expecter) IncrementFloat(ctx interface{}, key interface{}, delta interface{}, ttl interface{}) *MockMemoryDriver_IncrementFloat_Call {
+	return &MockMemoryDriver_IncrementFloat_Call{Call: _e.mock.On("IncrementFloat", ctx, key, delta, ttl)}
+}
+
+func (_c *MockMemoryDriver_IncrementFloat_Call) Run(run func(ctx context.Context, key string, delta float64, ttl time.Duration)) *MockMemoryDriver_IncrementFloat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(float64), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockMemoryDriver_IncrementFloat_Call) Return(_a0 float64, _a1 error) *MockMemoryDriver_IncrementFloat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMemoryDriver_IncrementFloat_Call) RunAndReturn(run func(context.Context, string, float64, time.Duration) (float64, error)) *MockMemoryDriver_IncrementFloat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Keys provides a mock function with given fields: ctx, pattern
+func (_m *MockMemoryDriver) Keys(ctx context.Context, pattern string) ([]string, error) {
+	ret := _m.Called(ctx, pattern)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Keys")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, pattern)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, pattern)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pattern)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMemoryDriver_Keys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Keys'
+type MockMemoryDriver_Keys_Call struct {
+	*mock.Call
+}
+
+// Keys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pattern string
+func (_e *MockMemoryDriver_Expecter) Keys(ctx interface{}, pattern interface{}) *MockMemoryDriver_Keys_Call {
+	return &MockMemoryDriver_Keys_Call{Call: _e.mock.On("Keys", ctx, pattern)}
+}
+
+func (_c *MockMemoryDriver_Keys_Call) Run(run func(ctx context.Context, pattern string)) *MockMemoryDriver_Keys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockMemoryDriver_Keys_Call) Return(_a0 []string, _a1 error) *MockMemoryDriver_Keys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMemoryDriver_Keys_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *MockMemoryDriver_Keys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remember provides a mock function with given fields: ctx, key, ttl, callback
+func (_m *MockMemoryDriver) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	ret := _m.Called(ctx, key, ttl, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remember")
 	}
 
 	var r0 interface{}
-	var r1 bool
-	if rf, ok := ret.Get(0).(func(context.Context, string) (interface{}, bool)); ok {
-		return rf(ctx, key)
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
+		return rf(ctx, key, ttl, callback)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) interface{}); ok {
-		r0 = rf(ctx, key)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, func() (interface{}, error)) interface{}); ok {
+		r0 = rf(ctx, key, ttl, callback)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(interface{})
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
-		r1 = rf(ctx, key)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, func() (interface{}, error)) error); ok {
+		r1 = rf(ctx, key, ttl, callback)
 	} else {
-		r1 = ret.Get(1).(bool)
+		r1 = ret.Error(1)
 	}
 
 	return r0, r1
 }
 
-// MockMemoryDriver_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockMemoryDriver_Get_Call struct {
+// MockMemoryDriver_Remember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remember'
+type MockMemoryDriver_Remember_Call struct {
 	*mock.Call
 }
 
-// Get is a helper method to define mock.On call
+// Remember is a helper method to define mock.On call
 //   - ctx context.Context
 //   - key string
-func (_e *MockMemoryDriver_Expecter) Get(ctx interface{}, key interface{}) *MockMemoryDriver_Get_Call {
-	return &MockMemoryDriver_Get_Call{Call: _e.mock.On("Get", ctx, key)}
+//   - ttl time.Duration
+//   - callback func()(interface{} , error)
+func (_e *MockMemoryDriver_Expecter) Remember(ctx interface{}, key interface{}, ttl interface{}, callback interface{}) *MockMemoryDriver_Remember_Call {
+	return &MockMemoryDriver_Remember_Call{Call: _e.mock.On("Remember", ctx, key, ttl, callback)}
 }
 
-func (_c *MockMemoryDriver_Get_Call) Run(run func(ctx context.Context, key string)) *MockMemoryDriver_Get_Call {
+func (_c *MockMemoryDriver_Remember_Call) Run(run func(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error))) *MockMemoryDriver_Remember_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(func() (interface{}, error)))
 	})
 	return _c
 }
 
-func (_c *MockMemoryDriver_Get_Call) Return(_a0 interface{}, _a1 bool) *MockMemoryDriver_Get_Call {
+func (_c *MockMemoryDriver_Remember_Call) Return(_a0 interface{}, _a1 error) *MockMemoryDriver_Remember_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockMemoryDriver_Get_Call) RunAndReturn(run func(context.Context, string) (interface{}, bool)) *MockMemoryDriver_Get_Call {
+func (_c *MockMemoryDriver_Remember_Call) RunAndReturn(run func(context.Context, string, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockMemoryDriver_Remember_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMultiple provides a mock function with given fields: ctx, keys
-func (_m *MockMemoryDriver) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, []string) {
-	ret := _m.Called(ctx, keys)
+// RememberStale provides a mock function with given fields: ctx, key, ttl, grace, callback
+func (_m *MockMemoryDriver) RememberStale(ctx context.Context, key string, ttl time.Duration, grace time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	ret := _m.Called(ctx, key, ttl, grace, callback)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMultiple")
+		panic("no return value specified for RememberStale")
 	}
 
-	var r0 map[string]interface{}
-	var r1 []string
-	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]interface{}, []string)); ok {
-		return rf(ctx, keys)
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
+		return rf(ctx, key, ttl, grace, callback)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]interface{}); ok {
-		r0 = rf(ctx, keys)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, time.Duration, func() (interface{}, error)) interface{}); ok {
+		r0 = rf(ctx, key, ttl, grace, callback)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]interface{})
+			r0 = ret.Get(0).(interface{})
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []string) []string); ok {
-		r1 = rf(ctx, keys)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, time.Duration, func() (interface{}, error)) error); ok {
+		r1 = rf(ctx, key, ttl, grace, callback)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).([]string)
-		}
+		r1 = ret.Error(1)
 	}
 
 	return r0, r1
 }
 
-// MockMemoryDriver_GetMultiple_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMultiple'
-type MockMemoryDriver_GetMultiple_Call struct {
+// MockMemoryDriver_RememberStale_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RememberStale'
+type MockMemoryDriver_RememberStale_Call struct {
 	*mock.Call
 }
 
-// GetMultiple is a helper method to define mock.On call
+// RememberStale is a helper method to define mock.On call
 //   - ctx context.Context
-//   - keys []string
-func (_e *MockMemoryDriver_Expecter) GetMultiple(ctx interface{}, keys interface{}) *MockMemoryDriver_GetMultiple_Call {
-	return &MockMemoryDriver_GetMultiple_Call{Call: _e.mock.On("GetMultiple", ctx, keys)}
+//   - key string
+//   - ttl time.Duration
+//   - grace time.Duration
+//   - callback func()(interface{} , error)
+func (_e *MockMemoryDriver_Expecter) RememberStale(ctx interface{}, key interface{}, ttl interface{}, grace interface{}, callback interface{}) *MockMemoryDriver_RememberStale_Call {
+	return &MockMemoryDriver_RememberStale_Call{Call: _e.mock.On("RememberStale", ctx, key, ttl, grace, callback)}
 }
 
-func (_c *MockMemoryDriver_GetMultiple_Call) Run(run func(ctx context.Context, keys []string)) *MockMemoryDriver_GetMultiple_Call {
+func (_c *MockMemoryDriver_RememberStale_Call) Run(run func(ctx context.Context, key string, ttl time.Duration, grace time.Duration, callback func() (interface{}, error))) *MockMemoryDriver_RememberStale_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].([]string))
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(time.Duration), args[4].(func() (interface{}, error)))
 	})
 	return _c
 }
 
-func (_c *MockMemoryDriver_GetMultiple_Call) Return(_a0 map[string]interface{}, _a1 []string) *MockMemoryDriver_GetMultiple_Call {
+func (_c *MockMemoryDriver_RememberStale_Call) Return(_a0 interface{}, _a1 error) *MockMemoryDriver_RememberStale_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockMemoryDriver_GetMultiple_Call) RunAndReturn(run func(context.Context, []string) (map[string]interface{}, []string)) *MockMemoryDriver_GetMultiple_Call {
+func (_c *MockMemoryDriver_RememberStale_Call) RunAndReturn(run func(context.Context, string, time.Duration, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockMemoryDriver_RememberStale_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Has provides a mock function with given fields: ctx, key
-func (_m *MockMemoryDriver) Has(ctx context.Context, key string) bool {
-	ret := _m.Called(ctx, key)
+// Replace provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockMemoryDriver) Replace(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, value, ttl)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Has")
+		panic("no return value specified for Replace")
 	}
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-		r0 = rf(ctx, key)
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, ttl)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockMemoryDriver_Has_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Has'
-type MockMemoryDriver_Has_Call struct {
+// MockMemoryDriver_Replace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Replace'
+type MockMemoryDriver_Replace_Call struct {
 	*mock.Call
 }
 
-// Has is a helper method to define mock.On call
+// Replace is a helper method to define mock.On call
 //   - ctx context.Context
 //   - key string
-func (_e *MockMemoryDriver_Expecter) Has(ctx interface{}, key interface{}) *MockMemoryDriver_Has_Call {
-	return &MockMemoryDriver_Has_Call{Call: _e.mock.On("Has", ctx, key)}
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockMemoryDriver_Expecter) Replace(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockMemoryDriver_Replace_Call {
+	return &MockMemoryDriver_Replace_Call{Call: _e.mock.On("Replace", ctx, key, value, ttl)}
 }
 
-func (_c *MockMemoryDriver_Has_Call) Run(run func(ctx context.Context, key string)) *MockMemoryDriver_Has_Call {
+func (_c *MockMemoryDriver_Replace_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *MockMemoryDriver_Replace_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
 	})
 	return _c
 }
 
-func (_c *MockMemoryDriver_Has_Call) Return(_a0 bool) *MockMemoryDriver_Has_Call {
-	_c.Call.Return(_a0)
+func (_c *MockMemoryDriver_Replace_Call) Return(_a0 bool, _a1 error) *MockMemoryDriver_Replace_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockMemoryDriver_Has_Call) RunAndReturn(run func(context.Context, string) bool) *MockMemoryDriver_Has_Call {
+func (_c *MockMemoryDriver_Replace_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) (bool, error)) *MockMemoryDriver_Replace_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Remember provides a mock function with given fields: ctx, key, ttl, callback
-func (_m *MockMemoryDriver) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
-	ret := _m.Called(ctx, key, ttl, callback)
+// Scan provides a mock function with given fields: ctx, pattern, fn
+func (_m *MockMemoryDriver) Scan(ctx context.Context, pattern string, fn func(string) bool) error {
+	ret := _m.Called(ctx, pattern, fn)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Remember")
-	}
-
-	var r0 interface{}
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
-		return rf(ctx, key, ttl, callback)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, func() (interface{}, error)) interface{}); ok {
-		r0 = rf(ctx, key, ttl, callback)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(interface{})
-		}
+		panic("no return value specified for Scan")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, func() (interface{}, error)) error); ok {
-		r1 = rf(ctx, key, ttl, callback)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(string) bool) error); ok {
+		r0 = rf(ctx, pattern, fn)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockMemoryDriver_Remember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remember'
-type MockMemoryDriver_Remember_Call struct {
+// MockMemoryDriver_Scan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Scan'
+type MockMemoryDriver_Scan_Call struct {
 	*mock.Call
 }
 
-// Remember is a helper method to define mock.On call
+// Scan is a helper method to define mock.On call
 //   - ctx context.Context
-//   - key string
-//   - ttl time.Duration
-//   - callback func()(interface{} , error)
-func (_e *MockMemoryDriver_Expecter) Remember(ctx interface{}, key interface{}, ttl interface{}, callback interface{}) *MockMemoryDriver_Remember_Call {
-	return &MockMemoryDriver_Remember_Call{Call: _e.mock.On("Remember", ctx, key, ttl, callback)}
+//   - pattern string
+//   - fn func(string) bool
+func (_e *MockMemoryDriver_Expecter) Scan(ctx interface{}, pattern interface{}, fn interface{}) *MockMemoryDriver_Scan_Call {
+	return &MockMemoryDriver_Scan_Call{Call: _e.mock.On("Scan", ctx, pattern, fn)}
 }
 
-func (_c *MockMemoryDriver_Remember_Call) Run(run func(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error))) *MockMemoryDriver_Remember_Call {
+func (_c *MockMemoryDriver_Scan_Call) Run(run func(ctx context.Context, pattern string, fn func(string) bool)) *MockMemoryDriver_Scan_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(func() (interface{}, error)))
+		run(args[0].(context.Context), args[1].(string), args[2].(func(string) bool))
 	})
 	return _c
 }
 
-func (_c *MockMemoryDriver_Remember_Call) Return(_a0 interface{}, _a1 error) *MockMemoryDriver_Remember_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockMemoryDriver_Scan_Call) Return(_a0 error) *MockMemoryDriver_Scan_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockMemoryDriver_Remember_Call) RunAndReturn(run func(context.Context, string, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockMemoryDriver_Remember_Call {
+func (_c *MockMemoryDriver_Scan_Call) RunAndReturn(run func(context.Context, string, func(string) bool) error) *MockMemoryDriver_Scan_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -533,6 +1418,56 @@ func (_c *MockMemoryDriver_SetMultiple_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// SetTagged provides a mock function with given fields: ctx, key, value, ttl, tags
+func (_m *MockMemoryDriver) SetTagged(ctx context.Context, key string, value interface{}, ttl time.Duration, tags []string) error {
+	ret := _m.Called(ctx, key, value, ttl, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTagged")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration, []string) error); ok {
+		r0 = rf(ctx, key, value, ttl, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMemoryDriver_SetTagged_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTagged'
+type MockMemoryDriver_SetTagged_Call struct {
+	*mock.Call
+}
+
+// SetTagged is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+//   - tags []string
+func (_e *MockMemoryDriver_Expecter) SetTagged(ctx interface{}, key interface{}, value interface{}, ttl interface{}, tags interface{}) *MockMemoryDriver_SetTagged_Call {
+	return &MockMemoryDriver_SetTagged_Call{Call: _e.mock.On("SetTagged", ctx, key, value, ttl, tags)}
+}
+
+func (_c *MockMemoryDriver_SetTagged_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration, tags []string)) *MockMemoryDriver_SetTagged_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration), args[4].([]string))
+	})
+	return _c
+}
+
+func (_c *MockMemoryDriver_SetTagged_Call) Return(_a0 error) *MockMemoryDriver_SetTagged_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMemoryDriver_SetTagged_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration, []string) error) *MockMemoryDriver_SetTagged_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Stats provides a mock function with given fields: ctx
 func (_m *MockMemoryDriver) Stats(ctx context.Context) map[string]interface{} {
 	ret := _m.Called(ctx)
@@ -581,6 +1516,121 @@ func (_c *MockMemoryDriver_Stats_Call) RunAndReturn(run func(context.Context) ma
 	return _c
 }
 
+// TTL provides a mock function with given fields: ctx, key
+func (_m *MockMemoryDriver) TTL(ctx context.Context, key string) (time.Duration, bool) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TTL")
+	}
+
+	var r0 time.Duration
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) (time.Duration, bool)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) time.Duration); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockMemoryDriver_TTL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TTL'
+type MockMemoryDriver_TTL_Call struct {
+	*mock.Call
+}
+
+// TTL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockMemoryDriver_Expecter) TTL(ctx interface{}, key interface{}) *MockMemoryDriver_TTL_Call {
+	return &MockMemoryDriver_TTL_Call{Call: _e.mock.On("TTL", ctx, key)}
+}
+
+func (_c *MockMemoryDriver_TTL_Call) Run(run func(ctx context.Context, key string)) *MockMemoryDriver_TTL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockMemoryDriver_TTL_Call) Return(_a0 time.Duration, _a1 bool) *MockMemoryDriver_TTL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMemoryDriver_TTL_Call) RunAndReturn(run func(context.Context, string) (time.Duration, bool)) *MockMemoryDriver_TTL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Touch provides a mock function with given fields: ctx, key, ttl
+func (_m *MockMemoryDriver) Touch(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Touch")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) bool); ok {
+		r0 = rf(ctx, key, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMemoryDriver_Touch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Touch'
+type MockMemoryDriver_Touch_Call struct {
+	*mock.Call
+}
+
+// Touch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - ttl time.Duration
+func (_e *MockMemoryDriver_Expecter) Touch(ctx interface{}, key interface{}, ttl interface{}) *MockMemoryDriver_Touch_Call {
+	return &MockMemoryDriver_Touch_Call{Call: _e.mock.On("Touch", ctx, key, ttl)}
+}
+
+func (_c *MockMemoryDriver_Touch_Call) Run(run func(ctx context.Context, key string, ttl time.Duration)) *MockMemoryDriver_Touch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockMemoryDriver_Touch_Call) Return(_a0 bool, _a1 error) *MockMemoryDriver_Touch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMemoryDriver_Touch_Call) RunAndReturn(run func(context.Context, string, time.Duration) (bool, error)) *MockMemoryDriver_Touch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockMemoryDriver creates a new instance of MockMemoryDriver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockMemoryDriver(t interface {
@@ -24,6 +24,65 @@ func (_m *MockRedisDriver) EXPECT() *MockRedisDriver_Expecter {
 	return &MockRedisDriver_Expecter{mock: &_m.Mock}
 }
 
+// Add provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockRedisDriver) Add(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRedisDriver_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type MockRedisDriver_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockRedisDriver_Expecter) Add(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockRedisDriver_Add_Call {
+	return &MockRedisDriver_Add_Call{Call: _e.mock.On("Add", ctx, key, value, ttl)}
+}
+
+func (_c *MockRedisDriver_Add_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *MockRedisDriver_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRedisDriver_Add_Call) Return(_a0 bool, _a1 error) *MockRedisDriver_Add_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRedisDriver_Add_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) (bool, error)) *MockRedisDriver_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Close provides a mock function with no fields
 func (_m *MockRedisDriver) Close() error {
 	ret := _m.Called()
@@ -69,6 +128,125 @@ func (_c *MockRedisDriver_Close_Call) RunAndReturn(run func() error) *MockRedisD
 	return _c
 }
 
+// CompareAndSwap provides a mock function with given fields: ctx, key, token, value, ttl
+func (_m *MockRedisDriver) CompareAndSwap(ctx context.Context, key string, token string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, token, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompareAndSwap")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, token, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, token, value, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, token, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRedisDriver_CompareAndSwap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareAndSwap'
+type MockRedisDriver_CompareAndSwap_Call struct {
+	*mock.Call
+}
+
+// CompareAndSwap is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - token string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockRedisDriver_Expecter) CompareAndSwap(ctx interface{}, key interface{}, token interface{}, value interface{}, ttl interface{}) *MockRedisDriver_CompareAndSwap_Call {
+	return &MockRedisDriver_CompareAndSwap_Call{Call: _e.mock.On("CompareAndSwap", ctx, key, token, value, ttl)}
+}
+
+func (_c *MockRedisDriver_CompareAndSwap_Call) Run(run func(ctx context.Context, key string, token string, value interface{}, ttl time.Duration)) *MockRedisDriver_CompareAndSwap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(interface{}), args[4].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRedisDriver_CompareAndSwap_Call) Return(_a0 bool, _a1 error) *MockRedisDriver_CompareAndSwap_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRedisDriver_CompareAndSwap_Call) RunAndReturn(run func(context.Context, string, string, interface{}, time.Duration) (bool, error)) *MockRedisDriver_CompareAndSwap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Decrement provides a mock function with given fields: ctx, key, delta, ttl
+func (_m *MockRedisDriver) Decrement(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	ret := _m.Called(ctx, key, delta, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Decrement")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) (int64, error)); ok {
+		return rf(ctx, key, delta, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) int64); ok {
+		r0 = rf(ctx, key, delta, ttl)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, time.Duration) error); ok {
+		r1 = rf(ctx, key, delta, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRedisDriver_Decrement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Decrement'
+type MockRedisDriver_Decrement_Call struct {
+	*mock.Call
+}
+
+// Decrement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - delta int64
+//   - ttl time.Duration
+func (_e *MockRedisDriver_Expecter) Decrement(ctx interface{}, key interface{}, delta interface{}, ttl interface{}) *MockRedisDriver_Decrement_Call {
+	return &MockRedisDriver_Decrement_Call{Call: _e.mock.On("Decrement", ctx, key, delta, ttl)}
+}
+
+func (_c *MockRedisDriver_Decrement_Call) Run(run func(ctx context.Context, key string, delta int64, ttl time.Duration)) *MockRedisDriver_Decrement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRedisDriver_Decrement_Call) Return(_a0 int64, _a1 error) *MockRedisDriver_Decrement_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRedisDriver_Decrement_Call) RunAndReturn(run func(context.Context, string, int64, time.Duration) (int64, error)) *MockRedisDriver_Decrement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function with given fields: ctx, key
 func (_m *MockRedisDriver) Delete(ctx context.Context, key string) error {
 	ret := _m.Called(ctx, key)
@@ -116,6 +294,120 @@ func (_c *MockRedisDriver_Delete_Call) RunAndReturn(run func(context.Context, st
 	return _c
 }
 
+// DeleteByPrefix provides a mock function with given fields: ctx, prefix
+func (_m *MockRedisDriver) DeleteByPrefix(ctx context.Context, prefix string) (int, error) {
+	ret := _m.Called(ctx, prefix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByPrefix")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, prefix)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, prefix)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRedisDriver_DeleteByPrefix_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByPrefix'
+type MockRedisDriver_DeleteByPrefix_Call struct {
+	*mock.Call
+}
+
+// DeleteByPrefix is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prefix string
+func (_e *MockRedisDriver_Expecter) DeleteByPrefix(ctx interface{}, prefix interface{}) *MockRedisDriver_DeleteByPrefix_Call {
+	return &MockRedisDriver_DeleteByPrefix_Call{Call: _e.mock.On("DeleteByPrefix", ctx, prefix)}
+}
+
+func (_c *MockRedisDriver_DeleteByPrefix_Call) Run(run func(ctx context.Context, prefix string)) *MockRedisDriver_DeleteByPrefix_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRedisDriver_DeleteByPrefix_Call) Return(_a0 int, _a1 error) *MockRedisDriver_DeleteByPrefix_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRedisDriver_DeleteByPrefix_Call) RunAndReturn(run func(context.Context, string) (int, error)) *MockRedisDriver_DeleteByPrefix_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMatching provides a mock function with given fields: ctx, pattern
+func (_m *MockRedisDriver) DeleteMatching(ctx context.Context, pattern string) (int, error) {
+	ret := _m.Called(ctx, pattern)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMatching")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, pattern)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, pattern)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pattern)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRedisDriver_DeleteMatching_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMatching'
+type MockRedisDriver_DeleteMatching_Call struct {
+	*mock.Call
+}
+
+// DeleteMatching is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pattern string
+func (_e *MockRedisDriver_Expecter) DeleteMatching(ctx interface{}, pattern interface{}) *MockRedisDriver_DeleteMatching_Call {
+	return &MockRedisDriver_DeleteMatching_Call{Call: _e.mock.On("DeleteMatching", ctx, pattern)}
+}
+
+func (_c *MockRedisDriver_DeleteMatching_Call) Run(run func(ctx context.Context, pattern string)) *MockRedisDriver_DeleteMatching_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRedisDriver_DeleteMatching_Call) Return(_a0 int, _a1 error) *MockRedisDriver_DeleteMatching_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRedisDriver_DeleteMatching_Call) RunAndReturn(run func(context.Context, string) (int, error)) *MockRedisDriver_DeleteMatching_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteMultiple provides a mock function with given fields: ctx, keys
 func (_m *MockRedisDriver) DeleteMultiple(ctx context.Context, keys []string) error {
 	ret := _m.Called(ctx, keys)
@@ -209,230 +501,870 @@ func (_c *MockRedisDriver_Flush_Call) RunAndReturn(run func(context.Context) err
 	return _c
 }
 
+// FlushTags provides a mock function with given fields: ctx, tags
+func (_m *MockRedisDriver) FlushTags(ctx context.Context, tags []string) error {
+	ret := _m.Called(ctx, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlushTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRedisDriver_FlushTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlushTags'
+type MockRedisDriver_FlushTags_Call struct {
+	*mock.Call
+}
+
+// FlushTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tags []string
+func (_e *MockRedisDriver_Expecter) FlushTags(ctx interface{}, tags interface{}) *MockRedisDriver_FlushTags_Call {
+	return &MockRedisDriver_FlushTags_Call{Call: _e.mock.On("FlushTags", ctx, tags)}
+}
+
+func (_c *MockRedisDriver_FlushTags_Call) Run(run func(ctx context.Context, tags []string)) *MockRedisDriver_FlushTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockRedisDriver_FlushTags_Call) Return(_a0 error) *MockRedisDriver_FlushTags_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRedisDriver_FlushTags_Call) RunAndReturn(run func(context.Context, []string) error) *MockRedisDriver_FlushTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Get provides a mock function with given fields: ctx, key
 func (_m *MockRedisDriver) Get(ctx context.Context, key string) (interface{}, bool) {
 	ret := _m.Called(ctx, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Get")
+		panic("no return value specified for Get")
+	}
+
+	var r0 interface{}
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) (interface{}, bool)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) interface{}); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockRedisDriver_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockRedisDriver_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockRedisDriver_Expecter) Get(ctx interface{}, key interface{}) *MockRedisDriver_Get_Call {
+	return &MockRedisDriver_Get_Call{Call: _e.mock.On("Get", ctx, key)}
+}
+
+func (_c *MockRedisDriver_Get_Call) Run(run func(ctx context.Context, key string)) *MockRedisDriver_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRedisDriver_Get_Call) Return(_a0 interface{}, _a1 bool) *MockRedisDriver_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRedisDriver_Get_Call) RunAndReturn(run func(context.Context, string) (interface{}, bool)) *MockRedisDriver_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAndDelete provides a mock function with given fields: ctx, key
+func (_m *MockRedisDriver) GetAndDelete(ctx context.Context, key string) (interface{}, bool, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAndDelete")
+	}
+
+	var r0 interface{}
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (interface{}, bool, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) interface{}); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, key)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockRedisDriver_GetAndDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAndDelete'
+type MockRedisDriver_GetAndDelete_Call struct {
+	*mock.Call
+}
+
+// GetAndDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockRedisDriver_Expecter) GetAndDelete(ctx interface{}, key interface{}) *MockRedisDriver_GetAndDelete_Call {
+	return &MockRedisDriver_GetAndDelete_Call{Call: _e.mock.On("GetAndDelete", ctx, key)}
+}
+
+func (_c *MockRedisDriver_GetAndDelete_Call) Run(run func(ctx context.Context, key string)) *MockRedisDriver_GetAndDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRedisDriver_GetAndDelete_Call) Return(_a0 interface{}, _a1 bool, _a2 error) *MockRedisDriver_GetAndDelete_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockRedisDriver_GetAndDelete_Call) RunAndReturn(run func(context.Context, string) (interface{}, bool, error)) *MockRedisDriver_GetAndDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAndSet provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockRedisDriver) GetAndSet(ctx context.Context, key string, value interface{}, ttl time.Duration) (interface{}, bool, error) {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAndSet")
+	}
+
+	var r0 interface{}
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (interface{}, bool, error)); ok {
+		return rf(ctx, key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) interface{}); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r1 = rf(ctx, key, value, ttl)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r2 = rf(ctx, key, value, ttl)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockRedisDriver_GetAndSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAndSet'
+type MockRedisDriver_GetAndSet_Call struct {
+	*mock.Call
+}
+
+// GetAndSet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockRedisDriver_Expecter) GetAndSet(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockRedisDriver_GetAndSet_Call {
+	return &MockRedisDriver_GetAndSet_Call{Call: _e.mock.On("GetAndSet", ctx, key, value, ttl)}
+}
+
+func (_c *MockRedisDriver_GetAndSet_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *MockRedisDriver_GetAndSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRedisDriver_GetAndSet_Call) Return(_a0 interface{}, _a1 bool, _a2 error) *MockRedisDriver_GetAndSet_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockRedisDriver_GetAndSet_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) (interface{}, bool, error)) *MockRedisDriver_GetAndSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMultiple provides a mock function with given fields: ctx, keys
+func (_m *MockRedisDriver) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, []string) {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMultiple")
+	}
+
+	var r0 map[string]interface{}
+	var r1 []string
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]interface{}, []string)); ok {
+		return rf(ctx, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]interface{}); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) []string); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	return r0, r1
+}
+
+// MockRedisDriver_GetMultiple_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMultiple'
+type MockRedisDriver_GetMultiple_Call struct {
+	*mock.Call
+}
+
+// GetMultiple is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []string
+func (_e *MockRedisDriver_Expecter) GetMultiple(ctx interface{}, keys interface{}) *MockRedisDriver_GetMultiple_Call {
+	return &MockRedisDriver_GetMultiple_Call{Call: _e.mock.On("GetMultiple", ctx, keys)}
+}
+
+func (_c *MockRedisDriver_GetMultiple_Call) Run(run func(ctx context.Context, keys []string)) *MockRedisDriver_GetMultiple_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockRedisDriver_GetMultiple_Call) Return(_a0 map[string]interface{}, _a1 []string) *MockRedisDriver_GetMultiple_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRedisDriver_GetMultiple_Call) RunAndReturn(run func(context.Context, []string) (map[string]interface{}, []string)) *MockRedisDriver_GetMultiple_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWithVersion provides a mock function with given fields: ctx, key
+func (_m *MockRedisDriver) GetWithVersion(ctx context.Context, key string) (interface{}, string, bool) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWithVersion")
+	}
+
+	var r0 interface{}
+	var r1 string
+	var r2 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) (interface{}, string, bool)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) interface{}); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) bool); ok {
+		r2 = rf(ctx, key)
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+
+	return r0, r1, r2
+}
+
+// MockRedisDriver_GetWithVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWithVersion'
+type MockRedisDriver_GetWithVersion_Call struct {
+	*mock.Call
+}
+
+// GetWithVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockRedisDriver_Expecter) GetWithVersion(ctx interface{}, key interface{}) *MockRedisDriver_GetWithVersion_Call {
+	return &MockRedisDriver_GetWithVersion_Call{Call: _e.mock.On("GetWithVersion", ctx, key)}
+}
+
+func (_c *MockRedisDriver_GetWithVersion_Call) Run(run func(ctx context.Context, key string)) *MockRedisDriver_GetWithVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRedisDriver_GetWithVersion_Call) Return(_a0 interface{}, _a1 string, _a2 bool) *MockRedisDriver_GetWithVersion_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockRedisDriver_GetWithVersion_Call) RunAndReturn(run func(context.Context, string) (interface{}, string, bool)) *MockRedisDriver_GetWithVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Has provides a mock function with given fields: ctx, key
+func (_m *MockRedisDriver) Has(ctx context.Context, key string) bool {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Has")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockRedisDriver_Has_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Has'
+type MockRedisDriver_Has_Call struct {
+	*mock.Call
+}
+
+// Has is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockRedisDriver_Expecter) Has(ctx interface{}, key interface{}) *MockRedisDriver_Has_Call {
+	return &MockRedisDriver_Has_Call{Call: _e.mock.On("Has", ctx, key)}
+}
+
+func (_c *MockRedisDriver_Has_Call) Run(run func(ctx context.Context, key string)) *MockRedisDriver_Has_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRedisDriver_Has_Call) Return(_a0 bool) *MockRedisDriver_Has_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRedisDriver_Has_Call) RunAndReturn(run func(context.Context, string) bool) *MockRedisDriver_Has_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Increment provides a mock function with given fields: ctx, key, delta, ttl
+func (_m *MockRedisDriver) Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	ret := _m.Called(ctx, key, delta, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Increment")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) (int64, error)); ok {
+		return rf(ctx, key, delta, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) int64); ok {
+		r0 = rf(ctx, key, delta, ttl)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, time.Duration) error); ok {
+		r1 = rf(ctx, key, delta, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRedisDriver_Increment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Increment'
+type MockRedisDriver_Increment_Call struct {
+	*mock.Call
+}
+
+// Increment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - delta int64
+//   - ttl time.Duration
+func (_e *MockRedisDriver_Expecter) Increment(ctx interface{}, key interface{}, delta interface{}, ttl interface{}) *MockRedisDriver_Increment_Call {
+	return &MockRedisDriver_Increment_Call{Call: _e.mock.On("Increment", ctx, key, delta, ttl)}
+}
+
+func (_c *MockRedisDriver_Increment_Call) Run(run func(ctx context.Context, key string, delta int64, ttl time.Duration)) *MockRedisDriver_Increment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRedisDriver_Increment_Call) Return(_a0 int64, _a1 error) *MockRedisDriver_Increment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRedisDriver_Increment_Call) RunAndReturn(run func(context.Context, string, int64, time.Duration) (int64, error)) *MockRedisDriver_Increment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementFloat provides a mock function with given fields: ctx, key, delta, ttl
+func (_m *MockRedisDriver) IncrementFloat(ctx context.Context, key string, delta float64, ttl time.Duration) (float64, error) {
+	ret := _m.Called(ctx, key, delta, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementFloat")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, time.Duration) (float64, error)); ok {
+		return rf(ctx, key, delta, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, time.Duration) float64); ok {
+		r0 = rf(ctx, key, delta, ttl)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, float64, time.Duration) error); ok {
+		r1 = rf(ctx, key, delta, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRedisDriver_IncrementFloat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementFloat'
+type MockRedisDriver_IncrementFloat_Call struct {
+	*mock.Call
+}
+
+// IncrementFloat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - delta float64
+//   - ttl time.Duration
+func (_e *MockRedisDriver_Expecter) IncrementFloat(ctx interface{}, key interface{}, delta interface{}, ttl interface{}) *MockRedisDriver_IncrementFloat_Call {
+	return &MockRedisDriver_IncrementFloat_Call{Call: _e.mock.On("IncrementFloat", ctx, key, delta, ttl)}
+}
+
+func (_c *MockRedisDriver_IncrementFloat_Call) Run(run func(ctx context.Context, key string, delta float64, ttl time.Duration)) *MockRedisDriver_IncrementFloat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(float64), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRedisDriver_IncrementFloat_Call) Return(_a0 float64, _a1 error) *MockRedisDriver_IncrementFloat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRedisDriver_IncrementFloat_Call) RunAndReturn(run func(context.Context, string, float64, time.Duration) (float64, error)) *MockRedisDriver_IncrementFloat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Invalidation provides a mock function with no fields
+func (_m *MockRedisDriver) Invalidation() driver.InvalidationBus {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Invalidation")
+	}
+
+	var r0 driver.InvalidationBus
+	if rf, ok := ret.Get(0).(func() driver.InvalidationBus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(driver.InvalidationBus)
+		}
+	}
+
+	return r0
+}
+
+// MockRedisDriver_Invalidation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Invalidation'
+type MockRedisDriver_Invalidation_Call struct {
+	*mock.Call
+}
+
+// Invalidation is a helper method to define mock.On call
+func (_e *MockRedisDriver_Expecter) Invalidation() *MockRedisDriver_Invalidation_Call {
+	return &MockRedisDriver_Invalidation_Call{Call: _e.mock.On("Invalidation")}
+}
+
+func (_c *MockRedisDriver_Invalidation_Call) Run(run func()) *MockRedisDriver_Invalidation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRedisDriver_Invalidation_Call) Return(_a0 driver.InvalidationBus) *MockRedisDriver_Invalidation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRedisDriver_Invalidation_Call) RunAndReturn(run func() driver.InvalidationBus) *MockRedisDriver_Invalidation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Keys provides a mock function with given fields: ctx, pattern
+func (_m *MockRedisDriver) Keys(ctx context.Context, pattern string) ([]string, error) {
+	ret := _m.Called(ctx, pattern)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Keys")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, pattern)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, pattern)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pattern)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRedisDriver_Keys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Keys'
+type MockRedisDriver_Keys_Call struct {
+	*mock.Call
+}
+
+// Keys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pattern string
+func (_e *MockRedisDriver_Expecter) Keys(ctx interface{}, pattern interface{}) *MockRedisDriver_Keys_Call {
+	return &MockRedisDriver_Keys_Call{Call: _e.mock.On("Keys", ctx, pattern)}
+}
+
+func (_c *MockRedisDriver_Keys_Call) Run(run func(ctx context.Context, pattern string)) *MockRedisDriver_Keys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRedisDriver_Keys_Call) Return(_a0 []string, _a1 error) *MockRedisDriver_Keys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRedisDriver_Keys_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *MockRedisDriver_Keys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remember provides a mock function with given fields: ctx, key, ttl, callback
+func (_m *MockRedisDriver) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	ret := _m.Called(ctx, key, ttl, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remember")
 	}
 
 	var r0 interface{}
-	var r1 bool
-	if rf, ok := ret.Get(0).(func(context.Context, string) (interface{}, bool)); ok {
-		return rf(ctx, key)
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
+		return rf(ctx, key, ttl, callback)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) interface{}); ok {
-		r0 = rf(ctx, key)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, func() (interface{}, error)) interface{}); ok {
+		r0 = rf(ctx, key, ttl, callback)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(interface{})
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
-		r1 = rf(ctx, key)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, func() (interface{}, error)) error); ok {
+		r1 = rf(ctx, key, ttl, callback)
 	} else {
-		r1 = ret.Get(1).(bool)
+		r1 = ret.Error(1)
 	}
 
 	return r0, r1
 }
 
-// MockRedisDriver_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockRedisDriver_Get_Call struct {
+// MockRedisDriver_Remember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remember'
+type MockRedisDriver_Remember_Call struct {
 	*mock.Call
 }
 
-// Get is a helper method to define mock.On call
+// Remember is a helper method to define mock.On call
 //   - ctx context.Context
 //   - key string
-func (_e *MockRedisDriver_Expecter) Get(ctx interface{}, key interface{}) *MockRedisDriver_Get_Call {
-	return &MockRedisDriver_Get_Call{Call: _e.mock.On("Get", ctx, key)}
+//   - ttl time.Duration
+//   - callback func()(interface{} , error)
+func (_e *MockRedisDriver_Expecter) Remember(ctx interface{}, key interface{}, ttl interface{}, callback interface{}) *MockRedisDriver_Remember_Call {
+	return &MockRedisDriver_Remember_Call{Call: _e.mock.On("Remember", ctx, key, ttl, callback)}
 }
 
-func (_c *MockRedisDriver_Get_Call) Run(run func(ctx context.Context, key string)) *MockRedisDriver_Get_Call {
+func (_c *MockRedisDriver_Remember_Call) Run(run func(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error))) *MockRedisDriver_Remember_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(func() (interface{}, error)))
 	})
 	return _c
 }
 
-func (_c *MockRedisDriver_Get_Call) Return(_a0 interface{}, _a1 bool) *MockRedisDriver_Get_Call {
+func (_c *MockRedisDriver_Remember_Call) Return(_a0 interface{}, _a1 error) *MockRedisDriver_Remember_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRedisDriver_Get_Call) RunAndReturn(run func(context.Context, string) (interface{}, bool)) *MockRedisDriver_Get_Call {
+func (_c *MockRedisDriver_Remember_Call) RunAndReturn(run func(context.Context, string, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockRedisDriver_Remember_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMultiple provides a mock function with given fields: ctx, keys
-func (_m *MockRedisDriver) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, []string) {
-	ret := _m.Called(ctx, keys)
+// RememberStale provides a mock function with given fields: ctx, key, ttl, grace, callback
+func (_m *MockRedisDriver) RememberStale(ctx context.Context, key string, ttl time.Duration, grace time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	ret := _m.Called(ctx, key, ttl, grace, callback)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMultiple")
+		panic("no return value specified for RememberStale")
 	}
 
-	var r0 map[string]interface{}
-	var r1 []string
-	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]interface{}, []string)); ok {
-		return rf(ctx, keys)
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
+		return rf(ctx, key, ttl, grace, callback)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]interface{}); ok {
-		r0 = rf(ctx, keys)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, time.Duration, func() (interface{}, error)) interface{}); ok {
+		r0 = rf(ctx, key, ttl, grace, callback)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]interface{})
+			r0 = ret.Get(0).(interface{})
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []string) []string); ok {
-		r1 = rf(ctx, keys)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, time.Duration, func() (interface{}, error)) error); ok {
+		r1 = rf(ctx, key, ttl, grace, callback)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).([]string)
-		}
+		r1 = ret.Error(1)
 	}
 
 	return r0, r1
 }
 
-// MockRedisDriver_GetMultiple_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMultiple'
-type MockRedisDriver_GetMultiple_Call struct {
+// MockRedisDriver_RememberStale_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RememberStale'
+type MockRedisDriver_RememberStale_Call struct {
 	*mock.Call
 }
 
-// GetMultiple is a helper method to define mock.On call
+// RememberStale is a helper method to define mock.On call
 //   - ctx context.Context
-//   - keys []string
-func (_e *MockRedisDriver_Expecter) GetMultiple(ctx interface{}, keys interface{}) *MockRedisDriver_GetMultiple_Call {
-	return &MockRedisDriver_GetMultiple_Call{Call: _e.mock.On("GetMultiple", ctx, keys)}
+//   - key string
+//   - ttl time.Duration
+//   - grace time.Duration
+//   - callback func()(interface{} , error)
+func (_e *MockRedisDriver_Expecter) RememberStale(ctx interface{}, key interface{}, ttl interface{}, grace interface{}, callback interface{}) *MockRedisDriver_RememberStale_Call {
+	return &MockRedisDriver_RememberStale_Call{Call: _e.mock.On("RememberStale", ctx, key, ttl, grace, callback)}
 }
 
-func (_c *MockRedisDriver_GetMultiple_Call) Run(run func(ctx context.Context, keys []string)) *MockRedisDriver_GetMultiple_Call {
+func (_c *MockRedisDriver_RememberStale_Call) Run(run func(ctx context.Context, key string, ttl time.Duration, grace time.Duration, callback func() (interface{}, error))) *MockRedisDriver_RememberStale_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].([]string))
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(time.Duration), args[4].(func() (interface{}, error)))
 	})
 	return _c
 }
 
-func (_c *MockRedisDriver_GetMultiple_Call) Return(_a0 map[string]interface{}, _a1 []string) *MockRedisDriver_GetMultiple_Call {
+func (_c *MockRedisDriver_RememberStale_Call) Return(_a0 interface{}, _a1 error) *MockRedisDriver_RememberStale_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRedisDriver_GetMultiple_Call) RunAndReturn(run func(context.Context, []string) (map[string]interface{}, []string)) *MockRedisDriver_GetMultiple_Call {
+func (_c *MockRedisDriver_RememberStale_Call) RunAndReturn(run func(context.Context, string, time.Duration, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockRedisDriver_RememberStale_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Has provides a mock function with given fields: ctx, key
-func (_m *MockRedisDriver) Has(ctx context.Context, key string) bool {
-	ret := _m.Called(ctx, key)
+// Replace provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockRedisDriver) Replace(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, value, ttl)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Has")
+		panic("no return value specified for Replace")
 	}
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-		r0 = rf(ctx, key)
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, ttl)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockRedisDriver_Has_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Has'
-type MockRedisDriver_Has_Call struct {
+// MockRedisDriver_Replace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Replace'
+type MockRedisDriver_Replace_Call struct {
 	*mock.Call
 }
 
-// Has is a helper method to define mock.On call
+// Replace is a helper method to define mock.On call
 //   - ctx context.Context
 //   - key string
-func (_e *MockRedisDriver_Expecter) Has(ctx interface{}, key interface{}) *MockRedisDriver_Has_Call {
-	return &MockRedisDriver_Has_Call{Call: _e.mock.On("Has", ctx, key)}
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockRedisDriver_Expecter) Replace(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockRedisDriver_Replace_Call {
+	return &MockRedisDriver_Replace_Call{Call: _e.mock.On("Replace", ctx, key, value, ttl)}
 }
 
-func (_c *MockRedisDriver_Has_Call) Run(run func(ctx context.Context, key string)) *MockRedisDriver_Has_Call {
+func (_c *MockRedisDriver_Replace_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *MockRedisDriver_Replace_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
 	})
 	return _c
 }
 
-func (_c *MockRedisDriver_Has_Call) Return(_a0 bool) *MockRedisDriver_Has_Call {
-	_c.Call.Return(_a0)
+func (_c *MockRedisDriver_Replace_Call) Return(_a0 bool, _a1 error) *MockRedisDriver_Replace_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRedisDriver_Has_Call) RunAndReturn(run func(context.Context, string) bool) *MockRedisDriver_Has_Call {
+func (_c *MockRedisDriver_Replace_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) (bool, error)) *MockRedisDriver_Replace_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Remember provides a mock function with given fields: ctx, key, ttl, callback
-func (_m *MockRedisDriver) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
-	ret := _m.Called(ctx, key, ttl, callback)
+// Scan provides a mock function with given fields: ctx, pattern, fn
+func (_m *MockRedisDriver) Scan(ctx context.Context, pattern string, fn func(string) bool) error {
+	ret := _m.Called(ctx, pattern, fn)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Remember")
-	}
-
-	var r0 interface{}
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
-		return rf(ctx, key, ttl, callback)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, func() (interface{}, error)) interface{}); ok {
-		r0 = rf(ctx, key, ttl, callback)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(interface{})
-		}
+		panic("no return value specified for Scan")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, func() (interface{}, error)) error); ok {
-		r1 = rf(ctx, key, ttl, callback)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(string) bool) error); ok {
+		r0 = rf(ctx, pattern, fn)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockRedisDriver_Remember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remember'
-type MockRedisDriver_Remember_Call struct {
+// MockRedisDriver_Scan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Scan'
+type MockRedisDriver_Scan_Call struct {
 	*mock.Call
 }
 
-// Remember is a helper method to define mock.On call
+// Scan is a helper method to define mock.On call
 //   - ctx context.Context
-//   - key string
-//   - ttl time.Duration
-//   - callback func()(interface{} , error)
-func (_e *MockRedisDriver_Expecter) Remember(ctx interface{}, key interface{}, ttl interface{}, callback interface{}) *MockRedisDriver_Remember_Call {
-	return &MockRedisDriver_Remember_Call{Call: _e.mock.On("Remember", ctx, key, ttl, callback)}
+//   - pattern string
+//   - fn func(string) bool
+func (_e *MockRedisDriver_Expecter) Scan(ctx interface{}, pattern interface{}, fn interface{}) *MockRedisDriver_Scan_Call {
+	return &MockRedisDriver_Scan_Call{Call: _e.mock.On("Scan", ctx, pattern, fn)}
 }
 
-func (_c *MockRedisDriver_Remember_Call) Run(run func(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error))) *MockRedisDriver_Remember_Call {
+func (_c *MockRedisDriver_Scan_Call) Run(run func(ctx context.Context, pattern string, fn func(string) bool)) *MockRedisDriver_Scan_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(func() (interface{}, error)))
+		run(args[0].(context.Context), args[1].(string), args[2].(func(string) bool))
 	})
 	return _c
 }
 
-func (_c *MockRedisDriver_Remember_Call) Return(_a0 interface{}, _a1 error) *MockRedisDriver_Remember_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockRedisDriver_Scan_Call) Return(_a0 error) *MockRedisDriver_Scan_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockRedisDriver_Remember_Call) RunAndReturn(run func(context.Context, string, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockRedisDriver_Remember_Call {
+func (_c *MockRedisDriver_Scan_Call) RunAndReturn(run func(context.Context, string, func(string) bool) error) *MockRedisDriver_Scan_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -534,6 +1466,56 @@ func (_c *MockRedisDriver_SetMultiple_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// SetTagged provides a mock function with given fields: ctx, key, value, ttl, tags
+func (_m *MockRedisDriver) SetTagged(ctx context.Context, key string, value interface{}, ttl time.Duration, tags []string) error {
+	ret := _m.Called(ctx, key, value, ttl, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTagged")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration, []string) error); ok {
+		r0 = rf(ctx, key, value, ttl, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRedisDriver_SetTagged_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTagged'
+type MockRedisDriver_SetTagged_Call struct {
+	*mock.Call
+}
+
+// SetTagged is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+//   - tags []string
+func (_e *MockRedisDriver_Expecter) SetTagged(ctx interface{}, key interface{}, value interface{}, ttl interface{}, tags interface{}) *MockRedisDriver_SetTagged_Call {
+	return &MockRedisDriver_SetTagged_Call{Call: _e.mock.On("SetTagged", ctx, key, value, ttl, tags)}
+}
+
+func (_c *MockRedisDriver_SetTagged_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration, tags []string)) *MockRedisDriver_SetTagged_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration), args[4].([]string))
+	})
+	return _c
+}
+
+func (_c *MockRedisDriver_SetTagged_Call) Return(_a0 error) *MockRedisDriver_SetTagged_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRedisDriver_SetTagged_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration, []string) error) *MockRedisDriver_SetTagged_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Stats provides a mock function with given fields: ctx
 func (_m *MockRedisDriver) Stats(ctx context.Context) map[string]interface{} {
 	ret := _m.Called(ctx)
@@ -582,6 +1564,121 @@ func (_c *MockRedisDriver_Stats_Call) RunAndReturn(run func(context.Context) map
 	return _c
 }
 
+// TTL provides a mock function with given fields: ctx, key
+func (_m *MockRedisDriver) TTL(ctx context.Context, key string) (time.Duration, bool) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TTL")
+	}
+
+	var r0 time.Duration
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) (time.Duration, bool)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) time.Duration); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockRedisDriver_TTL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TTL'
+type MockRedisDriver_TTL_Call struct {
+	*mock.Call
+}
+
+// TTL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockRedisDriver_Expecter) TTL(ctx interface{}, key interface{}) *MockRedisDriver_TTL_Call {
+	return &MockRedisDriver_TTL_Call{Call: _e.mock.On("TTL", ctx, key)}
+}
+
+func (_c *MockRedisDriver_TTL_Call) Run(run func(ctx context.Context, key string)) *MockRedisDriver_TTL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRedisDriver_TTL_Call) Return(_a0 time.Duration, _a1 bool) *MockRedisDriver_TTL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRedisDriver_TTL_Call) RunAndReturn(run func(context.Context, string) (time.Duration, bool)) *MockRedisDriver_TTL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Touch provides a mock function with given fields: ctx, key, ttl
+func (_m *MockRedisDriver) Touch(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Touch")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) bool); ok {
+		r0 = rf(ctx, key, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRedisDriver_Touch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Touch'
+type MockRedisDriver_Touch_Call struct {
+	*mock.Call
+}
+
+// Touch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - ttl time.Duration
+func (_e *MockRedisDriver_Expecter) Touch(ctx interface{}, key interface{}, ttl interface{}) *MockRedisDriver_Touch_Call {
+	return &MockRedisDriver_Touch_Call{Call: _e.mock.On("Touch", ctx, key, ttl)}
+}
+
+func (_c *MockRedisDriver_Touch_Call) Run(run func(ctx context.Context, key string, ttl time.Duration)) *MockRedisDriver_Touch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRedisDriver_Touch_Call) Return(_a0 bool, _a1 error) *MockRedisDriver_Touch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRedisDriver_Touch_Call) RunAndReturn(run func(context.Context, string, time.Duration) (bool, error)) *MockRedisDriver_Touch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WithSerializer provides a mock function with given fields: serializer
 func (_m *MockRedisDriver) WithSerializer(serializer string) driver.RedisDriver {
 	ret := _m.Called(serializer)
@@ -0,0 +1,488 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package cache_mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTaggedCache is an autogenerated mock type for the TaggedCache type
+type MockTaggedCache struct {
+	mock.Mock
+}
+
+type MockTaggedCache_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTaggedCache) EXPECT() *MockTaggedCache_Expecter {
+	return &MockTaggedCache_Expecter{mock: &_m.Mock}
+}
+
+// Flush provides a mock function with no fields
+func (_m *MockTaggedCache) Flush() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Flush")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTaggedCache_Flush_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Flush'
+type MockTaggedCache_Flush_Call struct {
+	*mock.Call
+}
+
+// Flush is a helper method to define mock.On call
+func (_e *MockTaggedCache_Expecter) Flush() *MockTaggedCache_Flush_Call {
+	return &MockTaggedCache_Flush_Call{Call: _e.mock.On("Flush")}
+}
+
+func (_c *MockTaggedCache_Flush_Call) Run(run func()) *MockTaggedCache_Flush_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockTaggedCache_Flush_Call) Return(_a0 error) *MockTaggedCache_Flush_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTaggedCache_Flush_Call) RunAndReturn(run func() error) *MockTaggedCache_Flush_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FlushContext provides a mock function with given fields: ctx
+func (_m *MockTaggedCache) FlushContext(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlushContext")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTaggedCache_FlushContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlushContext'
+type MockTaggedCache_FlushContext_Call struct {
+	*mock.Call
+}
+
+// FlushContext is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockTaggedCache_Expecter) FlushContext(ctx interface{}) *MockTaggedCache_FlushContext_Call {
+	return &MockTaggedCache_FlushContext_Call{Call: _e.mock.On("FlushContext", ctx)}
+}
+
+func (_c *MockTaggedCache_FlushContext_Call) Run(run func(ctx context.Context)) *MockTaggedCache_FlushContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockTaggedCache_FlushContext_Call) Return(_a0 error) *MockTaggedCache_FlushContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTaggedCache_FlushContext_Call) RunAndReturn(run func(context.Context) error) *MockTaggedCache_FlushContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remember provides a mock function with given fields: key, ttl, callback
+func (_m *MockTaggedCache) Remember(key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	ret := _m.Called(key, ttl, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remember")
+	}
+
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
+		return rf(key, ttl, callback)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Duration, func() (interface{}, error)) interface{}); ok {
+		r0 = rf(key, ttl, callback)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Duration, func() (interface{}, error)) error); ok {
+		r1 = rf(key, ttl, callback)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTaggedCache_Remember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remember'
+type MockTaggedCache_Remember_Call struct {
+	*mock.Call
+}
+
+// Remember is a helper method to define mock.On call
+//   - key string
+//   - ttl time.Duration
+//   - callback func()(interface{} , error)
+func (_e *MockTaggedCache_Expecter) Remember(key interface{}, ttl interface{}, callback interface{}) *MockTaggedCache_Remember_Call {
+	return &MockTaggedCache_Remember_Call{Call: _e.mock.On("Remember", key, ttl, callback)}
+}
+
+func (_c *MockTaggedCache_Remember_Call) Run(run func(key string, ttl time.Duration, callback func() (interface{}, error))) *MockTaggedCache_Remember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Duration), args[2].(func() (interface{}, error)))
+	})
+	return _c
+}
+
+func (_c *MockTaggedCache_Remember_Call) Return(_a0 interface{}, _a1 error) *MockTaggedCache_Remember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTaggedCache_Remember_Call) RunAndReturn(run func(string, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockTaggedCache_Remember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RememberContext provides a mock function with given fields: ctx, key, ttl, callback
+func (_m *MockTaggedCache) RememberContext(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	ret := _m.Called(ctx, key, ttl, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RememberContext")
+	}
+
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
+		return rf(ctx, key, ttl, callback)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, func() (interface{}, error)) interface{}); ok {
+		r0 = rf(ctx, key, ttl, callback)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, func() (interface{}, error)) error); ok {
+		r1 = rf(ctx, key, ttl, callback)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTaggedCache_RememberContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RememberContext'
+type MockTaggedCache_RememberContext_Call struct {
+	*mock.Call
+}
+
+// RememberContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - ttl time.Duration
+//   - callback func()(interface{} , error)
+func (_e *MockTaggedCache_Expecter) RememberContext(ctx interface{}, key interface{}, ttl interface{}, callback interface{}) *MockTaggedCache_RememberContext_Call {
+	return &MockTaggedCache_RememberContext_Call{Call: _e.mock.On("RememberContext", ctx, key, ttl, callback)}
+}
+
+func (_c *MockTaggedCache_RememberContext_Call) Run(run func(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error))) *MockTaggedCache_RememberContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(func() (interface{}, error)))
+	})
+	return _c
+}
+
+func (_c *MockTaggedCache_RememberContext_Call) Return(_a0 interface{}, _a1 error) *MockTaggedCache_RememberContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTaggedCache_RememberContext_Call) RunAndReturn(run func(context.Context, string, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockTaggedCache_RememberContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function with given fields: key, value, ttl
+func (_m *MockTaggedCache) Set(key string, value interface{}, ttl time.Duration) error {
+	ret := _m.Called(key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Set")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, interface{}, time.Duration) error); ok {
+		r0 = rf(key, value, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTaggedCache_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type MockTaggedCache_Set_Call struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockTaggedCache_Expecter) Set(key interface{}, value interface{}, ttl interface{}) *MockTaggedCache_Set_Call {
+	return &MockTaggedCache_Set_Call{Call: _e.mock.On("Set", key, value, ttl)}
+}
+
+func (_c *MockTaggedCache_Set_Call) Run(run func(key string, value interface{}, ttl time.Duration)) *MockTaggedCache_Set_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(interface{}), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockTaggedCache_Set_Call) Return(_a0 error) *MockTaggedCache_Set_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTaggedCache_Set_Call) RunAndReturn(run func(string, interface{}, time.Duration) error) *MockTaggedCache_Set_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetContext provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockTaggedCache) SetContext(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetContext")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTaggedCache_SetContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetContext'
+type MockTaggedCache_SetContext_Call struct {
+	*mock.Call
+}
+
+// SetContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockTaggedCache_Expecter) SetContext(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockTaggedCache_SetContext_Call {
+	return &MockTaggedCache_SetContext_Call{Call: _e.mock.On("SetContext", ctx, key, value, ttl)}
+}
+
+func (_c *MockTaggedCache_SetContext_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *MockTaggedCache_SetContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockTaggedCache_SetContext_Call) Return(_a0 error) *MockTaggedCache_SetContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTaggedCache_SetContext_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) error) *MockTaggedCache_SetContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMultiple provides a mock function with given fields: values, ttl
+func (_m *MockTaggedCache) SetMultiple(values map[string]interface{}, ttl time.Duration) error {
+	ret := _m.Called(values, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMultiple")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, time.Duration) error); ok {
+		r0 = rf(values, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTaggedCache_SetMultiple_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMultiple'
+type MockTaggedCache_SetMultiple_Call struct {
+	*mock.Call
+}
+
+// SetMultiple is a helper method to define mock.On call
+//   - values map[string]interface{}
+//   - ttl time.Duration
+func (_e *MockTaggedCache_Expecter) SetMultiple(values interface{}, ttl interface{}) *MockTaggedCache_SetMultiple_Call {
+	return &MockTaggedCache_SetMultiple_Call{Call: _e.mock.On("SetMultiple", values, ttl)}
+}
+
+func (_c *MockTaggedCache_SetMultiple_Call) Run(run func(values map[string]interface{}, ttl time.Duration)) *MockTaggedCache_SetMultiple_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(map[string]interface{}), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockTaggedCache_SetMultiple_Call) Return(_a0 error) *MockTaggedCache_SetMultiple_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTaggedCache_SetMultiple_Call) RunAndReturn(run func(map[string]interface{}, time.Duration) error) *MockTaggedCache_SetMultiple_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMultipleContext provides a mock function with given fields: ctx, values, ttl
+func (_m *MockTaggedCache) SetMultipleContext(ctx context.Context, values map[string]interface{}, ttl time.Duration) error {
+	ret := _m.Called(ctx, values, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMultipleContext")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}, time.Duration) error); ok {
+		r0 = rf(ctx, values, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTaggedCache_SetMultipleContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMultipleContext'
+type MockTaggedCache_SetMultipleContext_Call struct {
+	*mock.Call
+}
+
+// SetMultipleContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - values map[string]interface{}
+//   - ttl time.Duration
+func (_e *MockTaggedCache_Expecter) SetMultipleContext(ctx interface{}, values interface{}, ttl interface{}) *MockTaggedCache_SetMultipleContext_Call {
+	return &MockTaggedCache_SetMultipleContext_Call{Call: _e.mock.On("SetMultipleContext", ctx, values, ttl)}
+}
+
+func (_c *MockTaggedCache_SetMultipleContext_Call) Run(run func(ctx context.Context, values map[string]interface{}, ttl time.Duration)) *MockTaggedCache_SetMultipleContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(map[string]interface{}), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockTaggedCache_SetMultipleContext_Call) Return(_a0 error) *MockTaggedCache_SetMultipleContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTaggedCache_SetMultipleContext_Call) RunAndReturn(run func(context.Context, map[string]interface{}, time.Duration) error) *MockTaggedCache_SetMultipleContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Tags provides a mock function with no fields
+func (_m *MockTaggedCache) Tags() []string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Tags")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// MockTaggedCache_Tags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Tags'
+type MockTaggedCache_Tags_Call struct {
+	*mock.Call
+}
+
+// Tags is a helper method to define mock.On call
+func (_e *MockTaggedCache_Expecter) Tags() *MockTaggedCache_Tags_Call {
+	return &MockTaggedCache_Tags_Call{Call: _e.mock.On("Tags")}
+}
+
+func (_c *MockTaggedCache_Tags_Call) Run(run func()) *MockTaggedCache_Tags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockTaggedCache_Tags_Call) Return(_a0 []string) *MockTaggedCache_Tags_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTaggedCache_Tags_Call) RunAndReturn(run func() []string) *MockTaggedCache_Tags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTaggedCache creates a new instance of MockTaggedCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTaggedCache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTaggedCache {
+	mock := &MockTaggedCache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -84,6 +84,9 @@ func (p *serviceProvider) Register(app di.Application) {
 	}
 
 	if cfg.Drivers.File != nil && cfg.Drivers.File.Enabled {
+		if cfg.Drivers.File.Encryption == nil {
+			cfg.Drivers.File.Encryption = &cfg.Encryption
+		}
 		// Đăng ký File Driver vào cache manager
 		fileDriver, err := driver.NewFileDriver(*cfg.Drivers.File)
 		if err != nil {
@@ -99,6 +102,9 @@ func (p *serviceProvider) Register(app di.Application) {
 		if redisManager == nil {
 			panic("Redis manager is nil, please ensure Redis provider is registered")
 		}
+		if cfg.Drivers.Redis.Encryption == nil {
+			cfg.Drivers.Redis.Encryption = &cfg.Encryption
+		}
 		// Đăng ký Redis Driver vào cache manager
 		redisDriver, err := driver.NewRedisDriver(*cfg.Drivers.Redis, redisManager)
 		if err != nil {
@@ -107,6 +113,15 @@ func (p *serviceProvider) Register(app di.Application) {
 		manager.AddDriver("redis", redisDriver)
 		c.Instance("cache.redis", redisDriver)
 		p.providers = append(p.providers, "cache.redis")
+
+		if bus := redisDriver.Invalidation(); bus != nil {
+			// Memory driver cục bộ bị xóa entry khi instance khác ghi vào Redis
+			if memoryDriver, err := manager.Driver("memory"); err == nil {
+				bus.Register(memoryDriver)
+			}
+			c.Instance("cache.invalidation", bus)
+			p.providers = append(p.providers, "cache.invalidation")
+		}
 	}
 
 	if cfg.Drivers.MongoDB != nil && cfg.Drivers.MongoDB.Enabled {
@@ -115,6 +130,9 @@ func (p *serviceProvider) Register(app di.Application) {
 			panic("MongoDB manager is nil, please ensure MongoDB provider is registered")
 		}
 
+		if cfg.Drivers.MongoDB.Encryption == nil {
+			cfg.Drivers.MongoDB.Encryption = &cfg.Encryption
+		}
 		// Đăng ký MongoDB Driver vào cache manager
 		mongodbDriver, err := driver.NewMongoDBDriver(*cfg.Drivers.MongoDB, mongodbManager)
 		if err != nil {
@@ -124,6 +142,27 @@ func (p *serviceProvider) Register(app di.Application) {
 		c.Instance("cache.mongodb", mongodbDriver)
 		p.providers = append(p.providers, "cache.mongodb")
 	}
+
+	if cfg.Drivers.Tiered != nil && cfg.Drivers.Tiered.Enabled {
+		// Các tầng tham chiếu tới những driver đã được đăng ký ở trên
+		tiers := make([]driver.Driver, 0, len(cfg.Drivers.Tiered.Tiers))
+		for _, name := range cfg.Drivers.Tiered.Tiers {
+			tier, err := manager.Driver(name)
+			if err != nil {
+				panic("Tiered driver tier '" + name + "' is not enabled: " + err.Error())
+			}
+			tiers = append(tiers, tier)
+		}
+
+		// Đăng ký Tiered Driver vào cache manager
+		tieredDriver, err := driver.NewTieredDriverWithConfig(*cfg.Drivers.Tiered, tiers...)
+		if err != nil {
+			panic("Failed to create Tiered driver: " + err.Error())
+		}
+		manager.AddDriver("tiered", tieredDriver)
+		c.Instance("cache.tiered", tieredDriver)
+		p.providers = append(p.providers, "cache.tiered")
+	}
 }
 
 // Boot được gọi sau khi tất cả các service provider đã được đăng ký.
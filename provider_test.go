@@ -1,6 +1,8 @@
 package cache_test
 
 import (
+	"context"
+	"encoding/base64"
 	"errors"
 	"testing"
 
@@ -8,6 +10,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"go.fork.vn/cache"
 	"go.fork.vn/cache/config"
+	"go.fork.vn/cache/driver"
 	cachemocks "go.fork.vn/cache/mocks"
 	configmocks "go.fork.vn/config/mocks"
 	"go.fork.vn/di"
@@ -161,6 +164,142 @@ func TestServiceProvider_Register(t *testing.T) {
 		mockConfigManager.AssertExpectations(t)
 	})
 
+	t.Run("register_tiered_driver_successfully", func(t *testing.T) {
+		// Arrange
+		provider := cache.NewServiceProvider()
+		mockApp := &dimocks.MockApplication{}
+		mockContainer := &dimocks.MockContainer{}
+		mockConfigManager := &configmocks.MockManager{}
+		tempDir := t.TempDir()
+
+		mockApp.EXPECT().Container().Return(mockContainer).Times(1)
+		mockContainer.EXPECT().MustMake("config").Return(mockConfigManager).Times(1)
+		mockConfigManager.EXPECT().UnmarshalKey("cache", mock.AnythingOfType("*config.Config")).RunAndReturn(
+			func(key string, target interface{}) error {
+				cfg := target.(*config.Config)
+				cfg.Drivers = config.DriversConfig{
+					Memory: &config.DriverMemoryConfig{Enabled: true},
+					File:   &config.DriverFileConfig{Enabled: true, Path: tempDir},
+					Tiered: &config.DriverTieredConfig{
+						Enabled:     true,
+						Tiers:       []string{"memory", "file"},
+						BackfillTTL: 30,
+					},
+				}
+				return nil
+			}).Times(1)
+
+		var manager cache.Manager
+		var tiered driver.TieredDriver
+		mockContainer.EXPECT().Instance("cache", mock.Anything).Run(func(_ string, instance interface{}) {
+			manager = instance.(cache.Manager)
+		}).Return().Times(1)
+		mockContainer.EXPECT().Instance("cache.memory", mock.Anything).Return().Times(1)
+		mockContainer.EXPECT().Instance("cache.file", mock.Anything).Return().Times(1)
+		mockContainer.EXPECT().Instance("cache.tiered", mock.Anything).Run(func(_ string, instance interface{}) {
+			tiered = instance.(driver.TieredDriver)
+		}).Return().Times(1)
+
+		// Act
+		assert.NotPanics(t, func() {
+			provider.Register(mockApp)
+		})
+		defer manager.Close()
+
+		// Assert
+		memoryDriver, _ := manager.Driver("memory")
+		fileDriver, _ := manager.Driver("file")
+		tieredDriver, err := manager.Driver("tiered")
+		assert.NoError(t, err)
+		assert.Same(t, tiered, tieredDriver)
+		assert.Equal(t, []driver.Driver{memoryDriver, fileDriver}, tiered.Tiers())
+		assert.Contains(t, provider.Providers(), "cache.tiered")
+
+		mockApp.AssertExpectations(t)
+		mockContainer.AssertExpectations(t)
+		mockConfigManager.AssertExpectations(t)
+	})
+
+	t.Run("file_driver_uses_top_level_encryption", func(t *testing.T) {
+		// Arrange
+		provider := cache.NewServiceProvider()
+		mockApp := &dimocks.MockApplication{}
+		mockContainer := &dimocks.MockContainer{}
+		mockConfigManager := &configmocks.MockManager{}
+		tempDir := t.TempDir()
+
+		mockApp.EXPECT().Container().Return(mockContainer).Times(1)
+		mockContainer.EXPECT().MustMake("config").Return(mockConfigManager).Times(1)
+		mockConfigManager.EXPECT().UnmarshalKey("cache", mock.AnythingOfType("*config.Config")).RunAndReturn(
+			func(key string, target interface{}) error {
+				cfg := target.(*config.Config)
+				cfg.Drivers = config.DriversConfig{
+					File: &config.DriverFileConfig{Enabled: true, Path: tempDir},
+				}
+				cfg.Encryption = config.EncryptionConfig{
+					Enabled:   true,
+					ActiveKey: "2024-01",
+					Keys: []config.EncryptionKeyConfig{
+						{ID: "2024-01", Key: base64.StdEncoding.EncodeToString(make([]byte, 32))},
+					},
+				}
+				return nil
+			}).Times(1)
+
+		var fileDriver driver.FileDriver
+		mockContainer.EXPECT().Instance("cache", mock.Anything).Return().Times(1)
+		mockContainer.EXPECT().Instance("cache.file", mock.Anything).Run(func(_ string, instance interface{}) {
+			fileDriver = instance.(driver.FileDriver)
+		}).Return().Times(1)
+
+		// Act
+		assert.NotPanics(t, func() {
+			provider.Register(mockApp)
+		})
+		defer fileDriver.Close()
+
+		// Assert
+		assert.Equal(t, "2024-01", fileDriver.Stats(context.Background())["encryption_key"])
+
+		mockApp.AssertExpectations(t)
+		mockContainer.AssertExpectations(t)
+		mockConfigManager.AssertExpectations(t)
+	})
+
+	t.Run("panic_when_tiered_tier_is_not_enabled", func(t *testing.T) {
+		// Arrange
+		provider := cache.NewServiceProvider()
+		mockApp := &dimocks.MockApplication{}
+		mockContainer := &dimocks.MockContainer{}
+		mockConfigManager := &configmocks.MockManager{}
+
+		mockApp.EXPECT().Container().Return(mockContainer).Times(1)
+		mockContainer.EXPECT().MustMake("config").Return(mockConfigManager).Times(1)
+		mockConfigManager.EXPECT().UnmarshalKey("cache", mock.AnythingOfType("*config.Config")).RunAndReturn(
+			func(key string, target interface{}) error {
+				cfg := target.(*config.Config)
+				cfg.Drivers = config.DriversConfig{
+					Memory: &config.DriverMemoryConfig{Enabled: true},
+					Tiered: &config.DriverTieredConfig{
+						Enabled: true,
+						Tiers:   []string{"memory", "redis"},
+					},
+				}
+				return nil
+			}).Times(1)
+		mockContainer.EXPECT().Instance("cache", mock.Anything).Return().Times(1)
+		mockContainer.EXPECT().Instance("cache.memory", mock.Anything).Return().Times(1)
+
+		// Act & Assert
+		assert.PanicsWithValue(t, "Tiered driver tier 'redis' is not enabled: cache driver 'redis' not found", func() {
+			provider.Register(mockApp)
+		})
+
+		mockApp.AssertExpectations(t)
+		mockContainer.AssertExpectations(t)
+		mockConfigManager.AssertExpectations(t)
+	})
+
 	t.Run("register_redis_driver_failure_due_to_interface_conversion", func(t *testing.T) {
 		// Arrange
 		provider := cache.NewServiceProvider()
@@ -0,0 +1,146 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// algorithm tính quyết định giới hạn từ trạng thái đã lưu của một key.
+//
+// Trạng thái được mã hóa thành chuỗi để đi qua được serializer của mọi driver.
+type algorithm interface {
+	// apply tính decision cho n request tại now (UnixNano) từ state hiện tại
+	// (rỗng nếu key chưa có trạng thái); n = 0 tính decision cho một request mà
+	// không ghi nhận. Trả về trạng thái mới và thời gian sống của nó.
+	apply(state string, now int64, n int) (string, time.Duration, decision)
+}
+
+// newAlgorithm tạo cài đặt của thuật toán cho các driver không hỗ trợ Lua script.
+//
+// Params:
+//   - name: Thuật toán giới hạn
+//   - limit: Số request tối đa trong window
+//   - window: Độ dài cửa sổ
+//
+// Returns:
+//   - algorithm: Cài đặt của thuật toán
+//   - error: Lỗi nếu thuật toán không được hỗ trợ
+func newAlgorithm(name Algorithm, limit int, window time.Duration) (algorithm, error) {
+	switch name {
+	case FixedWindow:
+		return fixedWindow{limit: limit, window: int64(window)}, nil
+	case SlidingWindowLog:
+		return slidingWindowLog{limit: limit, window: int64(window)}, nil
+	case TokenBucket:
+		return tokenBucket{capacity: float64(limit), rate: float64(limit) / float64(window)}, nil
+	}
+	return nil, fmt.Errorf("ratelimit: unsupported algorithm '%s'", name)
+}
+
+// fixedWindow cài đặt FixedWindow với trạng thái "<start> <count>".
+type fixedWindow struct {
+	limit  int   // Số request tối đa trong cửa sổ
+	window int64 // Độ dài cửa sổ (nanoseconds)
+}
+
+func (a fixedWindow) apply(state string, now int64, n int) (string, time.Duration, decision) {
+	start, count := now, 0
+	if fields := strings.Fields(state); len(fields) == 2 {
+		s, errStart := strconv.ParseInt(fields[0], 10, 64)
+		c, errCount := strconv.Atoi(fields[1])
+		if errStart == nil && errCount == nil && now < s+a.window {
+			start, count = s, c
+		}
+	}
+
+	resetAfter := time.Duration(start + a.window - now)
+	need := max(n, 1)
+	if count+need > a.limit {
+		return state, resetAfter, decision{allowed: false, remaining: max(a.limit-count, 0), retryAfter: resetAfter}
+	}
+	if n == 0 {
+		return state, resetAfter, decision{allowed: true, remaining: a.limit - count}
+	}
+
+	count += n
+	next := strconv.FormatInt(start, 10) + " " + strconv.Itoa(count)
+	return next, resetAfter, decision{allowed: true, remaining: a.limit - count}
+}
+
+// slidingWindowLog cài đặt SlidingWindowLog với trạng thái là danh sách thời điểm của
+// các request, cách nhau bởi dấu cách và theo thứ tự tăng dần.
+type slidingWindowLog struct {
+	limit  int   // Số request tối đa trong mọi khoảng window
+	window int64 // Độ dài cửa sổ (nanoseconds)
+}
+
+func (a slidingWindowLog) apply(state string, now int64, n int) (string, time.Duration, decision) {
+	var log []int64
+	for _, field := range strings.Fields(state) {
+		ts, err := strconv.ParseInt(field, 10, 64)
+		if err == nil && ts > now-a.window {
+			log = append(log, ts)
+		}
+	}
+
+	count := len(log)
+	need := max(n, 1)
+	if count+need > a.limit {
+		retryAfter := time.Duration(a.window)
+		// Cần count+need-limit request cũ nhất rời khỏi cửa sổ
+		if idx := count + need - a.limit - 1; idx < count {
+			retryAfter = time.Duration(log[idx] + a.window - now)
+		}
+		return state, time.Duration(a.window), decision{allowed: false, remaining: max(a.limit-count, 0), retryAfter: retryAfter}
+	}
+	if n == 0 {
+		return state, time.Duration(a.window), decision{allowed: true, remaining: a.limit - count}
+	}
+
+	fields := make([]string, 0, count+n)
+	for _, ts := range log {
+		fields = append(fields, strconv.FormatInt(ts, 10))
+	}
+	for i := 0; i < n; i++ {
+		fields = append(fields, strconv.FormatInt(now, 10))
+	}
+	return strings.Join(fields, " "), time.Duration(a.window), decision{allowed: true, remaining: a.limit - count - n}
+}
+
+// tokenBucket cài đặt TokenBucket với trạng thái "<tokens> <last>".
+type tokenBucket struct {
+	capacity float64 // Số token tối đa của bucket
+	rate     float64 // Số token được nạp mỗi nanosecond
+}
+
+func (a tokenBucket) apply(state string, now int64, n int) (string, time.Duration, decision) {
+	tokens := a.capacity
+	if fields := strings.Fields(state); len(fields) == 2 {
+		t, errTokens := strconv.ParseFloat(fields[0], 64)
+		last, errLast := strconv.ParseInt(fields[1], 10, 64)
+		if errTokens == nil && errLast == nil {
+			tokens = math.Min(a.capacity, t+float64(max(now-last, 0))*a.rate)
+		}
+	}
+
+	need := float64(max(n, 1))
+	if tokens < need {
+		retryAfter := time.Duration(math.Ceil((need - tokens) / a.rate))
+		return state, a.refillTime(tokens), decision{allowed: false, remaining: int(tokens), retryAfter: retryAfter}
+	}
+	if n == 0 {
+		return state, a.refillTime(tokens), decision{allowed: true, remaining: int(tokens)}
+	}
+
+	tokens -= float64(n)
+	next := strconv.FormatFloat(tokens, 'f', -1, 64) + " " + strconv.FormatInt(now, 10)
+	return next, a.refillTime(tokens), decision{allowed: true, remaining: int(tokens)}
+}
+
+// refillTime tính thời gian để bucket được nạp đầy từ số token hiện tại.
+func (a tokenBucket) refillTime(tokens float64) time.Duration {
+	return max(time.Duration(math.Ceil((a.capacity-tokens)/a.rate)), time.Millisecond)
+}
@@ -0,0 +1,220 @@
+// Package ratelimit cung cấp rate limiter xây dựng trên các cache driver.
+//
+// Limiter giới hạn số request theo từng key (ví dụ user ID hoặc route) với một trong
+// ba thuật toán: fixed window, sliding window log và token bucket. Trạng thái của mỗi key
+// được lưu trong cache driver, nên nhiều instance dùng chung một driver phân tán (redis,
+// mongodb) chia sẻ cùng giới hạn.
+//
+// Với Redis driver, mỗi thao tác là một Lua script nguyên tử. Với các driver khác, trạng
+// thái được cập nhật dưới khóa trong process và ghi bằng Add/CompareAndSwap, nên vẫn
+// đúng khi nhiều process dùng chung driver.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.fork.vn/cache/driver"
+)
+
+// Algorithm là thuật toán giới hạn của Limiter.
+type Algorithm string
+
+const (
+	// FixedWindow cho phép tối đa Limit request trong mỗi cửa sổ Window. Cửa sổ bắt đầu
+	// từ request đầu tiên và bộ đếm được đặt lại khi cửa sổ kết thúc.
+	FixedWindow Algorithm = "fixed_window"
+
+	// SlidingWindowLog lưu thời điểm của từng request và cho phép tối đa Limit request
+	// trong mọi khoảng Window liên tiếp. Chính xác nhất nhưng tốn bộ nhớ theo Limit.
+	SlidingWindowLog Algorithm = "sliding_window_log"
+
+	// TokenBucket cho phép burst tối đa Limit request, sau đó token được nạp lại đều với
+	// tốc độ Limit token mỗi Window.
+	TokenBucket Algorithm = "token_bucket"
+)
+
+// DefaultPrefix là tiền tố mặc định của các cache key lưu trạng thái limiter.
+//
+// Các key bắt đầu bằng "__" được dành cho dữ liệu nội bộ và không phải entry của cache.
+const DefaultPrefix = "__ratelimit:"
+
+// maxUpdateAttempts là số lần thử lại tối đa khi trạng thái của key bị một process
+// khác thay đổi giữa lần đọc và lần ghi.
+const maxUpdateAttempts = 16
+
+// Config là cấu hình của một Limiter.
+type Config struct {
+	Algorithm Algorithm     // Thuật toán giới hạn (mặc định FixedWindow)
+	Limit     int           // Số request tối đa trong Window (dung lượng bucket với TokenBucket)
+	Window    time.Duration // Độ dài cửa sổ (thời gian nạp đầy bucket với TokenBucket)
+	Prefix    string        // Tiền tố của cache key (mặc định DefaultPrefix)
+}
+
+// Limiter giới hạn số request theo từng key.
+//
+// Mọi phương thức an toàn khi được gọi đồng thời.
+type Limiter interface {
+	// Allow kiểm tra và ghi nhận một request cho key.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Key cần giới hạn (ví dụ "user:42" hoặc "route:/login")
+	//
+	// Returns:
+	//   - bool: true nếu request được phép
+	//   - error: Lỗi từ cache driver
+	Allow(ctx context.Context, key string) (bool, error)
+
+	// AllowN kiểm tra và ghi nhận n request cho key.
+	//
+	// Request bị từ chối không được ghi nhận; n request được phép hoặc bị từ chối cùng nhau.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Key cần giới hạn
+	//   - n: Số request (> 0)
+	//
+	// Returns:
+	//   - bool: true nếu cả n request được phép
+	//   - error: Lỗi từ cache driver hoặc n không hợp lệ
+	AllowN(ctx context.Context, key string, n int) (bool, error)
+
+	// Remaining trả về số request còn được phép cho key tại thời điểm hiện tại.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Key cần kiểm tra
+	//
+	// Returns:
+	//   - int: Số request còn được phép
+	//   - error: Lỗi từ cache driver
+	Remaining(ctx context.Context, key string) (int, error)
+
+	// RetryAfter trả về thời gian cần chờ trước khi request tiếp theo của key được phép.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Key cần kiểm tra
+	//
+	// Returns:
+	//   - time.Duration: Thời gian cần chờ, 0 nếu request được phép ngay
+	//   - error: Lỗi từ cache driver
+	RetryAfter(ctx context.Context, key string) (time.Duration, error)
+
+	// Reset xóa trạng thái của key, cho phép lại toàn bộ giới hạn.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Key cần đặt lại
+	//
+	// Returns:
+	//   - error: Lỗi từ cache driver
+	Reset(ctx context.Context, key string) error
+}
+
+// decision là kết quả của một lần kiểm tra giới hạn.
+type decision struct {
+	allowed    bool          // true nếu request được phép
+	remaining  int           // Số request còn được phép sau lần kiểm tra
+	retryAfter time.Duration // Thời gian cần chờ trước khi request được phép
+}
+
+// store thực hiện các thuật toán giới hạn trên một cache driver.
+type store interface {
+	// take kiểm tra n request cho key và ghi nhận nếu được phép; n = 0 chỉ đọc trạng thái
+	// và tính decision cho một request.
+	take(ctx context.Context, key string, n int) (decision, error)
+}
+
+// limiter cài đặt Limiter trên một cache driver.
+type limiter struct {
+	driver driver.Driver // Driver lưu trạng thái
+	store  store         // Cài đặt thuật toán cho driver
+	prefix string        // Tiền tố của cache key
+}
+
+// New tạo một Limiter trên cache driver.
+//
+// Với tiered driver, trạng thái được lưu ở tầng cuối, là tầng dùng chung giữa các instance.
+//
+// Params:
+//   - d: Cache driver lưu trạng thái của limiter
+//   - cfg: Cấu hình của limiter
+//
+// Returns:
+//   - Limiter: Limiter đã được khởi tạo
+//   - error: Lỗi nếu cấu hình không hợp lệ
+func New(d driver.Driver, cfg Config) (Limiter, error) {
+	if cfg.Limit <= 0 {
+		return nil, errors.New("ratelimit: limit must be positive")
+	}
+	if cfg.Window < time.Millisecond {
+		return nil, errors.New("ratelimit: window must be at least 1ms")
+	}
+	if cfg.Algorithm == "" {
+		cfg.Algorithm = FixedWindow
+	}
+	if cfg.Prefix == "" {
+		cfg.Prefix = DefaultPrefix
+	}
+
+	if tiered, ok := d.(driver.TieredDriver); ok {
+		tiers := tiered.Tiers()
+		d = tiers[len(tiers)-1]
+	}
+
+	var s store
+	if runner, ok := d.(driver.ScriptRunner); ok {
+		script, err := redisScript(cfg.Algorithm)
+		if err != nil {
+			return nil, err
+		}
+		owner, err := driver.NewLockOwner()
+		if err != nil {
+			return nil, err
+		}
+		s = &scriptStore{runner: runner, script: script, limit: cfg.Limit, window: cfg.Window, id: owner}
+	} else {
+		alg, err := newAlgorithm(cfg.Algorithm, cfg.Limit, cfg.Window)
+		if err != nil {
+			return nil, err
+		}
+		s = &driverStore{driver: d, algorithm: alg}
+	}
+
+	return &limiter{driver: d, store: s, prefix: cfg.Prefix}, nil
+}
+
+// Allow kiểm tra và ghi nhận một request cho key.
+func (l *limiter) Allow(ctx context.Context, key string) (bool, error) {
+	return l.AllowN(ctx, key, 1)
+}
+
+// AllowN kiểm tra và ghi nhận n request cho key.
+func (l *limiter) AllowN(ctx context.Context, key string, n int) (bool, error) {
+	if n <= 0 {
+		return false, fmt.Errorf("ratelimit: n must be positive, got %d", n)
+	}
+	d, err := l.store.take(ctx, l.prefix+key, n)
+	return d.allowed, err
+}
+
+// Remaining trả về số request còn được phép cho key tại thời điểm hiện tại.
+func (l *limiter) Remaining(ctx context.Context, key string) (int, error) {
+	d, err := l.store.take(ctx, l.prefix+key, 0)
+	return d.remaining, err
+}
+
+// RetryAfter trả về thời gian cần chờ trước khi request tiếp theo của key được phép.
+func (l *limiter) RetryAfter(ctx context.Context, key string) (time.Duration, error) {
+	d, err := l.store.take(ctx, l.prefix+key, 0)
+	return d.retryAfter, err
+}
+
+// Reset xóa trạng thái của key.
+func (l *limiter) Reset(ctx context.Context, key string) error {
+	return l.driver.Delete(ctx, l.prefix+key)
+}
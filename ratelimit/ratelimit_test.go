@@ -0,0 +1,287 @@
+// Package ratelimit_test cung cấp các test cho package ratelimit
+package ratelimit_test
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.fork.vn/cache/config"
+	"go.fork.vn/cache/driver"
+	"go.fork.vn/cache/ratelimit"
+	redispkg "go.fork.vn/redis"
+)
+
+// redisManager là redis Manager tối thiểu trả về một client có sẵn
+type redisManager struct {
+	client *redis.Client
+}
+
+func (m *redisManager) Client() (*redis.Client, error) {
+	return m.client, nil
+}
+
+func (m *redisManager) UniversalClient() (*redis.UniversalClient, error) {
+	return nil, nil
+}
+
+func (m *redisManager) GetConfig() *redispkg.Config {
+	return nil
+}
+
+func (m *redisManager) Close() error {
+	return nil
+}
+
+func (m *redisManager) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (m *redisManager) ClusterPing(ctx context.Context) error {
+	return nil
+}
+
+// newMemoryDriver tạo memory driver cho test
+func newMemoryDriver(t *testing.T) driver.Driver {
+	memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+	t.Cleanup(func() { _ = memoryDriver.Close() })
+	return memoryDriver
+}
+
+// newFileDriver tạo file driver trong thư mục tạm cho test
+func newFileDriver(t *testing.T) driver.Driver {
+	dir, err := os.MkdirTemp("", "ratelimit-test")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	fileDriver, err := driver.NewFileDriver(config.DriverFileConfig{Path: dir, DefaultTTL: 300})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = fileDriver.Close() })
+	return fileDriver
+}
+
+// newRedisDriver tạo redis driver trên miniredis cho test
+func newRedisDriver(t *testing.T) (driver.Driver, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	redisDriver, err := driver.NewRedisDriver(config.DriverRedisConfig{
+		Enabled:    true,
+		DefaultTTL: 300,
+		Serializer: "json",
+	}, &redisManager{client: client})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = redisDriver.Close() })
+	return redisDriver, server
+}
+
+// TestNew kiểm tra việc kiểm tra cấu hình khi tạo Limiter
+func TestNew(t *testing.T) {
+	d := newMemoryDriver(t)
+
+	_, err := ratelimit.New(d, ratelimit.Config{Limit: 0, Window: time.Second})
+	assert.Error(t, err)
+
+	_, err = ratelimit.New(d, ratelimit.Config{Limit: 1, Window: time.Microsecond})
+	assert.Error(t, err)
+
+	_, err = ratelimit.New(d, ratelimit.Config{Algorithm: "leaky_bucket", Limit: 1, Window: time.Second})
+	assert.Error(t, err)
+
+	limiter, err := ratelimit.New(d, ratelimit.Config{Limit: 1, Window: time.Second})
+	require.NoError(t, err)
+
+	_, err = limiter.AllowN(context.Background(), "user:1", 0)
+	assert.Error(t, err)
+}
+
+// TestLimiter kiểm tra các thuật toán trên memory, file và redis driver
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+	algorithms := []ratelimit.Algorithm{ratelimit.FixedWindow, ratelimit.SlidingWindowLog, ratelimit.TokenBucket}
+	drivers := map[string]func(t *testing.T) driver.Driver{
+		"memory": newMemoryDriver,
+		"file":   newFileDriver,
+		"redis": func(t *testing.T) driver.Driver {
+			d, _ := newRedisDriver(t)
+			return d
+		},
+	}
+
+	for name, newDriver := range drivers {
+		for _, algorithm := range algorithms {
+			t.Run(name+"/"+string(algorithm), func(t *testing.T) {
+				limiter, err := ratelimit.New(newDriver(t), ratelimit.Config{
+					Algorithm: algorithm,
+					Limit:     3,
+					Window:    time.Minute,
+				})
+				require.NoError(t, err)
+
+				remaining, err := limiter.Remaining(ctx, "user:1")
+				require.NoError(t, err)
+				assert.Equal(t, 3, remaining)
+
+				for i := 0; i < 3; i++ {
+					allowed, err := limiter.Allow(ctx, "user:1")
+					require.NoError(t, err)
+					assert.True(t, allowed, "request %d", i+1)
+				}
+
+				allowed, err := limiter.Allow(ctx, "user:1")
+				require.NoError(t, err)
+				assert.False(t, allowed)
+
+				remaining, err = limiter.Remaining(ctx, "user:1")
+				require.NoError(t, err)
+				assert.Equal(t, 0, remaining)
+
+				retryAfter, err := limiter.RetryAfter(ctx, "user:1")
+				require.NoError(t, err)
+				assert.Greater(t, retryAfter, time.Duration(0))
+				assert.LessOrEqual(t, retryAfter, time.Minute)
+
+				// Các key được giới hạn độc lập
+				allowed, err = limiter.Allow(ctx, "user:2")
+				require.NoError(t, err)
+				assert.True(t, allowed)
+
+				require.NoError(t, limiter.Reset(ctx, "user:1"))
+				remaining, err = limiter.Remaining(ctx, "user:1")
+				require.NoError(t, err)
+				assert.Equal(t, 3, remaining)
+
+				retryAfter, err = limiter.RetryAfter(ctx, "user:1")
+				require.NoError(t, err)
+				assert.Equal(t, time.Duration(0), retryAfter)
+			})
+		}
+	}
+}
+
+// TestLimiter_AllowN kiểm tra việc n request được phép hoặc bị từ chối cùng nhau
+func TestLimiter_AllowN(t *testing.T) {
+	ctx := context.Background()
+	for _, algorithm := range []ratelimit.Algorithm{ratelimit.FixedWindow, ratelimit.SlidingWindowLog, ratelimit.TokenBucket} {
+		t.Run(string(algorithm), func(t *testing.T) {
+			limiter, err := ratelimit.New(newMemoryDriver(t), ratelimit.Config{
+				Algorithm: algorithm,
+				Limit:     5,
+				Window:    time.Minute,
+			})
+			require.NoError(t, err)
+
+			allowed, err := limiter.AllowN(ctx, "route:/upload", 4)
+			require.NoError(t, err)
+			assert.True(t, allowed)
+
+			// Request bị từ chối không được ghi nhận
+			allowed, err = limiter.AllowN(ctx, "route:/upload", 2)
+			require.NoError(t, err)
+			assert.False(t, allowed)
+
+			remaining, err := limiter.Remaining(ctx, "route:/upload")
+			require.NoError(t, err)
+			assert.Equal(t, 1, remaining)
+
+			allowed, err = limiter.AllowN(ctx, "route:/upload", 1)
+			require.NoError(t, err)
+			assert.True(t, allowed)
+		})
+	}
+}
+
+// TestLimiter_WindowExpiry kiểm tra việc giới hạn được nạp lại sau window
+func TestLimiter_WindowExpiry(t *testing.T) {
+	ctx := context.Background()
+	for _, algorithm := range []ratelimit.Algorithm{ratelimit.FixedWindow, ratelimit.SlidingWindowLog, ratelimit.TokenBucket} {
+		t.Run(string(algorithm), func(t *testing.T) {
+			limiter, err := ratelimit.New(newMemoryDriver(t), ratelimit.Config{
+				Algorithm: algorithm,
+				Limit:     2,
+				Window:    100 * time.Millisecond,
+			})
+			require.NoError(t, err)
+
+			for i := 0; i < 2; i++ {
+				allowed, _ := limiter.Allow(ctx, "user:1")
+				require.True(t, allowed)
+			}
+			allowed, _ := limiter.Allow(ctx, "user:1")
+			require.False(t, allowed)
+
+			time.Sleep(120 * time.Millisecond)
+
+			allowed, err = limiter.Allow(ctx, "user:1")
+			require.NoError(t, err)
+			assert.True(t, allowed)
+		})
+	}
+}
+
+// TestLimiter_RedisWindowExpiry kiểm tra fixed window trên redis hết hạn theo TTL của key
+func TestLimiter_RedisWindowExpiry(t *testing.T) {
+	ctx := context.Background()
+	redisDriver, server := newRedisDriver(t)
+
+	limiter, err := ratelimit.New(redisDriver, ratelimit.Config{Limit: 1, Window: time.Minute})
+	require.NoError(t, err)
+
+	allowed, _ := limiter.Allow(ctx, "user:1")
+	require.True(t, allowed)
+	allowed, _ = limiter.Allow(ctx, "user:1")
+	require.False(t, allowed)
+
+	server.FastForward(time.Minute)
+
+	allowed, err = limiter.Allow(ctx, "user:1")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+// TestLimiter_Concurrent kiểm tra việc không vượt giới hạn khi có nhiều goroutine đồng thời
+func TestLimiter_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	redisDriver, _ := newRedisDriver(t)
+	drivers := map[string]driver.Driver{
+		"memory": newMemoryDriver(t),
+		"redis":  redisDriver,
+	}
+
+	for name, d := range drivers {
+		t.Run(name, func(t *testing.T) {
+			limiter, err := ratelimit.New(d, ratelimit.Config{
+				Algorithm: ratelimit.SlidingWindowLog,
+				Limit:     10,
+				Window:    time.Minute,
+			})
+			require.NoError(t, err)
+
+			var mu sync.Mutex
+			var wg sync.WaitGroup
+			allowedCount := 0
+			for i := 0; i < 50; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					allowed, err := limiter.Allow(ctx, "user:1")
+					assert.NoError(t, err)
+					if allowed {
+						mu.Lock()
+						allowedCount++
+						mu.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+
+			assert.Equal(t, 10, allowedCount)
+		})
+	}
+}
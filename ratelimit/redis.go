@@ -0,0 +1,158 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.fork.vn/cache/driver"
+)
+
+// Các script nhận KEYS[1] là key trạng thái và ARGV gồm limit, window (milliseconds),
+// n (0 để chỉ đọc), thời điểm hiện tại (milliseconds) và tiền tố thành viên duy nhất.
+// Kết quả là {allowed, remaining, retry_after_ms}.
+
+// redisFixedWindowScript đếm request bằng INCRBY; cửa sổ bắt đầu từ request đầu tiên
+// và kết thúc khi key hết hạn.
+var redisFixedWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+local count = tonumber(redis.call("GET", KEYS[1]) or "0")
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl < 0 then
+	ttl = window
+end
+local need = math.max(n, 1)
+if count + need > limit then
+	return {0, limit - count, ttl}
+end
+if n == 0 then
+	return {1, limit - count, 0}
+end
+count = redis.call("INCRBY", KEYS[1], n)
+if redis.call("PTTL", KEYS[1]) < 0 then
+	redis.call("PEXPIRE", KEYS[1], window)
+end
+return {1, limit - count, 0}
+`)
+
+// redisSlidingWindowLogScript lưu thời điểm của từng request trong một sorted set.
+var redisSlidingWindowLogScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+local now = tonumber(ARGV[4])
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - window)
+local count = redis.call("ZCARD", KEYS[1])
+local need = math.max(n, 1)
+if count + need > limit then
+	local retry = window
+	local idx = count + need - limit - 1
+	if idx < count then
+		local entry = redis.call("ZRANGE", KEYS[1], idx, idx, "WITHSCORES")
+		retry = tonumber(entry[2]) + window - now
+	end
+	return {0, limit - count, retry}
+end
+if n == 0 then
+	return {1, limit - count, 0}
+end
+local members = {}
+for i = 1, n do
+	table.insert(members, now)
+	table.insert(members, ARGV[5] .. ":" .. i)
+end
+redis.call("ZADD", KEYS[1], unpack(members))
+redis.call("PEXPIRE", KEYS[1], window)
+return {1, limit - count - n, 0}
+`)
+
+// redisTokenBucketScript lưu số token và thời điểm nạp gần nhất trong một hash.
+var redisTokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+local now = tonumber(ARGV[4])
+local rate = capacity / window
+local state = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = capacity
+else
+	tokens = math.min(capacity, tokens + math.max(now - ts, 0) * rate)
+end
+local need = math.max(n, 1)
+if tokens < need then
+	return {0, math.floor(tokens), math.ceil((need - tokens) / rate)}
+end
+if n == 0 then
+	return {1, math.floor(tokens), 0}
+end
+tokens = tokens - n
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.max(math.ceil((capacity - tokens) / rate), 1))
+return {1, math.floor(tokens), 0}
+`)
+
+// redisScript trả về Lua script của thuật toán.
+//
+// Params:
+//   - name: Thuật toán giới hạn
+//
+// Returns:
+//   - *redis.Script: Script của thuật toán
+//   - error: Lỗi nếu thuật toán không được hỗ trợ
+func redisScript(name Algorithm) (*redis.Script, error) {
+	switch name {
+	case FixedWindow:
+		return redisFixedWindowScript, nil
+	case SlidingWindowLog:
+		return redisSlidingWindowLogScript, nil
+	case TokenBucket:
+		return redisTokenBucketScript, nil
+	}
+	return nil, fmt.Errorf("ratelimit: unsupported algorithm '%s'", name)
+}
+
+// scriptStore cài đặt store bằng Lua script nguyên tử trên Redis.
+type scriptStore struct {
+	runner driver.ScriptRunner // Driver thực thi script
+	script *redis.Script       // Script của thuật toán
+	limit  int                 // Số request tối đa trong window
+	window time.Duration       // Độ dài cửa sổ
+	id     string              // Định danh của limiter, dùng tạo thành viên duy nhất của sorted set
+	seq    atomic.Uint64       // Bộ đếm các lần gọi script
+}
+
+// take kiểm tra n request cho key và ghi nhận nếu được phép trong một Lua script.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key lưu trạng thái
+//   - n: Số request, 0 để chỉ đọc trạng thái
+//
+// Returns:
+//   - decision: Kết quả kiểm tra
+//   - error: Lỗi từ Redis
+func (s *scriptStore) take(ctx context.Context, key string, n int) (decision, error) {
+	member := s.id + ":" + strconv.FormatUint(s.seq.Add(1), 10)
+	result, err := s.runner.RunScript(ctx, s.script, []string{key},
+		s.limit, s.window.Milliseconds(), n, time.Now().UnixMilli(), member,
+	).Int64Slice()
+	if err != nil {
+		return decision{}, err
+	}
+	if len(result) != 3 {
+		return decision{}, fmt.Errorf("ratelimit: unexpected script result %v", result)
+	}
+	return decision{
+		allowed:    result[0] == 1,
+		remaining:  max(int(result[1]), 0),
+		retryAfter: time.Duration(max(result[2], 0)) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"go.fork.vn/cache/driver"
+)
+
+// lockStripes là số mutex dùng để tuần tự hóa các lần cập nhật trong process.
+const lockStripes = 64
+
+// driverStore cài đặt store trên các thao tác chung của driver.Driver.
+//
+// Các lần cập nhật cùng key trong process được tuần tự hóa bằng mutex; trạng thái được
+// ghi bằng Add hoặc CompareAndSwap nên lần ghi bị process khác chen vào sẽ được thử lại.
+type driverStore struct {
+	driver    driver.Driver           // Driver lưu trạng thái
+	algorithm algorithm               // Thuật toán giới hạn
+	locks     [lockStripes]sync.Mutex // Mutex theo key
+}
+
+// take kiểm tra n request cho key và ghi nhận nếu được phép.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key lưu trạng thái
+//   - n: Số request, 0 để chỉ đọc trạng thái
+//
+// Returns:
+//   - decision: Kết quả kiểm tra
+//   - error: Lỗi từ driver hoặc khi không ghi được trạng thái sau nhiều lần thử
+func (s *driverStore) take(ctx context.Context, key string, n int) (decision, error) {
+	mu := s.lock(key)
+	mu.Lock()
+	defer mu.Unlock()
+
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		value, token, found := s.driver.GetWithVersion(ctx, key)
+		state, _ := value.(string)
+
+		next, ttl, d := s.algorithm.apply(state, time.Now().UnixNano(), n)
+		if n == 0 || !d.allowed {
+			return d, nil
+		}
+
+		var written bool
+		var err error
+		if found {
+			written, err = s.driver.CompareAndSwap(ctx, key, token, next, ttl)
+		} else {
+			written, err = s.driver.Add(ctx, key, next, ttl)
+		}
+		if err != nil {
+			return decision{}, err
+		}
+		if written {
+			return d, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return decision{}, err
+		}
+	}
+	return decision{}, fmt.Errorf("ratelimit: too many concurrent updates for key '%s'", key)
+}
+
+// lock trả về mutex của key.
+func (s *driverStore) lock(key string) *sync.Mutex {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return &s.locks[h.Sum32()%lockStripes]
+}
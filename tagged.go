@@ -0,0 +1,275 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"go.fork.vn/cache/driver"
+)
+
+// TaggedCache là một view của cache gắn với một tập tag.
+//
+// Mọi entry được ghi qua TaggedCache đều mang các tag của nó, và Flush xóa tất cả
+// các entry mang ít nhất một trong các tag đó trên driver mặc định của manager.
+type TaggedCache interface {
+	// Tags trả về danh sách tag của view.
+	//
+	// Returns:
+	//   - []string: Danh sách tag
+	Tags() []string
+
+	// Set đặt một giá trị vào cache với các tag của view.
+	//
+	// Params:
+	//   - key: Cache key để lưu giá trị
+	//   - value: Giá trị cần lưu trữ
+	//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
+	Set(key string, value interface{}, ttl time.Duration) error
+
+	// SetContext đặt một giá trị vào cache với các tag của view và context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Cache key để lưu giá trị
+	//   - value: Giá trị cần lưu trữ
+	//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
+	SetContext(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+
+	// SetMultiple đặt nhiều giá trị vào cache với các tag của view.
+	//
+	// Params:
+	//   - values: Map chứa các key và giá trị tương ứng cần lưu trữ
+	//   - ttl: Thời gian sống chung cho tất cả các giá trị
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình thực hiện hoặc driver mặc định không được cấu hình
+	SetMultiple(values map[string]interface{}, ttl time.Duration) error
+
+	// SetMultipleContext đặt nhiều giá trị vào cache với các tag của view và context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - values: Map chứa các key và giá trị tương ứng cần lưu trữ
+	//   - ttl: Thời gian sống chung cho tất cả các giá trị
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình thực hiện hoặc driver mặc định không được cấu hình
+	SetMultipleContext(ctx context.Context, values map[string]interface{}, ttl time.Duration) error
+
+	// Remember lấy một giá trị từ cache hoặc thực thi callback nếu không tìm thấy.
+	//
+	// Giá trị lấy từ callback được lưu với các tag của view.
+	//
+	// Params:
+	//   - key: Cache key cần tìm hoặc lưu vào cache
+	//   - ttl: Thời gian sống của giá trị nếu phải lấy từ callback
+	//   - callback: Hàm được gọi để lấy dữ liệu khi key không có trong cache
+	//
+	// Returns:
+	//   - interface{}: Giá trị từ cache hoặc từ callback
+	//   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc driver mặc định không được cấu hình
+	Remember(key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error)
+
+	// RememberContext lấy một giá trị từ cache hoặc thực thi callback nếu không tìm thấy,
+	// với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//   - key: Cache key cần tìm hoặc lưu vào cache
+	//   - ttl: Thời gian sống của giá trị nếu phải lấy từ callback
+	//   - callback: Hàm được gọi để lấy dữ liệu khi key không có trong cache
+	//
+	// Returns:
+	//   - interface{}: Giá trị từ cache hoặc từ callback
+	//   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc driver mặc định không được cấu hình
+	RememberContext(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error)
+
+	// Flush xóa tất cả các entry mang ít nhất một trong các tag của view.
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
+	Flush() error
+
+	// FlushContext xóa tất cả các entry mang ít nhất một trong các tag của view
+	// với context được chỉ định.
+	//
+	// Params:
+	//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+	//
+	// Returns:
+	//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
+	FlushContext(ctx context.Context) error
+}
+
+// taggedCache là implementation mặc định của TaggedCache.
+type taggedCache struct {
+	manager *manager // Manager cung cấp driver mặc định
+	tags    []string // Các tag của view
+}
+
+// Tags trả về danh sách tag của view.
+//
+// Returns:
+//   - []string: Bản sao danh sách tag
+func (c *taggedCache) Tags() []string {
+	return append([]string(nil), c.tags...)
+}
+
+// Set đặt một giá trị vào cache với các tag của view.
+//
+// Params:
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
+func (c *taggedCache) Set(key string, value interface{}, ttl time.Duration) error {
+	return c.SetContext(context.Background(), key, value, ttl)
+}
+
+// SetContext đặt một giá trị vào cache với các tag của view và context được chỉ định.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình lưu trữ hoặc driver mặc định không được cấu hình
+func (c *taggedCache) SetContext(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	d, err := c.manager.DefaultDriver()
+	if err != nil {
+		return err
+	}
+	return d.SetTagged(ctx, key, value, ttl, c.tags)
+}
+
+// SetMultiple đặt nhiều giá trị vào cache với các tag của view.
+//
+// Params:
+//   - values: Map chứa các key và giá trị tương ứng cần lưu trữ
+//   - ttl: Thời gian sống chung cho tất cả các giá trị
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình thực hiện hoặc driver mặc định không được cấu hình
+func (c *taggedCache) SetMultiple(values map[string]interface{}, ttl time.Duration) error {
+	return c.SetMultipleContext(context.Background(), values, ttl)
+}
+
+// SetMultipleContext đặt nhiều giá trị vào cache với các tag của view và context được chỉ định.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - values: Map chứa các key và giá trị tương ứng cần lưu trữ
+//   - ttl: Thời gian sống chung cho tất cả các giá trị
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình thực hiện hoặc driver mặc định không được cấu hình
+func (c *taggedCache) SetMultipleContext(ctx context.Context, values map[string]interface{}, ttl time.Duration) error {
+	d, err := c.manager.DefaultDriver()
+	if err != nil {
+		return err
+	}
+	for key, value := range values {
+		if err := d.SetTagged(ctx, key, value, ttl, c.tags); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remember lấy một giá trị từ cache hoặc thực thi callback nếu không tìm thấy.
+//
+// Params:
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - ttl: Thời gian sống của giá trị nếu phải lấy từ callback
+//   - callback: Hàm được gọi để lấy dữ liệu khi key không có trong cache
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc driver mặc định không được cấu hình
+func (c *taggedCache) Remember(key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	return c.RememberContext(context.Background(), key, ttl, callback)
+}
+
+// RememberContext lấy một giá trị từ cache hoặc thực thi callback nếu không tìm thấy,
+// với context được chỉ định.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - ttl: Thời gian sống của giá trị nếu phải lấy từ callback
+//   - callback: Hàm được gọi để lấy dữ liệu khi key không có trong cache
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi nếu có trong quá trình thực hiện, từ callback, hoặc driver mặc định không được cấu hình
+func (c *taggedCache) RememberContext(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	d, err := c.manager.DefaultDriver()
+	if err != nil {
+		return nil, err
+	}
+	return rememberTagged(ctx, d, key, ttl, c.tags, callback)
+}
+
+// Flush xóa tất cả các entry mang ít nhất một trong các tag của view.
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
+func (c *taggedCache) Flush() error {
+	return c.FlushContext(context.Background())
+}
+
+// FlushContext xóa tất cả các entry mang ít nhất một trong các tag của view
+// với context được chỉ định.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình xóa hoặc driver mặc định không được cấu hình
+func (c *taggedCache) FlushContext(ctx context.Context) error {
+	d, err := c.manager.DefaultDriver()
+	if err != nil {
+		return err
+	}
+	return d.FlushTags(ctx, c.tags)
+}
+
+// rememberTagged cài đặt Remember pattern với các tag trên một driver.
+//
+// Giá trị được lấy bằng Remember của driver, nên các lần gọi đồng thời được gộp
+// (single-flight, khóa phân tán) và entry không giải mã được trả về lỗi thay vì bị ghi
+// đè. Goroutine đã thực thi callback ghi lại giá trị kèm các tag bằng SetTagged.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - d: Driver đích
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - ttl: Thời gian sống của giá trị nếu phải lấy từ callback
+//   - tags: Các tag gắn với entry mới
+//   - callback: Hàm được gọi để lấy dữ liệu khi key không có trong cache
+//
+// Returns:
+//   - interface{}: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi từ callback hoặc từ driver
+func rememberTagged(ctx context.Context, d driver.Driver, key string, ttl time.Duration, tags []string, callback func() (interface{}, error)) (interface{}, error) {
+	computed := false
+	value, err := d.Remember(ctx, key, ttl, func() (interface{}, error) {
+		computed = true
+		return callback()
+	})
+	if err != nil || !computed {
+		return value, err
+	}
+
+	return value, d.SetTagged(ctx, key, value, ttl, tags)
+}
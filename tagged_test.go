@@ -0,0 +1,177 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.fork.vn/cache"
+	"go.fork.vn/cache/config"
+	"go.fork.vn/cache/driver"
+	"go.fork.vn/cache/encryption"
+	cache_mocks "go.fork.vn/cache/mocks"
+)
+
+// TestTaggedCache kiểm tra Tags của Manager với memory driver thật
+func TestTaggedCache(t *testing.T) {
+	newManager := func(t *testing.T) cache.Manager {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+		t.Cleanup(func() { _ = memoryDriver.Close() })
+
+		manager := cache.NewManager()
+		manager.AddDriver("memory", memoryDriver)
+		return manager
+	}
+
+	t.Run("flush_drops_entries_carrying_tag", func(t *testing.T) {
+		manager := newManager(t)
+
+		assert.NoError(t, manager.Tags("user:42", "org:7").Set("user:42:profile", "An", time.Minute))
+		assert.NoError(t, manager.Tags("user:43", "org:7").Set("user:43:profile", "Binh", time.Minute))
+		assert.NoError(t, manager.Tags("user:44", "org:8").Set("user:44:profile", "Chi", time.Minute))
+		assert.NoError(t, manager.Set("plain", "value", time.Minute))
+
+		assert.NoError(t, manager.Tags("org:7").Flush())
+
+		assert.False(t, manager.Has("user:42:profile"))
+		assert.False(t, manager.Has("user:43:profile"))
+		assert.True(t, manager.Has("user:44:profile"))
+		assert.True(t, manager.Has("plain"))
+	})
+
+	t.Run("set_multiple_and_manager_flush_tags", func(t *testing.T) {
+		manager := newManager(t)
+
+		err := manager.Tags("reports").SetMultiple(map[string]interface{}{
+			"report:daily":  1,
+			"report:weekly": 2,
+		}, time.Minute)
+		assert.NoError(t, err)
+
+		assert.NoError(t, manager.FlushTags("reports"))
+
+		assert.False(t, manager.Has("report:daily"))
+		assert.False(t, manager.Has("report:weekly"))
+	})
+
+	t.Run("remember_tags_value_from_callback", func(t *testing.T) {
+		manager := newManager(t)
+		tagged := manager.Tags("org:7")
+		calls := 0
+		callback := func() (interface{}, error) {
+			calls++
+			return "computed", nil
+		}
+
+		value, err := tagged.Remember("org:7:settings", time.Minute, callback)
+		assert.NoError(t, err)
+		assert.Equal(t, "computed", value)
+
+		value, err = tagged.RememberContext(context.Background(), "org:7:settings", time.Minute, callback)
+		assert.NoError(t, err)
+		assert.Equal(t, "computed", value)
+		assert.Equal(t, 1, calls)
+
+		assert.NoError(t, tagged.Flush())
+		assert.False(t, manager.Has("org:7:settings"))
+	})
+
+	t.Run("remember_returns_callback_error", func(t *testing.T) {
+		manager := newManager(t)
+
+		_, err := manager.Tags("org:7").Remember("broken", time.Minute, func() (interface{}, error) {
+			return nil, errors.New("boom")
+		})
+
+		assert.EqualError(t, err, "boom")
+		assert.False(t, manager.Has("broken"))
+	})
+
+	t.Run("remember_runs_callback_once_for_concurrent_callers", func(t *testing.T) {
+		manager := newManager(t)
+		tagged := manager.Tags("org:7")
+		var calls atomic.Int32
+		release := make(chan struct{})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				value, err := tagged.Remember("org:7:report", time.Minute, func() (interface{}, error) {
+					calls.Add(1)
+					<-release
+					return "computed", nil
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, "computed", value)
+			}()
+		}
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), calls.Load())
+		assert.NoError(t, tagged.Flush())
+		assert.False(t, manager.Has("org:7:report"))
+	})
+
+	t.Run("tags_are_copied", func(t *testing.T) {
+		manager := newManager(t)
+		names := []string{"a", "b"}
+
+		tagged := manager.Tags(names...)
+		names[0] = "changed"
+
+		assert.Equal(t, []string{"a", "b"}, tagged.Tags())
+	})
+
+	t.Run("no_default_driver", func(t *testing.T) {
+		tagged := cache.NewManager().Tags("org:7")
+
+		assert.Error(t, tagged.Set("key", "value", time.Minute))
+		assert.Error(t, tagged.Flush())
+		_, err := tagged.Remember("key", time.Minute, func() (interface{}, error) { return "value", nil })
+		assert.Error(t, err)
+	})
+}
+
+// TestTaggedCache_ForwardsToDriver kiểm tra TaggedCache gọi đúng phương thức của driver
+func TestTaggedCache_ForwardsToDriver(t *testing.T) {
+	ctx := context.Background()
+	mockDriver := cache_mocks.NewMockDriver(t)
+	manager := cache.NewManager()
+	manager.AddDriver("mock", mockDriver)
+
+	tags := []string{"user:42", "org:7"}
+	mockDriver.EXPECT().SetTagged(ctx, "key", "value", time.Minute, tags).Return(nil).Once()
+	mockDriver.EXPECT().FlushTags(ctx, tags).Return(nil).Once()
+	mockDriver.EXPECT().FlushTags(mock.Anything, []string{"org:7"}).Return(nil).Once()
+
+	tagged := manager.Tags(tags...)
+	assert.NoError(t, tagged.SetContext(ctx, "key", "value", time.Minute))
+	assert.NoError(t, tagged.FlushContext(ctx))
+	assert.NoError(t, manager.FlushTags("org:7"))
+}
+
+// TestTaggedCache_RememberReturnsDriverError kiểm tra lỗi của Remember (ví dụ entry không
+// giải mã được) được trả về mà không ghi đè entry
+func TestTaggedCache_RememberReturnsDriverError(t *testing.T) {
+	ctx := context.Background()
+	mockDriver := cache_mocks.NewMockDriver(t)
+	manager := cache.NewManager()
+	manager.AddDriver("mock", mockDriver)
+
+	mockDriver.EXPECT().Remember(ctx, "key", time.Minute, mock.Anything).Return(nil, encryption.ErrAuthentication).Once()
+
+	_, err := manager.Tags("org:7").RememberContext(ctx, "key", time.Minute, func() (interface{}, error) {
+		t.Fatal("callback must not run when the entry cannot be decrypted")
+		return nil, nil
+	})
+	assert.ErrorIs(t, err, encryption.ErrAuthentication)
+}
@@ -0,0 +1,205 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"go.fork.vn/cache/driver"
+)
+
+// TypeMismatchError được trả về khi giá trị trong cache không thể được giải mã
+// hoặc gán vào kiểu T của một Typed cache.
+//
+// Lỗi này phân biệt trường hợp "có dữ liệu nhưng sai kiểu" với cache miss, giúp
+// caller phát hiện sớm các thay đổi cấu trúc dữ liệu hoặc xung đột key.
+type TypeMismatchError struct {
+	Key  string       // Cache key chứa giá trị không phù hợp
+	Type reflect.Type // Kiểu đích được yêu cầu
+	Err  error        // Lỗi gốc từ driver
+}
+
+// Error trả về mô tả của lỗi.
+func (e *TypeMismatchError) Error() string {
+	return fmt.Sprintf("cache value for key '%s' is not a %s: %v", e.Key, e.Type, e.Err)
+}
+
+// Unwrap trả về lỗi gốc, cho phép sử dụng errors.Is(err, driver.ErrTypeMismatch).
+func (e *TypeMismatchError) Unwrap() error {
+	return e.Err
+}
+
+// Typed là một lớp facade generic trên cache driver, làm việc trực tiếp với kiểu T.
+//
+// Typed giải mã giá trị thẳng vào T đối với các driver có serialization (redis, file,
+// mongodb) và kiểm tra kiểu đối với memory driver, thông qua driver.TypedGetter.
+// Driver không cài đặt driver.TypedGetter sẽ được kiểm tra bằng type assertion.
+// Giá trị sai kiểu được trả về dưới dạng *TypeMismatchError thay vì một cache miss.
+type Typed[T any] struct {
+	resolve func() (driver.Driver, error) // Hàm lấy driver đích cho mỗi thao tác
+}
+
+// NewTyped tạo một Typed cache sử dụng driver mặc định của manager.
+//
+// Driver mặc định được lấy lại ở mỗi thao tác, nên Typed luôn phản ánh
+// lần gọi SetDefaultDriver gần nhất.
+//
+// Params:
+//   - manager: Cache manager chứa driver mặc định
+//
+// Returns:
+//   - *Typed[T]: Typed cache cho kiểu T
+func NewTyped[T any](manager Manager) *Typed[T] {
+	return &Typed[T]{resolve: manager.DefaultDriver}
+}
+
+// NewTypedDriver tạo một Typed cache trên một driver cụ thể.
+//
+// Params:
+//   - d: Driver được sử dụng cho mọi thao tác
+//
+// Returns:
+//   - *Typed[T]: Typed cache cho kiểu T
+func NewTypedDriver[T any](d driver.Driver) *Typed[T] {
+	return &Typed[T]{resolve: func() (driver.Driver, error) { return d, nil }}
+}
+
+// Get lấy một giá trị kiểu T từ cache.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm
+//
+// Returns:
+//   - T: Giá trị tìm thấy (zero value nếu không tìm thấy hoặc có lỗi)
+//   - bool: true nếu tìm thấy key và giá trị đúng kiểu T
+//   - error: *TypeMismatchError nếu giá trị không phải T, lỗi khác nếu driver gặp sự cố
+func (c *Typed[T]) Get(ctx context.Context, key string) (T, bool, error) {
+	d, err := c.resolve()
+	if err != nil {
+		var zero T
+		return zero, false, err
+	}
+	return c.get(ctx, d, key)
+}
+
+// Set đặt một giá trị kiểu T vào cache.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key để lưu giá trị
+//   - value: Giá trị cần lưu trữ
+//   - ttl: Thời gian sống của giá trị (0 để sử dụng mặc định của driver, -1 để không hết hạn)
+//
+// Returns:
+//   - error: Lỗi nếu có trong quá trình lưu trữ
+func (c *Typed[T]) Set(ctx context.Context, key string, value T, ttl time.Duration) error {
+	d, err := c.resolve()
+	if err != nil {
+		return err
+	}
+	return d.Set(ctx, key, value, ttl)
+}
+
+// Remember lấy một giá trị kiểu T từ cache hoặc thực thi callback nếu không tìm thấy.
+//
+// Nếu key tồn tại nhưng giá trị không phải T, callback không được gọi và
+// *TypeMismatchError được trả về để tránh ghi đè dữ liệu một cách âm thầm.
+// Khi driver trả về giá trị chưa giải mã theo T (ví dụ giá trị do caller khác ghi),
+// key được đọc lại; nếu key đã hết hạn hoặc bị xóa trước lần đọc lại,
+// *TypeMismatchError cũng được trả về thay vì giá trị zero.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - key: Cache key cần tìm hoặc lưu vào cache
+//   - ttl: Thời gian sống của giá trị nếu phải lấy từ callback
+//   - callback: Hàm được gọi để lấy dữ liệu khi key không có trong cache
+//
+// Returns:
+//   - T: Giá trị từ cache hoặc từ callback
+//   - error: Lỗi từ driver, từ callback hoặc *TypeMismatchError
+func (c *Typed[T]) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (T, error)) (T, error) {
+	var zero T
+	d, err := c.resolve()
+	if err != nil {
+		return zero, err
+	}
+
+	value, found, err := c.get(ctx, d, key)
+	if err != nil {
+		return zero, err
+	}
+	if found {
+		return value, nil
+	}
+
+	raw, err := d.Remember(ctx, key, ttl, func() (interface{}, error) {
+		return callback()
+	})
+	if err != nil {
+		return zero, err
+	}
+	if typed, ok := raw.(T); ok {
+		return typed, nil
+	}
+
+	// Giá trị được ghi bởi một caller khác giữa hai lần đọc và trả về ở dạng
+	// chưa giải mã theo T, đọc lại qua đường typed.
+	value, found, err = c.get(ctx, d, key)
+	if err != nil {
+		return zero, err
+	}
+	if !found {
+		// Key đã hết hạn hoặc bị xóa trước khi đọc lại: không có giá trị T nào để trả về.
+		return zero, c.mismatch(key, fmt.Errorf("%w: got %T and key is gone on re-read", driver.ErrTypeMismatch, raw))
+	}
+	return value, nil
+}
+
+// get đọc key từ driver và chuyển đổi sang T.
+//
+// Params:
+//   - ctx: Context để kiểm soát thời gian thực thi của thao tác
+//   - d: Driver cần đọc
+//   - key: Cache key cần tìm
+//
+// Returns:
+//   - T: Giá trị tìm thấy
+//   - bool: true nếu tìm thấy key và giá trị đúng kiểu T
+//   - error: *TypeMismatchError hoặc lỗi từ driver
+func (c *Typed[T]) get(ctx context.Context, d driver.Driver, key string) (T, bool, error) {
+	var value T
+
+	if getter, ok := d.(driver.TypedGetter); ok {
+		found, err := getter.GetInto(ctx, key, &value)
+		if err != nil {
+			var zero T
+			if errors.Is(err, driver.ErrTypeMismatch) {
+				return zero, false, c.mismatch(key, err)
+			}
+			return zero, false, err
+		}
+		return value, found, nil
+	}
+
+	raw, found := d.Get(ctx, key)
+	if !found {
+		return value, false, nil
+	}
+	value, ok := raw.(T)
+	if !ok {
+		return value, false, c.mismatch(key, fmt.Errorf("%w: got %T", driver.ErrTypeMismatch, raw))
+	}
+	return value, true, nil
+}
+
+// mismatch tạo một *TypeMismatchError cho kiểu T.
+func (c *Typed[T]) mismatch(key string, err error) error {
+	return &TypeMismatchError{
+		Key:  key,
+		Type: reflect.TypeOf((*T)(nil)).Elem(),
+		Err:  err,
+	}
+}
@@ -0,0 +1,222 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.fork.vn/cache"
+	"go.fork.vn/cache/codec"
+	"go.fork.vn/cache/config"
+	"go.fork.vn/cache/driver"
+	cache_mocks "go.fork.vn/cache/mocks"
+)
+
+type typedUser struct {
+	ID   int
+	Name string
+}
+
+// TestTyped kiểm tra Typed cache với memory driver thật
+func TestTyped(t *testing.T) {
+	ctx := context.Background()
+
+	newManager := func(t *testing.T) cache.Manager {
+		memoryDriver := driver.NewMemoryDriver(config.DriverMemoryConfig{DefaultTTL: 300})
+		t.Cleanup(func() { _ = memoryDriver.Close() })
+
+		manager := cache.NewManager()
+		manager.AddDriver("memory", memoryDriver)
+		manager.SetDefaultDriver("memory")
+		return manager
+	}
+
+	t.Run("set_and_get_round_trip", func(t *testing.T) {
+		users := cache.NewTyped[typedUser](newManager(t))
+
+		err := users.Set(ctx, "user:1", typedUser{ID: 1, Name: "An"}, time.Minute)
+		assert.NoError(t, err)
+
+		user, found, err := users.Get(ctx, "user:1")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, typedUser{ID: 1, Name: "An"}, user)
+	})
+
+	t.Run("get_returns_miss_without_error", func(t *testing.T) {
+		users := cache.NewTyped[typedUser](newManager(t))
+
+		user, found, err := users.Get(ctx, "user:missing")
+		assert.NoError(t, err)
+		assert.False(t, found)
+		assert.Equal(t, typedUser{}, user)
+	})
+
+	t.Run("get_returns_type_mismatch_error", func(t *testing.T) {
+		manager := newManager(t)
+		manager.Set("user:2", "not a user", time.Minute)
+		users := cache.NewTyped[typedUser](manager)
+
+		_, found, err := users.Get(ctx, "user:2")
+		assert.False(t, found)
+
+		var mismatch *cache.TypeMismatchError
+		assert.True(t, errors.As(err, &mismatch))
+		assert.Equal(t, "user:2", mismatch.Key)
+		assert.ErrorIs(t, err, driver.ErrTypeMismatch)
+	})
+
+	t.Run("remember_calls_callback_once", func(t *testing.T) {
+		users := cache.NewTyped[typedUser](newManager(t))
+		calls := 0
+		callback := func() (typedUser, error) {
+			calls++
+			return typedUser{ID: 3, Name: "Binh"}, nil
+		}
+
+		first, err := users.Remember(ctx, "user:3", time.Minute, callback)
+		assert.NoError(t, err)
+		second, err := users.Remember(ctx, "user:3", time.Minute, callback)
+		assert.NoError(t, err)
+
+		assert.Equal(t, typedUser{ID: 3, Name: "Binh"}, first)
+		assert.Equal(t, first, second)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("remember_does_not_overwrite_mismatched_value", func(t *testing.T) {
+		manager := newManager(t)
+		manager.Set("user:4", 42, time.Minute)
+		users := cache.NewTyped[typedUser](manager)
+
+		_, err := users.Remember(ctx, "user:4", time.Minute, func() (typedUser, error) {
+			t.Fatal("callback must not be called")
+			return typedUser{}, nil
+		})
+
+		assert.ErrorIs(t, err, driver.ErrTypeMismatch)
+		value, found := manager.Get("user:4")
+		assert.True(t, found)
+		assert.Equal(t, 42, value)
+	})
+
+	t.Run("remember_propagates_callback_error", func(t *testing.T) {
+		users := cache.NewTyped[typedUser](newManager(t))
+		expected := errors.New("database unavailable")
+
+		_, err := users.Remember(ctx, "user:5", time.Minute, func() (typedUser, error) {
+			return typedUser{}, expected
+		})
+
+		assert.ErrorIs(t, err, expected)
+	})
+
+	t.Run("returns_error_without_default_driver", func(t *testing.T) {
+		users := cache.NewTyped[typedUser](cache.NewManager())
+
+		_, _, err := users.Get(ctx, "user:1")
+		assert.Error(t, err)
+		assert.Error(t, users.Set(ctx, "user:1", typedUser{}, time.Minute))
+	})
+}
+
+// TestTypedDriver_File kiểm tra Typed cache giải mã struct trực tiếp từ file driver
+func TestTypedDriver_File(t *testing.T) {
+	ctx := context.Background()
+
+	for _, name := range []string{codec.JSON, codec.MsgPack} {
+		t.Run(name, func(t *testing.T) {
+			fileDriver, err := driver.NewFileDriver(config.DriverFileConfig{Path: t.TempDir(), DefaultTTL: 300, Codec: name})
+			assert.NoError(t, err)
+			t.Cleanup(func() { _ = fileDriver.Close() })
+
+			users := cache.NewTypedDriver[typedUser](fileDriver)
+			assert.NoError(t, users.Set(ctx, "user:1", typedUser{ID: 1, Name: "An"}, time.Minute))
+
+			user, found, err := users.Get(ctx, "user:1")
+			assert.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, typedUser{ID: 1, Name: "An"}, user)
+
+			calls := 0
+			for i := 0; i < 2; i++ {
+				user, err = users.Remember(ctx, "user:2", time.Minute, func() (typedUser, error) {
+					calls++
+					return typedUser{ID: 2, Name: "Binh"}, nil
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, typedUser{ID: 2, Name: "Binh"}, user)
+			}
+			assert.Equal(t, 1, calls)
+
+			assert.NoError(t, fileDriver.Set(ctx, "user:3", "not a user", time.Minute))
+			_, found, err = users.Get(ctx, "user:3")
+			assert.False(t, found)
+			assert.ErrorIs(t, err, driver.ErrTypeMismatch)
+		})
+	}
+}
+
+// TestTypedDriver_Fallback kiểm tra Typed cache với driver không cài đặt TypedGetter
+func TestTypedDriver_Fallback(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("asserts_type_of_raw_value", func(t *testing.T) {
+		mockDriver := cache_mocks.NewMockDriver(t)
+		mockDriver.EXPECT().Get(ctx, "count").Return(7, true)
+
+		counts := cache.NewTypedDriver[int](mockDriver)
+		value, found, err := counts.Get(ctx, "count")
+
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, 7, value)
+	})
+
+	t.Run("reports_mismatch_of_raw_value", func(t *testing.T) {
+		mockDriver := cache_mocks.NewMockDriver(t)
+		mockDriver.EXPECT().Get(ctx, "count").Return("seven", true)
+
+		counts := cache.NewTypedDriver[int](mockDriver)
+		_, found, err := counts.Get(ctx, "count")
+
+		assert.False(t, found)
+		assert.ErrorIs(t, err, driver.ErrTypeMismatch)
+	})
+
+	t.Run("remember_uses_driver_remember_on_miss", func(t *testing.T) {
+		mockDriver := cache_mocks.NewMockDriver(t)
+		mockDriver.EXPECT().Get(ctx, "count").Return(nil, false)
+		mockDriver.EXPECT().Remember(ctx, "count", time.Minute, mock.Anything).
+			RunAndReturn(func(_ context.Context, _ string, _ time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+				return callback()
+			})
+
+		counts := cache.NewTypedDriver[int](mockDriver)
+		value, err := counts.Remember(ctx, "count", time.Minute, func() (int, error) {
+			return 9, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 9, value)
+	})
+
+	t.Run("remember_reports_mismatch_when_reread_misses", func(t *testing.T) {
+		mockDriver := cache_mocks.NewMockDriver(t)
+		mockDriver.EXPECT().Get(ctx, "count").Return(nil, false)
+		mockDriver.EXPECT().Remember(ctx, "count", time.Minute, mock.Anything).Return("9", nil)
+
+		counts := cache.NewTypedDriver[int](mockDriver)
+		value, err := counts.Remember(ctx, "count", time.Minute, func() (int, error) {
+			return 9, nil
+		})
+
+		var mismatch *cache.TypeMismatchError
+		assert.ErrorAs(t, err, &mismatch)
+		assert.ErrorIs(t, err, driver.ErrTypeMismatch)
+		assert.Equal(t, 0, value)
+	})
+}